@@ -53,6 +53,8 @@ func main() {
 	cpuProfile := flag.String("cpu-profile", "", "Write CPU profile to file")
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
+	printTutorial := flag.Bool("print-tutorial", false, "Print the built-in tutorial as Markdown to stdout and exit")
+	verboseFlag := flag.Bool("verbose", false, "With --version, show version source, Go version and VCS revision; with --check-drift, list dismissed alerts")
 	// Update flags (bv-182)
 	updateFlag := flag.Bool("update", false, "Update bv to the latest version")
 	checkUpdateFlag := flag.Bool("check-update", false, "Check if a new version is available")
@@ -61,7 +63,7 @@ func main() {
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
 	robotDocs := flag.String("robot-docs", "", "Machine-readable JSON docs for AI agents. Topics: guide, commands, examples, env, exit-codes, all")
-	outputFormat := flag.String("format", "", "Structured output format for --robot-* commands: json or toon (env: BV_OUTPUT_FORMAT, TOON_DEFAULT_FORMAT)")
+	outputFormat := flag.String("format", "", "Structured output format for --robot-* commands: json or toon, or csv for --robot-labels and --robot-correlate (env: BV_OUTPUT_FORMAT, TOON_DEFAULT_FORMAT)")
 	toonStats := flag.Bool("stats", false, "Show JSON vs TOON token estimates on stderr (env: TOON_STATS=1)")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
@@ -75,7 +77,11 @@ func main() {
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
+	robotLabelSpotlight := flag.String("robot-label", "", "Output every metric for one label (health, co-occurrence, flow, stale and top issues) as JSON")
+	robotHealth := flag.Bool("robot-health", false, "Output a single project health score rolled up from label health as JSON (for status badges)")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
+	robotLabels := flag.Bool("robot-labels", false, "Output full label health analysis as JSON (exit 1 if any label is below --labels-min-health)")
+	labelsMinHealth := flag.Int("labels-min-health", analysis.WarningThreshold, "Minimum label health for --robot-labels to exit 0")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
 	robotMetrics := flag.Bool("robot-metrics", false, "Output performance metrics (timing, cache, memory) as JSON")
 	// JSON Schema for robot outputs (bd-2kxo)
@@ -93,6 +99,7 @@ func main() {
 	graphDepth := flag.Int("graph-depth", 0, "Max depth for subgraph (0 = unlimited)")
 	// Graph snapshot export (bv-94)
 	exportGraph := flag.String("export-graph", "", "Export graph: .html for interactive, .png/.svg for static (auto-names if empty)")
+	exportDOT := flag.String("export-dot", "", "Export the blocking dependency graph as Graphviz DOT to a file ('-' for stdout)")
 	graphPreset := flag.String("graph-preset", "compact", "Graph layout preset: compact (default) or roomy")
 	graphTitle := flag.String("graph-title", "", "Title for graph export (default: project name)")
 	// Robot output filters (bv-84)
@@ -122,13 +129,21 @@ func main() {
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
 	saveBaseline := flag.String("save-baseline", "", "Save current metrics as baseline with optional description")
 	baselineInfo := flag.Bool("baseline-info", false, "Show information about the current baseline")
+	baselineName := flag.String("baseline-name", "", "Use a named baseline in .bv/baselines/<name>.json (with --save-baseline, --check-drift, --baseline-info)")
 	checkDrift := flag.Bool("check-drift", false, "Check for drift from baseline (exit codes: 0=OK, 1=critical, 2=warning)")
+	dismissDrift := flag.String("dismiss-drift", "", "Dismiss the current drift alert with this fingerprint until a new baseline is saved")
+	printDriftSchema := flag.Bool("print-drift-schema", false, "Print JSON Schema for .bv/drift.yaml (for editor validation)")
 	robotDriftCheck := flag.Bool("robot-drift", false, "Output drift check as JSON (use with --check-drift)")
+	driftTrend := flag.Bool("drift-trend", false, "Show drift trend across recent --check-drift runs (from .bv/drift-history.jsonl)")
+	driftFormat := flag.String("drift-format", "text", "Drift check output format: text, json, or markdown (use with --check-drift)")
+	driftExitPolicy := flag.String("drift-exit-policy", "strict", "How --check-drift severity maps to the exit code: strict, warn-ok (warnings exit 0), or report-only (always 0)")
 	robotHistory := flag.Bool("robot-history", false, "Output bead-to-commit correlations as JSON")
+	robotCorrelate := flag.Bool("robot-correlate", false, "Output correlated commits as a flat list (--format json or csv) for notebooks and spreadsheets")
 	beadHistory := flag.String("bead-history", "", "Show history for specific bead ID")
 	historySince := flag.String("history-since", "", "Limit history to commits after this date/ref (e.g., '30 days ago', '2024-01-01')")
 	historyLimit := flag.Int("history-limit", 500, "Max commits to analyze (0 = unlimited)")
 	minConfidence := flag.Float64("min-confidence", 0.0, "Filter correlations by minimum confidence (0.0-1.0)")
+	noCorrelationCache := flag.Bool("no-correlation-cache", false, "Recompute co-commit correlations instead of reusing .bv/correlation-cache.json")
 	// Correlation audit flags (bv-e1u6)
 	robotExplainCorrelation := flag.String("robot-explain-correlation", "", "Explain why a commit is linked to a bead (format: SHA:beadID)")
 	robotConfirmCorrelation := flag.String("robot-confirm-correlation", "", "Confirm a correlation is correct (format: SHA:beadID)")
@@ -157,6 +172,7 @@ func main() {
 	relatedIncludeClosed := flag.Bool("related-include-closed", false, "Include closed beads in related work results")
 	// Blocker chain analysis flag (bv-nlo0)
 	robotBlockerChain := flag.String("robot-blocker-chain", "", "Output full blocker chain analysis for issue ID as JSON")
+	robotExplain := flag.String("robot-explain", "", "Output centrality, blockers, unblock impact and critical path status for issue ID as JSON")
 	// Impact network graph flag (bv-48kr)
 	robotImpactNetwork := flag.String("robot-impact-network", "", "Output bead impact network as JSON (empty for full, or bead ID for subnetwork)")
 	networkDepth := flag.Int("network-depth", 2, "Depth of subnetwork when querying specific bead (1-3)")
@@ -218,6 +234,8 @@ func main() {
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
 	}
+	// Tooling-only flags are accepted but kept out of --help output
+	_ = flag.CommandLine.MarkHidden("print-drift-schema")
 	flag.Parse()
 
 	// CPU profiling support
@@ -274,6 +292,9 @@ func main() {
 		*robotLabelHealth ||
 		*robotLabelFlow ||
 		*robotLabelAttention ||
+		*robotLabelSpotlight != "" ||
+		*robotHealth ||
+		*robotLabels ||
 		*robotAlerts ||
 		*robotMetrics ||
 		*robotSchema ||
@@ -282,12 +303,14 @@ func main() {
 		*robotSearch ||
 		*robotDriftCheck ||
 		*robotHistory ||
+		*robotCorrelate ||
 		*robotFileBeads != "" ||
 		*fileHotspots ||
 		*robotImpact != "" ||
 		*robotFileRelations != "" ||
 		*robotRelatedWork != "" ||
 		*robotBlockerChain != "" ||
+		*robotExplain != "" ||
 		*robotImpactNetwork != "" ||
 		*robotCausality != "" ||
 		*robotSprintList ||
@@ -308,12 +331,18 @@ func main() {
 		envRobot = true
 	}
 
+	correlationCacheEnabled = !*noCorrelationCache
+
 	// Structured output format for --robot-* commands.
 	robotOutputFormat = resolveRobotOutputFormat(*outputFormat)
 	robotToonEncodeOptions = resolveToonEncodeOptionsFromEnv()
 	robotShowToonStats = *toonStats || strings.TrimSpace(os.Getenv("TOON_STATS")) == "1"
-	if robotOutputFormat != "json" && robotOutputFormat != "toon" {
-		fmt.Fprintf(os.Stderr, "Invalid --format %q (expected json|toon)\n", robotOutputFormat)
+	if robotOutputFormat == "csv" && !*robotLabels && !*robotCorrelate {
+		fmt.Fprintln(os.Stderr, "Error: --format csv is only supported with --robot-labels and --robot-correlate")
+		os.Exit(2)
+	}
+	if robotOutputFormat != "json" && robotOutputFormat != "toon" && robotOutputFormat != "csv" {
+		fmt.Fprintf(os.Stderr, "Invalid --format %q (expected json|toon|csv)\n", robotOutputFormat)
 		os.Exit(2)
 	}
 
@@ -411,9 +440,20 @@ func main() {
 		fmt.Println("      - --history-since <ref>: Limit to recent commits")
 		fmt.Println("      - --history-limit <n>: Max commits to analyze (default: 500)")
 		fmt.Println("      - --min-confidence <0.0-1.0>: Filter by minimum confidence score")
+		fmt.Println("      - --no-correlation-cache: Ignore the cached co-commit scan (.bv/correlation-cache.json)")
 		fmt.Println("      Example: bv --robot-history --history-since '30 days ago'")
 		fmt.Println("      Example: bv --robot-history --min-confidence 0.7")
 		fmt.Println("")
+		fmt.Println("  --robot-correlate")
+		fmt.Println("      Outputs the correlated commits from --robot-history as one flat list,")
+		fmt.Println("      one entry per bead/commit pair, for notebooks and spreadsheets.")
+		fmt.Println("      Takes the same --bead-history, --history-since, --history-limit and")
+		fmt.Println("      --min-confidence flags.")
+		fmt.Println("      --format json (default): array of commits, each with bead_id")
+		fmt.Println("      --format csv: bead_id, sha, short_sha, method, confidence, author,")
+		fmt.Println("        files_changed, insertions, deletions, event_type")
+		fmt.Println("      Example: bv --robot-correlate --format csv > commits.csv")
+		fmt.Println("")
 		fmt.Println("  --robot-file-beads <path>")
 		fmt.Println("      Outputs beads that have touched a file path as JSON.")
 		fmt.Println("      Answers: 'What beads have touched this file, and why?'")
@@ -580,7 +620,10 @@ func main() {
 		fmt.Println("      View issue state at a point in time (works with all robot commands).")
 		fmt.Println("      Useful for historical analysis without modifying the working tree.")
 		fmt.Println("      Robot outputs include 'as_of' and 'as_of_commit' metadata fields.")
+		fmt.Println("      A date or RFC3339 timestamp also becomes the reference time")
+		fmt.Println("      for label health and drift, so repeated runs give identical results.")
 		fmt.Println("      Examples: --as-of HEAD~30, --as-of v1.0.0, --as-of '2024-01-01'")
+		fmt.Println("                --as-of 2024-01-05T17:00:00Z")
 		fmt.Println("")
 		fmt.Println("  --robot-diff")
 		fmt.Println("      Output diff as JSON (use with --diff-since).")
@@ -615,6 +658,23 @@ func main() {
 		fmt.Println("                  bottleneck_labels (highest outgoing), total_cross_label_deps.")
 		fmt.Println("      Use when you need to see which labels are blocking others at a glance.")
 		fmt.Println("")
+		fmt.Println("  --robot-labels [--labels-min-health=N]")
+		fmt.Println("      Outputs the full label analysis (per-label health, summaries, cross-label flow) as JSON.")
+		fmt.Println("      Exit codes: 0=all labels at or above N (default: 40), 1=at least one label below N.")
+		fmt.Println("      Scoring weights and thresholds are read from .bv/labels.yaml when present.")
+		fmt.Println("      Use in CI to fail builds when a label's health turns critical.")
+		fmt.Println("      With --format csv, prints the cross-label flow matrix as CSV (row=from, col=to).")
+		fmt.Println("")
+		fmt.Println("  --robot-label <name>")
+		fmt.Println("      Outputs a spotlight on one label as JSON: its health, co-occurring labels,")
+		fmt.Println("      inbound/outbound cross-label dependencies, stale issues and top open issues.")
+		fmt.Println("      Scoring settings are read from .bv/labels.yaml when present. Exits 1 for a label with no issues.")
+		fmt.Println("")
+		fmt.Println("  --robot-health")
+		fmt.Println("      Outputs one project health score (0-100) rolled up from label health as JSON.")
+		fmt.Println("      Larger labels weigh more; blocked work and dependency cycles pull the score down.")
+		fmt.Println("      Key fields: health, health_level, reasons. Settings are read from .bv/labels.yaml.")
+		fmt.Println("")
 		fmt.Println("  --robot-label-attention [--attention-limit=N]")
 		fmt.Println("      Outputs attention-ranked labels as JSON (default limit: 5).")
 		fmt.Println("      Labels ranked by attention score = (pagerank * staleness * block_impact) / velocity.")
@@ -663,6 +723,12 @@ func main() {
 		fmt.Println("      Example: bv --export-graph deps.svg --label=api --graph-title='API Dependencies'")
 		fmt.Println("      Example: bv --export-graph full.png --graph-style=force --graph-preset=roomy")
 		fmt.Println("")
+		fmt.Println("  --export-dot <path.dot|-> [--graph-root=ID] [--graph-depth=N]")
+		fmt.Println("      Export the blocking dependency graph as a Graphviz digraph ('-' writes to stdout).")
+		fmt.Println("      Nodes are colored by status; arrows point at the blocking issue; cycles are outlined in red.")
+		fmt.Println("      --graph-root limits the output to issues within --graph-depth hops (0 = unlimited) of ID.")
+		fmt.Println("      Example: bv --export-dot deps.dot && dot -Tsvg deps.dot -o deps.svg")
+		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Graph metrics JSON for agents.")
 		fmt.Println("      Top lists: Bottlenecks (betweenness), Keystones (critical path), Influencers (eigenvector),")
@@ -740,6 +806,12 @@ func main() {
 		fmt.Println("      Use for drift detection: compare current state to saved baseline.")
 		fmt.Println("      Example: bv --save-baseline \"Before major refactor\"")
 		fmt.Println("")
+		fmt.Println("  --baseline-name NAME")
+		fmt.Println("      Use a named baseline stored in .bv/baselines/NAME.json instead of")
+		fmt.Println("      the default .bv/baseline.json. Applies to --save-baseline,")
+		fmt.Println("      --check-drift, and --baseline-info.")
+		fmt.Println("      Example: bv --save-baseline \"Sprint 14\" --baseline-name sprint-start")
+		fmt.Println("")
 		fmt.Println("  --baseline-info")
 		fmt.Println("      Show information about the saved baseline.")
 		fmt.Println("      Displays: creation date, git commit, graph stats, top metrics.")
@@ -751,10 +823,37 @@ func main() {
 		fmt.Println("        1 = Critical alerts (new cycles detected)")
 		fmt.Println("        2 = Warning alerts (blocked increase, density growth)")
 		fmt.Println("      Human-readable output by default, use --robot-drift for JSON.")
+		fmt.Println("      Dismissed alerts don't count; add --verbose to list them.")
+		fmt.Println("")
+		fmt.Println("  --dismiss-drift FINGERPRINT")
+		fmt.Println("      Accept a drift alert so --check-drift stops counting it.")
+		fmt.Println("      The fingerprint is shown next to each --check-drift alert.")
+		fmt.Println("      Stored in .bv/drift-dismissals.json; dismissals expire when the")
+		fmt.Println("      baseline they were raised against is replaced.")
+		fmt.Println("      Example: bv --dismiss-drift 3f9a1c07b2e4")
+		fmt.Println("")
+		fmt.Println("  --drift-exit-policy <strict|warn-ok|report-only>")
+		fmt.Println("      How --check-drift results map to the exit code (default: strict).")
+		fmt.Println("        strict      = 1 for critical, 2 for warning (as above)")
+		fmt.Println("        warn-ok     = 1 for critical, 0 for warning")
+		fmt.Println("        report-only = always 0; the report still lists every alert")
+		fmt.Println("      Example: bv --check-drift --drift-exit-policy warn-ok")
 		fmt.Println("")
 		fmt.Println("  --robot-drift")
 		fmt.Println("      Output drift check as JSON (use with --check-drift).")
-		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, baseline}")
+		fmt.Println("      Output: {has_drift, exit_code, summary, alerts, groups, baseline}")
+		fmt.Println("      groups lists alert indexes under the label owning their issues, or \"global\".")
+		fmt.Println("")
+		fmt.Println("  --drift-trend")
+		fmt.Println("      Show whether drift is accelerating across recent --check-drift runs.")
+		fmt.Println("      Each --check-drift run appends to .bv/drift-history.jsonl (capped by")
+		fmt.Println("      history_max_entries in .bv/drift.yaml). Use --robot-drift for JSON.")
+		fmt.Println("")
+		fmt.Println("  --drift-format <text|json|markdown>")
+		fmt.Println("      Output format for --check-drift (default: text; --robot-drift implies json).")
+		fmt.Println("      markdown renders a PR-ready report: severity header, alerts table,")
+		fmt.Println("      and a baseline vs current metrics comparison.")
+		fmt.Println("      Example: bv --check-drift --drift-format markdown >> pr-body.md")
 		fmt.Println("")
 		fmt.Println("  Static Site Export & GitHub Pages (bv-7pu):")
 		fmt.Println("      --pages")
@@ -787,12 +886,23 @@ func main() {
 		fmt.Println("      Customize drift detection thresholds:")
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
 		fmt.Println("      - blocked_increase_threshold: 5   # Warn if 5+ more blocked")
+		fmt.Println("      Personal defaults in ~/.config/bv/drift.yaml; .bv/drift.yaml wins over them.")
+		fmt.Println("      Override any threshold via env, e.g. BV_DRIFT_DENSITY_WARNING_PCT=30")
 		fmt.Println("      Run 'bv --baseline-info' to see current baseline state.")
 		os.Exit(0)
 	}
 
 	if *versionFlag {
-		fmt.Printf("bv %s\n", version.Version)
+		if *verboseFlag {
+			fmt.Print(version.Info().String())
+		} else {
+			fmt.Printf("bv %s\n", version.Version)
+		}
+		os.Exit(0)
+	}
+
+	if *printTutorial {
+		fmt.Print(ui.ExportTutorialMarkdown(ui.DefaultTutorialPages()))
 		os.Exit(0)
 	}
 
@@ -1198,6 +1308,13 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --print-drift-schema (hidden; for editor integration)
+	if *printDriftSchema {
+		os.Stdout.Write(drift.ConfigJSONSchema())
+		fmt.Println()
+		os.Exit(0)
+	}
+
 	// Handle --robot-schema (bd-2kxo)
 	if *robotSchema {
 		schemas := generateRobotSchemas()
@@ -1250,7 +1367,11 @@ func main() {
 
 	// Get project directory for baseline operations (moved up to allow info check without loading issues)
 	projectDir, _ := os.Getwd()
-	baselinePath := baseline.DefaultPath(projectDir)
+	baselinePath, err := drift.BaselinePath(projectDir, *baselineName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Handle --baseline-info
 	if *baselineInfo {
@@ -1268,6 +1389,26 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --drift-trend (reads recorded history; no issue loading needed)
+	if *driftTrend {
+		history, err := drift.LoadDriftHistory(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading drift history: %v\n", err)
+			os.Exit(1)
+		}
+		trend := drift.SummarizeDriftTrend(history)
+		if *robotDriftCheck || *driftFormat == "json" {
+			encoder := newRobotEncoder(os.Stdout)
+			if err := encoder.Encode(trend); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding drift trend: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			fmt.Print(trend.Summary())
+		}
+		os.Exit(0)
+	}
+
 	// Validate recipe name if provided (before loading issues)
 	var activeRecipe *recipe.Recipe
 	if *recipeName != "" {
@@ -1290,6 +1431,14 @@ func main() {
 	var workspaceInfo *workspace.LoadSummary
 	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
 
+	// Reference "now" for time-dependent analysis (label health, drift).
+	// A timestamp --as-of pins it so historical queries are reproducible.
+	analysisNow, err := analysisTimeForAsOf(*asOf, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
 		// Note: --as-of takes precedence over --workspace (can't combine historical + multi-repo)
@@ -1397,7 +1546,7 @@ func main() {
 			issues = subgraphIssues
 			// Compute label health for context
 			cfg := analysis.DefaultLabelHealthConfig()
-			allHealth := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), nil)
+			allHealth := analysis.ComputeAllLabelHealth(issues, cfg, analysisNow, nil)
 			for i := range allHealth.Labels {
 				if allHealth.Labels[i].Label == *labelScope {
 					labelScopeContext = &allHealth.Labels[i]
@@ -1913,7 +2062,7 @@ func main() {
 	// Handle --robot-label-health
 	if *robotLabelHealth {
 		cfg := analysis.DefaultLabelHealthConfig()
-		results := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), nil)
+		results := analysis.ComputeAllLabelHealth(issues, cfg, analysisNow, nil)
 
 		output := struct {
 			GeneratedAt    string                       `json:"generated_at"`
@@ -1941,6 +2090,38 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-labels (exit code reflects label health for CI)
+	if *robotLabels {
+		cfg, err := analysis.LoadLabelHealthConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading label health config: %v\n", err)
+			cfg = analysis.DefaultLabelHealthConfig()
+		}
+		results := analysis.ComputeAllLabelHealth(issues, cfg, analysisNow, nil)
+		flow := analysis.ComputeCrossLabelFlow(issues, cfg)
+		results.CrossLabelFlow = &flow
+
+		if robotOutputFormat == "csv" {
+			// Spreadsheet export: just the label flow matrix
+			if err := flow.WriteCSV(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing label flow CSV: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			encoder := newRobotEncoder(os.Stdout)
+			if err := encoder.Encode(results); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding label analysis: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		for _, summary := range results.Summaries {
+			if summary.Health < *labelsMinHealth {
+				os.Exit(1)
+			}
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-label-flow (can be used stand-alone to avoid full health computation)
 	if *robotLabelFlow {
 		cfg := analysis.DefaultLabelHealthConfig()
@@ -1970,10 +2151,76 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-label
+	if *robotLabelSpotlight != "" {
+		cfg, err := analysis.LoadLabelHealthConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading label health config: %v\n", err)
+			cfg = analysis.DefaultLabelHealthConfig()
+		}
+		spotlight, err := analysis.ComputeLabelSpotlight(*robotLabelSpotlight, issues, cfg, analysisNow, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		output := struct {
+			GeneratedAt string                  `json:"generated_at"`
+			DataHash    string                  `json:"data_hash"`
+			Spotlight   analysis.LabelSpotlight `json:"spotlight"`
+			UsageHints  []string                `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			Spotlight:   spotlight,
+			UsageHints: []string{
+				"jq '.spotlight.health.health' - composite health score",
+				"jq '.spotlight.inbound[] | {from:.from_label,count:.issue_count}' - labels blocking this one",
+				"jq '.spotlight.stale_issues' - issues gone stale",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding label spotlight: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// Handle --robot-health
+	if *robotHealth {
+		cfg, err := analysis.LoadLabelHealthConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading label health config: %v\n", err)
+			cfg = analysis.DefaultLabelHealthConfig()
+		}
+		results := analysis.ComputeAllLabelHealth(issues, cfg, analysisNow, nil)
+		output := struct {
+			GeneratedAt   string                      `json:"generated_at"`
+			DataHash      string                      `json:"data_hash"`
+			ProjectHealth analysis.ProjectHealthScore `json:"project_health"`
+			UsageHints    []string                    `json:"usage_hints"`
+		}{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			DataHash:      dataHash,
+			ProjectHealth: analysis.ComputeProjectHealth(results),
+			UsageHints: []string{
+				"jq '.project_health.health' - score for a status badge",
+				"jq '.project_health.reasons' - what is pulling the score down",
+				"bv --robot-label-attention - labels to fix first",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding project health: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-label-attention (bv-121)
 	if *robotLabelAttention {
 		cfg := analysis.DefaultLabelHealthConfig()
-		result := analysis.ComputeLabelAttentionScores(issues, cfg, time.Now().UTC())
+		result := analysis.ComputeLabelAttentionScores(issues, cfg, analysisNow)
 
 		// Apply limit
 		limit := *attentionLimit
@@ -2174,9 +2421,52 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --export-dot
+	if *exportDOT != "" {
+		analyzer := analysis.NewAnalyzer(issues)
+		stats := analyzer.Analyze()
+
+		opts := analysis.DOTOptions{Issues: issues, ColorByStatus: true, HighlightCycles: true}
+		if *graphRoot != "" {
+			depth := *graphDepth
+			if depth <= 0 {
+				depth = -1 // --graph-depth 0 means unlimited
+			}
+			focus := analyzer.FocusSubgraph(*graphRoot, depth)
+			if len(focus.Issues) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: issue %q not found\n", *graphRoot)
+				os.Exit(1)
+			}
+			opts.Focus = &focus
+		}
+
+		if *exportDOT == "-" {
+			if err := stats.WriteDOT(os.Stdout, opts); err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting DOT graph: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+		f, err := os.Create(*exportDOT)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating DOT file: %v\n", err)
+			os.Exit(1)
+		}
+		err = stats.WriteDOT(f, opts)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting DOT graph: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ DOT graph exported to %s\n", *exportDOT)
+		os.Exit(0)
+	}
+
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
-		driftConfig, err := drift.LoadConfig(projectDir)
+		driftConfig, err := drift.LoadConfigLayered(projectDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
 			os.Exit(1)
@@ -2185,20 +2475,19 @@ func main() {
 		analyzer := analysis.NewAnalyzer(issues)
 		stats := analyzer.Analyze()
 
-		openCount, closedCount, blockedCount := 0, 0, 0
+		openCount, closedCount := 0, 0
 		for _, issue := range issues {
 			switch issue.Status {
 			case model.StatusClosed:
 				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
 			case model.StatusOpen, model.StatusInProgress:
 				openCount++
 			default:
 				// Ignore tombstones and any unknown statuses for summary counts.
 			}
 		}
-		actionableCount := len(analyzer.GetActionableIssues())
+		blockedCount := drift.CountBlocked(analyzer, issues, driftConfig)
+		actionableCount := analyzer.ActionableCount()
 		cycles := stats.Cycles()
 		curStats := baseline.GraphStats{
 			NodeCount:       stats.NodeCount,
@@ -2209,12 +2498,14 @@ func main() {
 			BlockedCount:    blockedCount,
 			CycleCount:      len(cycles),
 			ActionableCount: actionableCount,
+			ClosedPerWeek:   driftClosedPerWeek(projectDir, issues, analysisNow),
 		}
+		drift.SetLiveGraphStats(&curStats, issues)
 
 		// Default behavior (no baseline): drift comparisons are suppressed by using
 		// baseline=current for stats, while still allowing cycle/staleness/cascade alerts.
-		bl := &baseline.Baseline{Stats: curStats}
-		cur := &baseline.Baseline{Stats: curStats, Cycles: cycles}
+		bl := &baseline.Baseline{Stats: curStats, StatsVersion: baseline.CurrentStatsVersion}
+		cur := &baseline.Baseline{Stats: curStats, StatsVersion: baseline.CurrentStatsVersion, Cycles: cycles}
 
 		// If a baseline exists, compare against it for real drift deltas.
 		if baseline.Exists(baselinePath) {
@@ -2232,12 +2523,14 @@ func main() {
 					Hubs:         buildMetricItems(stats.Hubs(), 10),
 					Authorities:  buildMetricItems(stats.Authorities(), 10),
 				}
-				cur = &baseline.Baseline{Stats: curStats, TopMetrics: topMetrics, Cycles: cycles}
+				cur = &baseline.Baseline{Stats: curStats, StatsVersion: baseline.CurrentStatsVersion, TopMetrics: topMetrics, Cycles: cycles, LabelStats: drift.ComputeLabelStats(issues, driftConfig)}
+				cur.LabelHealth = driftLabelHealth(projectDir, issues, analysisNow)
 			}
 		}
 
 		calc := drift.NewCalculator(bl, cur, driftConfig)
 		calc.SetIssues(issues)
+		calc.SetNow(analysisNow)
 		driftResult := calc.Calculate()
 
 		// Apply optional filters
@@ -2352,21 +2645,27 @@ func main() {
 		}
 		stats := analyzer.Analyze()
 
+		// Drift ignore lists shape the blocked counts stored in the baseline
+		driftConfig, err := drift.LoadConfigLayered(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
+			driftConfig = drift.DefaultConfig()
+		}
+
 		// Compute status counts from issues
-		openCount, closedCount, blockedCount := 0, 0, 0
+		openCount, closedCount := 0, 0
 		for _, issue := range issues {
 			switch issue.Status {
 			case model.StatusOpen, model.StatusInProgress:
 				openCount++
 			case model.StatusClosed:
 				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
 			}
 		}
+		blockedCount := drift.CountBlocked(analyzer, issues, driftConfig)
 
 		// Get actionable count from analyzer
-		actionableCount := len(analyzer.GetActionableIssues())
+		actionableCount := analyzer.ActionableCount()
 
 		// Get cycles (method returns a copy)
 		cycles := stats.Cycles()
@@ -2381,7 +2680,9 @@ func main() {
 			BlockedCount:    blockedCount,
 			CycleCount:      len(cycles),
 			ActionableCount: actionableCount,
+			ClosedPerWeek:   driftClosedPerWeek(projectDir, issues, analysisNow),
 		}
+		drift.SetLiveGraphStats(&graphStats, issues)
 
 		// Build TopMetrics from analysis (top 10 for each)
 		// Methods return copies of the maps
@@ -2394,8 +2695,11 @@ func main() {
 		}
 
 		bl := baseline.New(graphStats, topMetrics, cycles, *saveBaseline)
+		bl.LabelStats = drift.ComputeLabelStats(issues, driftConfig)
+		bl.LabelHealth = driftLabelHealth(projectDir, issues, analysisNow)
+		bl.Issues = baseline.SnapshotIssues(issues)
 
-		if err := bl.Save(baselinePath); err != nil {
+		if err := drift.SaveBaselineNamed(projectDir, *baselineName, *saveBaseline, bl); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving baseline: %v\n", err)
 			os.Exit(1)
 		}
@@ -2405,8 +2709,12 @@ func main() {
 		os.Exit(0)
 	}
 
-	// Handle --check-drift
-	if *checkDrift {
+	// Handle --check-drift and --dismiss-drift
+	if *checkDrift || *dismissDrift != "" {
+		if _, err := driftExitCode(0, *driftExitPolicy); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		if !baseline.Exists(baselinePath) {
 			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
 			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
@@ -2427,19 +2735,27 @@ func main() {
 		}
 		stats := analyzer.Analyze()
 
+		// Load drift config (ignore lists affect blocked counts)
+		driftConfig, err := drift.LoadConfigLayered(projectDir)
+		if err != nil {
+			if !envRobot {
+				fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
+			}
+			driftConfig = drift.DefaultConfig()
+		}
+
 		// Compute status counts from issues
-		openCount, closedCount, blockedCount := 0, 0, 0
+		openCount, closedCount := 0, 0
 		for _, issue := range issues {
 			switch issue.Status {
 			case model.StatusOpen, model.StatusInProgress:
 				openCount++
 			case model.StatusClosed:
 				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
 			}
 		}
-		actionableCount := len(analyzer.GetActionableIssues())
+		blockedCount := drift.CountBlocked(analyzer, issues, driftConfig)
+		actionableCount := analyzer.ActionableCount()
 		cycles := stats.Cycles()
 
 		// Build current snapshot as baseline for comparison
@@ -2452,7 +2768,9 @@ func main() {
 			BlockedCount:    blockedCount,
 			CycleCount:      len(cycles),
 			ActionableCount: actionableCount,
+			ClosedPerWeek:   driftClosedPerWeek(projectDir, issues, analysisNow),
 		}
+		drift.SetLiveGraphStats(&currentStats, issues)
 		currentMetrics := baseline.TopMetrics{
 			PageRank:     buildMetricItems(stats.PageRank(), 10),
 			Betweenness:  buildMetricItems(stats.Betweenness(), 10),
@@ -2461,20 +2779,68 @@ func main() {
 			Authorities:  buildMetricItems(stats.Authorities(), 10),
 		}
 		current := baseline.New(currentStats, currentMetrics, cycles, "current")
+		current.LabelStats = drift.ComputeLabelStats(issues, driftConfig)
+		current.LabelHealth = driftLabelHealth(projectDir, issues, analysisNow)
+		current.Issues = baseline.SnapshotIssues(issues) // Labels for grouping alerts
 
-		// Load drift config and run calculator
-		driftConfig, err := drift.LoadConfig(projectDir)
+		dismissals, err := drift.LoadDismissals(projectDir)
 		if err != nil {
+			if *dismissDrift != "" {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 			if !envRobot {
-				fmt.Fprintf(os.Stderr, "Warning: Error loading drift config: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 			}
-			driftConfig = drift.DefaultConfig()
+			dismissals = &drift.Dismissals{}
 		}
 
 		calc := drift.NewCalculator(bl, current, driftConfig)
+		calc.SetIssues(issues)
+		calc.SetNow(analysisNow)
+		calc.SetDismissals(dismissals)
 		result := calc.Calculate()
 
+		if *dismissDrift != "" {
+			if dismissals.Has(*dismissDrift) {
+				fmt.Printf("Drift alert %s is already dismissed\n", *dismissDrift)
+				os.Exit(0)
+			}
+			var target *drift.Alert
+			for i := range result.Alerts {
+				if result.Alerts[i].Fingerprint == *dismissDrift {
+					target = &result.Alerts[i]
+					break
+				}
+			}
+			if target == nil {
+				fmt.Fprintf(os.Stderr, "Error: no current drift alert has fingerprint %q\n", *dismissDrift)
+				fmt.Fprintln(os.Stderr, "Run bv --check-drift to see alert fingerprints.")
+				os.Exit(1)
+			}
+			dismissals.Dismiss(*target, bl.ID(), analysisNow)
+			if err := dismissals.Save(projectDir); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving drift dismissals: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Dismissed drift alert %s: [%s] %s\n", target.Fingerprint, target.Type, target.Message)
+			os.Exit(0)
+		}
+
+		// Record this run for --drift-trend
+		entry := drift.NewHistoryEntry(result, current, *baselineName, analysisNow)
+		if err := drift.AppendDriftHistory(projectDir, entry, driftConfig.HistoryMaxEntries); err != nil && !envRobot {
+			fmt.Fprintf(os.Stderr, "Warning: Error recording drift history: %v\n", err)
+		}
+
+		format := *driftFormat
 		if *robotDriftCheck {
+			format = "json"
+		}
+		exitCode, _ := driftExitCode(result.ExitCode(), *driftExitPolicy) // validated above
+
+		switch format {
+		case "json":
 			// JSON output
 			output := struct {
 				GeneratedAt string `json:"generated_at"`
@@ -2485,16 +2851,20 @@ func main() {
 					Warning  int `json:"warning"`
 					Info     int `json:"info"`
 				} `json:"summary"`
-				Alerts   []drift.Alert `json:"alerts"`
-				Baseline struct {
+				Alerts    []drift.Alert    `json:"alerts"`
+				Groups    map[string][]int `json:"groups,omitempty"` // Indexes into alerts by owning label
+				Dismissed []drift.Alert    `json:"dismissed,omitempty"`
+				Baseline  struct {
 					CreatedAt string `json:"created_at"`
 					CommitSHA string `json:"commit_sha,omitempty"`
 				} `json:"baseline"`
 			}{
 				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
 				HasDrift:    result.HasDrift,
-				ExitCode:    result.ExitCode(),
+				ExitCode:    exitCode,
 				Alerts:      result.Alerts,
+				Groups:      drift.GroupAlertIndexesByLabel(result.Alerts, issues),
+				Dismissed:   result.Dismissed,
 			}
 			output.Summary.Critical = result.CriticalCount
 			output.Summary.Warning = result.WarningCount
@@ -2507,12 +2877,25 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error encoding drift result: %v\n", err)
 				os.Exit(1)
 			}
-		} else {
+		case "markdown", "md":
+			fmt.Print(drift.RenderMarkdown(drift.DriftReport{
+				Result:       result,
+				Baseline:     bl,
+				Current:      current,
+				BaselineName: *baselineName,
+			}))
+		case "text":
 			// Human-readable output
 			fmt.Print(result.Summary())
+			if *verboseFlag {
+				fmt.Print(result.DismissedSummary())
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Error: invalid --drift-format %q (use text, json, or markdown)\n", format)
+			os.Exit(1)
 		}
 
-		os.Exit(result.ExitCode())
+		os.Exit(exitCode)
 	}
 
 	if *robotInsights {
@@ -2917,7 +3300,7 @@ func main() {
 								}
 							}
 
-							correlator := correlation.NewCorrelator(cwd, beadsPath)
+							correlator := newCorrelator(cwd, beadsPath)
 							opts := correlation.CorrelatorOptions{Limit: limit}
 
 							// Swallow errors for triage flow - staleness is optional
@@ -2938,7 +3321,8 @@ func main() {
 			UseFastConfig: true, // Use minimal Phase 2 config for robot mode (bv-t1js)
 			History:       historyReport,
 		}
-		triage := analysis.ComputeTriageWithOptions(issues, opts)
+		triageAnalyzer, triageStats := analysis.AnalyzeForTriage(issues, opts)
+		triage := analysis.ComputeTriageFromAnalyzer(triageAnalyzer, triageStats, issues, opts, time.Now())
 
 		// bv-90: Load feedback data for output
 		var feedbackInfo *analysis.FeedbackJSON
@@ -2982,6 +3366,7 @@ func main() {
 				Score      float64  `json:"score"`
 				Reasons    []string `json:"reasons"`
 				Unblocks   int      `json:"unblocks"`
+				Impact     int      `json:"unblock_impact"` // Includes cascading unblocks
 				ClaimCmd   string   `json:"claim_command"`
 				ShowCmd    string   `json:"show_command"`
 			}{
@@ -2993,6 +3378,7 @@ func main() {
 				Score:         top.Score,
 				Reasons:       top.Reasons,
 				Unblocks:      top.Unblocks,
+				Impact:        triageAnalyzer.UnblockImpact(top.ID),
 				ClaimCmd:      fmt.Sprintf("br update %s --status=in_progress", top.ID),
 				ShowCmd:       fmt.Sprintf("br show %s", top.ID),
 			}
@@ -3235,7 +3621,7 @@ func main() {
 	}
 
 	// Handle --robot-history flag
-	if *robotHistory || *beadHistory != "" {
+	if *robotHistory || *beadHistory != "" || *robotCorrelate {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
@@ -3289,7 +3675,7 @@ func main() {
 		}
 
 		// Generate report with explicit beads path
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
@@ -3318,6 +3704,20 @@ func main() {
 			}
 		}
 
+		// Flat export of the correlated commits
+		if *robotCorrelate {
+			commits := correlation.CorrelatedCommitsFromHistories(report.Histories)
+			write := correlation.WriteCorrelatedCommitsJSON
+			if robotOutputFormat == "csv" {
+				write = correlation.WriteCorrelatedCommitsCSV
+			}
+			if err := write(os.Stdout, commits); err != nil {
+				fmt.Fprintf(os.Stderr, "Error writing correlated commits: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
 		// Output JSON
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(report); err != nil {
@@ -3380,7 +3780,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := newCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3459,7 +3859,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := newCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3529,7 +3929,7 @@ func main() {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
 				os.Exit(1)
 			}
-			correlator := correlation.NewCorrelator(cwd, beadsPath)
+			correlator := newCorrelator(cwd, beadsPath)
 
 			beadInfos := make([]correlation.BeadInfo, len(issues))
 			for i, issue := range issues {
@@ -3613,7 +4013,7 @@ func main() {
 		}
 
 		// Generate history report first (to get existing correlations)
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		correlatorOpts := correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		}
@@ -3711,7 +4111,7 @@ func main() {
 		}
 
 		// Generate history report first
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3810,7 +4210,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3894,7 +4294,7 @@ func main() {
 			}
 		}
 
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -3969,7 +4369,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := newCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -4067,6 +4467,31 @@ func main() {
 		os.Exit(0)
 	}
 
+	// Handle --robot-explain flag
+	// Uses the issues already in scope so --as-of and filters apply.
+	if *robotExplain != "" {
+		explanation, err := analysis.NewAnalyzer(issues).ExplainIssue(*robotExplain)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		output := struct {
+			RobotEnvelope
+			Result analysis.IssueExplanation `json:"result"`
+		}{
+			RobotEnvelope: NewRobotEnvelope(dataHash),
+			Result:        explanation,
+		}
+
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding explanation: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	// Handle --robot-impact-network flag (bv-48kr)
 	// Use "all" for full network or a bead ID for subnetwork
 	if *robotImpactNetwork != "" {
@@ -4106,7 +4531,7 @@ func main() {
 		}
 
 		// Generate history report
-		correlator := correlation.NewCorrelator(cwd, beadsPath)
+		correlator := newCorrelator(cwd, beadsPath)
 		report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -4195,7 +4620,7 @@ func main() {
 			}
 		}
 
-		correlatorObj := correlation.NewCorrelator(cwd, beadsPath)
+		correlatorObj := newCorrelator(cwd, beadsPath)
 		report, err := correlatorObj.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 			Limit: *historyLimit,
 		})
@@ -5700,6 +6125,75 @@ func filterByRepo(issues []model.Issue, repoFilter string) []model.Issue {
 	return result
 }
 
+// analysisTimeForAsOf returns the reference time for time-dependent analysis.
+// A date or timestamp --as-of value pins it to that moment; an empty value or
+// a git revision (SHA, branch, tag) uses now. Values shaped like a date that
+// fail to parse are rejected rather than silently treated as a git ref.
+func analysisTimeForAsOf(asOf string, now time.Time) (time.Time, error) {
+	if asOf == "" {
+		return now.UTC(), nil
+	}
+	if t, ok := loader.ParseRevisionDate(asOf); ok {
+		return t.UTC(), nil
+	}
+	if looksLikeDate(asOf) {
+		return time.Time{}, fmt.Errorf("invalid --as-of timestamp %q: use RFC3339 (e.g. 2024-01-05T17:00:00Z) or YYYY-MM-DD", asOf)
+	}
+	return now.UTC(), nil
+}
+
+// driftClosedPerWeek is the closure velocity recorded for drift, leaving out
+// issues with labels the label health config excludes from velocity
+func driftClosedPerWeek(projectDir string, issues []model.Issue, now time.Time) float64 {
+	cfg, err := analysis.LoadLabelHealthConfig(projectDir)
+	if err != nil {
+		cfg = analysis.DefaultLabelHealthConfig()
+	}
+	return drift.ClosedPerWeekExcluding(issues, now, cfg.ExcludeFromVelocity)
+}
+
+// driftLabelHealth is the per-label health recorded for drift, scored with
+// the project's label health config
+func driftLabelHealth(projectDir string, issues []model.Issue, now time.Time) map[string]baseline.LabelHealthSummary {
+	cfg, err := analysis.LoadLabelHealthConfig(projectDir)
+	if err != nil {
+		cfg = analysis.DefaultLabelHealthConfig()
+	}
+	return drift.ComputeLabelHealth(issues, cfg, now)
+}
+
+// driftExitCode maps a drift result's exit code (0 ok, 1 critical, 2
+// warning) to the process exit code under a --drift-exit-policy mode:
+// strict keeps it, warn-ok lets warnings pass, report-only never fails.
+func driftExitCode(code int, policy string) (int, error) {
+	switch policy {
+	case "", "strict":
+		return code, nil
+	case "warn-ok":
+		if code == 2 {
+			return 0, nil
+		}
+		return code, nil
+	case "report-only":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("invalid --drift-exit-policy %q (use strict, warn-ok, or report-only)", policy)
+	}
+}
+
+// looksLikeDate reports whether s starts with a YYYY-MM-DD date
+func looksLikeDate(s string) bool {
+	if len(s) < 10 || s[4] != '-' || s[7] != '-' {
+		return false
+	}
+	for _, i := range []int{0, 1, 2, 3, 5, 6, 8, 9} {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // buildMetricItems converts a metrics map to a sorted slice of MetricItems
 func buildMetricItems(metrics map[string]float64, limit int) []baseline.MetricItem {
 	if len(metrics) == 0 {
@@ -7343,7 +7837,7 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	}
 
 	// Generate correlation report
-	correlator := correlation.NewCorrelator(cwd, beadsPath)
+	correlator := newCorrelator(cwd, beadsPath)
 	report, err := correlator.GenerateReport(beadInfos, correlation.CorrelatorOptions{
 		Limit: 500, // Reasonable limit for time-travel
 	})
@@ -7407,6 +7901,19 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 	}, nil
 }
 
+// correlationCacheEnabled is cleared by --no-correlation-cache
+var correlationCacheEnabled = true
+
+// newCorrelator creates a correlator that reuses co-commit scans from
+// .bv/correlation-cache.json unless --no-correlation-cache was given
+func newCorrelator(repoPath, beadsPath string) *correlation.Correlator {
+	c := correlation.NewCorrelator(repoPath, beadsPath)
+	if correlationCacheEnabled {
+		c.SetCachePath(correlation.CoCommitCachePath(repoPath))
+	}
+	return c
+}
+
 var robotOutputFormat = "json"
 var robotToonEncodeOptions = toon.DefaultEncodeOptions()
 var robotShowToonStats bool
@@ -7641,7 +8148,13 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		"robot-history": {
 			Flag: "--robot-history", Description: "Bead-to-commit correlations from git history.",
 			KeyFields:   []string{"correlations", "confidence", "commit_sha", "bead_id"},
-			Params:      []string{"--bead-history <id>", "--history-since <date>", "--history-limit <n>", "--min-confidence 0.0-1.0"},
+			Params:      []string{"--bead-history <id>", "--history-since <date>", "--history-limit <n>", "--min-confidence 0.0-1.0", "--no-correlation-cache"},
+			NeedsIssues: true,
+		},
+		"robot-correlate": {
+			Flag: "--robot-correlate", Description: "Correlated commits as a flat list, one row per bead/commit pair.",
+			KeyFields:   []string{"bead_id", "sha", "method", "confidence", "files_changed"},
+			Params:      []string{"--format json|csv", "--bead-history <id>", "--history-since <date>", "--history-limit <n>", "--min-confidence 0.0-1.0"},
 			NeedsIssues: true,
 		},
 		"robot-diff": {
@@ -7662,6 +8175,21 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Flag: "--robot-label-flow", Description: "Cross-label dependency flow analysis.",
 			NeedsIssues: true,
 		},
+		"robot-labels": {
+			Flag: "--robot-labels", Description: "Full label health analysis; exits 1 if any label is below the health threshold.",
+			Params:      []string{"--labels-min-health <n>", "--format csv"},
+			NeedsIssues: true,
+		},
+		"robot-label": {
+			Flag: "--robot-label <name>", Description: "Everything about one label: health, co-occurring labels, inbound/outbound flow, stale and top issues.",
+			KeyFields:   []string{"spotlight.health", "spotlight.co_occurring", "spotlight.inbound", "spotlight.outbound", "spotlight.stale_issues", "spotlight.top_issues"},
+			NeedsIssues: true,
+		},
+		"robot-health": {
+			Flag: "--robot-health", Description: "One project health score rolled up from label health, weighted by label size.",
+			KeyFields:   []string{"project_health.health", "project_health.health_level", "project_health.reasons"},
+			NeedsIssues: true,
+		},
 		"robot-label-attention": {
 			Flag: "--robot-label-attention", Description: "Attention-ranked labels requiring focus.",
 			Params:      []string{"--attention-limit <n>"},
@@ -7705,6 +8233,10 @@ func generateRobotDocs(topic string) map[string]interface{} {
 			Flag: "--robot-blocker-chain <id>", Description: "Full blocker chain analysis for an issue.",
 			NeedsIssues: true,
 		},
+		"robot-explain": {
+			Flag: "--robot-explain <id>", Description: "Everything about one issue: centrality, open blockers, unblock impact, critical path and ready status.",
+			NeedsIssues: true,
+		},
 		"robot-impact-network": {
 			Flag: "--robot-impact-network [<id>|all]", Description: "Impact network graph (full or subnetwork for a bead).",
 			Params:      []string{"--network-depth 1-3"},
@@ -7738,6 +8270,7 @@ func generateRobotDocs(topic string) map[string]interface{} {
 		},
 		"robot-drift": {
 			Flag: "--robot-drift", Description: "Drift detection from saved baseline.",
+			Params:      []string{"--drift-exit-policy strict|warn-ok|report-only"},
 			NeedsIssues: true,
 		},
 	}
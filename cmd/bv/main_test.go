@@ -268,3 +268,37 @@ func repoRoot(t *testing.T) string {
 		dir = parent
 	}
 }
+
+func TestAnalysisTimeForAsOf(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		asOf    string
+		want    time.Time
+		wantErr bool
+	}{
+		{asOf: "", want: now},
+		{asOf: "HEAD~3", want: now},
+		{asOf: "v1.0.0", want: now},
+		{asOf: "2024-01-05T17:00:00Z", want: time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC)},
+		{asOf: "2024-01-05T19:00:00+02:00", want: time.Date(2024, 1, 5, 17, 0, 0, 0, time.UTC)},
+		{asOf: "2024-13-45T00:00:00Z", wantErr: true},
+		{asOf: "2024-01-05T25:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := analysisTimeForAsOf(tt.asOf, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("analysisTimeForAsOf(%q) = %v, want error", tt.asOf, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("analysisTimeForAsOf(%q) error: %v", tt.asOf, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("analysisTimeForAsOf(%q) = %v, want %v", tt.asOf, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package jsonl implements the append-only JSON Lines history files kept
+// under .bv (drift history, label health history): appending one record per
+// line, reading the lines back, and rotating out the oldest records.
+package jsonl
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxLineBytes bounds a single record when reading a history file
+const maxLineBytes = 4 * 1024 * 1024
+
+// Append marshals v and appends it as one line to path, creating the file
+// and its parent directory if needed.
+func Append(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshaling entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating history directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", path, err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadLines returns the non-blank lines of path, oldest first. Errors from
+// opening the file are returned unwrapped so callers can test os.IsNotExist.
+func ReadLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineBytes)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// KeepLast rewrites path to hold only its newest maxLines lines. It is a
+// no-op when the file already fits.
+func KeepLast(path string, maxLines int) error {
+	lines, err := ReadLines(path)
+	if err != nil {
+		return err
+	}
+	if len(lines) <= maxLines {
+		return nil
+	}
+	return rewrite(path, lines[len(lines)-maxLines:])
+}
+
+// TrimToSize drops the oldest lines once path is larger than maxBytes,
+// keeping the newest lines that fit in maxBytes/2 so that appends don't
+// rewrite the file every time. The newest line is always kept.
+func TrimToSize(path string, maxBytes int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("rotating %s: %w", path, err)
+	}
+	if info.Size() <= maxBytes {
+		return nil
+	}
+
+	lines, err := ReadLines(path)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	keep := len(lines) - 1
+	size := int64(len(lines[keep]) + 1)
+	for keep > 0 && size+int64(len(lines[keep-1])+1) <= maxBytes/2 {
+		keep--
+		size += int64(len(lines[keep]) + 1)
+	}
+	return rewrite(path, lines[keep:])
+}
+
+// rewrite atomically replaces path with lines
+func rewrite(path string, lines []string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		return fmt.Errorf("rotating %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("rotating %s: %w", path, err)
+	}
+	return nil
+}
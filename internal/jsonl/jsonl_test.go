@@ -0,0 +1,76 @@
+package jsonl
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+type record struct {
+	N int `json:"n"`
+}
+
+func TestAppendAndReadLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".bv", "history.jsonl")
+	for i := 0; i < 3; i++ {
+		if err := Append(path, record{N: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lines, err := ReadLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`{"n":0}`, `{"n":1}`, `{"n":2}`}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+
+	if _, err := ReadLines(filepath.Join(t.TempDir(), "missing.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("missing file should report IsNotExist, got %v", err)
+	}
+}
+
+func TestKeepLast(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	for i := 0; i < 5; i++ {
+		if err := Append(path, record{N: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := KeepLast(path, 2); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := ReadLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{`{"n":3}`, `{"n":4}`}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestTrimToSizeKeepsNewest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	for i := 0; i < 10; i++ {
+		if err := Append(path, record{N: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each line is 8 bytes; a 1-byte budget still keeps the newest line.
+	if err := TrimToSize(path, 1); err != nil {
+		t.Fatal(err)
+	}
+	lines, err := ReadLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{`{"n":9}`}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+}
@@ -0,0 +1,82 @@
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Default PageRank parameters, used when AnalyzerOptions leaves a field zero
+const (
+	DefaultPageRankDamping       = 0.85
+	DefaultPageRankMaxIterations = 1000
+	DefaultPageRankTolerance     = 1e-6
+)
+
+// AnalyzerOptions tunes the PageRank computation. Zero fields take the
+// defaults above, so AnalyzerOptions{DampingFactor: 0.7} only changes damping.
+type AnalyzerOptions struct {
+	DampingFactor float64 `json:"damping_factor"` // Probability of following an edge, in (0,1)
+	MaxIterations int     `json:"max_iterations"` // Hard cap on power iterations
+	Tolerance     float64 `json:"tolerance"`      // Stop once the L2 change drops below this
+}
+
+// DefaultAnalyzerOptions returns the PageRank parameters NewAnalyzer uses
+func DefaultAnalyzerOptions() AnalyzerOptions {
+	return AnalyzerOptions{
+		DampingFactor: DefaultPageRankDamping,
+		MaxIterations: DefaultPageRankMaxIterations,
+		Tolerance:     DefaultPageRankTolerance,
+	}
+}
+
+// withDefaults fills zero fields with the default parameters
+func (o AnalyzerOptions) withDefaults() AnalyzerOptions {
+	def := DefaultAnalyzerOptions()
+	if o.DampingFactor == 0 {
+		o.DampingFactor = def.DampingFactor
+	}
+	if o.MaxIterations == 0 {
+		o.MaxIterations = def.MaxIterations
+	}
+	if o.Tolerance == 0 {
+		o.Tolerance = def.Tolerance
+	}
+	return o
+}
+
+// Validate checks the options after defaults are applied
+func (o AnalyzerOptions) Validate() error {
+	o = o.withDefaults()
+	if o.DampingFactor <= 0 || o.DampingFactor >= 1 {
+		return fmt.Errorf("damping factor must be between 0 and 1 (exclusive), got %g", o.DampingFactor)
+	}
+	if o.MaxIterations < 0 {
+		return fmt.Errorf("max iterations must be positive, got %d", o.MaxIterations)
+	}
+	if o.Tolerance < 0 {
+		return fmt.Errorf("tolerance must be positive, got %g", o.Tolerance)
+	}
+	return nil
+}
+
+// cacheSuffix distinguishes non-default options in analysis cache keys.
+// Defaults yield "" so existing cache entries stay valid.
+func (o AnalyzerOptions) cacheSuffix() string {
+	o = o.withDefaults()
+	if o == DefaultAnalyzerOptions() {
+		return ""
+	}
+	return fmt.Sprintf("|pr:%g:%d:%g", o.DampingFactor, o.MaxIterations, o.Tolerance)
+}
+
+// NewAnalyzerWithOptions is NewAnalyzer with tunable PageRank parameters.
+// The effective parameters are reported on GraphStats.Options.
+func NewAnalyzerWithOptions(issues []model.Issue, opts AnalyzerOptions) (*Analyzer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	a := NewAnalyzer(issues)
+	a.options = opts.withDefaults()
+	return a, nil
+}
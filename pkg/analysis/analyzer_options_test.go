@@ -0,0 +1,112 @@
+package analysis_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// hubAndChainIssues builds an asymmetric graph: HUB has three direct
+// dependents, while DEEP sits at the end of a 20-issue dependency chain.
+// Low damping favors direct in-links; high damping lets rank flow down the
+// whole chain.
+func hubAndChainIssues() []model.Issue {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "HUB", Status: model.StatusOpen, Labels: []string{"hub"}},
+		{ID: "DEEP", Status: model.StatusOpen, Labels: []string{"deep"}},
+	}
+	for i := 0; i < 3; i++ {
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("LEAF-%d", i), Status: model.StatusOpen, Dependencies: blocks("HUB")})
+	}
+	const chain = 20
+	for i := 0; i < chain; i++ {
+		next := fmt.Sprintf("CHAIN-%d", i+1)
+		if i == chain-1 {
+			next = "DEEP"
+		}
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("CHAIN-%d", i), Status: model.StatusOpen, Dependencies: blocks(next)})
+	}
+	return issues
+}
+
+func TestNewAnalyzerWithOptions_DampingChangesOrdering(t *testing.T) {
+	issues := hubAndChainIssues()
+
+	rank := func(damping float64) (hub, deep float64, opts analysis.AnalyzerOptions) {
+		t.Helper()
+		a, err := analysis.NewAnalyzerWithOptions(issues, analysis.AnalyzerOptions{DampingFactor: damping})
+		if err != nil {
+			t.Fatalf("NewAnalyzerWithOptions(%g): %v", damping, err)
+		}
+		stats := a.Analyze()
+		hub, _ = stats.PageRankValue("HUB")
+		deep, _ = stats.PageRankValue("DEEP")
+		return hub, deep, stats.Options
+	}
+
+	hub, deep, low := rank(0.6)
+	if hub <= deep {
+		t.Errorf("damping 0.6: expected HUB (%.4f) above DEEP (%.4f)", hub, deep)
+	}
+	hub, deep, high := rank(0.9)
+	if deep <= hub {
+		t.Errorf("damping 0.9: expected DEEP (%.4f) above HUB (%.4f)", deep, hub)
+	}
+
+	want := analysis.AnalyzerOptions{DampingFactor: 0.9, MaxIterations: analysis.DefaultPageRankMaxIterations, Tolerance: analysis.DefaultPageRankTolerance}
+	if high != want {
+		t.Errorf("GraphStats.Options = %+v, want %+v", high, want)
+	}
+	if low.DampingFactor != 0.6 {
+		t.Errorf("low damping stats report %g", low.DampingFactor)
+	}
+	if def := analysis.NewAnalyzer(issues).Analyze(); def.Options != analysis.DefaultAnalyzerOptions() {
+		t.Errorf("NewAnalyzer should report default options, got %+v", def.Options)
+	}
+}
+
+func TestAnalyzerOptionsValidate(t *testing.T) {
+	for _, damping := range []float64{-0.5, 1, 1.5} {
+		if _, err := analysis.NewAnalyzerWithOptions(nil, analysis.AnalyzerOptions{DampingFactor: damping}); err == nil {
+			t.Errorf("damping %g: expected an error", damping)
+		}
+	}
+	if err := (analysis.AnalyzerOptions{MaxIterations: -1}).Validate(); err == nil {
+		t.Error("negative max iterations should be rejected")
+	}
+	if err := (analysis.AnalyzerOptions{}).Validate(); err != nil {
+		t.Errorf("zero options mean defaults, got %v", err)
+	}
+}
+
+func TestComputeAllLabelHealthWithOptions(t *testing.T) {
+	issues := hubAndChainIssues()
+	now := time.Now()
+	cfg := analysis.DefaultLabelHealthConfig()
+
+	if _, err := analysis.ComputeAllLabelHealthWithOptions(issues, cfg, now, nil, analysis.AnalyzerOptions{DampingFactor: 2}); err == nil {
+		t.Fatal("expected invalid damping to be rejected")
+	}
+
+	criticality := func(damping float64) map[string]float64 {
+		result, err := analysis.ComputeAllLabelHealthWithOptions(issues, cfg, now, nil, analysis.AnalyzerOptions{DampingFactor: damping})
+		if err != nil {
+			t.Fatal(err)
+		}
+		out := make(map[string]float64)
+		for _, h := range result.Labels {
+			out[h.Label] = h.Criticality.AvgPageRank
+		}
+		return out
+	}
+	low, high := criticality(0.6), criticality(0.9)
+	if low["hub"] <= low["deep"] || high["deep"] <= high["hub"] {
+		t.Errorf("label PageRank should follow damping: 0.6 %v, 0.9 %v", low, high)
+	}
+}
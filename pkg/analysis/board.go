@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Board is a status board snapshot: issues grouped into the columns of the
+// Board view, each sorted by priority, then PageRank (highest first), then ID.
+type Board struct {
+	Open       []model.Issue `json:"open"`
+	InProgress []model.Issue `json:"in_progress"`
+	Blocked    []model.Issue `json:"blocked"`
+	Closed     []model.Issue `json:"closed"`
+	Counts     BoardCounts   `json:"counts"`
+}
+
+// BoardCounts holds the number of issues in each board column
+type BoardCounts struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Closed     int `json:"closed"`
+	Total      int `json:"total"`
+}
+
+// BuildBoard classifies issues into board columns. Blocking is taken from
+// the dependency graph, not just the stored status: an open issue with an
+// open blocker lands in Blocked even though it says "open". Issues marked
+// blocked stay there, and in-progress work stays in In Progress so active
+// work remains visible. analyzer may be nil, in which case one is built
+// from issues.
+func BuildBoard(issues []model.Issue, analyzer *Analyzer) Board {
+	if analyzer == nil {
+		analyzer = NewAnalyzer(issues)
+	}
+
+	var board Board
+	for _, issue := range issues {
+		switch {
+		case isClosedLikeStatus(issue.Status):
+			board.Closed = append(board.Closed, issue)
+		case issue.Status == model.StatusInProgress:
+			board.InProgress = append(board.InProgress, issue)
+		case issue.Status == model.StatusBlocked || len(analyzer.GetOpenBlockers(issue.ID)) > 0:
+			board.Blocked = append(board.Blocked, issue)
+		default:
+			board.Open = append(board.Open, issue)
+		}
+	}
+
+	stats := analyzer.Analyze()
+	for _, col := range [][]model.Issue{board.Open, board.InProgress, board.Blocked, board.Closed} {
+		sortBoardColumn(col, &stats)
+	}
+
+	board.Counts = BoardCounts{
+		Open:       len(board.Open),
+		InProgress: len(board.InProgress),
+		Blocked:    len(board.Blocked),
+		Closed:     len(board.Closed),
+		Total:      len(issues),
+	}
+	return board
+}
+
+// sortBoardColumn orders a column by priority, then PageRank, then ID
+func sortBoardColumn(col []model.Issue, stats *GraphStats) {
+	sort.SliceStable(col, func(i, j int) bool {
+		if col[i].Priority != col[j].Priority {
+			return col[i].Priority < col[j].Priority
+		}
+		pi, _ := stats.PageRankValue(col[i].ID)
+		pj, _ := stats.PageRankValue(col[j].ID)
+		if pi != pj {
+			return pi > pj
+		}
+		return col[i].ID < col[j].ID
+	})
+}
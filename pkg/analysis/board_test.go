@@ -0,0 +1,72 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func boardIDs(issues []model.Issue) []string {
+	ids := make([]string, len(issues))
+	for i, issue := range issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestBuildBoard_GraphAwareBlocked(t *testing.T) {
+	blockedBy := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "ROOT", Status: model.StatusOpen, Priority: 1},
+		{ID: "FREE", Status: model.StatusOpen, Priority: 2},
+		// Stored as open but waiting on ROOT
+		{ID: "WAITING", Status: model.StatusOpen, Priority: 1, Dependencies: blockedBy("ROOT")},
+		// Its only blocker is closed, so it stays open
+		{ID: "RELEASED", Status: model.StatusOpen, Priority: 3, Dependencies: blockedBy("DONE")},
+		{ID: "DONE", Status: model.StatusClosed, Priority: 1},
+		{ID: "WIP", Status: model.StatusInProgress, Priority: 2, Dependencies: blockedBy("ROOT")},
+		{ID: "MARKED", Status: model.StatusBlocked, Priority: 2},
+	}
+
+	board := analysis.BuildBoard(issues, nil)
+
+	check := func(name string, got []model.Issue, want ...string) {
+		t.Helper()
+		if ids := boardIDs(got); !reflect.DeepEqual(ids, want) {
+			t.Errorf("%s column = %v, want %v", name, ids, want)
+		}
+	}
+	check("open", board.Open, "ROOT", "FREE", "RELEASED")
+	check("blocked", board.Blocked, "WAITING", "MARKED")
+	check("in progress", board.InProgress, "WIP")
+	check("closed", board.Closed, "DONE")
+
+	want := analysis.BoardCounts{Open: 3, InProgress: 1, Blocked: 2, Closed: 1, Total: 7}
+	if board.Counts != want {
+		t.Errorf("counts = %+v, want %+v", board.Counts, want)
+	}
+}
+
+func TestBuildBoard_SortsByPriorityThenPageRank(t *testing.T) {
+	// HUB and LONE share a priority; HUB blocks two issues so it ranks higher
+	issues := []model.Issue{
+		{ID: "A-LONE", Status: model.StatusOpen, Priority: 1},
+		{ID: "Z-HUB", Status: model.StatusOpen, Priority: 1},
+		{ID: "URGENT", Status: model.StatusOpen, Priority: 0},
+		{ID: "X", Status: model.StatusOpen, Priority: 2, Dependencies: []*model.Dependency{{DependsOnID: "Z-HUB", Type: model.DepBlocks}}},
+		{ID: "Y", Status: model.StatusOpen, Priority: 2, Dependencies: []*model.Dependency{{DependsOnID: "Z-HUB", Type: model.DepBlocks}}},
+	}
+	an := analysis.NewAnalyzer(issues)
+	board := analysis.BuildBoard(issues, an)
+
+	if got, want := boardIDs(board.Open), []string{"URGENT", "Z-HUB", "A-LONE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("open column = %v, want %v", got, want)
+	}
+	if got, want := boardIDs(board.Blocked), []string{"X", "Y"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("blocked column = %v, want %v", got, want)
+	}
+}
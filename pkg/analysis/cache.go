@@ -18,7 +18,7 @@ import (
 )
 
 const (
-	robotAnalysisDiskCacheVersion      = 1
+	robotAnalysisDiskCacheVersion      = 2
 	robotAnalysisDiskCacheFileName     = "analysis_cache.json"
 	robotAnalysisDiskCacheDirName      = "bv"
 	robotAnalysisDiskCacheMaxEntries   = 10
@@ -610,6 +610,8 @@ func (ca *CachedAnalyzer) Analyze() GraphStats {
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
 		Config:            stats.Config,
+		Options:           stats.Options,
+		dependsOn:         stats.dependsOn,
 		pageRank:          stats.pageRank,
 		betweenness:       stats.betweenness,
 		eigenvector:       stats.eigenvector,
@@ -645,13 +647,15 @@ type robotAnalysisDiskCacheEntry struct {
 }
 
 type graphStatsCacheBlob struct {
-	OutDegree        map[string]int `json:"out_degree"`
-	InDegree         map[string]int `json:"in_degree"`
-	TopologicalOrder []string       `json:"topological_order"`
-	Density          float64        `json:"density"`
-	NodeCount        int            `json:"node_count"`
-	EdgeCount        int            `json:"edge_count"`
-	Config           AnalysisConfig `json:"config"`
+	OutDegree        map[string]int      `json:"out_degree"`
+	InDegree         map[string]int      `json:"in_degree"`
+	TopologicalOrder []string            `json:"topological_order"`
+	Density          float64             `json:"density"`
+	NodeCount        int                 `json:"node_count"`
+	EdgeCount        int                 `json:"edge_count"`
+	Config           AnalysisConfig      `json:"config"`
+	Options          AnalyzerOptions     `json:"options"`
+	DependsOn        map[string][]string `json:"depends_on,omitempty"`
 
 	PageRank          map[string]float64 `json:"page_rank"`
 	Betweenness       map[string]float64 `json:"betweenness"`
@@ -675,6 +679,8 @@ func (b graphStatsCacheBlob) toGraphStats() *GraphStats {
 		NodeCount:        b.NodeCount,
 		EdgeCount:        b.EdgeCount,
 		Config:           b.Config,
+		Options:          b.Options.withDefaults(),
+		dependsOn:        b.DependsOn,
 
 		phase2Ready: true,
 		phase2Done:  make(chan struct{}),
@@ -864,6 +870,8 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 		NodeCount:        stats.NodeCount,
 		EdgeCount:        stats.EdgeCount,
 		Config:           stats.Config,
+		Options:          stats.Options,
+		DependsOn:        stats.dependsOn,
 
 		PageRank:          stats.pageRank,
 		Betweenness:       stats.betweenness,
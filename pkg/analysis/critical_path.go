@@ -0,0 +1,187 @@
+package analysis
+
+import "sort"
+
+// CriticalPath returns the longest chain of blocking dependencies as issue
+// IDs, ordered from the root blocker (nothing left to wait on) to the issue
+// that waits on the whole chain. Cycles are broken by ignoring back-edges.
+// Returns nil when no issue has a blocking dependency.
+func (s *GraphStats) CriticalPath() []string {
+	paths := s.CriticalPaths(1)
+	if len(paths) == 0 {
+		return nil
+	}
+	return paths[0]
+}
+
+// CriticalPaths returns up to topN distinct blocker chains, longest first.
+// Each chain ends at an issue nothing else depends on and follows its
+// deepest dependency at every step (ties broken by smallest ID), so chains
+// may share a prefix but never share an end. Ties in length sort by end ID.
+func (s *GraphStats) CriticalPaths(topN int) [][]string {
+	return longestChains(s.dependsOn, topN)
+}
+
+// longestChains implements CriticalPaths over an issue -> blocking
+// dependencies map.
+func longestChains(dependsOn map[string][]string, topN int) [][]string {
+	if topN <= 0 || len(dependsOn) == 0 {
+		return nil
+	}
+
+	deps := acyclicDependencies(dependsOn)
+
+	// depth[id] is the number of issues in the longest chain ending at id;
+	// next[id] is the dependency that chain continues through.
+	depth := make(map[string]int)
+	next := make(map[string]string)
+	var longest func(id string) int
+	longest = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		best, via := 0, ""
+		for _, dep := range deps[id] { // sorted, so first max wins ties
+			if d := longest(dep); d > best {
+				best, via = d, dep
+			}
+		}
+		depth[id] = best + 1
+		if via != "" {
+			next[id] = via
+		}
+		return best + 1
+	}
+
+	dependedOn := make(map[string]bool)
+	for _, list := range deps {
+		for _, dep := range list {
+			dependedOn[dep] = true
+		}
+	}
+
+	var ends []string
+	for _, id := range sortedKeys(deps) {
+		if !dependedOn[id] && longest(id) >= 2 {
+			ends = append(ends, id)
+		}
+	}
+	sort.SliceStable(ends, func(i, j int) bool {
+		return depth[ends[i]] > depth[ends[j]]
+	})
+	if len(ends) > topN {
+		ends = ends[:topN]
+	}
+
+	paths := make([][]string, 0, len(ends))
+	for _, end := range ends {
+		path := make([]string, depth[end])
+		for i, id := len(path)-1, end; i >= 0; i-- {
+			path[i] = id
+			id = next[id]
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// DependencyDepth returns, for every issue in the graph, the length of the
+// longest chain of blockers reaching it: 0 for an issue with no blocking
+// dependencies, 1 for one waiting only on such issues, and so on. Cycles are
+// broken by ignoring back-edges, as in CriticalPath, so depth stays finite.
+// The returned map is a copy and safe to modify.
+func (s *GraphStats) DependencyDepth() map[string]int {
+	depths := s.dependencyDepths()
+	cp := make(map[string]int, len(depths))
+	for id, d := range depths {
+		cp[id] = d
+	}
+	return cp
+}
+
+// dependencyDepths returns the memoized DependencyDepth map, computing it on
+// the first call. dependsOn is set in Phase 1, so no Phase 2 wait is needed.
+func (s *GraphStats) dependencyDepths() map[string]int {
+	s.mu.RLock()
+	depths := s.dependencyDepth
+	s.mu.RUnlock()
+	if depths != nil {
+		return depths
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.dependencyDepth == nil {
+		s.dependencyDepth = chainDepths(s.dependsOn, s.OutDegree)
+	}
+	return s.dependencyDepth
+}
+
+// chainDepths computes DependencyDepth over an issue -> blocking
+// dependencies map. Every key of nodes gets an entry.
+func chainDepths(dependsOn map[string][]string, nodes map[string]int) map[string]int {
+	deps := acyclicDependencies(dependsOn)
+	depth := make(map[string]int, len(nodes))
+	var longest func(id string) int
+	longest = func(id string) int {
+		if d, ok := depth[id]; ok {
+			return d
+		}
+		best := 0
+		for _, dep := range deps[id] {
+			best = max(best, longest(dep)+1)
+		}
+		depth[id] = best
+		return best
+	}
+	for id := range nodes {
+		longest(id)
+	}
+	for id := range dependsOn {
+		longest(id)
+	}
+	return depth
+}
+
+// acyclicDependencies returns a copy of deps without the back-edges found by
+// a depth-first walk in ID order, leaving a DAG.
+func acyclicDependencies(deps map[string][]string) map[string][]string {
+	const (
+		unvisited = iota
+		onStack
+		done
+	)
+	state := make(map[string]int)
+	out := make(map[string][]string, len(deps))
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = onStack
+		for _, dep := range deps[id] {
+			switch state[dep] {
+			case onStack:
+				continue // back-edge closes a cycle
+			case unvisited:
+				visit(dep)
+			}
+			out[id] = append(out[id], dep)
+		}
+		state[id] = done
+	}
+
+	for _, id := range sortedKeys(deps) {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
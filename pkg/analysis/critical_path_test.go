@@ -0,0 +1,183 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// blockedIssue returns an open issue blocked by each of blockers
+func blockedIssue(id string, blockers ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask}
+	for _, b := range blockers {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+			IssueID: id, DependsOnID: b, Type: model.DepBlocks,
+		})
+	}
+	return issue
+}
+
+func TestCriticalPath_Chain(t *testing.T) {
+	// A <- B <- C <- D, plus a short side chain E <- F
+	issues := []model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C", "B"),
+		blockedIssue("D", "C"),
+		blockedIssue("E"),
+		blockedIssue("F", "E"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	if got, want := stats.CriticalPath(), []string{"A", "B", "C", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CriticalPath() = %v, want %v", got, want)
+	}
+
+	paths := stats.CriticalPaths(5)
+	want := [][]string{{"A", "B", "C", "D"}, {"E", "F"}}
+	if !reflect.DeepEqual(paths, want) {
+		t.Errorf("CriticalPaths(5) = %v, want %v", paths, want)
+	}
+	if got := stats.CriticalPaths(1); len(got) != 1 {
+		t.Errorf("CriticalPaths(1) returned %d paths", len(got))
+	}
+	if got := stats.CriticalPaths(0); got != nil {
+		t.Errorf("CriticalPaths(0) = %v, want nil", got)
+	}
+}
+
+func TestCriticalPath_PrefersDeepestBranch(t *testing.T) {
+	// D depends on both C (depth 3 via B, A) and X (depth 1)
+	issues := []model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C", "B"),
+		blockedIssue("X"),
+		blockedIssue("D", "C", "X"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	if got, want := stats.CriticalPath(), []string{"A", "B", "C", "D"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CriticalPath() = %v, want %v", got, want)
+	}
+}
+
+func TestCriticalPath_CycleTerminates(t *testing.T) {
+	// A -> B -> C -> A cycle, with D waiting on C
+	issues := []model.Issue{
+		blockedIssue("A", "B"),
+		blockedIssue("B", "C"),
+		blockedIssue("C", "A"),
+		blockedIssue("D", "C"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	done := make(chan []string, 1)
+	go func() { done <- stats.CriticalPath() }()
+
+	select {
+	case path := <-done:
+		// The back-edge C -> A is dropped, leaving A -> B -> C as the deepest chain
+		if len(path) != 3 {
+			t.Errorf("expected a 3-issue chain, got %v", path)
+		}
+		seen := make(map[string]bool)
+		for _, id := range path {
+			if seen[id] {
+				t.Fatalf("path repeats %s: %v", id, path)
+			}
+			seen[id] = true
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("CriticalPath did not terminate on a cyclic graph")
+	}
+}
+
+func TestCriticalPath_NoDependencies(t *testing.T) {
+	stats := analysis.NewAnalyzer([]model.Issue{blockedIssue("A"), blockedIssue("B")}).Analyze()
+	if got := stats.CriticalPath(); got != nil {
+		t.Errorf("CriticalPath() = %v, want nil", got)
+	}
+}
+
+func TestCriticalPath_IgnoresRelatedEdges(t *testing.T) {
+	b := blockedIssue("B")
+	b.Dependencies = []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepRelated}}
+	stats := analysis.NewAnalyzer([]model.Issue{blockedIssue("A"), b}).Analyze()
+	if got := stats.CriticalPath(); got != nil {
+		t.Errorf("related edges should not form a chain, got %v", got)
+	}
+}
+
+func TestDependencyDepth_Chain(t *testing.T) {
+	// A <- B <- C <- D
+	issues := []model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C", "B"),
+		blockedIssue("D", "C"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	want := map[string]int{"A": 0, "B": 1, "C": 2, "D": 3}
+	if got := stats.DependencyDepth(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyDepth() = %v, want %v", got, want)
+	}
+
+	// Per-label average and maximum
+	for i := range issues {
+		issues[i].Labels = []string{"chain"}
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	crit := analysis.ComputeLabelHealthForLabel("chain", issues, analysis.DefaultLabelHealthConfig(), now, &stats).Criticality
+	if crit.AvgDependencyDepth != 1.5 || crit.MaxDependencyDepth != 3 {
+		t.Errorf("avg/max depth = %v/%d, want 1.5/3", crit.AvgDependencyDepth, crit.MaxDependencyDepth)
+	}
+}
+
+func TestDependencyDepth_DiamondTakesLongerPath(t *testing.T) {
+	// D waits on A through B (two steps) and through C1, C2 (three steps)
+	issues := []model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C1", "A"),
+		blockedIssue("C2", "C1"),
+		blockedIssue("D", "B", "C2"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	want := map[string]int{"A": 0, "B": 1, "C1": 1, "C2": 2, "D": 3}
+	if got := stats.DependencyDepth(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DependencyDepth() = %v, want %v", got, want)
+	}
+}
+
+func TestDependencyDepth_CycleIsCapped(t *testing.T) {
+	// A -> B -> C -> A cycle, with D waiting on C
+	issues := []model.Issue{
+		blockedIssue("A", "B"),
+		blockedIssue("B", "C"),
+		blockedIssue("C", "A"),
+		blockedIssue("D", "C"),
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+
+	depths := stats.DependencyDepth()
+	if len(depths) != 4 {
+		t.Fatalf("DependencyDepth() = %v, want an entry per issue", depths)
+	}
+	for id, d := range depths {
+		if d < 0 || d > 3 {
+			t.Errorf("depth of %s = %d, want at most 3 for a 4-issue graph", id, d)
+		}
+	}
+
+	// The result is a copy
+	depths["A"] = 99
+	if stats.DependencyDepth()["A"] == 99 {
+		t.Error("DependencyDepth() returned the memoized map")
+	}
+}
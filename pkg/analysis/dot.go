@@ -0,0 +1,150 @@
+package analysis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DOTOptions tunes GraphStats.WriteDOT
+type DOTOptions struct {
+	// Issues supplies titles, priorities and statuses for node labels. Nodes
+	// without a matching issue are labeled with their ID alone.
+	Issues []model.Issue
+
+	// ColorByStatus fills nodes with a color for their issue's status
+	ColorByStatus bool
+
+	// HighlightCycles outlines nodes and edges on dependency cycles in red.
+	// Cycles are only known once Phase 2 is done.
+	HighlightCycles bool
+
+	// Focus limits the output to a subgraph's issues when set
+	Focus *Subgraph
+}
+
+// dotTitleRunes caps the title shown in a node label
+const dotTitleRunes = 40
+
+// dotCycleColor marks cycle members when HighlightCycles is set
+const dotCycleColor = "#D32F2F"
+
+// WriteDOT writes the blocking dependency graph as a Graphviz digraph. Nodes
+// are issue IDs and each edge runs from an issue to the issue blocking it,
+// so arrowheads point at blockers. Output is sorted for stable diffs.
+func (s *GraphStats) WriteDOT(w io.Writer, opts DOTOptions) error {
+	issues := make(map[string]model.Issue, len(opts.Issues))
+	for _, iss := range opts.Issues {
+		issues[iss.ID] = iss
+	}
+
+	var focus map[string]bool
+	if opts.Focus != nil {
+		focus = make(map[string]bool, len(opts.Focus.Issues))
+		for _, iss := range opts.Focus.Issues {
+			focus[iss.ID] = true
+		}
+	}
+	ids := make([]string, 0, len(s.OutDegree))
+	for id := range s.OutDegree {
+		if focus == nil || focus[id] {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	included := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		included[id] = true
+	}
+
+	cycleNodes := make(map[string]bool)
+	cycleEdges := make(map[[2]string]bool)
+	if opts.HighlightCycles {
+		for _, cycle := range s.Cycles() {
+			for i, id := range cycle {
+				next := cycle[(i+1)%len(cycle)]
+				cycleNodes[id] = true
+				cycleEdges[[2]string{id, next}] = true
+				cycleEdges[[2]string{next, id}] = true
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph G {")
+	fmt.Fprintln(bw, "    rankdir=LR;")
+	fmt.Fprintln(bw, "    node [shape=box, fontname=\"Helvetica\", fontsize=10];")
+	fmt.Fprintln(bw)
+
+	for _, id := range ids {
+		attrs := []string{fmt.Sprintf("label=%s", dotQuote(dotNodeLabel(id, issues)))}
+		if iss, ok := issues[id]; ok && opts.ColorByStatus {
+			attrs = append(attrs, "style=filled", fmt.Sprintf("fillcolor=%s", dotQuote(dotStatusColor(iss.Status))))
+		}
+		if cycleNodes[id] {
+			attrs = append(attrs, fmt.Sprintf("color=%s", dotQuote(dotCycleColor)), "penwidth=2")
+		}
+		fmt.Fprintf(bw, "    %s [%s];\n", dotQuote(id), strings.Join(attrs, ", "))
+	}
+
+	fmt.Fprintln(bw)
+	for _, id := range ids {
+		for _, blocker := range s.dependsOn[id] {
+			if !included[blocker] {
+				continue
+			}
+			if cycleEdges[[2]string{id, blocker}] {
+				fmt.Fprintf(bw, "    %s -> %s [color=%s, penwidth=2];\n", dotQuote(id), dotQuote(blocker), dotQuote(dotCycleColor))
+			} else {
+				fmt.Fprintf(bw, "    %s -> %s;\n", dotQuote(id), dotQuote(blocker))
+			}
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// dotStatusColor returns the node fill color for a status
+func dotStatusColor(status model.Status) string {
+	switch {
+	case isClosedLikeStatus(status):
+		return "#CFD8DC" // Light gray
+	case status == model.StatusOpen:
+		return "#C8E6C9" // Light green
+	case status == model.StatusInProgress:
+		return "#BBDEFB" // Light blue
+	case status == model.StatusBlocked:
+		return "#FFCDD2" // Light red
+	default:
+		return "#FFFFFF"
+	}
+}
+
+// dotNodeLabel is "ID\ntitle\nP<n> status" for known issues, else the ID
+func dotNodeLabel(id string, issues map[string]model.Issue) string {
+	iss, ok := issues[id]
+	if !ok {
+		return id
+	}
+	title := iss.Title
+	if runes := []rune(title); len(runes) > dotTitleRunes {
+		title = string(runes[:dotTitleRunes-1]) + "…"
+	}
+	return fmt.Sprintf("%s\n%s\nP%d %s", id, title, iss.Priority, iss.Status)
+}
+
+// dotQuote renders s as a DOT string literal. Quotes and backslashes are
+// escaped; newlines become DOT's centered line break.
+func dotQuote(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\r", "",
+		"\n", `\n`,
+	)
+	return `"` + r.Replace(s) + `"`
+}
@@ -0,0 +1,105 @@
+package analysis_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func dotFixtureIssues() []model.Issue {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "A", Title: `Say "hello"`, Status: model.StatusOpen, Priority: 1, Dependencies: blocks("B")},
+		{ID: "B", Title: "Base work", Status: model.StatusInProgress, Priority: 2},
+		{ID: "C", Title: "Loose end", Status: model.StatusClosed, Priority: 3,
+			Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+		{ID: "X", Title: "Cycle one", Status: model.StatusOpen, Dependencies: blocks("Y")},
+		{ID: "Y", Title: "Cycle two", Status: model.StatusOpen, Dependencies: blocks("X")},
+	}
+}
+
+func writeDOT(t *testing.T, issues []model.Issue, opts analysis.DOTOptions) string {
+	t.Helper()
+	a := analysis.NewAnalyzer(issues)
+	stats := a.Analyze()
+	var buf bytes.Buffer
+	if err := stats.WriteDOT(&buf, opts); err != nil {
+		t.Fatalf("WriteDOT: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWriteDOT(t *testing.T) {
+	issues := dotFixtureIssues()
+	out := writeDOT(t, issues, analysis.DOTOptions{Issues: issues, ColorByStatus: true})
+
+	if !strings.HasPrefix(out, "digraph G {\n") || !strings.HasSuffix(out, "}\n") {
+		t.Fatalf("not a digraph:\n%s", out)
+	}
+	for _, want := range []string{
+		`"A" [label="A\nSay \"hello\"\nP1 open", style=filled, fillcolor="#C8E6C9"];`,
+		`"B" [label="B\nBase work\nP2 in_progress", style=filled, fillcolor="#BBDEFB"];`,
+		`"C" [label="C\nLoose end\nP3 closed", style=filled, fillcolor="#CFD8DC"];`,
+		`"A" -> "B";`,
+		`"X" -> "Y";`,
+		`"Y" -> "X";`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+	// Related dependencies are not blocking edges
+	if strings.Contains(out, `"C" -> "A"`) {
+		t.Errorf("non-blocking dependency drawn as an edge:\n%s", out)
+	}
+}
+
+func TestWriteDOT_WithoutIssueDetails(t *testing.T) {
+	out := writeDOT(t, dotFixtureIssues(), analysis.DOTOptions{})
+	if !strings.Contains(out, `"A" [label="A"];`) {
+		t.Errorf("expected a bare ID label:\n%s", out)
+	}
+	if strings.Contains(out, "fillcolor") || strings.Contains(out, "penwidth") {
+		t.Errorf("expected no styling without options:\n%s", out)
+	}
+}
+
+func TestWriteDOT_HighlightCycles(t *testing.T) {
+	out := writeDOT(t, dotFixtureIssues(), analysis.DOTOptions{HighlightCycles: true})
+	for _, want := range []string{
+		`"X" [label="X", color="#D32F2F", penwidth=2];`,
+		`"X" -> "Y" [color="#D32F2F", penwidth=2];`,
+		`"Y" -> "X" [color="#D32F2F", penwidth=2];`,
+		`"A" -> "B";`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %s in:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteDOT_Focus(t *testing.T) {
+	issues := dotFixtureIssues()
+	a := analysis.NewAnalyzer(issues)
+	stats := a.Analyze()
+	focus := a.FocusSubgraph("A", 1)
+
+	var buf bytes.Buffer
+	if err := stats.WriteDOT(&buf, analysis.DOTOptions{Focus: &focus}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, `"A" -> "B";`) {
+		t.Errorf("focus lost the A -> B edge:\n%s", out)
+	}
+	for _, id := range []string{`"C"`, `"X"`, `"Y"`} {
+		if strings.Contains(out, id) {
+			t.Errorf("%s is outside the focus but was written:\n%s", id, out)
+		}
+	}
+}
@@ -280,6 +280,26 @@ func computeMedianEstimatedMinutes(issues []model.Issue) int {
 	return estimates[mid]
 }
 
+// hasEstimates reports whether any issue has a positive EstimatedMinutes
+func hasEstimates(issues []model.Issue) bool {
+	for _, issue := range issues {
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// effortMinutes returns an issue's estimate, or fallback when it has none.
+// Callers pass the median of the known estimates, so unestimated issues
+// count as typical-sized rather than zero.
+func effortMinutes(issue model.Issue, fallback int) float64 {
+	if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+		return float64(*issue.EstimatedMinutes)
+	}
+	return float64(fallback)
+}
+
 func durationDays(days float64) time.Duration {
 	if days <= 0 {
 		return 0
@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+)
+
+// IssueExplanation bundles the graph analysis of a single issue, so robot
+// consumers can get everything about it in one call.
+type IssueExplanation struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Status   string   `json:"status"`
+	Priority int      `json:"priority"`
+	Labels   []string `json:"labels"`
+
+	// Readiness
+	Ready        bool     `json:"ready"`         // Open or in progress with no open blockers
+	Blocked      bool     `json:"blocked"`       // Not closed and waiting on open blockers
+	OpenBlockers []string `json:"open_blockers"` // Direct blockers still open
+
+	// Downstream impact
+	Dependents     []string `json:"dependents"`      // Issues that directly depend on this one
+	DirectUnblocks []string `json:"direct_unblocks"` // Dependents closing this would make actionable
+	UnblockImpact  int      `json:"unblock_impact"`  // Issues made actionable, including cascades
+
+	// Centrality
+	PageRank        float64 `json:"pagerank"`
+	PageRankRank    int     `json:"pagerank_rank"`
+	Betweenness     float64 `json:"betweenness"`
+	BetweennessRank int     `json:"betweenness_rank"`
+
+	// Critical path
+	CriticalPathScore float64 `json:"critical_path_score"` // Depth of the chain waiting on this issue
+	Slack             float64 `json:"slack"`
+	OnCriticalPath    bool    `json:"on_critical_path"` // Zero slack: lies on a longest dependency chain
+}
+
+// ExplainIssue composes the analyzer's primitives into an IssueExplanation
+// for issueID. It runs a full synchronous analysis for the centrality and
+// critical path metrics. Returns an error if the issue is unknown.
+func (a *Analyzer) ExplainIssue(issueID string) (IssueExplanation, error) {
+	issue, ok := a.issueMap[issueID]
+	if !ok {
+		return IssueExplanation{}, fmt.Errorf("issue %q not found", issueID)
+	}
+
+	stats := a.Analyze()
+	exp := IssueExplanation{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+		Labels:   issue.Labels,
+
+		Ready:        a.isReady(issue),
+		Blocked:      a.IsBlocked(issueID),
+		OpenBlockers: a.GetOpenBlockers(issueID),
+
+		Dependents:     a.dependents(issueID),
+		DirectUnblocks: a.computeUnblocks(issueID),
+		UnblockImpact:  a.UnblockImpact(issueID),
+	}
+	if exp.Labels == nil {
+		exp.Labels = []string{}
+	}
+	if exp.OpenBlockers == nil {
+		exp.OpenBlockers = []string{}
+	}
+	if exp.DirectUnblocks == nil {
+		exp.DirectUnblocks = []string{}
+	}
+
+	exp.PageRank, _ = stats.PageRankValue(issueID)
+	exp.PageRankRank, _ = stats.PageRankRankValue(issueID)
+	exp.Betweenness, _ = stats.BetweennessValue(issueID)
+	exp.BetweennessRank, _ = stats.BetweennessRankValue(issueID)
+	exp.CriticalPathScore, _ = stats.CriticalPathValue(issueID)
+	if slack, ok := stats.SlackValue(issueID); ok {
+		exp.Slack = slack
+		exp.OnCriticalPath = slack == 0 && !stats.HasCycle()
+	}
+	return exp, nil
+}
+
+// dependents returns the non-closed issues that directly depend on issueID,
+// sorted by ID.
+func (a *Analyzer) dependents(issueID string) []string {
+	out := []string{}
+	nodeID, ok := a.idToNode[issueID]
+	if !ok {
+		return out
+	}
+	for to := a.g.To(nodeID); to.Next(); {
+		id := a.nodeToID[to.Node().ID()]
+		if issue, ok := a.issueMap[id]; ok && !isClosedLikeStatus(issue.Status) {
+			out = append(out, id)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -71,9 +71,15 @@ type GraphStats struct {
 	NodeCount        int // Number of nodes in graph
 	EdgeCount        int // Number of edges in graph
 
+	// Blocking dependencies per issue (read-only after init; see CriticalPath)
+	dependsOn map[string][]string
+
 	// Configuration used for this analysis (read-only after init)
 	Config AnalysisConfig
 
+	// Effective PageRank parameters, defaults filled in (read-only after init)
+	Options AnalyzerOptions
+
 	// Phase 2 - Computed in background, access via thread-safe methods only
 	mu                sync.RWMutex
 	phase2Ready       bool
@@ -101,6 +107,12 @@ type GraphStats struct {
 
 	// Phase 2 status flags for robot visibility
 	status MetricStatus
+
+	// Memoized centrality view shared by per-label computations (guarded by mu)
+	centrality *centralitySnapshot
+
+	// Memoized DependencyDepth result (guarded by mu)
+	dependencyDepth map[string]int
 }
 
 // metricStatus captures per-metric computation outcome for transparency.
@@ -586,12 +598,21 @@ func (s *GraphStats) OutDegreeRankValue(id string) (int, bool) {
 // single value is needed. Prefer the *Value() accessors above.
 // -----------------------------------------------------------------------------
 
-// PageRank returns a copy of the PageRank map. Safe for concurrent iteration.
-// Returns an empty map if Phase 2 is not yet complete.
+// PageRank returns the PageRank map. Once Phase 2 is complete this is the
+// map held by the memoized centrality view, shared by every caller: it must
+// be treated as read-only. Before then a fresh copy is returned (nil if
+// nothing has been computed yet).
 //
 // Deprecated: For single-value lookups, use PageRankValue() instead.
-// For iteration, use PageRankAll(). This method copies O(n) data.
+// For iteration, use PageRankAll().
 func (s *GraphStats) PageRank() map[string]float64 {
+	if !s.IsPhase2Ready() {
+		return s.copyPageRank()
+	}
+	return s.centralityView().pageRank
+}
+
+func (s *GraphStats) copyPageRank() map[string]float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	if s.pageRank == nil {
@@ -604,9 +625,17 @@ func (s *GraphStats) PageRank() map[string]float64 {
 	return cp
 }
 
-// Betweenness returns a copy of the Betweenness map. Safe for concurrent iteration.
-// Returns an empty map if Phase 2 is not yet complete.
+// Betweenness returns the Betweenness map. Like PageRank, once Phase 2 is
+// complete the map is shared with the centrality view and must be treated as
+// read-only; before then a fresh copy is returned.
 func (s *GraphStats) Betweenness() map[string]float64 {
+	if !s.IsPhase2Ready() {
+		return s.copyBetweenness()
+	}
+	return s.centralityView().betweenness
+}
+
+func (s *GraphStats) copyBetweenness() map[string]float64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	if s.betweenness == nil {
@@ -831,7 +860,9 @@ func (s *GraphStats) OutDegreeRank() map[string]int {
 }
 
 // Cycles returns a copy of detected cycles. Safe for concurrent iteration.
-// Returns nil if Phase 2 is not yet complete.
+// Each cycle is one per strongly-connected component (Tarjan) of the blocking
+// graph, listed as closed issue IDs starting at the smallest ID ([A, B, C, A]);
+// self-loops appear as [A, A]. Returns nil if Phase 2 is not yet complete.
 func (s *GraphStats) Cycles() [][]string {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -845,6 +876,82 @@ func (s *GraphStats) Cycles() [][]string {
 	return cp
 }
 
+// HasCycle reports whether any dependency cycle was detected.
+// Returns false if Phase 2 is not yet complete.
+func (s *GraphStats) HasCycle() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.cycles) > 0
+}
+
+// centralitySnapshot is a read-only view of PageRank and betweenness along
+// with their graph-wide maxima. It references the Phase 2 maps directly since
+// those are immutable once Phase 2 completes.
+type centralitySnapshot struct {
+	pageRank       map[string]float64
+	betweenness    map[string]float64
+	maxPageRank    float64
+	maxBetweenness float64
+
+	betweennessDesc []float64 // All betweenness values, highest first
+}
+
+// bottleneckThreshold returns the lowest betweenness that still ranks in the
+// top percentile of all issues. Only issues strictly above zero and at or
+// above the threshold count as bottlenecks; ties at the cutoff are included.
+func (c *centralitySnapshot) bottleneckThreshold(percentile float64) float64 {
+	if len(c.betweennessDesc) == 0 || percentile <= 0 {
+		return math.Inf(1)
+	}
+	k := int(math.Ceil(float64(len(c.betweennessDesc)) * percentile / 100))
+	if k < 1 {
+		k = 1
+	}
+	if k > len(c.betweennessDesc) {
+		k = len(c.betweennessDesc)
+	}
+	return c.betweennessDesc[k-1]
+}
+
+// centralityView returns the memoized centrality view, building it on the
+// first call after Phase 2 completes. Before Phase 2 is ready an empty,
+// uncached snapshot is returned so a later call can pick up the real values.
+func (s *GraphStats) centralityView() *centralitySnapshot {
+	s.mu.RLock()
+	snap := s.centrality
+	s.mu.RUnlock()
+	if snap != nil {
+		return snap
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.centrality != nil {
+		return s.centrality
+	}
+	if !s.phase2Ready {
+		return &centralitySnapshot{}
+	}
+	// The betweenness map may omit zero scores; pad so percentiles are
+	// taken over every issue in the graph
+	desc := make([]float64, 0, max(len(s.betweenness), s.NodeCount))
+	for _, v := range s.betweenness {
+		desc = append(desc, v)
+	}
+	for len(desc) < s.NodeCount {
+		desc = append(desc, 0)
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(desc)))
+	s.centrality = &centralitySnapshot{
+		pageRank:        s.pageRank,
+		betweenness:     s.betweenness,
+		maxPageRank:     findMax(s.pageRank),
+		maxBetweenness:  findMax(s.betweenness),
+		betweennessDesc: desc,
+	}
+	return s.centrality
+}
+
 // NewGraphStatsForTest creates a GraphStats with the given data for testing.
 // This allows tests to create GraphStats with specific values without needing
 // to run the full analyzer.
@@ -1173,6 +1280,11 @@ type Analyzer struct {
 	blockerCounts    []int
 	blockerCountsMax int
 	config           *AnalysisConfig // Optional custom config, nil means use size-based defaults
+	options          AnalyzerOptions // PageRank parameters (see NewAnalyzerWithOptions)
+
+	// Memoized blocked set shared by GetActionableIssues and ReadyIssues
+	blockedOnce sync.Once
+	blocked     map[string]bool
 }
 
 // SetConfig sets a custom analysis configuration.
@@ -1316,6 +1428,7 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 		issueMap:         issueMap,
 		blockerCounts:    blockerCounts,
 		blockerCountsMax: maxBlockers,
+		options:          DefaultAnalyzerOptions(),
 	}
 }
 
@@ -1344,7 +1457,7 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	nodeCount := len(a.issueMap)
 	edgeCount := a.g.Edges().Len()
 
-	configHash := ComputeConfigHash(&config)
+	configHash := ComputeConfigHash(&config) + a.options.cacheSuffix()
 	incCacheKey := ""
 	if !robotDiskCacheEnabled() {
 		incCacheKey = a.graphStructureHash() + "|" + configHash
@@ -1373,6 +1486,7 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 		NodeCount:         nodeCount,
 		EdgeCount:         edgeCount,
 		Config:            config,
+		Options:           a.options,
 		phase2Done:        make(chan struct{}),
 		pageRank:          make(map[string]float64),
 		betweenness:       make(map[string]float64),
@@ -1461,6 +1575,8 @@ func (a *Analyzer) Analyze() GraphStats {
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
 		Config:            stats.Config,
+		Options:           stats.Options,
+		dependsOn:         stats.dependsOn,
 		pageRank:          stats.pageRank,
 		betweenness:       stats.betweenness,
 		eigenvector:       stats.eigenvector,
@@ -1496,6 +1612,8 @@ func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 		NodeCount:         stats.NodeCount,
 		EdgeCount:         stats.EdgeCount,
 		Config:            stats.Config,
+		Options:           stats.Options,
+		dependsOn:         stats.dependsOn,
 		pageRank:          stats.pageRank,
 		betweenness:       stats.betweenness,
 		eigenvector:       stats.eigenvector,
@@ -1540,6 +1658,7 @@ func (a *Analyzer) AnalyzeWithProfile(config AnalysisConfig) (*GraphStats, *Star
 		NodeCount:         nodeCount,
 		EdgeCount:         edgeCount,
 		Config:            config,
+		Options:           a.options,
 		phase2Done:        make(chan struct{}),
 		pageRank:          make(map[string]float64),
 		betweenness:       make(map[string]float64),
@@ -1589,6 +1708,7 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 		from := a.g.From(n.ID())
 		stats.OutDegree[id] = from.Len()
 	}
+	stats.dependsOn = a.dependencyLists()
 	profile.Degree = time.Since(degreeStart)
 
 	// Topological Sort
@@ -1601,12 +1721,7 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 	}
 	profile.TopoSort = time.Since(topoStart)
 
-	// Density
-	n := float64(len(a.issueMap))
-	e := float64(a.g.Edges().Len())
-	if n > 1 {
-		stats.Density = e / (n * (n - 1))
-	}
+	stats.Density = GraphDensity(len(a.issueMap), a.g.Edges().Len())
 }
 
 // computePhase2WithProfile calculates expensive metrics with timing instrumentation.
@@ -1636,7 +1751,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
+			prDone <- computePageRank(a.g, a.options.DampingFactor, a.options.Tolerance, a.options.MaxIterations)
 		}()
 
 		timer := time.NewTimer(config.PageRankTimeout)
@@ -1809,6 +1924,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 					}
 					localCycles = append(localCycles, cycleIDs)
 				}
+				canonicalizeCycles(localCycles)
 			case <-timer.C:
 				profile.CyclesTO = true
 			case <-ctx.Done():
@@ -1920,6 +2036,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 		from := a.g.From(n.ID())
 		stats.OutDegree[id] = from.Len() // Issues I depend on
 	}
+	stats.dependsOn = a.dependencyLists()
 
 	// Topological Sort (execution order)
 	// Note: In our graph model, edge u -> v means u depends on v, so we reverse
@@ -1931,12 +2048,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 		}
 	}
 
-	// Density
-	n := float64(len(a.issueMap))
-	e := float64(a.g.Edges().Len())
-	if n > 1 {
-		stats.Density = e / (n * (n - 1))
-	}
+	stats.Density = GraphDensity(len(a.issueMap), a.g.Edges().Len())
 
 	// Compute Phase 1 Ranks
 	stats.inDegreeRank = computeIntRanks(stats.InDegree)
@@ -1984,6 +2096,36 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 	}
 }
 
+// GraphDensity returns the density of a directed graph, edges / (n*(n-1)).
+// Graphs with fewer than two nodes have density 0.
+func GraphDensity(nodes, edges int) float64 {
+	if nodes < 2 {
+		return 0
+	}
+	n := float64(nodes)
+	return float64(edges) / (n * (n - 1))
+}
+
+// dependencyLists returns each issue's blocking dependencies, sorted by ID.
+func (a *Analyzer) dependencyLists() map[string][]string {
+	deps := make(map[string][]string, len(a.issueMap))
+	nodes := a.g.Nodes()
+	for nodes.Next() {
+		nid := nodes.Node().ID()
+		from := a.g.From(nid)
+		if from.Len() == 0 {
+			continue
+		}
+		list := make([]string, 0, from.Len())
+		for from.Next() {
+			list = append(list, a.nodeToID[from.Node().ID()])
+		}
+		sort.Strings(list)
+		deps[a.nodeToID[nid]] = list
+	}
+	return deps
+}
+
 func (a *Analyzer) computeHeights(sorted []graph.Node) map[string]float64 {
 	heights := make(map[int64]float64)
 	impactScores := make(map[string]float64)
@@ -2333,6 +2475,41 @@ func findArticulationPoints(adj undirectedAdjacency) map[int64]bool {
 // Missing blockers don't block (graceful degradation).
 // Returns list sorted by ID for determinism.
 func (a *Analyzer) GetActionableIssues() []model.Issue {
+	blocked := a.blockedSet()
+
+	// Collect actionable issues (not closed, not blocked).
+	var ids []string
+	for id := range a.issueMap {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var actionable []model.Issue
+	for _, id := range ids {
+		issue := a.issueMap[id]
+		if isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		if blocked[id] {
+			continue
+		}
+		actionable = append(actionable, issue)
+	}
+
+	return actionable
+}
+
+// blockedSet returns the non-closed issues that cannot start: those with an
+// open blocking dependency, and (transitively) the children of blocked
+// parents. Computed once per Analyzer; callers must not modify the map.
+func (a *Analyzer) blockedSet() map[string]bool {
+	a.blockedOnce.Do(func() {
+		a.blocked = a.computeBlockedSet()
+	})
+	return a.blocked
+}
+
+func (a *Analyzer) computeBlockedSet() map[string]bool {
 	// Phase 1: Compute the set of directly blocked issues.
 	// An issue is directly blocked if it has an open blocking-type dependency.
 	directlyBlocked := make(map[string]bool)
@@ -2396,26 +2573,7 @@ func (a *Analyzer) GetActionableIssues() []model.Issue {
 		}
 	}
 
-	// Phase 4: Collect actionable issues (not closed, not blocked).
-	var ids []string
-	for id := range a.issueMap {
-		ids = append(ids, id)
-	}
-	sort.Strings(ids)
-
-	var actionable []model.Issue
-	for _, id := range ids {
-		issue := a.issueMap[id]
-		if isClosedLikeStatus(issue.Status) {
-			continue
-		}
-		if blocked[id] {
-			continue
-		}
-		actionable = append(actionable, issue)
-	}
-
-	return actionable
+	return blocked
 }
 
 // GetIssue returns a single issue by ID, or nil if not found
@@ -2444,26 +2602,51 @@ func (a *Analyzer) GetBlockers(issueID string) []string {
 	return blockers
 }
 
-// GetOpenBlockers returns the IDs of non-closed issues that block the given issue
+// GetOpenBlockers returns the IDs of non-closed issues that block the given
+// issue. Only blocking dependencies count; related, parent-child and
+// discovered-from edges never block.
 func (a *Analyzer) GetOpenBlockers(issueID string) []string {
+	return a.GetOpenBlockersOfType(issueID, model.DepBlocks)
+}
+
+// GetOpenBlockersOfType returns the IDs of non-closed issues the given issue
+// depends on through any of the listed dependency types, for callers that
+// want soft edges (e.g. DepRelated) too. Untyped legacy dependencies count
+// as DepBlocks. Each blocker is listed once, in dependency order.
+func (a *Analyzer) GetOpenBlockersOfType(issueID string, types ...model.DependencyType) []string {
 	issue, ok := a.issueMap[issueID]
 	if !ok {
 		return nil
 	}
 
 	var openBlockers []string
+	seen := make(map[string]bool)
 	for _, dep := range issue.Dependencies {
-		if dep != nil && dep.Type.IsBlocking() {
-			if blocker, exists := a.issueMap[dep.DependsOnID]; exists {
-				if !isClosedLikeStatus(blocker.Status) {
-					openBlockers = append(openBlockers, dep.DependsOnID)
-				}
+		if dep == nil || seen[dep.DependsOnID] || !dependencyTypeIn(dep.Type, types) {
+			continue
+		}
+		if blocker, exists := a.issueMap[dep.DependsOnID]; exists {
+			if !isClosedLikeStatus(blocker.Status) {
+				seen[dep.DependsOnID] = true
+				openBlockers = append(openBlockers, dep.DependsOnID)
 			}
 		}
 	}
 	return openBlockers
 }
 
+// dependencyTypeIn reports whether t is one of types. Asking for a blocking
+// type matches every type in model.BlockingDepTypes, including the legacy
+// empty type.
+func dependencyTypeIn(t model.DependencyType, types []model.DependencyType) bool {
+	for _, want := range types {
+		if t == want || (want.IsBlocking() && t.IsBlocking()) {
+			return true
+		}
+	}
+	return false
+}
+
 // BlockerChainEntry represents a single entry in a blocker chain.
 type BlockerChainEntry struct {
 	ID          string `json:"id"`
@@ -2619,15 +2802,18 @@ func (a *Analyzer) countBlockedBy(issueID string) int {
 // computePageRank returns PageRank weights for nodes of g.
 //
 // It uses a deterministic power iteration with damping factor damp and terminates
-// when the L2 norm of the delta is below tol (or after a hard iteration cap).
-func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
+// when the L2 norm of the delta is below tol (or after maxIterations).
+func computePageRank(g graph.Directed, damp, tol float64, maxIterations int) map[int64]float64 {
 	nodes := graph.NodesOf(g.Nodes())
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
 	if len(nodes) == 0 {
 		return map[int64]float64{}
 	}
 	if tol <= 0 {
-		tol = 1e-6
+		tol = DefaultPageRankTolerance
+	}
+	if maxIterations <= 0 {
+		maxIterations = DefaultPageRankMaxIterations
 	}
 
 	// In this codebase, node IDs are densely allocated by gonum (0..n-1), so we
@@ -2685,7 +2871,6 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	next := make([]float64, len(nodes))
 
 	base := (1 - damp) / n
-	const maxIterations = 1000
 	for iter := 0; iter < maxIterations; iter++ {
 		for i := range next {
 			next[i] = base
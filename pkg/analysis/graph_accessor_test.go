@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"math"
+	"reflect"
 	"testing"
 )
 
@@ -378,3 +379,30 @@ func TestIsArticulationPoint(t *testing.T) {
 		t.Error("nonexistent should not exist")
 	}
 }
+
+func TestPageRankBetweennessShared(t *testing.T) {
+	stats := createTestGraphStatsForAccessors()
+
+	pr1, pr2 := stats.PageRank(), stats.PageRank()
+	if len(pr1) != 3 {
+		t.Fatalf("expected 3 PageRank entries, got %d", len(pr1))
+	}
+	if reflect.ValueOf(pr1).Pointer() != reflect.ValueOf(pr2).Pointer() {
+		t.Error("PageRank() should return the shared map once Phase 2 is ready")
+	}
+	if reflect.ValueOf(pr1).Pointer() != reflect.ValueOf(stats.centralityView().pageRank).Pointer() {
+		t.Error("PageRank() should come from the centrality view")
+	}
+	bw1, bw2 := stats.Betweenness(), stats.Betweenness()
+	if reflect.ValueOf(bw1).Pointer() != reflect.ValueOf(bw2).Pointer() {
+		t.Error("Betweenness() should return the shared map once Phase 2 is ready")
+	}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		_ = stats.PageRank()
+		_ = stats.Betweenness()
+	})
+	if allocs != 0 {
+		t.Errorf("shared accessors allocated %.0f times per run, want 0", allocs)
+	}
+}
@@ -141,3 +141,37 @@ func findOneCycleInSCC(g graph.Directed, scc []graph.Node) []graph.Node {
 
 	return nil
 }
+
+// canonicalizeCycles puts closed cycles ([a, b, c, a]) into a stable form:
+// each is rotated to start at its lexicographically smallest issue ID, and
+// cycles are ordered by length, then by their IDs.
+func canonicalizeCycles(cycles [][]string) {
+	for i, cycle := range cycles {
+		if len(cycle) < 2 {
+			continue
+		}
+		open := cycle[:len(cycle)-1] // Drop the closing repeat before rotating
+		minIdx := 0
+		for j, id := range open {
+			if id < open[minIdx] {
+				minIdx = j
+			}
+		}
+		rotated := make([]string, 0, len(cycle))
+		rotated = append(rotated, open[minIdx:]...)
+		rotated = append(rotated, open[:minIdx]...)
+		cycles[i] = append(rotated, rotated[0])
+	}
+
+	sort.SliceStable(cycles, func(i, j int) bool {
+		if len(cycles[i]) != len(cycles[j]) {
+			return len(cycles[i]) < len(cycles[j])
+		}
+		for k := range cycles[i] {
+			if cycles[i][k] != cycles[j][k] {
+				return cycles[i][k] < cycles[j][k]
+			}
+		}
+		return false
+	})
+}
@@ -1,8 +1,10 @@
 package analysis
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
 	graph "gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
@@ -294,6 +296,57 @@ func TestFindCyclesSafe_DiamondTopology(t *testing.T) {
 	}
 }
 
+func TestGraphStatsCycles_ReportsMembers(t *testing.T) {
+	// A <- B <- C <- A: each issue is blocked by the previous one
+	blocks := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	issues := []model.Issue{
+		{ID: "C", Status: model.StatusOpen, Dependencies: blocks("B")},
+		{ID: "A", Status: model.StatusOpen, Dependencies: blocks("C")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blocks("A")},
+	}
+
+	stats := NewAnalyzer(issues).Analyze()
+	want := [][]string{{"A", "B", "C", "A"}}
+	if got := stats.Cycles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Cycles() = %v, want %v", got, want)
+	}
+	if !stats.HasCycle() {
+		t.Error("HasCycle() = false, want true")
+	}
+}
+
+func TestGraphStatsCycles_AcyclicChain(t *testing.T) {
+	stats := NewAnalyzer(testutil.QuickChain(5)).Analyze()
+	if cycles := stats.Cycles(); len(cycles) != 0 {
+		t.Errorf("expected no cycles in a chain, got %v", cycles)
+	}
+	if stats.HasCycle() {
+		t.Error("HasCycle() = true, want false")
+	}
+}
+
+func TestCanonicalizeCycles(t *testing.T) {
+	cycles := [][]string{
+		{"z", "y", "x", "z"},
+		{"q", "p", "q"},
+		{"c", "a", "b", "c"},
+		{"s", "s"},
+	}
+	canonicalizeCycles(cycles)
+	want := [][]string{
+		{"s", "s"},
+		{"p", "q", "p"},
+		{"a", "b", "c", "a"},
+		{"x", "z", "y", "x"},
+	}
+	if !reflect.DeepEqual(cycles, want) {
+		t.Errorf("canonicalizeCycles = %v, want %v", cycles, want)
+	}
+}
+
 func BenchmarkFindCyclesSafe_Small(b *testing.B) {
 	g := buildTestGraph(5, [][2]int{{0, 1}, {1, 2}, {2, 0}})
 
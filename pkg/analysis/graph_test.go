@@ -2,6 +2,8 @@ package analysis_test
 
 import (
 	"fmt"
+	"math"
+	"reflect"
 	"sort"
 	"testing"
 	"time"
@@ -569,6 +571,75 @@ func TestGetOpenBlockers(t *testing.T) {
 	}
 }
 
+func TestGetOpenBlockersOfType(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"api"}, Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepRelated},
+			{DependsOnID: "D", Type: model.DepDiscoveredFrom},
+		}},
+		{ID: "B", Status: model.StatusOpen},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen},
+		// Only soft edges: not blocked
+		{ID: "E", Status: model.StatusOpen, Labels: []string{"ui"}, Dependencies: []*model.Dependency{
+			{DependsOnID: "C", Type: model.DepRelated},
+		}},
+	}
+	an := analysis.NewAnalyzer(issues)
+
+	if got := an.GetOpenBlockers("A"); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("GetOpenBlockers(A) = %v, want [B]", got)
+	}
+	if got := an.GetOpenBlockersOfType("A", model.DepBlocks, model.DepRelated); !reflect.DeepEqual(got, []string{"B", "C"}) {
+		t.Errorf("GetOpenBlockersOfType(A, blocks, related) = %v, want [B C]", got)
+	}
+	if got := an.GetOpenBlockersOfType("E", model.DepBlocks); len(got) != 0 {
+		t.Errorf("related edges should not block E, got %v", got)
+	}
+
+	blocked := analysis.ComputeBlockedByLabel(issues, an)
+	if blocked["api"] != 1 {
+		t.Errorf("api blocked = %d, want 1 (via the blocks edge)", blocked["api"])
+	}
+	if blocked["ui"] != 0 {
+		t.Errorf("ui blocked = %d, want 0 (related edge only)", blocked["ui"])
+	}
+}
+
+func TestGetOpenBlockersOfType_LegacyUntyped(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B"}}},
+		{ID: "B", Status: model.StatusOpen},
+	}
+	an := analysis.NewAnalyzer(issues)
+	if got := an.GetOpenBlockersOfType("A", model.DepBlocks); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("untyped dependency should count as blocks, got %v", got)
+	}
+	if got := an.GetOpenBlockersOfType("A", model.DepRelated); len(got) != 0 {
+		t.Errorf("untyped dependency is not related, got %v", got)
+	}
+	// Asking for the legacy empty type is the same as asking for blocks
+	if got := an.GetOpenBlockersOfType("A", ""); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("GetOpenBlockersOfType(A, \"\") = %v, want [B]", got)
+	}
+}
+
+func TestGetOpenBlockersOfType_FollowsBlockingDepTypes(t *testing.T) {
+	const gate model.DependencyType = "gates"
+	model.BlockingDepTypes[gate] = true
+	t.Cleanup(func() { delete(model.BlockingDepTypes, gate) })
+
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: gate}}},
+		{ID: "B", Status: model.StatusOpen},
+	}
+	an := analysis.NewAnalyzer(issues)
+	if got := an.GetOpenBlockers("A"); !reflect.DeepEqual(got, []string{"B"}) {
+		t.Errorf("a type added to BlockingDepTypes should block, got %v", got)
+	}
+}
+
 // TestAnalyzeCompletesWithinTimeout ensures that Analyze() does not hang
 // even on graphs that might cause HITS or cycle detection to take a long time.
 // This test creates a sparse graph structure that could cause convergence issues
@@ -866,3 +937,43 @@ func TestGetBlockerChain(t *testing.T) {
 		}
 	})
 }
+
+func TestGraphDensity(t *testing.T) {
+	tests := []struct {
+		nodes, edges int
+		want         float64
+	}{
+		{0, 0, 0},
+		{1, 0, 0},
+		{1, 1, 0},
+		{2, 1, 0.5},
+		{10, 1, 1.0 / 90},
+	}
+	for _, tt := range tests {
+		if got := analysis.GraphDensity(tt.nodes, tt.edges); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("GraphDensity(%d, %d) = %v, want %v", tt.nodes, tt.edges, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzeDensityMatchesGraphDensity(t *testing.T) {
+	// 10 issues with a single blocking edge
+	issues := make([]model.Issue, 10)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("I%d", i), Status: model.StatusOpen}
+	}
+	issues[1].Dependencies = []*model.Dependency{{IssueID: "I1", DependsOnID: "I0", Type: model.DepBlocks}}
+
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	if math.Abs(stats.Density-0.0111) > 0.0001 {
+		t.Errorf("Density = %v, want ≈ 0.0111", stats.Density)
+	}
+	if stats.Density != analysis.GraphDensity(stats.NodeCount, stats.EdgeCount) {
+		t.Errorf("Density %v disagrees with GraphDensity(%d, %d)", stats.Density, stats.NodeCount, stats.EdgeCount)
+	}
+
+	single := analysis.NewAnalyzer([]model.Issue{{ID: "solo", Status: model.StatusOpen}}).Analyze()
+	if single.Density != 0 {
+		t.Errorf("single-node Density = %v, want 0", single.Density)
+	}
+}
@@ -0,0 +1,226 @@
+package analysis
+
+import (
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelFilter decides whether a label takes part in an analysis. stats is the
+// label's extraction statistics and may be nil when none are available.
+// Filters compose as plain functions: AndFilters, OrFilters and NotFilter
+// evaluate their arguments left to right, so precedence is exactly the
+// nesting of the calls.
+type LabelFilter func(label string, stats *LabelStats) bool
+
+// AndFilters accepts a label only if every filter does. Nil filters are
+// skipped; with none left every label is accepted.
+func AndFilters(filters ...LabelFilter) LabelFilter {
+	return func(label string, stats *LabelStats) bool {
+		for _, f := range filters {
+			if f != nil && !f(label, stats) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// OrFilters accepts a label if any filter does. Nil filters are skipped;
+// with none left no label is accepted.
+func OrFilters(filters ...LabelFilter) LabelFilter {
+	return func(label string, stats *LabelStats) bool {
+		for _, f := range filters {
+			if f != nil && f(label, stats) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NotFilter inverts f. A nil f accepts everything, so its inverse accepts
+// nothing.
+func NotFilter(f LabelFilter) LabelFilter {
+	return func(label string, stats *LabelStats) bool {
+		return f != nil && !f(label, stats)
+	}
+}
+
+// RegexFilter accepts labels matching re
+func RegexFilter(re *regexp.Regexp) LabelFilter {
+	return func(label string, _ *LabelStats) bool {
+		return re.MatchString(label)
+	}
+}
+
+// MinIssuesFilter accepts labels carried by at least n issues, judged by
+// LabelStats.TotalCount. Labels without stats are rejected when n > 0.
+func MinIssuesFilter(n int) LabelFilter {
+	return func(_ string, stats *LabelStats) bool {
+		if stats == nil {
+			return n <= 0
+		}
+		return stats.TotalCount >= n
+	}
+}
+
+// ActiveWithinFilter accepts labels with an issue updated in the withinDays
+// days before now, the same test FilterActiveLabels applies to computed
+// results. withinDays <= 0 accepts every label.
+func ActiveWithinFilter(issues []model.Issue, now time.Time, withinDays int) LabelFilter {
+	if withinDays <= 0 {
+		return func(string, *LabelStats) bool { return true }
+	}
+	cutoff := now.Add(-time.Duration(withinDays) * 24 * time.Hour)
+	active := make(map[string]bool)
+	for _, iss := range issues {
+		if iss.UpdatedAt.IsZero() || iss.UpdatedAt.Before(cutoff) {
+			continue
+		}
+		for _, label := range iss.Labels {
+			active[label] = true
+		}
+	}
+	return func(label string, _ *LabelStats) bool {
+		return active[label]
+	}
+}
+
+// applyLabelFilter returns the labels accepted by filter, preserving order.
+// A nil filter keeps every label.
+func applyLabelFilter(labels []string, stats map[string]*LabelStats, filter LabelFilter) []string {
+	if filter == nil {
+		return labels
+	}
+	kept := make([]string, 0, len(labels))
+	for _, label := range labels {
+		if filter(label, stats[label]) {
+			kept = append(kept, label)
+		}
+	}
+	return kept
+}
+
+// ActiveLabelOptions tunes FilterActiveLabelsWithOptions
+type ActiveLabelOptions struct {
+	// RescopeFlow trims CrossLabelFlow to the active labels, dropping
+	// dependencies that touch a filtered-out label. By default the flow is
+	// kept as computed over every label.
+	RescopeFlow bool
+}
+
+// FilterActiveLabels returns a copy of result holding only labels with an
+// issue updated in the withinDays days before now, judged by
+// Freshness.MostRecentUpdate. TotalLabels, the level counters, Summaries and
+// AttentionNeeded are recomputed for the kept labels; each label keeps the
+// level and attention verdict it was scored with. CrossLabelFlow is kept
+// as-is. withinDays <= 0 keeps every label.
+func FilterActiveLabels(result LabelAnalysisResult, now time.Time, withinDays int) LabelAnalysisResult {
+	return FilterActiveLabelsWithOptions(result, now, withinDays, ActiveLabelOptions{})
+}
+
+// FilterActiveLabelsWithOptions is FilterActiveLabels with the option of
+// re-scoping CrossLabelFlow to the active labels.
+func FilterActiveLabelsWithOptions(result LabelAnalysisResult, now time.Time, withinDays int, opts ActiveLabelOptions) LabelAnalysisResult {
+	active := make(map[string]bool, len(result.Labels))
+	cutoff := now.Add(-time.Duration(withinDays) * 24 * time.Hour)
+	for _, h := range result.Labels {
+		last := h.Freshness.MostRecentUpdate
+		if withinDays <= 0 || (!last.IsZero() && !last.Before(cutoff)) {
+			active[h.Label] = true
+		}
+	}
+
+	filtered := LabelAnalysisResult{
+		GeneratedAt:     result.GeneratedAt,
+		Labels:          []LabelHealth{},
+		Summaries:       []LabelSummary{},
+		AttentionNeeded: []string{},
+	}
+	for _, h := range result.Labels {
+		if !active[h.Label] {
+			continue
+		}
+		filtered.Labels = append(filtered.Labels, h)
+		switch h.HealthLevel {
+		case HealthLevelHealthy:
+			filtered.HealthyCount++
+		case HealthLevelWarning:
+			filtered.WarningCount++
+		case HealthLevelCritical:
+			filtered.CriticalCount++
+		case HealthLevelInsufficient:
+			filtered.InsufficientCount++
+		}
+	}
+	filtered.TotalLabels = len(filtered.Labels)
+
+	// Summaries and AttentionNeeded are already ordered; filtering keeps that
+	for _, s := range result.Summaries {
+		if active[s.Label] {
+			filtered.Summaries = append(filtered.Summaries, s)
+		}
+	}
+	for _, label := range result.AttentionNeeded {
+		if active[label] {
+			filtered.AttentionNeeded = append(filtered.AttentionNeeded, label)
+		}
+	}
+
+	if result.CrossLabelFlow != nil {
+		flow := *result.CrossLabelFlow
+		if opts.RescopeFlow {
+			flow = restrictCrossLabelFlow(flow, active)
+		}
+		filtered.CrossLabelFlow = &flow
+	}
+	return filtered
+}
+
+// restrictCrossLabelFlow keeps the part of flow between the given labels,
+// recomputing the dependency total and bottleneck labels
+func restrictCrossLabelFlow(flow CrossLabelFlow, keep map[string]bool) CrossLabelFlow {
+	var kept []int
+	out := CrossLabelFlow{Labels: []string{}, FlowMatrix: [][]int{}, Dependencies: []LabelDependency{}}
+	for i, label := range flow.Labels {
+		if keep[label] {
+			kept = append(kept, i)
+			out.Labels = append(out.Labels, label)
+		}
+	}
+
+	maxOut := 0
+	outCounts := make([]int, len(kept))
+	for r, i := range kept {
+		row := make([]int, len(kept))
+		for c, j := range kept {
+			if i < len(flow.FlowMatrix) && j < len(flow.FlowMatrix[i]) {
+				row[c] = flow.FlowMatrix[i][j]
+			}
+			outCounts[r] += row[c]
+		}
+		out.FlowMatrix = append(out.FlowMatrix, row)
+		out.TotalCrossLabelDeps += outCounts[r]
+		maxOut = max(maxOut, outCounts[r])
+	}
+	for r, count := range outCounts {
+		if count == maxOut && count > 0 {
+			out.BottleneckLabels = append(out.BottleneckLabels, out.Labels[r])
+		}
+	}
+
+	for _, dep := range flow.Dependencies {
+		if keep[dep.FromLabel] && keep[dep.ToLabel] {
+			out.Dependencies = append(out.Dependencies, dep)
+		}
+	}
+	for _, path := range flow.CriticalPaths {
+		if !slices.ContainsFunc(path.Labels, func(l string) bool { return !keep[l] }) {
+			out.CriticalPaths = append(out.CriticalPaths, path)
+		}
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package analysis
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// activeLabelsFixture has two recently touched labels (api, ui) and two
+// long-idle ones (legacy, docs). legacy and api both block ui.
+func activeLabelsFixture(now time.Time) LabelAnalysisResult {
+	daysAgo := func(d int) time.Time { return now.Add(-time.Duration(d) * 24 * time.Hour) }
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	closedAt := daysAgo(3)
+	issues := []model.Issue{
+		{ID: "API-1", Status: model.StatusOpen, Labels: []string{"api"}, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(2)},
+		{ID: "API-2", Status: model.StatusClosed, Labels: []string{"api"}, CreatedAt: daysAgo(8), UpdatedAt: daysAgo(3), ClosedAt: &closedAt},
+		{ID: "UI-1", Status: model.StatusOpen, Labels: []string{"ui"}, CreatedAt: daysAgo(9), UpdatedAt: daysAgo(5),
+			Dependencies: append(blocks("LEG-1"), blocks("API-1")...)},
+		{ID: "LEG-1", Status: model.StatusOpen, Labels: []string{"legacy"}, CreatedAt: daysAgo(400), UpdatedAt: daysAgo(200)},
+		{ID: "LEG-2", Status: model.StatusOpen, Labels: []string{"legacy"}, CreatedAt: daysAgo(400), UpdatedAt: daysAgo(250)},
+		{ID: "DOC-1", Status: model.StatusOpen, Labels: []string{"docs"}, CreatedAt: daysAgo(150), UpdatedAt: daysAgo(100)},
+	}
+	cfg := DefaultLabelHealthConfig()
+	result := ComputeAllLabelHealth(issues, cfg, now, nil)
+	flow := ComputeCrossLabelFlow(issues, cfg)
+	result.CrossLabelFlow = &flow
+	return result
+}
+
+func TestFilterActiveLabels(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	full := activeLabelsFixture(now)
+
+	got := FilterActiveLabels(full, now, 30)
+
+	var labels []string
+	for _, h := range got.Labels {
+		labels = append(labels, h.Label)
+	}
+	if !reflect.DeepEqual(labels, []string{"api", "ui"}) {
+		t.Fatalf("active labels = %v, want [api ui]", labels)
+	}
+	if got.TotalLabels != 2 || len(got.Summaries) != 2 {
+		t.Errorf("total=%d summaries=%d, want 2 and 2", got.TotalLabels, len(got.Summaries))
+	}
+
+	// Full result: api warning; docs, legacy and ui critical
+	if full.WarningCount != 1 || full.CriticalCount != 3 {
+		t.Fatalf("fixture levels changed: warning=%d critical=%d", full.WarningCount, full.CriticalCount)
+	}
+	if got.HealthyCount != 0 || got.WarningCount != 1 || got.CriticalCount != 1 {
+		t.Errorf("counters healthy=%d warning=%d critical=%d, want 0/1/1", got.HealthyCount, got.WarningCount, got.CriticalCount)
+	}
+	// Urgency order from the full result is kept
+	if !reflect.DeepEqual(got.AttentionNeeded, []string{"ui", "api"}) {
+		t.Errorf("attention = %v, want [ui api] (from %v)", got.AttentionNeeded, full.AttentionNeeded)
+	}
+
+	// The flow is preserved as computed over every label
+	if got.CrossLabelFlow == nil || !reflect.DeepEqual(*got.CrossLabelFlow, *full.CrossLabelFlow) {
+		t.Errorf("cross-label flow should be kept as-is, got %+v", got.CrossLabelFlow)
+	}
+
+	// The input is untouched
+	if full.TotalLabels != 4 || len(full.Labels) != 4 {
+		t.Errorf("input result was modified: total=%d labels=%d", full.TotalLabels, len(full.Labels))
+	}
+
+	if all := FilterActiveLabels(full, now, 0); all.TotalLabels != 4 {
+		t.Errorf("withinDays 0 kept %d labels, want all 4", all.TotalLabels)
+	}
+}
+
+func TestFilterActiveLabels_RescopeFlow(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	full := activeLabelsFixture(now)
+	if full.CrossLabelFlow.TotalCrossLabelDeps != 2 {
+		t.Fatalf("fixture should have two cross-label deps, got %d", full.CrossLabelFlow.TotalCrossLabelDeps)
+	}
+
+	got := FilterActiveLabelsWithOptions(full, now, 30, ActiveLabelOptions{RescopeFlow: true})
+	flow := got.CrossLabelFlow
+	if flow == nil {
+		t.Fatal("expected a re-scoped flow")
+	}
+	if !reflect.DeepEqual(flow.Labels, []string{"api", "ui"}) {
+		t.Errorf("flow labels = %v, want [api ui]", flow.Labels)
+	}
+	// Only api -> ui survives; legacy -> ui is dropped
+	if !reflect.DeepEqual(flow.FlowMatrix, [][]int{{0, 1}, {0, 0}}) || flow.TotalCrossLabelDeps != 1 {
+		t.Errorf("matrix = %v total = %d, want [[0 1] [0 0]] and 1", flow.FlowMatrix, flow.TotalCrossLabelDeps)
+	}
+	if len(flow.Dependencies) != 1 || flow.Dependencies[0].FromLabel != "api" {
+		t.Errorf("dependencies = %+v, want only api -> ui", flow.Dependencies)
+	}
+	if !reflect.DeepEqual(flow.BottleneckLabels, []string{"api"}) {
+		t.Errorf("bottlenecks = %v, want [api]", flow.BottleneckLabels)
+	}
+}
+
+func TestComputeAllLabelHealth_LabelFilter(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A-1", Status: model.StatusOpen, Labels: []string{"area/api", "bug"}, UpdatedAt: now},
+		{ID: "A-2", Status: model.StatusOpen, Labels: []string{"area/api"}, UpdatedAt: now},
+		{ID: "U-1", Status: model.StatusOpen, Labels: []string{"area/ui", "bug"}, UpdatedAt: now},
+		{ID: "D-1", Status: model.StatusOpen, Labels: []string{"area/docs"}, UpdatedAt: now},
+		{ID: "D-2", Status: model.StatusOpen, Labels: []string{"area/docs"}, UpdatedAt: now.AddDate(0, 0, -90)},
+	}
+	filter := AndFilters(RegexFilter(regexp.MustCompile(`^area/`)), MinIssuesFilter(2))
+
+	result := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil, filter)
+	var got []string
+	for _, h := range result.Labels {
+		got = append(got, h.Label)
+	}
+	if want := []string{"area/api", "area/docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+	if result.TotalLabels != 2 || len(result.Summaries) != 2 {
+		t.Errorf("TotalLabels = %d, summaries = %d, want 2 and 2", result.TotalLabels, len(result.Summaries))
+	}
+
+	// Several filters are and-ed together
+	result = ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil, filter, NotFilter(RegexFilter(regexp.MustCompile(`docs`))))
+	if len(result.Labels) != 1 || result.Labels[0].Label != "area/api" {
+		t.Errorf("with a second filter got %d labels, want only area/api", len(result.Labels))
+	}
+
+	// No filter keeps every label
+	if all := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil); all.TotalLabels != 4 {
+		t.Errorf("unfiltered TotalLabels = %d, want 4", all.TotalLabels)
+	}
+}
+
+func TestLabelFilterCombinators(t *testing.T) {
+	now := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A-1", Labels: []string{"api"}, UpdatedAt: now.AddDate(0, 0, -2)},
+		{ID: "L-1", Labels: []string{"legacy"}, UpdatedAt: now.AddDate(0, 0, -200)},
+	}
+	stats := ExtractLabels(issues).Stats
+	labels := []string{"api", "legacy", "unused"}
+	keep := func(f LabelFilter) []string {
+		var out []string
+		for _, l := range labels {
+			if f(l, stats[l]) {
+				out = append(out, l)
+			}
+		}
+		return out
+	}
+	isAPI := RegexFilter(regexp.MustCompile(`^api$`))
+	isLegacy := RegexFilter(regexp.MustCompile(`^legacy$`))
+
+	tests := []struct {
+		name   string
+		filter LabelFilter
+		want   []string
+	}{
+		{"and of nothing", AndFilters(), labels},
+		{"or of nothing", OrFilters(), nil},
+		{"or", OrFilters(isAPI, isLegacy), []string{"api", "legacy"}},
+		{"not", NotFilter(isAPI), []string{"legacy", "unused"}},
+		{"not nil", NotFilter(nil), nil},
+		{"min issues rejects missing stats", MinIssuesFilter(1), []string{"api", "legacy"}},
+		{"min issues zero", MinIssuesFilter(0), labels},
+		{"active within", ActiveWithinFilter(issues, now, 30), []string{"api"}},
+		{"active within disabled", ActiveWithinFilter(issues, now, 0), labels},
+		{"nested", AndFilters(OrFilters(isAPI, isLegacy), NotFilter(ActiveWithinFilter(issues, now, 30))), []string{"legacy"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keep(tt.filter); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("kept %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// WriteCSV writes FlowMatrix as a labeled grid for spreadsheets: the header
+// row and first column hold label names (rows are blocking labels, columns
+// blocked labels) and each cell is a dependency count. Labels containing
+// commas or quotes are quoted.
+func (f CrossLabelFlow) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, 0, len(f.Labels)+1)
+	header = append(header, "from\\to")
+	header = append(header, f.Labels...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, label := range f.Labels {
+		row := make([]string, 0, len(f.Labels)+1)
+		row = append(row, label)
+		for j := range f.Labels {
+			count := 0
+			if i < len(f.FlowMatrix) && j < len(f.FlowMatrix[i]) {
+				count = f.FlowMatrix[i][j]
+			}
+			row = append(row, strconv.Itoa(count))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteEdgeListCSV writes Dependencies as from,to,count rows under a header,
+// one row per label pair with at least one cross-label dependency.
+func (f CrossLabelFlow) WriteEdgeListCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"from", "to", "count"}); err != nil {
+		return err
+	}
+	for _, dep := range f.Dependencies {
+		if err := cw.Write([]string{dep.FromLabel, dep.ToLabel, strconv.Itoa(dep.IssueCount)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestCrossLabelFlowWriteCSV_RoundTrip(t *testing.T) {
+	flow := CrossLabelFlow{
+		Labels: []string{"api", "backend, core", "ui"},
+		FlowMatrix: [][]int{
+			{0, 2, 1},
+			{0, 0, 3},
+			{0, 0, 0},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := flow.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+
+	firstLine := strings.SplitN(buf.String(), "\n", 2)[0]
+	if firstLine != `from\to,api,"backend, core",ui` {
+		t.Errorf("header = %q", firstLine)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("got %d rows, want header + 3", len(records))
+	}
+	if !reflect.DeepEqual(records[0][1:], flow.Labels) {
+		t.Errorf("header labels = %v, want %v", records[0][1:], flow.Labels)
+	}
+
+	matrix := make([][]int, 0, 3)
+	for i, rec := range records[1:] {
+		if rec[0] != flow.Labels[i] {
+			t.Errorf("row %d label = %q, want %q", i, rec[0], flow.Labels[i])
+		}
+		row := make([]int, 0, len(rec)-1)
+		for _, cell := range rec[1:] {
+			n, err := strconv.Atoi(cell)
+			if err != nil {
+				t.Fatalf("cell %q: %v", cell, err)
+			}
+			row = append(row, n)
+		}
+		matrix = append(matrix, row)
+	}
+	if !reflect.DeepEqual(matrix, flow.FlowMatrix) {
+		t.Errorf("matrix = %v, want %v", matrix, flow.FlowMatrix)
+	}
+}
+
+func TestCrossLabelFlowWriteEdgeListCSV(t *testing.T) {
+	flow := CrossLabelFlow{
+		Labels: []string{"api", "ui, web"},
+		Dependencies: []LabelDependency{
+			{FromLabel: "api", ToLabel: "ui, web", IssueCount: 2},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := flow.WriteEdgeListCSV(&buf); err != nil {
+		t.Fatalf("WriteEdgeListCSV: %v", err)
+	}
+	want := "from,to,count\napi,\"ui, web\",2\n"
+	if buf.String() != want {
+		t.Errorf("edge list = %q, want %q", buf.String(), want)
+	}
+}
@@ -3,6 +3,8 @@ package analysis
 import (
 	"fmt"
 	"math"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -20,18 +22,47 @@ import (
 // LabelHealth represents the overall health assessment of a single label
 // Health is a composite score based on velocity, freshness, flow, and criticality
 type LabelHealth struct {
-	Label       string             `json:"label"`            // The label name
-	IssueCount  int                `json:"issue_count"`      // Total issues with this label
-	OpenCount   int                `json:"open_count"`       // Open issues with this label
-	ClosedCount int                `json:"closed_count"`     // Closed issues with this label
-	Blocked     int                `json:"blocked_count"`    // Blocked issues with this label
-	Health      int                `json:"health"`           // Composite health score 0-100
-	HealthLevel string             `json:"health_level"`     // "healthy", "warning", "critical"
-	Velocity    VelocityMetrics    `json:"velocity"`         // Work completion rate
-	Freshness   FreshnessMetrics   `json:"freshness"`        // How recently updated
-	Flow        FlowMetrics        `json:"flow"`             // Cross-label dependencies
-	Criticality CriticalityMetrics `json:"criticality"`      // Graph-based importance
-	Issues      []string           `json:"issues,omitempty"` // Issue IDs with this label
+	Label       string             `json:"label"`               // The label name
+	IssueCount  int                `json:"issue_count"`         // Total issues with this label
+	OpenCount   int                `json:"open_count"`          // Open issues with this label
+	ClosedCount int                `json:"closed_count"`        // Closed issues with this label
+	Blocked     int                `json:"blocked_count"`       // Blocked issues with this label
+	Health      int                `json:"health"`              // Composite health score 0-100
+	HealthLevel string             `json:"health_level"`        // "healthy", "warning", "critical"
+	Velocity    VelocityMetrics    `json:"velocity"`            // Work completion rate
+	Freshness   FreshnessMetrics   `json:"freshness"`           // How recently updated
+	Flow        FlowMetrics        `json:"flow"`                // Cross-label dependencies
+	Criticality CriticalityMetrics `json:"criticality"`         // Graph-based importance
+	Breakdown   HealthBreakdown    `json:"breakdown"`           // How each component contributes to Health
+	Issues      []string           `json:"issues,omitempty"`    // Issue IDs with this label
+	TopIssue    string             `json:"top_issue,omitempty"` // Highest-priority open issue (closed if none open)
+	Reasons     []string           `json:"reasons,omitempty"`   // Why this label needs attention
+
+	// Insufficient marks a label with fewer issues than MinIssuesForHealth:
+	// its component scores are left neutral and its level is
+	// HealthLevelInsufficient rather than a verdict
+	Insufficient bool `json:"insufficient,omitempty"`
+}
+
+// HealthComponent is one component's share of a composite health score
+type HealthComponent struct {
+	Score        int     `json:"score"`        // Raw component score 0-100
+	Weight       float64 `json:"weight"`       // Configured weight
+	Contribution float64 `json:"contribution"` // Score * Weight, in health points
+}
+
+// HealthBreakdown explains a composite health score. Contributions sum to
+// Health before rounding.
+type HealthBreakdown struct {
+	Velocity    HealthComponent `json:"velocity"`
+	Freshness   HealthComponent `json:"freshness"`
+	Flow        HealthComponent `json:"flow"`
+	Criticality HealthComponent `json:"criticality"`
+}
+
+// Total returns the sum of the component contributions
+func (b HealthBreakdown) Total() float64 {
+	return b.Velocity.Contribution + b.Freshness.Contribution + b.Flow.Contribution + b.Criticality.Contribution
 }
 
 // VelocityMetrics tracks the rate of work completion for a label
@@ -39,9 +70,30 @@ type VelocityMetrics struct {
 	ClosedLast7Days  int     `json:"closed_last_7_days"`  // Issues closed in past week
 	ClosedLast30Days int     `json:"closed_last_30_days"` // Issues closed in past month
 	AvgDaysToClose   float64 `json:"avg_days_to_close"`   // Average time from open to close
-	TrendDirection   string  `json:"trend_direction"`     // "improving", "stable", "declining"
+	TrendDirection   string  `json:"trend_direction"`     // "improving", "stable", "declining", "dormant"
 	TrendPercent     float64 `json:"trend_percent"`       // Percent change vs prior period
 	VelocityScore    int     `json:"velocity_score"`      // Normalized 0-100 score
+
+	// PointsClosedLast30Days is the estimated effort, in hours, closed in the
+	// past month. Zero when no issue has an estimate (the score is then
+	// count-based); unestimated closures count at the median estimate.
+	PointsClosedLast30Days float64 `json:"points_closed_last_30_days,omitempty"`
+
+	// AnomalousCloseCount is the number of closed issues whose ClosedAt
+	// precedes CreatedAt; they are left out of AvgDaysToClose
+	AnomalousCloseCount int `json:"anomalous_close_count,omitempty"`
+
+	// CloseSampleCount is the number of closures AvgDaysToClose averages
+	CloseSampleCount int `json:"close_sample_count,omitempty"`
+
+	// MissingCreatedCount is the number of closed issues without a CreatedAt;
+	// they count as closures but are left out of AvgDaysToClose
+	MissingCreatedCount int `json:"missing_created_count,omitempty"`
+
+	// Excluded marks a label listed in ExcludeFromVelocity (bots, automation):
+	// the numbers are still reported but not comparable, so VelocityScore
+	// carries no weight in health and the label stays off leaderboards
+	Excluded bool `json:"excluded,omitempty"`
 }
 
 // HistoricalVelocity captures velocity data across multiple time periods (bv-123)
@@ -77,6 +129,25 @@ type FreshnessMetrics struct {
 	StaleCount         int       `json:"stale_count"`           // Issues with no updates > threshold
 	StaleThresholdDays int       `json:"stale_threshold_days"`  // What we consider stale (default 14)
 	FreshnessScore     int       `json:"freshness_score"`       // Normalized 0-100 score (higher = fresher)
+
+	// Priority-weighted average staleness the score was derived from; only
+	// set when FreshnessOptions.PriorityWeighted is on
+	WeightedAvgDaysSinceUpdate float64 `json:"weighted_avg_days_since_update,omitempty"`
+
+	// AgeBuckets counts issues by whole days since update, keyed by the
+	// labels from AgeBucketLabels (e.g. "0-7", "8-14", "15-30", "31+").
+	// Every issue counted in the staleness figures lands in exactly one bucket.
+	AgeBuckets map[string]int `json:"age_buckets"`
+
+	// Issues skipped for missing timestamps: MissingUpdatedCount are left out
+	// of staleness, MissingCreatedCount (open issues only) out of OldestOpenIssue
+	MissingUpdatedCount int `json:"missing_updated_count,omitempty"`
+	MissingCreatedCount int `json:"missing_created_count,omitempty"`
+
+	// Triage latency over open issues: the average days from creation to
+	// the first response (see firstResponse), and how many have none yet
+	AvgFirstResponseDays float64 `json:"avg_first_response_days"`
+	NoResponseCount      int     `json:"no_response_count"`
 }
 
 // FlowMetrics captures cross-label dependency relationships
@@ -98,6 +169,17 @@ type CriticalityMetrics struct {
 	CriticalPathCount int     `json:"critical_path_count"` // Issues on critical path
 	BottleneckCount   int     `json:"bottleneck_count"`    // Issues identified as bottlenecks
 	CriticalityScore  int     `json:"criticality_score"`   // 0-100, higher = more critical
+
+	// StaleCriticalCount counts stale open issues that other work waits on
+	StaleCriticalCount int `json:"stale_critical_count"`
+
+	// Dependency depth of the label's issues, as in GraphStats.DependencyDepth
+	AvgDependencyDepth float64 `json:"avg_dependency_depth"`
+	MaxDependencyDepth int     `json:"max_dependency_depth"`
+
+	// BottleneckIssueIDs are the label's issues in the graph-wide top
+	// BottleneckPercentile by betweenness, highest first
+	BottleneckIssueIDs []string `json:"bottleneck_issue_ids,omitempty"`
 }
 
 // LabelDependency represents a dependency relationship between two labels
@@ -185,26 +267,31 @@ type LabelPath struct {
 
 // LabelSummary provides a quick overview for display
 type LabelSummary struct {
-	Label          string `json:"label"`
-	IssueCount     int    `json:"issue_count"`
-	OpenCount      int    `json:"open_count"`
-	Health         int    `json:"health"`              // 0-100
-	HealthLevel    string `json:"health_level"`        // "healthy", "warning", "critical"
-	TopIssue       string `json:"top_issue,omitempty"` // Highest priority open issue
-	NeedsAttention bool   `json:"needs_attention"`     // Flag for labels requiring action
+	Label          string   `json:"label"`
+	IssueCount     int      `json:"issue_count"`
+	OpenCount      int      `json:"open_count"`
+	Health         int      `json:"health"`              // 0-100
+	HealthLevel    string   `json:"health_level"`        // "healthy", "warning", "critical"
+	TopIssue       string   `json:"top_issue,omitempty"` // Highest priority open issue
+	NeedsAttention bool     `json:"needs_attention"`     // Flag for labels requiring action
+	Reasons        []string `json:"reasons,omitempty"`   // Attention reasons (only when NeedsAttention)
+	UrgencyScore   int      `json:"urgency_score"`       // 0-100, staleness compounded by criticality
+	UrgencyReasons []string `json:"urgency_reasons,omitempty"`
 }
 
 // LabelAnalysisResult is the top-level result for label analysis
 type LabelAnalysisResult struct {
-	GeneratedAt     time.Time       `json:"generated_at"`
-	TotalLabels     int             `json:"total_labels"`
-	HealthyCount    int             `json:"healthy_count"`              // Labels with health >= 70
-	WarningCount    int             `json:"warning_count"`              // Labels with health 40-69
-	CriticalCount   int             `json:"critical_count"`             // Labels with health < 40
-	Labels          []LabelHealth   `json:"labels"`                     // Detailed per-label health
-	Summaries       []LabelSummary  `json:"summaries"`                  // Quick overview list
-	CrossLabelFlow  *CrossLabelFlow `json:"cross_label_flow,omitempty"` // Inter-label analysis
-	AttentionNeeded []string        `json:"attention_needed"`           // Labels requiring attention
+	GeneratedAt       time.Time       `json:"generated_at"`
+	TotalLabels       int             `json:"total_labels"`
+	HealthyCount      int             `json:"healthy_count"`              // Labels at or above the healthy threshold (default 70)
+	WarningCount      int             `json:"warning_count"`              // Labels between the warning and healthy thresholds
+	CriticalCount     int             `json:"critical_count"`             // Labels below the warning threshold (default 40)
+	InsufficientCount int             `json:"insufficient_count"`         // Labels with too few issues to score (see MinIssuesForHealth)
+	CycleCount        int             `json:"cycle_count"`                // Dependency cycles in the whole graph
+	Labels            []LabelHealth   `json:"labels"`                     // Detailed per-label health
+	Summaries         []LabelSummary  `json:"summaries"`                  // Quick overview list
+	CrossLabelFlow    *CrossLabelFlow `json:"cross_label_flow,omitempty"` // Inter-label analysis
+	AttentionNeeded   []string        `json:"attention_needed"`           // Labels requiring attention
 }
 
 // ComputeCrossLabelFlow analyzes blocking dependencies between labels and returns counts.
@@ -241,10 +328,7 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 		if !cfg.IncludeClosedInFlow && isClosedLikeStatus(blocked.Status) {
 			continue
 		}
-		for _, dep := range blocked.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
-				continue
-			}
+		for _, dep := range blocked.BlockingDependencies() {
 			blocker, ok := issueMap[dep.DependsOnID]
 			if !ok {
 				continue
@@ -335,24 +419,63 @@ func ComputeCrossLabelFlow(issues []model.Issue, cfg LabelHealthConfig) CrossLab
 
 // ComputeVelocityMetrics calculates simple velocity stats for a label.
 // It looks at closed issues and recent closures to give a quick pulse.
+// Missing (zero) timestamps are ignored rather than read as the year 1: a
+// closed issue without ClosedAt isn't a closure, and one without CreatedAt
+// is left out of AvgDaysToClose and counted in MissingCreatedCount.
+// A ClosedAt before CreatedAt (e.g. a clock skew, or an import that reset
+// CreatedAt on a reopened issue) still counts as a closure, but is excluded
+// from AvgDaysToClose and reported in AnomalousCloseCount instead, so one bad
+// timestamp can't drag the average negative.
+//
+// When any issue carries EstimatedMinutes, the score uses effort-weighted
+// throughput: closures in the past month are summed by estimate (the median
+// estimate standing in for issues without one) and divided by the median, so
+// closing one issue twice the typical size counts as two. Without any
+// estimates the score counts closures.
+//
+// The score is linear up to DefaultVelocityScaleMax closures a month; see
+// ComputeVelocityMetricsWithOptions to change the scale.
 func ComputeVelocityMetrics(issues []model.Issue, now time.Time) VelocityMetrics {
+	return ComputeVelocityMetricsWithOptions(issues, now, VelocityOptions{})
+}
+
+// VelocityOptions controls how the velocity score is derived
+type VelocityOptions struct {
+	// ScaleClosedForMax is how many closures in 30 days (median-sized
+	// issues, with estimates) score 100; fewer scale linearly. Zero means
+	// DefaultVelocityScaleMax.
+	ScaleClosedForMax int
+}
+
+// velocityOptions returns the velocity settings from the config
+func (cfg LabelHealthConfig) velocityOptions() VelocityOptions {
+	return VelocityOptions{ScaleClosedForMax: cfg.VelocityScaleClosedForMax}
+}
+
+// ComputeVelocityMetricsWithOptions is ComputeVelocityMetrics with explicit
+// scoring options.
+func ComputeVelocityMetricsWithOptions(issues []model.Issue, now time.Time, opts VelocityOptions) VelocityMetrics {
 	const day = 24 * time.Hour
 	var closed7, closed30 int
+	var minutes30 float64
+	estimated := hasEstimates(issues)
+	medianMinutes := computeMedianEstimatedMinutes(issues)
 	var totalCloseDur time.Duration
-	var closeSamples int
+	var closeSamples, anomalous, missingCreated int
 
 	// Rolling windows
 	weekAgo := now.Add(-7 * day)
 	monthAgo := now.Add(-30 * day)
 	prevWeekStart := now.Add(-14 * day)
 
-	var prevWeek, currentWeek int
+	var prevWeek, currentWeek, open int
 
 	for _, iss := range issues {
 		if !isClosedLikeStatus(iss.Status) {
+			open++
 			continue
 		}
-		if iss.ClosedAt == nil {
+		if iss.ClosedAt == nil || iss.ClosedAt.IsZero() {
 			continue
 		}
 		closedAt := *iss.ClosedAt
@@ -361,13 +484,19 @@ func ComputeVelocityMetrics(issues []model.Issue, now time.Time) VelocityMetrics
 		}
 		if closedAt.After(monthAgo) {
 			closed30++
+			minutes30 += effortMinutes(iss, medianMinutes)
 		}
 		if closedAt.After(prevWeekStart) && closedAt.Before(weekAgo) {
 			prevWeek++
 		} else if closedAt.After(weekAgo) {
 			currentWeek++
 		}
-		if !iss.CreatedAt.IsZero() {
+		switch {
+		case iss.CreatedAt.IsZero():
+			missingCreated++
+		case closedAt.Before(iss.CreatedAt):
+			anomalous++
+		default:
 			totalCloseDur += closedAt.Sub(iss.CreatedAt)
 			closeSamples++
 		}
@@ -391,17 +520,35 @@ func ComputeVelocityMetrics(issues []model.Issue, now time.Time) VelocityMetrics
 	} else if currentWeek > 0 {
 		trendDir = "improving"
 		trendPercent = 100
+	} else if open > 0 {
+		// Nothing closed for two weeks while work is waiting
+		trendDir = "dormant"
 	}
 
-	// Simple score: closed in last month scaled plus recency bonus
+	// Simple score: closed in last month scaled plus recency bonus.
+	// With estimates, closures are measured in median-sized issues.
+	throughput := float64(closed30)
+	points := 0.0
+	if estimated {
+		throughput = minutes30 / float64(medianMinutes)
+		points = minutes30 / 60
+	}
+	scale := opts.ScaleClosedForMax
+	if scale <= 0 {
+		scale = DefaultVelocityScaleMax
+	}
 	velocityScore := 0
-	if closed30 > 0 {
-		velocityScore = int(min(100.0, float64(closed30)*10))
+	if throughput > 0 {
+		velocityScore = int(min(100.0, throughput*100/float64(scale)))
 	}
 	// Bonus if trend improving
 	if trendDir == "improving" && velocityScore < 100 {
 		velocityScore = clampScore(velocityScore + 10)
 	}
+	// Older closures don't make an abandoned label look productive
+	if trendDir == "dormant" && velocityScore > DormantVelocityScore {
+		velocityScore = DormantVelocityScore
+	}
 
 	return VelocityMetrics{
 		ClosedLast7Days:  closed7,
@@ -410,18 +557,121 @@ func ComputeVelocityMetrics(issues []model.Issue, now time.Time) VelocityMetrics
 		TrendDirection:   trendDir,
 		TrendPercent:     trendPercent,
 		VelocityScore:    velocityScore,
+
+		PointsClosedLast30Days: points,
+		AnomalousCloseCount:    anomalous,
+		CloseSampleCount:       closeSamples,
+		MissingCreatedCount:    missingCreated,
 	}
 }
 
-// ComputeFreshnessMetrics calculates freshness and staleness for a label.
+// ComputeFreshnessMetrics calculates freshness and staleness for a label,
+// using the linear score curve.
 func ComputeFreshnessMetrics(issues []model.Issue, now time.Time, staleDays int) FreshnessMetrics {
+	return ComputeFreshnessMetricsWithDecay(issues, now, staleDays, FreshnessDecayLinear)
+}
+
+// ComputeFreshnessMetricsWithDecay is ComputeFreshnessMetrics with a chosen
+// score curve (one of the FreshnessDecay* values; empty means linear).
+func ComputeFreshnessMetricsWithDecay(issues []model.Issue, now time.Time, staleDays int, decay string) FreshnessMetrics {
+	return ComputeFreshnessMetricsWithOptions(issues, now, staleDays, FreshnessOptions{Decay: decay})
+}
+
+// FreshnessOptions controls how the freshness score is derived
+type FreshnessOptions struct {
+	Decay string // Score curve, one of the FreshnessDecay* values (empty = linear)
+
+	// PriorityWeighted scores the priority-weighted average staleness, so a
+	// stale P0 drags the score down more than a stale P4 (see
+	// PriorityStalenessWeight). StaleCount stays a raw count.
+	PriorityWeighted bool
+
+	// AgeBucketEdges are the inclusive upper bounds, in days, of the
+	// AgeBuckets histogram; empty means DefaultAgeBucketEdges
+	AgeBucketEdges []int
+
+	// ExcludeClosed leaves closed issues out of the staleness figures (the
+	// averages, StaleCount, AgeBuckets and MissingUpdatedCount), so the score
+	// reflects only open work. MostRecentUpdate still covers every issue.
+	ExcludeClosed bool
+}
+
+// DefaultAgeBucketEdges bucket issue ages into 0-7, 8-14, 15-30 and 31+ days
+var DefaultAgeBucketEdges = []int{7, 14, 30}
+
+// freshnessOptions returns the freshness settings from the config
+func (cfg LabelHealthConfig) freshnessOptions() FreshnessOptions {
+	return FreshnessOptions{
+		Decay:            cfg.FreshnessDecay,
+		PriorityWeighted: cfg.FreshnessPriorityWeighted,
+		AgeBucketEdges:   cfg.AgeBucketEdges,
+		ExcludeClosed:    !cfg.IncludeClosedInFreshness,
+	}
+}
+
+// AgeBucketLabels returns the bucket labels for ascending edges in
+// histogram order: one closed range per edge plus an open-ended last bucket.
+// Empty edges mean DefaultAgeBucketEdges.
+func AgeBucketLabels(edges []int) []string {
+	if len(edges) == 0 {
+		edges = DefaultAgeBucketEdges
+	}
+	labels := make([]string, 0, len(edges)+1)
+	low := 0
+	for _, edge := range edges {
+		labels = append(labels, fmt.Sprintf("%d-%d", low, edge))
+		low = edge + 1
+	}
+	return append(labels, fmt.Sprintf("%d+", low))
+}
+
+// ageBucketIndex returns the bucket for an age in whole days: the first edge
+// it does not exceed, or the open-ended bucket past the last edge
+func ageBucketIndex(ageDays int, edges []int) int {
+	for i, edge := range edges {
+		if ageDays <= edge {
+			return i
+		}
+	}
+	return len(edges)
+}
+
+// PriorityStalenessWeight is an issue's weight in the priority-weighted
+// staleness average: 5 for P0 down to 1 for P4 and lower priorities.
+func PriorityStalenessWeight(priority int) float64 {
+	switch {
+	case priority <= 0:
+		return 5
+	case priority >= 4:
+		return 1
+	default:
+		return float64(5 - priority)
+	}
+}
+
+// ComputeFreshnessMetricsWithOptions calculates freshness and staleness for a
+// label with explicit scoring options. Issues without an UpdatedAt are left
+// out of the staleness figures and counted in MissingUpdatedCount.
+func ComputeFreshnessMetricsWithOptions(issues []model.Issue, now time.Time, staleDays int, opts FreshnessOptions) FreshnessMetrics {
 	if staleDays <= 0 {
 		staleDays = DefaultStaleThresholdDays
 	}
+	edges := opts.AgeBucketEdges
+	if len(edges) == 0 {
+		edges = DefaultAgeBucketEdges
+	}
+	bucketLabels := AgeBucketLabels(edges)
+	buckets := make(map[string]int, len(bucketLabels))
+	for _, label := range bucketLabels {
+		buckets[label] = 0
+	}
+
 	var mostRecent time.Time
 	var oldestOpen time.Time
-	var totalStaleness float64
-	var count int
+	var totalStaleness, weightedStaleness, totalWeight float64
+	var count, missingUpdated, missingCreated int
+	var responseDays float64
+	var responded, noResponse int
 	staleCount := 0
 	threshold := float64(staleDays)
 
@@ -429,19 +679,40 @@ func ComputeFreshnessMetrics(issues []model.Issue, now time.Time, staleDays int)
 		if iss.UpdatedAt.After(mostRecent) {
 			mostRecent = iss.UpdatedAt
 		}
-		if !isClosedLikeStatus(iss.Status) {
+		closed := isClosedLikeStatus(iss.Status)
+		if !closed {
 			// Only consider issues with valid CreatedAt for oldest calculation
-			if !iss.CreatedAt.IsZero() && (oldestOpen.IsZero() || iss.CreatedAt.Before(oldestOpen)) {
-				oldestOpen = iss.CreatedAt
+			if iss.CreatedAt.IsZero() {
+				missingCreated++
+			} else {
+				if oldestOpen.IsZero() || iss.CreatedAt.Before(oldestOpen) {
+					oldestOpen = iss.CreatedAt
+				}
+				if at, ok := firstResponse(iss); ok {
+					responseDays += at.Sub(iss.CreatedAt).Hours() / 24.0
+					responded++
+				} else {
+					noResponse++
+				}
 			}
 		}
-		if !iss.UpdatedAt.IsZero() {
+		if closed && opts.ExcludeClosed {
+			continue
+		}
+		if iss.UpdatedAt.IsZero() {
+			missingUpdated++
+		} else {
 			days := now.Sub(iss.UpdatedAt).Hours() / 24.0
 			totalStaleness += days
+			weight := PriorityStalenessWeight(iss.Priority)
+			weightedStaleness += days * weight
+			totalWeight += weight
 			count++
 			if days >= threshold {
 				staleCount++
 			}
+			// Updates stamped in the future count as fresh
+			buckets[bucketLabels[ageBucketIndex(max(int(days), 0), edges)]]++
 		}
 	}
 
@@ -449,17 +720,169 @@ func ComputeFreshnessMetrics(issues []model.Issue, now time.Time, staleDays int)
 	if count > 0 {
 		avgStaleness = totalStaleness / float64(count)
 	}
-	// Freshness score: 100 when avg=0, declines linearly to 0 at 2x threshold
-	freshnessScore := int(max(0.0, 100-(avgStaleness/(threshold*2))*100))
 
-	return FreshnessMetrics{
+	metrics := FreshnessMetrics{
 		MostRecentUpdate:   mostRecent,
 		OldestOpenIssue:    oldestOpen,
 		AvgDaysSinceUpdate: avgStaleness,
 		StaleCount:         staleCount,
 		StaleThresholdDays: staleDays,
-		FreshnessScore:     clampScore(freshnessScore),
+		AgeBuckets:         buckets,
+
+		MissingUpdatedCount: missingUpdated,
+		MissingCreatedCount: missingCreated,
+		NoResponseCount:     noResponse,
 	}
+	if responded > 0 {
+		metrics.AvgFirstResponseDays = responseDays / float64(responded)
+	}
+	scored := avgStaleness
+	if opts.PriorityWeighted && totalWeight > 0 {
+		metrics.WeightedAvgDaysSinceUpdate = weightedStaleness / totalWeight
+		scored = metrics.WeightedAvgDaysSinceUpdate
+	}
+	metrics.FreshnessScore = clampScore(freshnessScore(scored, threshold, opts.Decay))
+	return metrics
+}
+
+// firstResponse returns when an issue was first touched after creation:
+// the earlier of its first comment and its last update, ignoring either
+// when it isn't after CreatedAt. Only the latest update is recorded, so an
+// issue updated several times without comments reports that latest one.
+// ok is false for an issue untouched since it was created.
+func firstResponse(iss model.Issue) (at time.Time, ok bool) {
+	if iss.UpdatedAt.After(iss.CreatedAt) {
+		at, ok = iss.UpdatedAt, true
+	}
+	for _, c := range iss.Comments {
+		if c != nil && c.CreatedAt.After(iss.CreatedAt) && (!ok || c.CreatedAt.Before(at)) {
+			at, ok = c.CreatedAt, true
+		}
+	}
+	return at, ok
+}
+
+// freshnessScore maps average staleness (days) to a 0-100 score:
+//   - linear: 100 when avg=0, declining to 0 at 2x threshold
+//   - exponential: halves every threshold days (50 at 1x, 25 at 2x)
+//   - step: 100 below the threshold, StepDecayStaleScore from it on
+func freshnessScore(avgStaleness, threshold float64, decay string) int {
+	switch decay {
+	case FreshnessDecayExponential:
+		return int(100 * math.Pow(0.5, avgStaleness/threshold))
+	case FreshnessDecayStep:
+		if avgStaleness < threshold {
+			return 100
+		}
+		return StepDecayStaleScore
+	default:
+		return int(max(0.0, 100-(avgStaleness/(threshold*2))*100))
+	}
+}
+
+// computeLabelFlow counts blocking dependencies crossing the label boundary
+// in both directions and scores them. Closed issues are skipped on either
+// side of a dependency unless cfg.IncludeClosedInFlow is set.
+func computeLabelFlow(label string, labeled, issues []model.Issue, cfg LabelHealthConfig) FlowMetrics {
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, iss := range issues {
+		issueMap[iss.ID] = iss
+	}
+	inFlow := func(iss model.Issue) bool {
+		return cfg.IncludeClosedInFlow || !isClosedLikeStatus(iss.Status)
+	}
+
+	flow := FlowMetrics{}
+	seenIn := make(map[string]struct{})
+	seenOut := make(map[string]struct{})
+	members := make(map[string]bool, len(labeled))
+	considered := 0
+	for _, iss := range labeled {
+		members[iss.ID] = true
+		if !inFlow(iss) {
+			continue
+		}
+		considered++
+		// incoming: an issue with another label blocks this one
+		blocked := false
+		for _, dep := range iss.BlockingDependencies() {
+			blocker, ok := issueMap[dep.DependsOnID]
+			if !ok || !inFlow(blocker) {
+				continue
+			}
+			for _, bl := range blocker.Labels {
+				if bl != "" && bl != label {
+					flow.IncomingDeps++
+					seenIn[bl] = struct{}{}
+					blocked = true
+				}
+			}
+		}
+		if blocked {
+			flow.BlockedByExternal++
+		}
+	}
+
+	// outgoing: this label's issues block issues with other labels
+	blocking := make(map[string]bool)
+	for _, dependent := range issues {
+		if !inFlow(dependent) {
+			continue
+		}
+		for _, dep := range dependent.BlockingDependencies() {
+			if !members[dep.DependsOnID] {
+				continue
+			}
+			if !inFlow(issueMap[dep.DependsOnID]) {
+				continue
+			}
+			for _, tl := range dependent.Labels {
+				if tl != "" && tl != label {
+					flow.OutgoingDeps++
+					seenOut[tl] = struct{}{}
+					blocking[dep.DependsOnID] = true
+				}
+			}
+		}
+	}
+	flow.BlockingExternal = len(blocking)
+
+	for l := range seenIn {
+		flow.IncomingLabels = append(flow.IncomingLabels, l)
+	}
+	for l := range seenOut {
+		flow.OutgoingLabels = append(flow.OutgoingLabels, l)
+	}
+	sort.Strings(flow.IncomingLabels)
+	sort.Strings(flow.OutgoingLabels)
+	flow.FlowScore = ComputeFlowScore(flow, considered, cfg)
+	return flow
+}
+
+// ComputeFlowScore scores cross-label flow from 0 to 100, higher meaning
+// the label is less held up by others. Two penalties are subtracted from 100:
+//   - the share of the label's issueCount issues blocked by other labels,
+//     scaled by the blocked penalty
+//   - the imbalance between incoming and outgoing dependencies, in [-1, 1]:
+//     net inflow (waiting on others) is scaled by the inflow penalty and net
+//     outflow (others waiting on it) by the usually smaller outflow penalty
+func ComputeFlowScore(flow FlowMetrics, issueCount int, cfg LabelHealthConfig) int {
+	blockedPenalty, inflowPenalty, outflowPenalty := cfg.flowPenalties()
+
+	penalty := 0.0
+	if issueCount > 0 {
+		blockedRatio := math.Min(1, float64(flow.BlockedByExternal)/float64(issueCount))
+		penalty += blockedPenalty * blockedRatio
+	}
+	if total := flow.IncomingDeps + flow.OutgoingDeps; total > 0 {
+		balance := float64(flow.IncomingDeps-flow.OutgoingDeps) / float64(total)
+		if balance > 0 {
+			penalty += inflowPenalty * balance
+		} else {
+			penalty += outflowPenalty * -balance
+		}
+	}
+	return clampScore(int(math.Round(100 - penalty)))
 }
 
 // ComputeLabelHealthForLabel computes health for a single label.
@@ -501,61 +924,45 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 		}
 	}
 
-	velocity := ComputeVelocityMetrics(labeled, now)
-	freshness := ComputeFreshnessMetrics(labeled, now, cfg.StaleThresholdDays)
-
-	// Flow: count cross-label deps
-	flow := FlowMetrics{}
-	seenIn := make(map[string]struct{})
-	seenOut := make(map[string]struct{})
-	for _, iss := range labeled {
-		for _, dep := range iss.Dependencies {
-			if dep == nil || dep.Type != model.DepBlocks {
-				continue
-			}
-			blockerLabels := GetLabelsForIssue(issues, dep.DependsOnID)
-			targetLabels := iss.Labels
-			// incoming: other label blocks this
-			for _, bl := range blockerLabels {
-				if bl != label {
-					flow.IncomingDeps++
-					seenIn[bl] = struct{}{}
-				}
-			}
-			// outgoing: this label blocks others
-			for _, tl := range targetLabels {
-				if tl == label {
-					continue
-				}
-				flow.OutgoingDeps++
-				seenOut[tl] = struct{}{}
-			}
-		}
-	}
-	for l := range seenIn {
-		flow.IncomingLabels = append(flow.IncomingLabels, l)
+	if health.IssueCount < cfg.MinIssuesForHealth {
+		return insufficientLabelHealth(health, labeled, cfg)
 	}
-	for l := range seenOut {
-		flow.OutgoingLabels = append(flow.OutgoingLabels, l)
-	}
-	sort.Strings(flow.IncomingLabels)
-	sort.Strings(flow.OutgoingLabels)
-	flow.FlowScore = clampScore(100 - (flow.IncomingDeps * 5))
+
+	velocity := ComputeVelocityMetricsWithOptions(labeled, now, cfg.velocityOptions())
+	velocity.Excluded = cfg.IsVelocityExcluded(label)
+	freshness := ComputeFreshnessMetricsWithOptions(labeled, now, cfg.StaleDaysForLabel(label), cfg.freshnessOptions())
+
+	flow := computeLabelFlow(label, labeled, issues, cfg)
 
 	// Criticality: derive from graph metrics (reuse precomputed stats when supplied)
-	if stats == nil {
+	if cfg.SubgraphCentrality {
+		stats = labelSubgraphStats(issues, health.Issues, cfg, stats)
+	} else if stats == nil {
 		analyzer := NewAnalyzer(issues)
 		s := analyzer.Analyze()
 		stats = &s
 	}
-	pr := stats.PageRank()
-	bw := stats.Betweenness()
-	maxPR := findMax(pr)
-	maxBW := findMax(bw)
+	// The snapshot is memoized on stats, so iterating many labels doesn't
+	// re-copy or re-scan the centrality maps for each one.
+	centrality := stats.centralityView()
+	pr := centrality.pageRank
+	bw := centrality.betweenness
+	maxPR := centrality.maxPageRank
+	maxBW := centrality.maxBetweenness
+
+	percentile := cfg.BottleneckPercentile
+	if percentile == 0 {
+		percentile = DefaultBottleneckPercentile
+	}
+	bwCutoff := centrality.bottleneckThreshold(percentile)
 
 	var prSum, bwSum float64
 	maxBwLabel := 0.0
-	var critCount, bottleneckCount int
+	var critCount, staleCritical int
+	var bottlenecks []string
+	depths := stats.dependencyDepths()
+	depthSum, maxDepth := 0, 0
+	staleCutoff := now.Add(-time.Duration(freshness.StaleThresholdDays) * 24 * time.Hour)
 	for _, iss := range labeled {
 		prSum += pr[iss.ID]
 		bwVal := bw[iss.ID]
@@ -566,15 +973,30 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 		if stats.GetCriticalPathScore(iss.ID) > 0 {
 			critCount++
 		}
-		if bwVal > 0 {
-			bottleneckCount++
+		// A score above 1 means at least one issue depends on this one
+		if stats.GetCriticalPathScore(iss.ID) > 1 && !isClosedLikeStatus(iss.Status) &&
+			!iss.UpdatedAt.IsZero() && !iss.UpdatedAt.After(staleCutoff) {
+			staleCritical++
+		}
+		if bwVal > 0 && bwVal >= bwCutoff {
+			bottlenecks = append(bottlenecks, iss.ID)
 		}
+		depthSum += depths[iss.ID]
+		maxDepth = max(maxDepth, depths[iss.ID])
 	}
+	sort.Slice(bottlenecks, func(i, j int) bool {
+		if bw[bottlenecks[i]] != bw[bottlenecks[j]] {
+			return bw[bottlenecks[i]] > bw[bottlenecks[j]]
+		}
+		return bottlenecks[i] < bottlenecks[j]
+	})
 	avgPR := 0.0
 	avgBW := 0.0
+	avgDepth := 0.0
 	if health.IssueCount > 0 {
 		avgPR = prSum / float64(health.IssueCount)
 		avgBW = bwSum / float64(health.IssueCount)
+		avgDepth = float64(depthSum) / float64(health.IssueCount)
 	}
 	critScore := 0
 	if maxPR > 0 {
@@ -593,53 +1015,178 @@ func ComputeLabelHealthForLabel(label string, issues []model.Issue, cfg LabelHea
 		AvgBetweenness:    avgBW,
 		MaxBetweenness:    maxBwLabel,
 		CriticalPathCount: critCount,
-		BottleneckCount:   bottleneckCount,
+		BottleneckCount:   len(bottlenecks),
 		CriticalityScore:  critScore,
+
+		BottleneckIssueIDs: bottlenecks,
+		StaleCriticalCount: staleCritical,
+		AvgDependencyDepth: avgDepth,
+		MaxDependencyDepth: maxDepth,
 	}
 
-	health.Health = ComputeCompositeHealth(velocity.VelocityScore, freshness.FreshnessScore, flow.FlowScore, critScore, cfg)
-	health.HealthLevel = HealthLevelFromScore(health.Health)
+	health.TopIssue = selectTopIssue(labeled, pr)
+
+	weights := cfg
+	if velocity.Excluded {
+		weights = cfg.withoutVelocityWeight()
+	}
+	health.Health, health.Breakdown = ComputeCompositeHealth(velocity.VelocityScore, freshness.FreshnessScore, flow.FlowScore, critScore, weights)
+	health.HealthLevel = cfg.HealthLevelFromScore(health.Health)
+	health.Reasons = AttentionReasons(health, cfg)
+	return health
+}
+
+// insufficientLabelHealth finishes a label too small to score: every
+// component gets neutralComponentScore, and the label gets no attention
+// reasons. The top issue is picked without PageRank, which isn't computed.
+func insufficientLabelHealth(health LabelHealth, labeled []model.Issue, cfg LabelHealthConfig) LabelHealth {
+	health.Insufficient = true
+	health.Velocity.VelocityScore = neutralComponentScore
+	health.Freshness.FreshnessScore = neutralComponentScore
+	health.Flow.FlowScore = neutralComponentScore
+	health.Criticality.CriticalityScore = neutralComponentScore
+	health.Health, health.Breakdown = ComputeCompositeHealth(neutralComponentScore, neutralComponentScore, neutralComponentScore, neutralComponentScore, cfg)
+	health.HealthLevel = HealthLevelInsufficient
+	health.TopIssue = selectTopIssue(labeled, nil)
 	return health
 }
 
+// labelSubgraphStats analyzes the subgraph of a label's issues, using the
+// PageRank parameters of the global stats when supplied
+func labelSubgraphStats(issues []model.Issue, labelIDs []string, cfg LabelHealthConfig, global *GraphStats) *GraphStats {
+	analyzer := NewAnalyzer(issues)
+	if global != nil && global.Options != (AnalyzerOptions{}) {
+		analyzer.options = global.Options
+	}
+	boundary := SubgraphDropBoundary
+	if cfg.SubgraphBoundaryNodes {
+		boundary = SubgraphKeepBoundary
+	}
+	s := analyzer.SubgraphWithBoundary(labelIDs, boundary).Analyze()
+	return &s
+}
+
+// selectTopIssue picks the label's most important issue: the highest-priority
+// (lowest number) open one, ties broken by PageRank then ID. Closed issues
+// are only considered when nothing is open.
+func selectTopIssue(labeled []model.Issue, pageRank map[string]float64) string {
+	better := func(a, b *model.Issue) bool { return issueOutranks(a, b, pageRank) }
+
+	var topOpen, topClosed *model.Issue
+	for i := range labeled {
+		iss := &labeled[i]
+		if isClosedLikeStatus(iss.Status) {
+			if topClosed == nil || better(iss, topClosed) {
+				topClosed = iss
+			}
+		} else if topOpen == nil || better(iss, topOpen) {
+			topOpen = iss
+		}
+	}
+	switch {
+	case topOpen != nil:
+		return topOpen.ID
+	case topClosed != nil:
+		return topClosed.ID
+	}
+	return ""
+}
+
+// issueOutranks orders issues for selectTopIssue: lower priority number
+// first, then higher PageRank, then ID
+func issueOutranks(a, b *model.Issue, pageRank map[string]float64) bool {
+	if a.Priority != b.Priority {
+		return a.Priority < b.Priority
+	}
+	if pageRank[a.ID] != pageRank[b.ID] {
+		return pageRank[a.ID] > pageRank[b.ID]
+	}
+	return a.ID < b.ID
+}
+
 // ComputeAllLabelHealth computes health for all labels in the issue set.
-func ComputeAllLabelHealth(issues []model.Issue, cfg LabelHealthConfig, now time.Time, stats *GraphStats) LabelAnalysisResult {
-	labels := ExtractLabels(issues)
+// Optional filters narrow the labels after extraction and after the config's
+// include/exclude settings; several filters are combined with AndFilters.
+func ComputeAllLabelHealth(issues []model.Issue, cfg LabelHealthConfig, now time.Time, stats *GraphStats, filters ...LabelFilter) LabelAnalysisResult {
+	return computeAllLabelHealth(issues, cfg, now, stats, NewAnalyzer, labelFilterOf(filters))
+}
+
+// ComputeAllLabelHealthWithOptions is ComputeAllLabelHealth with tunable
+// PageRank parameters, used when stats is nil and the graph is analyzed here.
+// Precomputed stats already carry their own parameters (see GraphStats.Options).
+func ComputeAllLabelHealthWithOptions(issues []model.Issue, cfg LabelHealthConfig, now time.Time, stats *GraphStats, opts AnalyzerOptions, filters ...LabelFilter) (LabelAnalysisResult, error) {
+	if err := opts.Validate(); err != nil {
+		return LabelAnalysisResult{}, err
+	}
+	newAnalyzer := func(issues []model.Issue) *Analyzer {
+		a, _ := NewAnalyzerWithOptions(issues, opts) // validated above
+		return a
+	}
+	return computeAllLabelHealth(issues, cfg, now, stats, newAnalyzer, labelFilterOf(filters)), nil
+}
+
+// labelFilterOf combines optional filters, returning nil when there are none
+func labelFilterOf(filters []LabelFilter) LabelFilter {
+	if len(filters) == 0 {
+		return nil
+	}
+	return AndFilters(filters...)
+}
+
+func computeAllLabelHealth(issues []model.Issue, cfg LabelHealthConfig, now time.Time, stats *GraphStats, newAnalyzer func([]model.Issue) *Analyzer, filter LabelFilter) LabelAnalysisResult {
+	extracted := ExtractLabels(issues)
+	labels := applyLabelFilter(cfg.filterLabels(extracted.Labels), extracted.Stats, filter)
 	result := LabelAnalysisResult{
-		GeneratedAt:     now,
-		TotalLabels:     labels.LabelCount,
-		Labels:          []LabelHealth{},
-		Summaries:       []LabelSummary{},
-		AttentionNeeded: []string{},
+		GeneratedAt: now,
+		TotalLabels: len(labels),
+		Labels:      []LabelHealth{},
 	}
 
 	// Deterministic traversal
-	sort.Strings(labels.Labels)
+	sort.Strings(labels)
 
 	// Precompute stats once for efficiency if not provided
 	var fullStats *GraphStats
 	if stats != nil {
 		fullStats = stats
 	} else {
-		analyzer := NewAnalyzer(issues)
+		analyzer := newAnalyzer(issues)
 		s := analyzer.Analyze()
 		fullStats = &s
 	}
 
-	for _, label := range labels.Labels {
+	for _, label := range labels {
 		health := ComputeLabelHealthForLabel(label, issues, cfg, now, fullStats)
 		result.Labels = append(result.Labels, health)
+	}
+	result.CycleCount = len(fullStats.Cycles())
+
+	summarizeLabelHealth(&result, cfg)
+	return result
+}
+
+// summarizeLabelHealth derives Summaries, level counts and AttentionNeeded
+// from result.Labels, which must be sorted by label.
+func summarizeLabelHealth(result *LabelAnalysisResult, cfg LabelHealthConfig) {
+	result.HealthyCount, result.WarningCount, result.CriticalCount, result.InsufficientCount = 0, 0, 0, 0
+	result.Summaries = []LabelSummary{}
+	result.AttentionNeeded = []string{}
+
+	for _, health := range result.Labels {
+		label := health.Label
 		summary := LabelSummary{
 			Label:          label,
 			IssueCount:     health.IssueCount,
 			OpenCount:      health.OpenCount,
 			Health:         health.Health,
 			HealthLevel:    health.HealthLevel,
-			NeedsAttention: NeedsAttention(health),
+			NeedsAttention: cfg.NeedsAttention(health),
 		}
-		if len(health.Issues) > 0 {
-			summary.TopIssue = health.Issues[0]
+		summary.TopIssue = health.TopIssue
+		if summary.NeedsAttention {
+			summary.Reasons = health.Reasons
 		}
+		summary.UrgencyScore, summary.UrgencyReasons = labelUrgency(health)
 		result.Summaries = append(result.Summaries, summary)
 		switch health.HealthLevel {
 		case HealthLevelHealthy:
@@ -650,17 +1197,58 @@ func ComputeAllLabelHealth(issues []model.Issue, cfg LabelHealthConfig, now time
 		case HealthLevelCritical:
 			result.CriticalCount++
 			result.AttentionNeeded = append(result.AttentionNeeded, label)
+		case HealthLevelInsufficient:
+			result.InsufficientCount++
 		}
 	}
 
+	// Most urgent first; AttentionNeeded is in label order, so ties stay alphabetical
+	urgency := make(map[string]int, len(result.Summaries))
+	for _, summary := range result.Summaries {
+		urgency[summary.Label] = summary.UrgencyScore
+	}
+	sort.SliceStable(result.AttentionNeeded, func(i, j int) bool {
+		return urgency[result.AttentionNeeded[i]] > urgency[result.AttentionNeeded[j]]
+	})
+
 	sort.Slice(result.Summaries, func(i, j int) bool {
 		if result.Summaries[i].Health != result.Summaries[j].Health {
 			return result.Summaries[i].Health > result.Summaries[j].Health
 		}
 		return result.Summaries[i].Label < result.Summaries[j].Label
 	})
+}
 
-	return result
+const (
+	urgencyPerStaleCritical = 15 // Urgency added per stale issue blocking other work
+	urgencyStaleCriticalCap = 60 // Max urgency from stale blocking issues
+)
+
+// labelUrgency scores how dangerous a label's staleness is. Staleness alone
+// counts for little: it is scaled by criticality, and each stale issue that
+// other work waits on adds urgencyPerStaleCritical on top.
+func labelUrgency(health LabelHealth) (int, []string) {
+	staleness := 100 - health.Freshness.FreshnessScore
+	crit := health.Criticality.CriticalityScore
+	score := staleness * crit / 100
+	score += min(health.Criticality.StaleCriticalCount*urgencyPerStaleCritical, urgencyStaleCriticalCap)
+
+	var reasons []string
+	if n := health.Criticality.StaleCriticalCount; n > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d stale %s blocking other work", n, pluralize(n, "issue")))
+	}
+	if staleness >= 50 && crit >= 50 {
+		reasons = append(reasons, fmt.Sprintf("stale (freshness %d) and central (criticality %d)", health.Freshness.FreshnessScore, crit))
+	}
+	return clampScore(score), reasons
+}
+
+// pluralize returns the singular or plural form of a word based on count.
+func pluralize(count int, singular string) string {
+	if count == 1 {
+		return singular
+	}
+	return singular + "s"
 }
 
 func clampScore(v int) int {
@@ -682,11 +1270,20 @@ const (
 	HealthLevelHealthy  = "healthy"  // Health >= 70
 	HealthLevelWarning  = "warning"  // Health 40-69
 	HealthLevelCritical = "critical" // Health < 40
+
+	HealthLevelInsufficient = "insufficient" // Fewer issues than MinIssuesForHealth
 )
 
+// neutralComponentScore is given to every health component of a label with
+// too few issues to score
+const neutralComponentScore = 50
+
 // Default thresholds for health calculations
 const (
 	DefaultStaleThresholdDays = 14   // Days without update to consider stale
+	DormantVelocityScore      = 10   // Max velocity score for a dormant label with open work
+	DefaultVelocityScaleMax   = 10   // 30-day closures that earn a full velocity score
+	StepDecayStaleScore       = 20   // Freshness score past the threshold with step decay
 	HealthyThreshold          = 70   // Min health score for "healthy"
 	WarningThreshold          = 40   // Min health score for "warning"
 	VelocityWeight            = 0.25 // Weight for velocity in composite score
@@ -695,19 +1292,175 @@ const (
 	CriticalityWeight         = 0.25 // Weight for criticality in composite score
 )
 
+// Freshness score curves for LabelHealthConfig.FreshnessDecay
+const (
+	FreshnessDecayLinear      = "linear"      // 100 at zero staleness, 0 at 2x threshold
+	FreshnessDecayExponential = "exponential" // Half-life of one threshold
+	FreshnessDecayStep        = "step"        // Full score until the threshold, then a fixed low score
+)
+
+// Default thresholds for attention reasons
+const (
+	DefaultAttentionVelocityDropPct  = 25.0 // Velocity decline worth reporting
+	DefaultAttentionStaleCount       = 3    // Stale issues worth reporting
+	DefaultAttentionExternalBlockers = 2    // Blocking labels worth reporting
+	DefaultAttentionCriticalPaths    = 3    // Critical-path issues worth reporting
+	DefaultBottleneckPercentile      = 10.0 // Top share of issues by betweenness flagged as bottlenecks
+)
+
+// Default flow score penalties (see ComputeFlowScore)
+const (
+	DefaultFlowBlockedPenalty = 60.0 // Every issue blocked by another label
+	DefaultFlowInflowPenalty  = 30.0 // All cross-label dependencies point into the label
+	DefaultFlowOutflowPenalty = 10.0 // All cross-label dependencies point out of the label
+)
+
 // ============================================================================
 // Configuration Types
 // ============================================================================
 
 // LabelHealthConfig configures label health computation
 type LabelHealthConfig struct {
-	StaleThresholdDays  int     `json:"stale_threshold_days"`   // Days to consider issue stale
-	VelocityWeight      float64 `json:"velocity_weight"`        // Weight for velocity component
-	FreshnessWeight     float64 `json:"freshness_weight"`       // Weight for freshness component
-	FlowWeight          float64 `json:"flow_weight"`            // Weight for flow component
-	CriticalityWeight   float64 `json:"criticality_weight"`     // Weight for criticality component
-	MinIssuesForHealth  int     `json:"min_issues_for_health"`  // Min issues to compute health
-	IncludeClosedInFlow bool    `json:"include_closed_in_flow"` // Include closed issues in flow analysis
+	StaleThresholdDays  int     `yaml:"stale_threshold_days" json:"stale_threshold_days"`     // Days to consider issue stale
+	VelocityWeight      float64 `yaml:"velocity_weight" json:"velocity_weight"`               // Weight for velocity component
+	FreshnessWeight     float64 `yaml:"freshness_weight" json:"freshness_weight"`             // Weight for freshness component
+	FlowWeight          float64 `yaml:"flow_weight" json:"flow_weight"`                       // Weight for flow component
+	CriticalityWeight   float64 `yaml:"criticality_weight" json:"criticality_weight"`         // Weight for criticality component
+	MinIssuesForHealth  int     `yaml:"min_issues_for_health" json:"min_issues_for_health"`   // Fewer issues leaves a label Insufficient
+	IncludeClosedInFlow bool    `yaml:"include_closed_in_flow" json:"include_closed_in_flow"` // Include closed issues in flow analysis
+
+	// IncludeClosedInFreshness counts closed issues in the freshness
+	// averages and stale count (the default). Turning it off scores only
+	// open issues, so closing old work can't make a neglected label look
+	// fresh; MostRecentUpdate still covers closed issues.
+	IncludeClosedInFreshness bool `yaml:"include_closed_in_freshness" json:"include_closed_in_freshness"`
+
+	// Attention reason thresholds: deviations below these are not reported
+	// by AttentionReasons. Zero values fall back to the Default* constants.
+	AttentionVelocityDropPct  float64 `yaml:"attention_velocity_drop_pct,omitempty" json:"attention_velocity_drop_pct,omitempty"` // Min velocity decline (percent)
+	AttentionStaleCount       int     `yaml:"attention_stale_count,omitempty" json:"attention_stale_count,omitempty"`             // Min stale issues
+	AttentionExternalBlockers int     `yaml:"attention_external_blockers,omitempty" json:"attention_external_blockers,omitempty"` // Min distinct blocking labels
+	AttentionCriticalPaths    int     `yaml:"attention_critical_paths,omitempty" json:"attention_critical_paths,omitempty"`       // Min issues on critical paths
+
+	// BottleneckPercentile is the top share (percent) of issues by
+	// betweenness counted as bottlenecks. Zero falls back to the default.
+	BottleneckPercentile float64 `yaml:"bottleneck_percentile,omitempty" json:"bottleneck_percentile,omitempty"`
+
+	// Flow score penalties (see ComputeFlowScore). Zero values fall back to
+	// the DefaultFlow* constants.
+	FlowBlockedPenalty float64 `yaml:"flow_blocked_penalty,omitempty" json:"flow_blocked_penalty,omitempty"` // Scaled by the share of issues blocked by other labels
+	FlowInflowPenalty  float64 `yaml:"flow_inflow_penalty,omitempty" json:"flow_inflow_penalty,omitempty"`   // Scaled by net incoming dependencies
+	FlowOutflowPenalty float64 `yaml:"flow_outflow_penalty,omitempty" json:"flow_outflow_penalty,omitempty"` // Scaled by net outgoing dependencies
+
+	// Minimum scores for the "healthy" and "warning" levels. Zero values fall
+	// back to HealthyThreshold and WarningThreshold.
+	HealthyThresholdScore int `yaml:"healthy_threshold_score,omitempty" json:"healthy_threshold_score,omitempty"`
+	WarningThresholdScore int `yaml:"warning_threshold_score,omitempty" json:"warning_threshold_score,omitempty"`
+
+	// Label filters for ComputeAllLabelHealth. ExcludeLabels wins over
+	// IncludeLabels/IncludePattern; with neither include set, all labels
+	// are included. Excluded labels still count as cross-label neighbors.
+	IncludeLabels  []string `yaml:"include_labels,omitempty" json:"include_labels,omitempty"`
+	ExcludeLabels  []string `yaml:"exclude_labels,omitempty" json:"exclude_labels,omitempty"`
+	IncludePattern string   `yaml:"include_pattern,omitempty" json:"include_pattern,omitempty"` // Regex matched against label names
+
+	// FreshnessDecay selects the freshness score curve: FreshnessDecayLinear
+	// (the default when empty), FreshnessDecayExponential or FreshnessDecayStep.
+	FreshnessDecay string `yaml:"freshness_decay,omitempty" json:"freshness_decay,omitempty"`
+
+	// FreshnessPriorityWeighted derives the freshness score from staleness
+	// averaged by priority weight (P0 heaviest) instead of a plain average.
+	FreshnessPriorityWeighted bool `yaml:"freshness_priority_weighted,omitempty" json:"freshness_priority_weighted,omitempty"`
+
+	// SubgraphCentrality scores criticality on the subgraph of the label's
+	// own issues instead of project-wide centrality, so a label is compared
+	// with itself rather than the whole graph. SubgraphBoundaryNodes also
+	// keeps the issues one dependency away (see SubgraphKeepBoundary).
+	SubgraphCentrality    bool `yaml:"subgraph_centrality,omitempty" json:"subgraph_centrality,omitempty"`
+	SubgraphBoundaryNodes bool `yaml:"subgraph_boundary_nodes,omitempty" json:"subgraph_boundary_nodes,omitempty"`
+
+	// VelocityScaleClosedForMax is how many closures in 30 days earn a full
+	// velocity score; larger projects raise it so busy labels still rank
+	// apart. Zero falls back to DefaultVelocityScaleMax.
+	VelocityScaleClosedForMax int `yaml:"velocity_scale_closed_for_max,omitempty" json:"velocity_scale_closed_for_max,omitempty"`
+
+	// ExcludeFromVelocity lists labels whose closure velocity isn't
+	// comparable, such as dependabot or automated. Their health is scored
+	// on freshness, flow and criticality alone, and they are left out of
+	// velocity leaderboards and velocity-based drift.
+	ExcludeFromVelocity []string `yaml:"exclude_from_velocity,omitempty" json:"exclude_from_velocity,omitempty"`
+
+	// PriorityNames maps priority levels to display names in label output
+	// (e.g. 0: critical, 1: high). Defaults to P0-P4; priorities without a
+	// name show as UnknownPriorityName.
+	PriorityNames map[int]string `yaml:"priority_names,omitempty" json:"priority_names,omitempty"`
+
+	// PerLabelStaleDays overrides StaleThresholdDays for specific labels
+	// (e.g. a long threshold for "backlog", a short one for "incident").
+	PerLabelStaleDays map[string]int `yaml:"per_label_stale_days,omitempty" json:"per_label_stale_days,omitempty"`
+
+	// AgeBucketEdges sets the day boundaries of the freshness age histogram
+	// (positive and strictly increasing). Empty means DefaultAgeBucketEdges.
+	AgeBucketEdges []int `yaml:"age_bucket_edges,omitempty" json:"age_bucket_edges,omitempty"`
+}
+
+// StaleDaysForLabel returns the stale threshold to use for a label,
+// honoring PerLabelStaleDays overrides. Non-positive overrides are ignored.
+func (cfg LabelHealthConfig) StaleDaysForLabel(label string) int {
+	if days, ok := cfg.PerLabelStaleDays[label]; ok && days > 0 {
+		return days
+	}
+	return cfg.StaleThresholdDays
+}
+
+// flowPenalties returns the flow score penalties, substituting defaults
+// for unset values.
+func (cfg LabelHealthConfig) flowPenalties() (blocked, inflow, outflow float64) {
+	blocked, inflow, outflow = cfg.FlowBlockedPenalty, cfg.FlowInflowPenalty, cfg.FlowOutflowPenalty
+	if blocked <= 0 {
+		blocked = DefaultFlowBlockedPenalty
+	}
+	if inflow <= 0 {
+		inflow = DefaultFlowInflowPenalty
+	}
+	if outflow <= 0 {
+		outflow = DefaultFlowOutflowPenalty
+	}
+	return blocked, inflow, outflow
+}
+
+// filterLabels returns the labels selected by the include/exclude settings,
+// preserving order. An IncludePattern that fails to compile matches nothing.
+func (cfg LabelHealthConfig) filterLabels(labels []string) []string {
+	if len(cfg.IncludeLabels) == 0 && len(cfg.ExcludeLabels) == 0 && cfg.IncludePattern == "" {
+		return labels
+	}
+
+	excluded := make(map[string]bool, len(cfg.ExcludeLabels))
+	for _, l := range cfg.ExcludeLabels {
+		excluded[l] = true
+	}
+	included := make(map[string]bool, len(cfg.IncludeLabels))
+	for _, l := range cfg.IncludeLabels {
+		included[l] = true
+	}
+	var pattern *regexp.Regexp
+	if cfg.IncludePattern != "" {
+		pattern, _ = regexp.Compile(cfg.IncludePattern)
+	}
+	filterInclude := len(included) > 0 || cfg.IncludePattern != ""
+
+	filtered := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if excluded[l] {
+			continue
+		}
+		if filterInclude && !included[l] && (pattern == nil || !pattern.MatchString(l)) {
+			continue
+		}
+		filtered = append(filtered, l)
+	}
+	return filtered
 }
 
 // DefaultLabelHealthConfig returns sensible defaults
@@ -720,38 +1473,163 @@ func DefaultLabelHealthConfig() LabelHealthConfig {
 		CriticalityWeight:   CriticalityWeight,
 		MinIssuesForHealth:  1,
 		IncludeClosedInFlow: false,
+
+		IncludeClosedInFreshness: true,
+
+		AttentionVelocityDropPct:  DefaultAttentionVelocityDropPct,
+		AttentionStaleCount:       DefaultAttentionStaleCount,
+		AttentionExternalBlockers: DefaultAttentionExternalBlockers,
+		AttentionCriticalPaths:    DefaultAttentionCriticalPaths,
+
+		BottleneckPercentile: DefaultBottleneckPercentile,
+
+		VelocityScaleClosedForMax: DefaultVelocityScaleMax,
+
+		FlowBlockedPenalty: DefaultFlowBlockedPenalty,
+		FlowInflowPenalty:  DefaultFlowInflowPenalty,
+		FlowOutflowPenalty: DefaultFlowOutflowPenalty,
+
+		HealthyThresholdScore: HealthyThreshold,
+		WarningThresholdScore: WarningThreshold,
+
+		FreshnessDecay: FreshnessDecayLinear,
+		AgeBucketEdges: slices.Clone(DefaultAgeBucketEdges),
+		PriorityNames:  DefaultPriorityNames(),
 	}
 }
 
-// ============================================================================
-// Helper Functions
-// ============================================================================
+// IsVelocityExcluded reports whether label is listed in ExcludeFromVelocity
+func (cfg LabelHealthConfig) IsVelocityExcluded(label string) bool {
+	return slices.Contains(cfg.ExcludeFromVelocity, label)
+}
 
-// HealthLevelFromScore returns the health level string for a score
-func HealthLevelFromScore(score int) string {
-	if score >= HealthyThreshold {
+// withoutVelocityWeight returns cfg with the velocity weight spread over the
+// other components in proportion to their weights (evenly if they are all
+// zero), so the composite stays on a 0-100 scale
+func (cfg LabelHealthConfig) withoutVelocityWeight() LabelHealthConfig {
+	rest := cfg.FreshnessWeight + cfg.FlowWeight + cfg.CriticalityWeight
+	total := rest + cfg.VelocityWeight
+	if rest <= 0 {
+		cfg.FreshnessWeight, cfg.FlowWeight, cfg.CriticalityWeight = total/3, total/3, total/3
+	} else {
+		scale := total / rest
+		cfg.FreshnessWeight *= scale
+		cfg.FlowWeight *= scale
+		cfg.CriticalityWeight *= scale
+	}
+	cfg.VelocityWeight = 0
+	return cfg
+}
+
+// PriorityName returns the configured display name for a priority
+func (cfg LabelHealthConfig) PriorityName(priority int) string {
+	return PriorityName(priority, cfg.PriorityNames)
+}
+
+// healthThresholds returns the configured healthy and warning minimums,
+// falling back to the package defaults for unset values.
+func (cfg LabelHealthConfig) healthThresholds() (healthy, warning int) {
+	healthy, warning = cfg.HealthyThresholdScore, cfg.WarningThresholdScore
+	if healthy <= 0 {
+		healthy = HealthyThreshold
+	}
+	if warning <= 0 {
+		warning = WarningThreshold
+	}
+	return healthy, warning
+}
+
+// HealthLevelFromScore returns the health level string for a score using
+// the configured thresholds
+func (cfg LabelHealthConfig) HealthLevelFromScore(score int) string {
+	healthy, warning := cfg.healthThresholds()
+	if score >= healthy {
 		return HealthLevelHealthy
 	}
-	if score >= WarningThreshold {
+	if score >= warning {
 		return HealthLevelWarning
 	}
 	return HealthLevelCritical
 }
 
-// NeedsAttention returns true if a label needs attention based on health
+// NeedsAttention returns true if a label is below the configured healthy threshold
+func (cfg LabelHealthConfig) NeedsAttention(health LabelHealth) bool {
+	if health.Insufficient {
+		return false
+	}
+	healthy, _ := cfg.healthThresholds()
+	return health.Health < healthy
+}
+
+// ============================================================================
+// Helper Functions
+// ============================================================================
+
+// HealthLevelFromScore returns the health level string for a score using
+// the default thresholds
+func HealthLevelFromScore(score int) string {
+	return DefaultLabelHealthConfig().HealthLevelFromScore(score)
+}
+
+// NeedsAttention returns true if a label needs attention based on health,
+// using the default thresholds
 func NeedsAttention(health LabelHealth) bool {
-	return health.Health < HealthyThreshold
+	return DefaultLabelHealthConfig().NeedsAttention(health)
 }
 
-// ComputeCompositeHealth calculates the overall health score from components
-func ComputeCompositeHealth(velocity, freshness, flow, criticality int, cfg LabelHealthConfig) int {
-	weighted := float64(velocity)*cfg.VelocityWeight +
-		float64(freshness)*cfg.FreshnessWeight +
-		float64(flow)*cfg.FlowWeight +
-		float64(criticality)*cfg.CriticalityWeight
+// AttentionReasons explains why a label needs attention. Each signal is
+// compared against its cfg threshold so trivial deviations are not reported.
+// Returns nil when nothing crosses a threshold.
+func AttentionReasons(health LabelHealth, cfg LabelHealthConfig) []string {
+	dropPct := cfg.AttentionVelocityDropPct
+	if dropPct <= 0 {
+		dropPct = DefaultAttentionVelocityDropPct
+	}
+	staleMin := cfg.AttentionStaleCount
+	if staleMin <= 0 {
+		staleMin = DefaultAttentionStaleCount
+	}
+	blockersMin := cfg.AttentionExternalBlockers
+	if blockersMin <= 0 {
+		blockersMin = DefaultAttentionExternalBlockers
+	}
+	critMin := cfg.AttentionCriticalPaths
+	if critMin <= 0 {
+		critMin = DefaultAttentionCriticalPaths
+	}
+
+	var reasons []string
+	if !health.Velocity.Excluded && health.Velocity.TrendDirection == "declining" && -health.Velocity.TrendPercent >= dropPct {
+		reasons = append(reasons, fmt.Sprintf("velocity declining %.0f%%", -health.Velocity.TrendPercent))
+	}
+	if n := health.Freshness.StaleCount; n >= staleMin {
+		reasons = append(reasons, fmt.Sprintf("%d stale %s", n, pluralize(n, "issue")))
+	}
+	if n := len(health.Flow.IncomingLabels); n >= blockersMin {
+		reasons = append(reasons, fmt.Sprintf("blocked by %d external %s", n, pluralize(n, "label")))
+	}
+	if n := health.Criticality.CriticalPathCount; n >= critMin {
+		reasons = append(reasons, fmt.Sprintf("on %d critical %s", n, pluralize(n, "path")))
+	}
+	return reasons
+}
+
+// ComputeCompositeHealth calculates the overall health score from components,
+// along with each component's share of it
+func ComputeCompositeHealth(velocity, freshness, flow, criticality int, cfg LabelHealthConfig) (int, HealthBreakdown) {
+	component := func(score int, weight float64) HealthComponent {
+		return HealthComponent{Score: score, Weight: weight, Contribution: float64(score) * weight}
+	}
+	breakdown := HealthBreakdown{
+		Velocity:    component(velocity, cfg.VelocityWeight),
+		Freshness:   component(freshness, cfg.FreshnessWeight),
+		Flow:        component(flow, cfg.FlowWeight),
+		Criticality: component(criticality, cfg.CriticalityWeight),
+	}
+	weighted := breakdown.Total()
 
 	// Normalize to 0-100 and clamp
-	return clampScore(int(weighted + 0.5))
+	return clampScore(int(weighted + 0.5)), breakdown
 }
 
 // NewLabelHealth creates a new LabelHealth with default values
@@ -793,6 +1671,30 @@ type LabelStats struct {
 	ByPriority  map[int]int    `json:"by_priority"`  // Count by priority level
 	ByType      map[string]int `json:"by_type"`      // Count by issue type
 	IssueIDs    []string       `json:"issue_ids"`    // All issue IDs with this label
+
+	// ByPriorityName is ByPriority keyed by priority name (see PriorityName);
+	// priorities sharing a name are summed
+	ByPriorityName map[string]int `json:"by_priority_name"`
+}
+
+// UnknownPriorityName is shown for priorities without a configured name
+const UnknownPriorityName = "P?"
+
+// DefaultPriorityNames returns the stock names P0 through P4
+func DefaultPriorityNames() map[int]string {
+	return map[int]string{0: "P0", 1: "P1", 2: "P2", 3: "P3", 4: "P4"}
+}
+
+// PriorityName returns the display name for a priority from names, or
+// UnknownPriorityName if it has none. Nil names means DefaultPriorityNames.
+func PriorityName(priority int, names map[int]string) string {
+	if names == nil {
+		names = DefaultPriorityNames()
+	}
+	if name, ok := names[priority]; ok && name != "" {
+		return name
+	}
+	return UnknownPriorityName
 }
 
 // LabelExtractionResult contains all extracted label data
@@ -805,9 +1707,27 @@ type LabelExtractionResult struct {
 	TopLabels      []string               `json:"top_labels"`      // Labels sorted by issue count
 }
 
+// ExtractLabelsOptions controls label extraction
+type ExtractLabelsOptions struct {
+	// DedupePerIssue counts a label at most once per issue, so a label
+	// listed twice on one issue doesn't inflate TotalCount and the status
+	// counts. Off by default for compatibility; recommended on.
+	DedupePerIssue bool
+
+	// PriorityNames names priorities for LabelStats.ByPriorityName; nil
+	// means DefaultPriorityNames
+	PriorityNames map[int]string
+}
+
 // ExtractLabels extracts unique labels from a slice of issues with statistics
-// Handles edge cases: nil issues, empty labels, duplicate labels
+// Handles edge cases: nil issues, empty labels, duplicate labels (counted
+// once per occurrence; see ExtractLabelsWithOptions)
 func ExtractLabels(issues []model.Issue) LabelExtractionResult {
+	return ExtractLabelsWithOptions(issues, ExtractLabelsOptions{})
+}
+
+// ExtractLabelsWithOptions is ExtractLabels with explicit options
+func ExtractLabelsWithOptions(issues []model.Issue, opts ExtractLabelsOptions) LabelExtractionResult {
 	result := LabelExtractionResult{
 		Stats:     make(map[string]*LabelStats),
 		Labels:    []string{},
@@ -828,11 +1748,21 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 		}
 
 		// Process each label on the issue
+		var seenOnIssue map[string]bool
+		if opts.DedupePerIssue {
+			seenOnIssue = make(map[string]bool, len(issue.Labels))
+		}
 		for _, label := range issue.Labels {
 			// Skip empty labels
 			if label == "" {
 				continue
 			}
+			if seenOnIssue != nil {
+				if seenOnIssue[label] {
+					continue
+				}
+				seenOnIssue[label] = true
+			}
 
 			// Track unique labels
 			labelSet[label] = true
@@ -841,10 +1771,11 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 			stats, exists := result.Stats[label]
 			if !exists {
 				stats = &LabelStats{
-					Label:      label,
-					ByPriority: make(map[int]int),
-					ByType:     make(map[string]int),
-					IssueIDs:   []string{},
+					Label:          label,
+					ByPriority:     make(map[int]int),
+					ByType:         make(map[string]int),
+					IssueIDs:       []string{},
+					ByPriorityName: make(map[string]int),
 				}
 				result.Stats[label] = stats
 			}
@@ -867,6 +1798,7 @@ func ExtractLabels(issues []model.Issue) LabelExtractionResult {
 
 			// Count by priority
 			stats.ByPriority[issue.Priority]++
+			stats.ByPriorityName[PriorityName(issue.Priority, opts.PriorityNames)]++
 
 			// Count by type
 			stats.ByType[string(issue.IssueType)]++
@@ -1008,8 +1940,8 @@ func ComputeBlockedByLabel(issues []model.Issue, analyzer *Analyzer) map[string]
 			continue
 		}
 
-		// Check if issue is blocked
-		blockers := analyzer.GetOpenBlockers(issue.ID)
+		// Check if issue is blocked; soft edges (related etc.) don't block
+		blockers := analyzer.GetOpenBlockersOfType(issue.ID, model.DepBlocks)
 		if len(blockers) > 0 {
 			// This issue is blocked - count for each of its labels
 			for _, label := range issue.Labels {
@@ -1569,8 +2501,8 @@ func ComputeLabelPageRank(sg LabelSubgraph) LabelPageRankResult {
 		}
 	}
 
-	// Run deterministic PageRank (damping 0.85, tolerance 1e-6)
-	pr := computePageRank(g, 0.85, 1e-6)
+	// Run deterministic PageRank with the default parameters
+	pr := computePageRank(g, DefaultPageRankDamping, DefaultPageRankTolerance, DefaultPageRankMaxIterations)
 
 	// Convert to string IDs and find min/max
 	var maxScore, minScore float64
@@ -2015,7 +2947,7 @@ func computeLabelAttention(label string, issues []model.Issue, issueMap map[stri
 	}
 
 	// Compute staleness factor
-	freshness := ComputeFreshnessMetrics(labeledIssues, now, cfg.StaleThresholdDays)
+	freshness := ComputeFreshnessMetricsWithOptions(labeledIssues, now, cfg.StaleDaysForLabel(label), cfg.freshnessOptions())
 	score.StaleCount = freshness.StaleCount
 	if score.OpenCount > 0 {
 		score.StalenessFactor = 1.0 + float64(score.StaleCount)/float64(score.OpenCount)
@@ -2043,8 +2975,12 @@ func computeLabelAttention(label string, issues []model.Issue, issueMap map[stri
 
 	// Compute velocity factor
 	velocity := ComputeVelocityMetrics(labeledIssues, now)
-	// Use closed in last 30 days + 1 to avoid division by zero
+	// Use closed in last 30 days + 1 to avoid division by zero; labels
+	// excluded from velocity get the neutral factor
 	score.VelocityFactor = float64(velocity.ClosedLast30Days) + 1.0
+	if cfg.IsVelocityExcluded(label) {
+		score.VelocityFactor = 1.0
+	}
 
 	// Compute attention score
 	// attention = (pagerank_sum * staleness_factor * (1 + block_impact)) / velocity
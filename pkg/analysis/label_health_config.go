@@ -0,0 +1,212 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LabelHealthConfigFilename is the default label health config filename
+const LabelHealthConfigFilename = "labels.yaml"
+
+// labelWeightSumTolerance allows for rounding in hand-written weights
+const labelWeightSumTolerance = 0.001
+
+// LabelHealthConfigPath returns the default label health config path for a project
+func LabelHealthConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", LabelHealthConfigFilename)
+}
+
+// LoadLabelHealthConfig loads label health configuration from .bv/labels.yaml.
+// Fields missing from the file keep their defaults; returns the default
+// config if the file doesn't exist.
+func LoadLabelHealthConfig(projectDir string) (LabelHealthConfig, error) {
+	cfg := DefaultLabelHealthConfig()
+
+	data, err := os.ReadFile(LabelHealthConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return LabelHealthConfig{}, fmt.Errorf("reading label health config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return LabelHealthConfig{}, fmt.Errorf("parsing label health config: %w", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return LabelHealthConfig{}, fmt.Errorf("invalid label health config: %w", err)
+	}
+	return cfg, nil
+}
+
+// SaveLabelHealthConfig saves label health configuration to .bv/labels.yaml
+func SaveLabelHealthConfig(projectDir string, cfg LabelHealthConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+
+	path := LabelHealthConfigPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("encoding label health config: %w", err)
+	}
+
+	header := "# Label health scoring configuration\n# See: bv --robot-help for label health options\n\n"
+	if err := os.WriteFile(path, []byte(header+string(data)), 0644); err != nil {
+		return fmt.Errorf("writing label health config: %w", err)
+	}
+	return nil
+}
+
+// Validate checks that config values are sensible. The component weights
+// must be non-negative and sum to 1 so the composite stays on a 0-100 scale.
+func (cfg LabelHealthConfig) Validate() error {
+	weights := []struct {
+		name  string
+		value float64
+	}{
+		{"velocity_weight", cfg.VelocityWeight},
+		{"freshness_weight", cfg.FreshnessWeight},
+		{"flow_weight", cfg.FlowWeight},
+		{"criticality_weight", cfg.CriticalityWeight},
+	}
+	sum := 0.0
+	for _, w := range weights {
+		if w.value < 0 {
+			return fmt.Errorf("%s must be non-negative, got %g", w.name, w.value)
+		}
+		sum += w.value
+	}
+	if math.Abs(sum-1) > labelWeightSumTolerance {
+		return fmt.Errorf("weights must sum to 1, got %g", sum)
+	}
+
+	if cfg.StaleThresholdDays <= 0 {
+		return fmt.Errorf("stale_threshold_days must be positive, got %d", cfg.StaleThresholdDays)
+	}
+	if cfg.MinIssuesForHealth < 0 {
+		return fmt.Errorf("min_issues_for_health must be non-negative, got %d", cfg.MinIssuesForHealth)
+	}
+	if cfg.AttentionVelocityDropPct < 0 || cfg.AttentionStaleCount < 0 ||
+		cfg.AttentionExternalBlockers < 0 || cfg.AttentionCriticalPaths < 0 {
+		return fmt.Errorf("attention thresholds must be non-negative")
+	}
+	if cfg.VelocityScaleClosedForMax < 0 {
+		return fmt.Errorf("velocity_scale_closed_for_max must be non-negative, got %d", cfg.VelocityScaleClosedForMax)
+	}
+	if cfg.BottleneckPercentile < 0 || cfg.BottleneckPercentile > 100 {
+		return fmt.Errorf("bottleneck_percentile must be between 0 and 100, got %g", cfg.BottleneckPercentile)
+	}
+	if cfg.FlowBlockedPenalty < 0 || cfg.FlowInflowPenalty < 0 || cfg.FlowOutflowPenalty < 0 {
+		return fmt.Errorf("flow penalties must be non-negative")
+	}
+	if cfg.HealthyThresholdScore < 0 || cfg.HealthyThresholdScore > 100 ||
+		cfg.WarningThresholdScore < 0 || cfg.WarningThresholdScore > 100 {
+		return fmt.Errorf("health threshold scores must be between 0 and 100")
+	}
+	if healthy, warning := cfg.healthThresholds(); healthy <= warning {
+		return fmt.Errorf("healthy_threshold_score (%d) must be greater than warning_threshold_score (%d)", healthy, warning)
+	}
+	switch cfg.FreshnessDecay {
+	case "", FreshnessDecayLinear, FreshnessDecayExponential, FreshnessDecayStep:
+	default:
+		return fmt.Errorf("freshness_decay must be linear, exponential or step, got %q", cfg.FreshnessDecay)
+	}
+	if cfg.IncludePattern != "" {
+		if _, err := regexp.Compile(cfg.IncludePattern); err != nil {
+			return fmt.Errorf("include_pattern: %w", err)
+		}
+	}
+	for i, edge := range cfg.AgeBucketEdges {
+		if edge <= 0 || (i > 0 && edge <= cfg.AgeBucketEdges[i-1]) {
+			return fmt.Errorf("age_bucket_edges must be positive and strictly increasing, got %v", cfg.AgeBucketEdges)
+		}
+	}
+	for label, days := range cfg.PerLabelStaleDays {
+		if days < 0 {
+			return fmt.Errorf("per_label_stale_days %q: must be non-negative, got %d", label, days)
+		}
+	}
+	return nil
+}
+
+// ExampleLabelHealthConfig returns an example configuration with comments
+func ExampleLabelHealthConfig() string {
+	return `# Label health scoring configuration
+
+# Component weights for the composite 0-100 health score (must sum to 1)
+velocity_weight: 0.25      # Closure throughput and trend
+freshness_weight: 0.25     # How recently issues were updated
+flow_weight: 0.25          # Cross-label blocking in and out
+criticality_weight: 0.25   # Centrality of the label's issues
+
+# Staleness
+stale_threshold_days: 14   # Issues untouched this long count as stale
+freshness_decay: linear    # Score curve: linear, exponential (half-life = threshold) or step
+freshness_priority_weighted: false   # Weight staleness by priority (P0 counts 5x a P4)
+age_bucket_edges: [7, 14, 30]        # Days-since-update histogram: 0-7, 8-14, 15-30, 31+
+include_closed_in_freshness: true    # Count closed issues in staleness (false = open issues only)
+
+# Closures in 30 days that earn a full velocity score (raise for busy projects)
+velocity_scale_closed_for_max: 10
+
+# Minimum issues needed to compute a label's health
+min_issues_for_health: 1
+
+# Count closed issues when building cross-label flow
+include_closed_in_flow: false
+
+# Flow score penalties, subtracted from 100
+flow_blocked_penalty: 60   # Scaled by the share of the label's issues blocked by other labels
+flow_inflow_penalty: 30    # Scaled by how far dependencies lean into the label (waiting on others)
+flow_outflow_penalty: 10   # Scaled by how far they lean out of it (others waiting on it)
+
+# Attention reasons below these thresholds are not reported
+attention_velocity_drop_pct: 25   # Min velocity decline (percent)
+attention_stale_count: 3          # Min stale issues
+attention_external_blockers: 2    # Min distinct blocking labels
+attention_critical_paths: 3       # Min issues on critical paths
+
+# Top share (percent) of issues by betweenness flagged as bottlenecks
+bottleneck_percentile: 10
+
+# Score criticality within each label's own subgraph instead of project-wide
+subgraph_centrality: false
+subgraph_boundary_nodes: false   # Also keep issues one dependency away as boundary nodes
+
+# Minimum composite scores for the healthy and warning levels
+healthy_threshold_score: 70
+warning_threshold_score: 40
+
+# Restrict the analysis to some labels (exclude wins over include)
+# include_pattern: "^area/"
+# include_labels:
+#   - release
+# exclude_labels:
+#   - area/legacy
+
+# Labels whose closure velocity isn't comparable (bots, automation): health
+# leans on freshness, flow and criticality, and they skip velocity rankings
+# exclude_from_velocity:
+#   - dependabot
+#   - automated
+
+# Priority display names (defaults to P0-P4; unnamed priorities show as P?)
+# priority_names:
+#   0: critical
+#   1: high
+
+# Per-label staleness overrides
+# per_label_stale_days:
+#   backlog: 60
+#   incident: 2
+`
+}
@@ -0,0 +1,248 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoadLabelHealthConfig_MissingFileReturnsDefaults(t *testing.T) {
+	cfg, err := LoadLabelHealthConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadLabelHealthConfig: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultLabelHealthConfig()) {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLabelHealthConfig_SaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultLabelHealthConfig()
+	cfg.VelocityWeight = 0.4
+	cfg.FreshnessWeight = 0.3
+	cfg.FlowWeight = 0.2
+	cfg.CriticalityWeight = 0.1
+	cfg.StaleThresholdDays = 21
+	cfg.PerLabelStaleDays = map[string]int{"backlog": 60}
+
+	if err := SaveLabelHealthConfig(dir, cfg); err != nil {
+		t.Fatalf("SaveLabelHealthConfig: %v", err)
+	}
+	loaded, err := LoadLabelHealthConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadLabelHealthConfig: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("round trip mismatch:\n got  %+v\n want %+v", loaded, cfg)
+	}
+}
+
+func TestLoadLabelHealthConfig_PartialFileKeepsDefaults(t *testing.T) {
+	dir := t.TempDir()
+	writeLabelsYAML(t, dir, "stale_threshold_days: 7\n")
+
+	cfg, err := LoadLabelHealthConfig(dir)
+	if err != nil {
+		t.Fatalf("LoadLabelHealthConfig: %v", err)
+	}
+	if cfg.StaleThresholdDays != 7 {
+		t.Errorf("StaleThresholdDays = %d, want 7", cfg.StaleThresholdDays)
+	}
+	if cfg.VelocityWeight != VelocityWeight {
+		t.Errorf("VelocityWeight = %v, want default %v", cfg.VelocityWeight, VelocityWeight)
+	}
+}
+
+func TestLoadLabelHealthConfig_InvalidWeights(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"sum too high", "velocity_weight: 0.5\n", "sum to 1"},
+		{"negative", "velocity_weight: -0.25\nfreshness_weight: 0.75\n", "non-negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeLabelsYAML(t, dir, tt.yaml)
+			_, err := LoadLabelHealthConfig(dir)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestSaveLabelHealthConfig_RejectsInvalid(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	cfg.FlowWeight = 0
+	if err := SaveLabelHealthConfig(t.TempDir(), cfg); err == nil {
+		t.Error("expected weights summing to 0.75 to be rejected")
+	}
+}
+
+func TestExampleLabelHealthConfig(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	if err := yaml.Unmarshal([]byte(ExampleLabelHealthConfig()), &cfg); err != nil {
+		t.Fatalf("ExampleLabelHealthConfig() returned invalid YAML: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("ExampleLabelHealthConfig() should validate: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, DefaultLabelHealthConfig()) {
+		t.Errorf("example should match defaults, got %+v", cfg)
+	}
+}
+
+func writeLabelsYAML(t *testing.T, dir, content string) {
+	t.Helper()
+	path := LabelHealthConfigPath(dir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLabelHealthConfig_InvalidIncludePattern(t *testing.T) {
+	dir := t.TempDir()
+	writeLabelsYAML(t, dir, "include_pattern: \"area/(\"\n")
+	if _, err := LoadLabelHealthConfig(dir); err == nil || !strings.Contains(err.Error(), "include_pattern") {
+		t.Errorf("expected include_pattern error, got %v", err)
+	}
+}
+
+func TestLabelHealthConfig_InvalidFreshnessDecay(t *testing.T) {
+	dir := t.TempDir()
+	writeLabelsYAML(t, dir, "freshness_decay: quadratic\n")
+	if _, err := LoadLabelHealthConfig(dir); err == nil || !strings.Contains(err.Error(), "freshness_decay") {
+		t.Errorf("expected freshness_decay error, got %v", err)
+	}
+
+	writeLabelsYAML(t, dir, "freshness_decay: step\n")
+	cfg, err := LoadLabelHealthConfig(dir)
+	if err != nil || cfg.FreshnessDecay != FreshnessDecayStep {
+		t.Errorf("step decay should load, got %q, %v", cfg.FreshnessDecay, err)
+	}
+}
+
+func TestLabelHealthConfig_VelocityScale(t *testing.T) {
+	dir := t.TempDir()
+	writeLabelsYAML(t, dir, "velocity_scale_closed_for_max: -5\n")
+	if _, err := LoadLabelHealthConfig(dir); err == nil || !strings.Contains(err.Error(), "velocity_scale_closed_for_max") {
+		t.Errorf("expected velocity_scale_closed_for_max error, got %v", err)
+	}
+
+	writeLabelsYAML(t, dir, "velocity_scale_closed_for_max: 50\n")
+	cfg, err := LoadLabelHealthConfig(dir)
+	if err != nil || cfg.VelocityScaleClosedForMax != 50 {
+		t.Errorf("scale should load, got %d, %v", cfg.VelocityScaleClosedForMax, err)
+	}
+}
+
+func TestLabelHealthConfig_AgeBucketEdges(t *testing.T) {
+	dir := t.TempDir()
+	for _, bad := range []string{"[7, 7, 30]", "[14, 7]", "[0, 7]"} {
+		writeLabelsYAML(t, dir, "age_bucket_edges: "+bad+"\n")
+		if _, err := LoadLabelHealthConfig(dir); err == nil || !strings.Contains(err.Error(), "age_bucket_edges") {
+			t.Errorf("%s: expected age_bucket_edges error, got %v", bad, err)
+		}
+	}
+
+	writeLabelsYAML(t, dir, "age_bucket_edges: [3, 10]\n")
+	cfg, err := LoadLabelHealthConfig(dir)
+	if err != nil || !reflect.DeepEqual(cfg.AgeBucketEdges, []int{3, 10}) {
+		t.Errorf("edges should load, got %v, %v", cfg.AgeBucketEdges, err)
+	}
+}
+
+func TestLoadLabelHealthConfig_PriorityNames(t *testing.T) {
+	dir := t.TempDir()
+	writeLabelsYAML(t, dir, "priority_names:\n  0: critical\n  1: high\n")
+	cfg, err := LoadLabelHealthConfig(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	// Configured names replace the defaults they cover; the rest keep P2-P4
+	want := map[int]string{0: "critical", 1: "high", 2: "P2", 3: "P3", 4: "P4"}
+	if !reflect.DeepEqual(cfg.PriorityNames, want) {
+		t.Errorf("PriorityNames = %v, want %v", cfg.PriorityNames, want)
+	}
+}
+
+func TestLabelHealthConfig_HealthThresholds(t *testing.T) {
+	strict := DefaultLabelHealthConfig()
+	strict.HealthyThresholdScore = 85
+	strict.WarningThresholdScore = 50
+	if err := strict.Validate(); err != nil {
+		t.Fatalf("85/50 should validate: %v", err)
+	}
+
+	if got := HealthLevelFromScore(75); got != HealthLevelHealthy {
+		t.Errorf("default HealthLevelFromScore(75) = %q, want healthy", got)
+	}
+	if got := strict.HealthLevelFromScore(75); got != HealthLevelWarning {
+		t.Errorf("85/50 HealthLevelFromScore(75) = %q, want warning", got)
+	}
+	if got := strict.HealthLevelFromScore(45); got != HealthLevelCritical {
+		t.Errorf("85/50 HealthLevelFromScore(45) = %q, want critical", got)
+	}
+	if got := (LabelHealthConfig{}).HealthLevelFromScore(70); got != HealthLevelHealthy {
+		t.Errorf("unset thresholds should fall back to defaults, got %q", got)
+	}
+
+	for _, tt := range []struct{ healthy, warning int }{{50, 50}, {40, 60}, {101, 40}} {
+		cfg := DefaultLabelHealthConfig()
+		cfg.HealthyThresholdScore, cfg.WarningThresholdScore = tt.healthy, tt.warning
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%d/%d should be rejected", tt.healthy, tt.warning)
+		}
+	}
+}
+
+func TestComputeAllLabelHealth_ConfiguredThresholds(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	issues := incrementalFixture(now)
+	cfg := DefaultLabelHealthConfig()
+
+	base := ComputeAllLabelHealth(issues, cfg, now, nil)
+	var score int
+	for _, h := range base.Labels {
+		if h.Label == "docs" {
+			score = h.Health
+		}
+	}
+
+	// Put the healthy bar just above docs' score so only it moves
+	cfg.HealthyThresholdScore = score + 1
+	cfg.WarningThresholdScore = score - 1
+	strict := ComputeAllLabelHealth(issues, cfg, now, nil)
+	for _, h := range strict.Labels {
+		if h.Label == "docs" && h.HealthLevel != HealthLevelWarning {
+			t.Errorf("docs (health %d) level = %q, want warning", h.Health, h.HealthLevel)
+		}
+	}
+	levels := map[string]int{}
+	for _, h := range strict.Labels {
+		levels[h.HealthLevel]++
+	}
+	if strict.HealthyCount != levels[HealthLevelHealthy] ||
+		strict.WarningCount != levels[HealthLevelWarning] ||
+		strict.CriticalCount != levels[HealthLevelCritical] {
+		t.Errorf("counts %d/%d/%d do not match levels %v",
+			strict.HealthyCount, strict.WarningCount, strict.CriticalCount, levels)
+	}
+	for _, s := range strict.Summaries {
+		if s.Label == "docs" && !s.NeedsAttention {
+			t.Error("docs should need attention below the configured healthy threshold")
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/internal/jsonl"
+)
+
+// LabelHealthHistoryFilename is the append-only label health history under .bv
+const LabelHealthHistoryFilename = "label-health-history.jsonl"
+
+// LabelHealthHistoryMaxBytes is the size past which the history file is
+// rotated. Rotation drops the oldest snapshots until the file is at most half
+// this size, so appends don't rewrite the file every time.
+const LabelHealthHistoryMaxBytes = 1 << 20
+
+// LabelHealthTrendWindow is the number of most recent points LabelHealthTrend
+// considers
+const LabelHealthTrendWindow = 5
+
+// labelTrendStableSlope is the health change per snapshot below which a
+// label's trend is "stable"
+const labelTrendStableSlope = 1.0
+
+// LabelHealthHistoryPath returns the label health history path for a project
+func LabelHealthHistoryPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", LabelHealthHistoryFilename)
+}
+
+// LabelHealthPoint is one label's scores at one snapshot
+type LabelHealthPoint struct {
+	At          time.Time `json:"at"`
+	Health      int       `json:"health"`
+	Velocity    int       `json:"velocity"`
+	Freshness   int       `json:"freshness"`
+	Flow        int       `json:"flow"`
+	Criticality int       `json:"criticality"`
+}
+
+// labelHealthSnapshot is one line of the history file: every label's scores
+// from a single analysis run
+type labelHealthSnapshot struct {
+	At     time.Time                   `json:"at"`
+	Labels map[string]labelHealthScore `json:"labels"`
+}
+
+// labelHealthScore is the per-label payload of a snapshot
+type labelHealthScore struct {
+	Health      int `json:"health"`
+	Velocity    int `json:"velocity"`
+	Freshness   int `json:"freshness"`
+	Flow        int `json:"flow"`
+	Criticality int `json:"criticality"`
+}
+
+// AppendLabelHealthSnapshot appends the scores of every label in result to
+// .bv/label-health-history.jsonl, stamped with result.GeneratedAt (or now if
+// unset). The file is rotated once it exceeds LabelHealthHistoryMaxBytes.
+func AppendLabelHealthSnapshot(projectDir string, result LabelAnalysisResult) error {
+	snapshot := labelHealthSnapshot{
+		At:     result.GeneratedAt.UTC(),
+		Labels: make(map[string]labelHealthScore, len(result.Labels)),
+	}
+	if result.GeneratedAt.IsZero() {
+		snapshot.At = time.Now().UTC()
+	}
+	for _, h := range result.Labels {
+		snapshot.Labels[h.Label] = labelHealthScore{
+			Health:      h.Health,
+			Velocity:    h.Velocity.VelocityScore,
+			Freshness:   h.Freshness.FreshnessScore,
+			Flow:        h.Flow.FlowScore,
+			Criticality: h.Criticality.CriticalityScore,
+		}
+	}
+
+	path := LabelHealthHistoryPath(projectDir)
+	if err := jsonl.Append(path, snapshot); err != nil {
+		return fmt.Errorf("appending label health history: %w", err)
+	}
+	if err := jsonl.TrimToSize(path, LabelHealthHistoryMaxBytes); err != nil {
+		return fmt.Errorf("rotating label health history: %w", err)
+	}
+	return nil
+}
+
+// LoadLabelHealthHistory returns a label's recorded scores from since onward
+// (zero since means all), oldest first. Snapshots taken before the label
+// existed are simply absent. A missing or unreadable file yields no points;
+// malformed lines are skipped.
+func LoadLabelHealthHistory(projectDir, label string, since time.Time) []LabelHealthPoint {
+	lines, err := jsonl.ReadLines(LabelHealthHistoryPath(projectDir))
+	if err != nil {
+		return nil
+	}
+
+	var points []LabelHealthPoint
+	for _, line := range lines {
+		var snapshot labelHealthSnapshot
+		if err := json.Unmarshal([]byte(line), &snapshot); err != nil {
+			continue
+		}
+		score, ok := snapshot.Labels[label]
+		if !ok || snapshot.At.Before(since) {
+			continue
+		}
+		points = append(points, LabelHealthPoint{
+			At:          snapshot.At,
+			Health:      score.Health,
+			Velocity:    score.Velocity,
+			Freshness:   score.Freshness,
+			Flow:        score.Flow,
+			Criticality: score.Criticality,
+		})
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].At.Before(points[j].At) })
+	return points
+}
+
+// LabelHealthTrend classifies the recent direction of a label's health as
+// "improving", "declining" or "stable", from the least-squares slope of the
+// last LabelHealthTrendWindow points. Fewer than two points are "stable".
+func LabelHealthTrend(points []LabelHealthPoint) string {
+	if len(points) > LabelHealthTrendWindow {
+		points = points[len(points)-LabelHealthTrendWindow:]
+	}
+	n := float64(len(points))
+	if n < 2 {
+		return "stable"
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, p := range points {
+		x, y := float64(i), float64(p.Health)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	slope := (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+
+	switch {
+	case slope >= labelTrendStableSlope:
+		return "improving"
+	case slope <= -labelTrendStableSlope:
+		return "declining"
+	default:
+		return "stable"
+	}
+}
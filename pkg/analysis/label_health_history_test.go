@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/internal/jsonl"
+)
+
+func historyResult(at time.Time, healths map[string]int) LabelAnalysisResult {
+	result := LabelAnalysisResult{GeneratedAt: at}
+	for label, h := range healths {
+		lh := NewLabelHealth(label)
+		lh.Health = h
+		lh.Velocity.VelocityScore = h + 1
+		lh.Freshness.FreshnessScore = h + 2
+		lh.Flow.FlowScore = h + 3
+		lh.Criticality.CriticalityScore = h + 4
+		result.Labels = append(result.Labels, lh)
+	}
+	return result
+}
+
+func TestLabelHealthHistory_Trajectory(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	week := 7 * 24 * time.Hour
+
+	snapshots := []map[string]int{
+		{"api": 80},
+		{"api": 70},
+		{"api": 60, "ui": 40}, // ui appears partway through
+		{"api": 50, "ui": 55},
+	}
+	for i, healths := range snapshots {
+		if err := AppendLabelHealthSnapshot(dir, historyResult(start.Add(time.Duration(i)*week), healths)); err != nil {
+			t.Fatalf("AppendLabelHealthSnapshot: %v", err)
+		}
+	}
+
+	api := LoadLabelHealthHistory(dir, "api", time.Time{})
+	if len(api) != 4 {
+		t.Fatalf("api points = %d, want 4", len(api))
+	}
+	for i, want := range []int{80, 70, 60, 50} {
+		if api[i].Health != want || !api[i].At.Equal(start.Add(time.Duration(i)*week)) {
+			t.Errorf("api[%d] = %+v, want health %d", i, api[i], want)
+		}
+	}
+	wantPoint := LabelHealthPoint{At: start, Health: 80, Velocity: 81, Freshness: 82, Flow: 83, Criticality: 84}
+	if !reflect.DeepEqual(api[0], wantPoint) {
+		t.Errorf("api[0] = %+v, want %+v", api[0], wantPoint)
+	}
+
+	ui := LoadLabelHealthHistory(dir, "ui", time.Time{})
+	if len(ui) != 2 || ui[0].Health != 40 || ui[1].Health != 55 || !ui[0].At.Equal(start.Add(2*week)) {
+		t.Errorf("ui trajectory = %+v, want 40 then 55 from week 3", ui)
+	}
+
+	recent := LoadLabelHealthHistory(dir, "api", start.Add(2*week))
+	if len(recent) != 2 || recent[0].Health != 60 {
+		t.Errorf("since filter: %+v", recent)
+	}
+
+	if got := LoadLabelHealthHistory(dir, "missing", time.Time{}); len(got) != 0 {
+		t.Errorf("unknown label should have no points, got %+v", got)
+	}
+	if got := LoadLabelHealthHistory(t.TempDir(), "api", time.Time{}); got != nil {
+		t.Errorf("missing file should have no points, got %+v", got)
+	}
+
+	if got := LabelHealthTrend(api); got != "declining" {
+		t.Errorf("api trend = %q, want declining", got)
+	}
+	if got := LabelHealthTrend(ui); got != "improving" {
+		t.Errorf("ui trend = %q, want improving", got)
+	}
+}
+
+func TestLabelHealthTrend_Stable(t *testing.T) {
+	if got := LabelHealthTrend(nil); got != "stable" {
+		t.Errorf("no points: %q", got)
+	}
+	if got := LabelHealthTrend([]LabelHealthPoint{{Health: 50}}); got != "stable" {
+		t.Errorf("one point: %q", got)
+	}
+	flat := []LabelHealthPoint{{Health: 60}, {Health: 61}, {Health: 60}, {Health: 60}}
+	if got := LabelHealthTrend(flat); got != "stable" {
+		t.Errorf("flat: %q", got)
+	}
+
+	// Only the recent window counts: an old collapse doesn't mask a recovery
+	var points []LabelHealthPoint
+	for _, h := range []int{90, 20, 30, 40, 50, 60, 70} {
+		points = append(points, LabelHealthPoint{Health: h})
+	}
+	if got := LabelHealthTrend(points); got != "improving" {
+		t.Errorf("windowed trend = %q, want improving", got)
+	}
+}
+
+func TestLabelHealthHistory_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		if err := AppendLabelHealthSnapshot(dir, historyResult(start.Add(time.Duration(i)*time.Hour), map[string]int{"api": i})); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := LabelHealthHistoryPath(dir)
+	before, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	maxBytes := before.Size() / 2
+	if err := jsonl.TrimToSize(path, maxBytes); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	after, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.Size() > maxBytes/2 {
+		t.Errorf("rotated size %d, want at most %d", after.Size(), maxBytes/2)
+	}
+
+	points := LoadLabelHealthHistory(dir, "api", time.Time{})
+	if len(points) == 0 || len(points) >= 20 {
+		t.Fatalf("expected some but not all points after rotation, got %d", len(points))
+	}
+	if last := points[len(points)-1]; last.Health != 19 {
+		t.Errorf("newest snapshot should survive rotation, got %+v", last)
+	}
+	if first := points[0]; first.Health != 20-len(points) {
+		t.Errorf("rotation should drop the oldest snapshots, first kept = %+v", first)
+	}
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// RecomputeForIssue updates prev after a single issue changed, recomputing
+// only the labels the change can reach: the issue's labels before (per
+// prev) and after, plus the labels of issues it blocks, whose incoming flow
+// counts its labels. Every other label is copied from prev, and summaries
+// and counts are re-derived. Graph metrics for untouched labels are not
+// refreshed, so edits that add or remove dependencies elsewhere still call
+// for ComputeAllLabelHealth.
+func RecomputeForIssue(prev LabelAnalysisResult, issues []model.Issue, changedID string, cfg LabelHealthConfig, now time.Time) LabelAnalysisResult {
+	affected := make(map[string]bool)
+	for _, health := range prev.Labels {
+		for _, id := range health.Issues {
+			if id == changedID {
+				affected[health.Label] = true
+				break
+			}
+		}
+	}
+	for _, iss := range issues {
+		if iss.ID == changedID {
+			for _, l := range iss.Labels {
+				affected[l] = true
+			}
+			continue
+		}
+		for _, dep := range iss.Dependencies {
+			if dep != nil && dep.Type == model.DepBlocks && dep.DependsOnID == changedID {
+				for _, l := range iss.Labels {
+					affected[l] = true
+				}
+				break
+			}
+		}
+	}
+
+	previous := make(map[string]LabelHealth, len(prev.Labels))
+	for _, health := range prev.Labels {
+		previous[health.Label] = health
+	}
+
+	labels := cfg.filterLabels(ExtractLabels(issues).Labels)
+	sort.Strings(labels)
+	result := LabelAnalysisResult{
+		GeneratedAt: now,
+		TotalLabels: len(labels),
+		Labels:      make([]LabelHealth, 0, len(labels)),
+	}
+
+	var stats *GraphStats
+	for _, label := range labels {
+		health, ok := previous[label]
+		if !ok || affected[label] {
+			if stats == nil {
+				s := NewAnalyzer(issues).Analyze()
+				stats = &s
+			}
+			health = ComputeLabelHealthForLabel(label, issues, cfg, now, stats)
+		}
+		result.Labels = append(result.Labels, health)
+	}
+
+	if prev.CrossLabelFlow != nil {
+		flow := ComputeCrossLabelFlow(issues, cfg)
+		result.CrossLabelFlow = &flow
+	}
+
+	summarizeLabelHealth(&result, cfg)
+	return result
+}
@@ -0,0 +1,123 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func incrementalFixture(now time.Time) []model.Issue {
+	day := 24 * time.Hour
+	old := now.Add(-40 * day)
+	recent := now.Add(-2 * day)
+	blocks := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "api-1", Labels: []string{"api"}, Status: model.StatusOpen, CreatedAt: old, UpdatedAt: recent},
+		{ID: "api-2", Labels: []string{"api", "backend"}, Status: model.StatusInProgress, CreatedAt: old, UpdatedAt: old},
+		{ID: "ui-1", Labels: []string{"ui"}, Status: model.StatusOpen, CreatedAt: old, UpdatedAt: old, Dependencies: blocks("api-1")},
+		{ID: "ui-2", Labels: []string{"ui"}, Status: model.StatusOpen, CreatedAt: old, UpdatedAt: recent, Dependencies: blocks("ui-1")},
+		{ID: "docs-1", Labels: []string{"docs"}, Status: model.StatusClosed, CreatedAt: old, UpdatedAt: old, ClosedAt: &old},
+	}
+}
+
+func setIssue(issues []model.Issue, id string, mutate func(*model.Issue)) {
+	for i := range issues {
+		if issues[i].ID == id {
+			mutate(&issues[i])
+		}
+	}
+}
+
+func TestRecomputeForIssue_MatchesFullRecompute(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultLabelHealthConfig()
+	closedAt := now.Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		id     string
+		mutate func(*model.Issue)
+	}{
+		{
+			name: "close a blocker",
+			id:   "api-1",
+			mutate: func(iss *model.Issue) {
+				iss.Status = model.StatusClosed
+				iss.ClosedAt = &closedAt
+				iss.UpdatedAt = closedAt
+			},
+		},
+		{
+			name:   "start work",
+			id:     "ui-2",
+			mutate: func(iss *model.Issue) { iss.Status = model.StatusInProgress; iss.UpdatedAt = now },
+		},
+		{
+			name:   "relabel",
+			id:     "api-2",
+			mutate: func(iss *model.Issue) { iss.Labels = []string{"backend", "infra"} },
+		},
+		{
+			name:   "drop the last issue of a label",
+			id:     "docs-1",
+			mutate: func(iss *model.Issue) { iss.Labels = nil },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := incrementalFixture(now)
+			prev := ComputeAllLabelHealth(issues, cfg, now, nil)
+
+			setIssue(issues, tt.id, tt.mutate)
+			want := ComputeAllLabelHealth(issues, cfg, now, nil)
+			got := RecomputeForIssue(prev, issues, tt.id, cfg, now)
+
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("incremental result differs from full recompute:\n got  %+v\n want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestRecomputeForIssue_CarriesOverUntouchedLabels(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultLabelHealthConfig()
+	issues := incrementalFixture(now)
+	prev := ComputeAllLabelHealth(issues, cfg, now, nil)
+
+	// Poison an untouched label in prev: it must be copied, not recomputed
+	for i := range prev.Labels {
+		if prev.Labels[i].Label == "docs" {
+			prev.Labels[i].Health = 1
+		}
+	}
+
+	setIssue(issues, "ui-2", func(iss *model.Issue) { iss.Status = model.StatusInProgress })
+	got := RecomputeForIssue(prev, issues, "ui-2", cfg, now)
+	for _, health := range got.Labels {
+		if health.Label == "docs" && health.Health != 1 {
+			t.Errorf("docs should be carried over from prev, got health %d", health.Health)
+		}
+	}
+}
+
+func TestRecomputeForIssue_RefreshesCrossLabelFlow(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cfg := DefaultLabelHealthConfig()
+	issues := incrementalFixture(now)
+	prev := ComputeAllLabelHealth(issues, cfg, now, nil)
+	flow := ComputeCrossLabelFlow(issues, cfg)
+	prev.CrossLabelFlow = &flow
+
+	setIssue(issues, "ui-1", func(iss *model.Issue) { iss.Labels = []string{"api"} })
+	got := RecomputeForIssue(prev, issues, "ui-1", cfg, now)
+	want := ComputeCrossLabelFlow(issues, cfg)
+	if got.CrossLabelFlow == nil || !reflect.DeepEqual(*got.CrossLabelFlow, want) {
+		t.Errorf("CrossLabelFlow = %+v, want %+v", got.CrossLabelFlow, want)
+	}
+}
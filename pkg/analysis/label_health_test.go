@@ -2,6 +2,9 @@ package analysis
 
 import (
 	"fmt"
+	"math"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -34,19 +37,19 @@ func TestComputeCompositeHealth(t *testing.T) {
 	cfg := DefaultLabelHealthConfig()
 
 	// All components at 100 should give 100
-	score := ComputeCompositeHealth(100, 100, 100, 100, cfg)
+	score, _ := ComputeCompositeHealth(100, 100, 100, 100, cfg)
 	if score != 100 {
 		t.Errorf("All 100s should give 100, got %d", score)
 	}
 
 	// All components at 0 should give 0
-	score = ComputeCompositeHealth(0, 0, 0, 0, cfg)
+	score, _ = ComputeCompositeHealth(0, 0, 0, 0, cfg)
 	if score != 0 {
 		t.Errorf("All 0s should give 0, got %d", score)
 	}
 
 	// All components at 50 should give 50
-	score = ComputeCompositeHealth(50, 50, 50, 50, cfg)
+	score, _ = ComputeCompositeHealth(50, 50, 50, 50, cfg)
 	if score != 50 {
 		t.Errorf("All 50s should give 50, got %d", score)
 	}
@@ -54,7 +57,7 @@ func TestComputeCompositeHealth(t *testing.T) {
 	// Test weighted average
 	// velocity=100, freshness=0, flow=100, criticality=0
 	// With equal weights: (100*0.25 + 0*0.25 + 100*0.25 + 0*0.25) = 50
-	score = ComputeCompositeHealth(100, 0, 100, 0, cfg)
+	score, _ = ComputeCompositeHealth(100, 0, 100, 0, cfg)
 	if score != 50 {
 		t.Errorf("Expected 50 for alternating 100/0, got %d", score)
 	}
@@ -420,6 +423,88 @@ func TestExtractLabelsDuplicateLabelsOnIssue(t *testing.T) {
 	}
 }
 
+func TestExtractLabelsWithOptionsDedupePerIssue(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Labels: []string{"api", "api", "ui"}, Status: model.StatusOpen},
+	}
+
+	raw := ExtractLabelsWithOptions(issues, ExtractLabelsOptions{})
+	if got := raw.Stats["api"].TotalCount; got != 2 {
+		t.Errorf("without dedupe: api TotalCount = %d, want 2", got)
+	}
+
+	deduped := ExtractLabelsWithOptions(issues, ExtractLabelsOptions{DedupePerIssue: true})
+	api := deduped.Stats["api"]
+	if api.TotalCount != 1 || api.OpenCount != 1 {
+		t.Errorf("with dedupe: api TotalCount = %d, OpenCount = %d, want 1 and 1", api.TotalCount, api.OpenCount)
+	}
+	if len(api.IssueIDs) != 1 || api.ByPriority[0] != 1 {
+		t.Errorf("with dedupe: api IssueIDs = %v, ByPriority = %v", api.IssueIDs, api.ByPriority)
+	}
+	if deduped.LabelCount != 2 || deduped.Stats["ui"].TotalCount != 1 {
+		t.Errorf("with dedupe: unexpected labels %v", deduped.Labels)
+	}
+
+	// Deduping happens during counting only: the issue's labels and the
+	// co-occurrence matrix built from them are unchanged
+	if !reflect.DeepEqual(issues[0].Labels, []string{"api", "api", "ui"}) {
+		t.Errorf("issue labels were modified: %v", issues[0].Labels)
+	}
+	before := GetLabelCooccurrence([]model.Issue{{ID: "bv-1", Labels: []string{"api", "api", "ui"}}})
+	if after := GetLabelCooccurrence(issues); !reflect.DeepEqual(before, after) {
+		t.Errorf("co-occurrence changed: %v vs %v", before, after)
+	}
+}
+
+func TestExtractLabelsPriorityNames(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Labels: []string{"api"}, Priority: 0},
+		{ID: "bv-2", Labels: []string{"api"}, Priority: 1},
+		{ID: "bv-3", Labels: []string{"api"}, Priority: 1},
+		{ID: "bv-4", Labels: []string{"api"}, Priority: 3},
+		{ID: "bv-5", Labels: []string{"api"}, Priority: 7},
+	}
+
+	api := ExtractLabels(issues).Stats["api"]
+	want := map[string]int{"P0": 1, "P1": 2, "P3": 1, UnknownPriorityName: 1}
+	if !reflect.DeepEqual(api.ByPriorityName, want) {
+		t.Errorf("default ByPriorityName = %v, want %v", api.ByPriorityName, want)
+	}
+
+	// Names may merge levels; the integer counts are untouched
+	names := map[int]string{0: "critical", 1: "high", 2: "normal", 3: "normal", 4: "normal"}
+	api = ExtractLabelsWithOptions(issues, ExtractLabelsOptions{PriorityNames: names}).Stats["api"]
+	want = map[string]int{"critical": 1, "high": 2, "normal": 1, UnknownPriorityName: 1}
+	if !reflect.DeepEqual(api.ByPriorityName, want) {
+		t.Errorf("custom ByPriorityName = %v, want %v", api.ByPriorityName, want)
+	}
+	if wantInts := map[int]int{0: 1, 1: 2, 3: 1, 7: 1}; !reflect.DeepEqual(api.ByPriority, wantInts) {
+		t.Errorf("ByPriority = %v, want %v", api.ByPriority, wantInts)
+	}
+}
+
+func TestPriorityName(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	for p, want := range map[int]string{0: "P0", 4: "P4", 5: "P?", -1: "P?"} {
+		if got := cfg.PriorityName(p); got != want {
+			t.Errorf("default PriorityName(%d) = %q, want %q", p, got, want)
+		}
+	}
+
+	// A zero config still gets the stock names
+	if got := (LabelHealthConfig{}).PriorityName(2); got != "P2" {
+		t.Errorf("zero config PriorityName(2) = %q, want P2", got)
+	}
+
+	cfg.PriorityNames = map[int]string{0: "critical", 1: "high"}
+	if got := cfg.PriorityName(0); got != "critical" {
+		t.Errorf("custom PriorityName(0) = %q, want critical", got)
+	}
+	if got := cfg.PriorityName(2); got != UnknownPriorityName {
+		t.Errorf("unmapped PriorityName(2) = %q, want %q", got, UnknownPriorityName)
+	}
+}
+
 func TestExtractLabelsEmptyLabelString(t *testing.T) {
 	// Edge case: empty string label (should be skipped)
 	issues := []model.Issue{
@@ -573,6 +658,112 @@ func TestComputeVelocityMetricsEmpty(t *testing.T) {
 	}
 }
 
+func TestHealthBreakdownSumsToComposite(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-30 * 24 * time.Hour)
+	recent := now.Add(-2 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "1", Labels: []string{"api"}, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "2", Labels: []string{"api"}, Status: model.StatusClosed, UpdatedAt: recent, CreatedAt: old, ClosedAt: &recent},
+		{ID: "3", Labels: []string{"api"}, Status: model.StatusOpen, UpdatedAt: recent,
+			Dependencies: []*model.Dependency{{DependsOnID: "4", Type: model.DepBlocks}}},
+		{ID: "4", Labels: []string{"ui"}, Status: model.StatusOpen, UpdatedAt: recent},
+	}
+
+	weights := [][4]float64{
+		{0.25, 0.25, 0.25, 0.25},
+		{0.7, 0.1, 0.1, 0.1},
+		{0, 0.5, 0.5, 0},
+		{0.1, 0.2, 0.3, 0.4},
+	}
+	for _, w := range weights {
+		t.Run(fmt.Sprint(w), func(t *testing.T) {
+			cfg := DefaultLabelHealthConfig()
+			cfg.VelocityWeight, cfg.FreshnessWeight, cfg.FlowWeight, cfg.CriticalityWeight = w[0], w[1], w[2], w[3]
+
+			health := ComputeLabelHealthForLabel("api", issues, cfg, now, nil)
+			b := health.Breakdown
+			if diff := math.Abs(b.Total() - float64(health.Health)); diff > 0.5 {
+				t.Errorf("breakdown total %.2f vs health %d (diff %.2f)", b.Total(), health.Health, diff)
+			}
+			if b.Velocity.Score != health.Velocity.VelocityScore || b.Freshness.Score != health.Freshness.FreshnessScore ||
+				b.Flow.Score != health.Flow.FlowScore || b.Criticality.Score != health.Criticality.CriticalityScore {
+				t.Errorf("breakdown scores %+v don't match component metrics", b)
+			}
+			if b.Velocity.Weight != w[0] || b.Criticality.Weight != w[3] {
+				t.Errorf("breakdown weights %+v don't match config %v", b, w)
+			}
+		})
+	}
+}
+
+func TestComputeVelocityMetricsDormant(t *testing.T) {
+	now := time.Now()
+	day := 24 * time.Hour
+	closedAt := func(daysAgo int) *time.Time {
+		ts := now.Add(-time.Duration(daysAgo) * day)
+		return &ts
+	}
+
+	tests := []struct {
+		name      string
+		issues    []model.Issue
+		wantTrend string
+		maxScore  int
+	}{
+		{
+			name:      "open work and no closures",
+			issues:    []model.Issue{{ID: "1", Status: model.StatusOpen}, {ID: "2", Status: model.StatusInProgress}},
+			wantTrend: "dormant",
+			maxScore:  0,
+		},
+		{
+			name: "open work, closures only before the two-week window",
+			issues: []model.Issue{
+				{ID: "1", Status: model.StatusOpen},
+				{ID: "2", Status: model.StatusClosed, ClosedAt: closedAt(20)},
+				{ID: "3", Status: model.StatusClosed, ClosedAt: closedAt(21)},
+				{ID: "4", Status: model.StatusClosed, ClosedAt: closedAt(22)},
+			},
+			wantTrend: "dormant",
+			maxScore:  DormantVelocityScore,
+		},
+		{
+			name:      "everything closed long ago",
+			issues:    []model.Issue{{ID: "1", Status: model.StatusClosed, ClosedAt: closedAt(60)}},
+			wantTrend: "stable",
+			maxScore:  0,
+		},
+		{
+			name:      "no issues",
+			wantTrend: "stable",
+			maxScore:  0,
+		},
+		{
+			name: "steady low throughput",
+			issues: []model.Issue{
+				{ID: "1", Status: model.StatusOpen},
+				{ID: "2", Status: model.StatusClosed, ClosedAt: closedAt(3)},
+				{ID: "3", Status: model.StatusClosed, ClosedAt: closedAt(10)},
+			},
+			wantTrend: "stable",
+			maxScore:  100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := ComputeVelocityMetrics(tt.issues, now)
+			if v.TrendDirection != tt.wantTrend {
+				t.Errorf("TrendDirection = %q, want %q", v.TrendDirection, tt.wantTrend)
+			}
+			if v.VelocityScore > tt.maxScore {
+				t.Errorf("VelocityScore = %d, want <= %d", v.VelocityScore, tt.maxScore)
+			}
+		})
+	}
+}
+
 func TestComputeVelocityMetricsWithClosures(t *testing.T) {
 	now := time.Now()
 	threeDaysAgo := now.Add(-3 * 24 * time.Hour)
@@ -699,6 +890,141 @@ func TestComputeVelocityMetricsAvgDaysToClose(t *testing.T) {
 	}
 }
 
+func TestComputeVelocityMetrics_ClosedBeforeCreated(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	closed := now.Add(-2 * day)
+	reopenedClose := now.Add(-3 * day)
+
+	issues := []model.Issue{
+		{ID: "ok-1", CreatedAt: now.Add(-6 * day), ClosedAt: &closed, Status: model.StatusClosed}, // 4 days
+		{ID: "ok-2", CreatedAt: now.Add(-8 * day), ClosedAt: &closed, Status: model.StatusClosed}, // 6 days
+		// CreatedAt a day after ClosedAt: would add -1 day to the average
+		{ID: "skewed", CreatedAt: now.Add(-2 * day), ClosedAt: &reopenedClose, Status: model.StatusClosed},
+	}
+
+	v := ComputeVelocityMetrics(issues, now)
+
+	if v.AnomalousCloseCount != 1 {
+		t.Errorf("AnomalousCloseCount = %d, want 1", v.AnomalousCloseCount)
+	}
+	if v.AvgDaysToClose != 5 {
+		t.Errorf("AvgDaysToClose = %.2f, want 5 (anomalous sample excluded)", v.AvgDaysToClose)
+	}
+	if v.ClosedLast7Days != 3 {
+		t.Errorf("anomalous issue should still count as a closure, ClosedLast7Days = %d", v.ClosedLast7Days)
+	}
+
+	if v := ComputeVelocityMetrics(issues[2:], now); v.AvgDaysToClose != 0 || v.AnomalousCloseCount != 1 {
+		t.Errorf("only-anomalous input: avg %.2f, anomalous %d; want 0 and 1", v.AvgDaysToClose, v.AnomalousCloseCount)
+	}
+}
+
+func TestComputeVelocityMetrics_MissingTimestamps(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	closed := now.Add(-2 * day)
+	var zero time.Time
+
+	issues := []model.Issue{
+		{ID: "ok", CreatedAt: now.Add(-6 * day), ClosedAt: &closed, Status: model.StatusClosed}, // 4 days
+		// No CreatedAt: would otherwise average in ~2000 years
+		{ID: "no-created", ClosedAt: &closed, Status: model.StatusClosed},
+		// Zero ClosedAt is no closure at all, not one in the year 1
+		{ID: "zero-closed", CreatedAt: now.Add(-9 * day), ClosedAt: &zero, Status: model.StatusClosed},
+		{ID: "nil-closed", CreatedAt: now.Add(-9 * day), Status: model.StatusClosed},
+	}
+
+	v := ComputeVelocityMetrics(issues, now)
+	if v.AvgDaysToClose != 4 || v.CloseSampleCount != 1 {
+		t.Errorf("AvgDaysToClose = %.2f over %d samples, want 4 over 1", v.AvgDaysToClose, v.CloseSampleCount)
+	}
+	if v.MissingCreatedCount != 1 {
+		t.Errorf("MissingCreatedCount = %d, want 1", v.MissingCreatedCount)
+	}
+	if v.ClosedLast7Days != 2 || v.AnomalousCloseCount != 0 {
+		t.Errorf("closed last 7 days %d, anomalous %d; want 2 and 0", v.ClosedLast7Days, v.AnomalousCloseCount)
+	}
+}
+
+func TestComputeVelocityMetrics_ScaleClosedForMax(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	// 25 closures 15-29 days ago: inside the month, outside the trend windows
+	var issues []model.Issue
+	for i := range 25 {
+		closedAt := now.Add(-time.Duration(15+i%15) * 24 * time.Hour)
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("C%d", i), Status: model.StatusClosed, ClosedAt: &closedAt})
+	}
+
+	if v := ComputeVelocityMetrics(issues, now); v.VelocityScore != 100 {
+		t.Errorf("default scale: score %d, want 100", v.VelocityScore)
+	}
+	scaled := ComputeVelocityMetricsWithOptions(issues, now, VelocityOptions{ScaleClosedForMax: 50})
+	if scaled.TrendDirection == "improving" {
+		t.Fatalf("fixture should not earn the improving bonus (trend %s)", scaled.TrendDirection)
+	}
+	if scaled.VelocityScore != 50 {
+		t.Errorf("scale of 50: score %d, want 50", scaled.VelocityScore)
+	}
+
+	// The improving bonus still applies on top of the scaled score
+	recent := now.Add(-24 * time.Hour)
+	issues = append(issues, model.Issue{ID: "new", Status: model.StatusClosed, ClosedAt: &recent})
+	if v := ComputeVelocityMetricsWithOptions(issues, now, VelocityOptions{ScaleClosedForMax: 50}); v.TrendDirection != "improving" || v.VelocityScore != 62 {
+		t.Errorf("improving at scale 50: trend %s score %d, want improving and 52+10", v.TrendDirection, v.VelocityScore)
+	}
+
+	// Label health picks the scale up from the config
+	for i := range issues {
+		issues[i].Labels = []string{"busy"}
+	}
+	cfg := DefaultLabelHealthConfig()
+	cfg.VelocityScaleClosedForMax = 50
+	if h := ComputeLabelHealthForLabel("busy", issues, cfg, now, nil); h.Velocity.VelocityScore != 62 {
+		t.Errorf("label health velocity score %d, want 62", h.Velocity.VelocityScore)
+	}
+}
+
+func TestComputeVelocityMetrics_EffortWeighted(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	// Closed 15-25 days ago: inside the month, outside the trend windows
+	closures := func(estimates ...int) []model.Issue {
+		var issues []model.Issue
+		for i, est := range estimates {
+			closedAt := now.Add(-time.Duration(15+i) * 24 * time.Hour)
+			issue := model.Issue{ID: fmt.Sprintf("C%d", i), Status: model.StatusClosed, ClosedAt: &closedAt}
+			if est > 0 {
+				issue.EstimatedMinutes = &est
+			}
+			issues = append(issues, issue)
+		}
+		return issues
+	}
+
+	counted := ComputeVelocityMetrics(closures(0, 0, 0, 0), now)
+	if counted.VelocityScore != 40 || counted.PointsClosedLast30Days != 0 {
+		t.Errorf("no estimates: score %d, points %g; want 40 and 0", counted.VelocityScore, counted.PointsClosedLast30Days)
+	}
+
+	// Same four closures, one of them five times the 60-minute median
+	weighted := ComputeVelocityMetrics(closures(60, 60, 60, 300), now)
+	if weighted.ClosedLast30Days != counted.ClosedLast30Days {
+		t.Errorf("closure counts should match: %d vs %d", weighted.ClosedLast30Days, counted.ClosedLast30Days)
+	}
+	if weighted.PointsClosedLast30Days != 8 {
+		t.Errorf("PointsClosedLast30Days = %g, want 8 hours", weighted.PointsClosedLast30Days)
+	}
+	if weighted.VelocityScore != 80 {
+		t.Errorf("effort-weighted score = %d, want 80 (8 median-sized issues)", weighted.VelocityScore)
+	}
+
+	// Mixed: unestimated closures count at the median of the known estimates (60)
+	mixed := ComputeVelocityMetrics(closures(60, 60, 0, 300), now)
+	if mixed.PointsClosedLast30Days != 8 || mixed.VelocityScore != 80 {
+		t.Errorf("mixed estimates: score %d, points %g; want 80 and 8", mixed.VelocityScore, mixed.PointsClosedLast30Days)
+	}
+}
+
 func TestComputeVelocityMetrics_IgnoresNonClosedWithClosedAt(t *testing.T) {
 	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
 	closedAt := now.Add(-2 * 24 * time.Hour)
@@ -815,6 +1141,69 @@ func TestComputeFreshnessMetricsOldestOpen(t *testing.T) {
 	}
 }
 
+func TestComputeFreshnessMetricsMissingTimestamps(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -30), UpdatedAt: now.AddDate(0, 0, -4)},
+		{ID: "B", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -20), UpdatedAt: now.AddDate(0, 0, -2)},
+		{ID: "no-updated", Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -10)},
+		{ID: "no-created", Status: model.StatusOpen, UpdatedAt: now.AddDate(0, 0, -3)},
+		{ID: "closed-bare", Status: model.StatusClosed},
+	}
+
+	f := ComputeFreshnessMetrics(issues, now, 14)
+	if f.AvgDaysSinceUpdate != 3 {
+		t.Errorf("AvgDaysSinceUpdate = %.2f, want 3 (issues without UpdatedAt skipped)", f.AvgDaysSinceUpdate)
+	}
+	if f.StaleCount != 0 {
+		t.Errorf("StaleCount = %d, want 0", f.StaleCount)
+	}
+	if want := now.AddDate(0, 0, -30); !f.OldestOpenIssue.Equal(want) {
+		t.Errorf("OldestOpenIssue = %v, want %v", f.OldestOpenIssue, want)
+	}
+	if f.MissingUpdatedCount != 2 || f.MissingCreatedCount != 1 {
+		t.Errorf("missing updated %d, created %d; want 2 and 1", f.MissingUpdatedCount, f.MissingCreatedCount)
+	}
+	total := 0
+	for _, n := range f.AgeBuckets {
+		total += n
+	}
+	if total != 3 {
+		t.Errorf("age buckets hold %d issues, want the 3 with UpdatedAt", total)
+	}
+}
+
+func TestComputeFreshnessMetricsFirstResponse(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	issues := []model.Issue{
+		// Touched 2 days after creation
+		{ID: "touched", Status: model.StatusOpen, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(8)},
+		// Commented 1 day after creation, updated later: the comment came first
+		{ID: "commented", Status: model.StatusOpen, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(3),
+			Comments: []*model.Comment{{Text: "on it", CreatedAt: daysAgo(9)}}},
+		// Untouched since creation
+		{ID: "untouched", Status: model.StatusOpen, CreatedAt: daysAgo(5), UpdatedAt: daysAgo(5)},
+		{ID: "never-updated", Status: model.StatusOpen, CreatedAt: daysAgo(4)},
+		// Closed issues don't count either way
+		{ID: "closed", Status: model.StatusClosed, CreatedAt: daysAgo(30), UpdatedAt: daysAgo(30)},
+	}
+
+	f := ComputeFreshnessMetrics(issues, now, 14)
+	if f.AvgFirstResponseDays != 1.5 {
+		t.Errorf("AvgFirstResponseDays = %v, want 1.5 (2 and 1 days)", f.AvgFirstResponseDays)
+	}
+	if f.NoResponseCount != 2 {
+		t.Errorf("NoResponseCount = %d, want 2", f.NoResponseCount)
+	}
+
+	// Nobody has responded yet
+	f = ComputeFreshnessMetrics(issues[2:], now, 14)
+	if f.AvgFirstResponseDays != 0 || f.NoResponseCount != 2 {
+		t.Errorf("untouched only: avg %v, no response %d; want 0 and 2", f.AvgFirstResponseDays, f.NoResponseCount)
+	}
+}
+
 func TestComputeFreshnessMetricsDefaultThreshold(t *testing.T) {
 	now := time.Now()
 	// Pass 0 or negative threshold - should use default
@@ -859,10 +1248,260 @@ func TestComputeFreshnessMetricsScoreCapping(t *testing.T) {
 	}
 }
 
+func TestComputeLabelHealth_IncludeClosedInFreshness(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	// Four issues closed yesterday hide two open issues untouched for a month
+	var issues []model.Issue
+	for i := range 4 {
+		closedAt := daysAgo(1)
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("C-%d", i), Status: model.StatusClosed, Labels: []string{"api"},
+			CreatedAt: daysAgo(60), UpdatedAt: daysAgo(1), ClosedAt: &closedAt})
+	}
+	issues = append(issues,
+		model.Issue{ID: "O-1", Status: model.StatusOpen, Labels: []string{"api"}, CreatedAt: daysAgo(60), UpdatedAt: daysAgo(30)},
+		model.Issue{ID: "O-2", Status: model.StatusOpen, Labels: []string{"api"}, CreatedAt: daysAgo(60), UpdatedAt: daysAgo(30)},
+	)
+
+	cfg := DefaultLabelHealthConfig()
+	if !cfg.IncludeClosedInFreshness {
+		t.Fatal("closed issues should count in freshness by default")
+	}
+	all := ComputeLabelHealthForLabel("api", issues, cfg, now, nil).Freshness
+	cfg.IncludeClosedInFreshness = false
+	open := ComputeLabelHealthForLabel("api", issues, cfg, now, nil).Freshness
+
+	// (4*1 + 2*30) / 6 days with closed issues, 30 days without
+	if want := 64.0 / 6; math.Abs(all.AvgDaysSinceUpdate-want) > 1e-9 {
+		t.Errorf("default AvgDaysSinceUpdate = %v, want %v", all.AvgDaysSinceUpdate, want)
+	}
+	if open.AvgDaysSinceUpdate != 30 {
+		t.Errorf("open-only AvgDaysSinceUpdate = %v, want 30", open.AvgDaysSinceUpdate)
+	}
+	if all.FreshnessScore <= open.FreshnessScore || open.FreshnessScore != 0 {
+		t.Errorf("freshness score %d with closed, %d without; want the closures to stop masking the stale open work",
+			all.FreshnessScore, open.FreshnessScore)
+	}
+	if all.StaleCount != 2 || open.StaleCount != 2 {
+		t.Errorf("stale count = %d / %d, want 2 either way", all.StaleCount, open.StaleCount)
+	}
+	if got := open.AgeBuckets["0-7"]; got != 0 {
+		t.Errorf("open-only 0-7 bucket = %d, want the closed issues left out", got)
+	}
+	if !all.MostRecentUpdate.Equal(daysAgo(1)) || !open.MostRecentUpdate.Equal(daysAgo(1)) {
+		t.Errorf("MostRecentUpdate = %v / %v, want the closures counted either way", all.MostRecentUpdate, open.MostRecentUpdate)
+	}
+}
+
+func TestComputeFreshnessMetricsPriorityWeighted(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	issues := []model.Issue{
+		{ID: "p0", Priority: 0, UpdatedAt: now.Add(-30 * day), Status: model.StatusOpen}, // Stale P0
+		{ID: "p4a", Priority: 4, UpdatedAt: now.Add(-1 * day), Status: model.StatusOpen},
+		{ID: "p4b", Priority: 4, UpdatedAt: now.Add(-1 * day), Status: model.StatusOpen},
+	}
+
+	plain := ComputeFreshnessMetricsWithOptions(issues, now, 14, FreshnessOptions{})
+	weighted := ComputeFreshnessMetricsWithOptions(issues, now, 14, FreshnessOptions{PriorityWeighted: true})
+
+	if weighted.FreshnessScore >= plain.FreshnessScore {
+		t.Errorf("weighted score %d should be below unweighted %d", weighted.FreshnessScore, plain.FreshnessScore)
+	}
+	if plain.StaleCount != 1 || weighted.StaleCount != 1 {
+		t.Errorf("StaleCount should stay a raw count: plain %d, weighted %d", plain.StaleCount, weighted.StaleCount)
+	}
+	if plain.AvgDaysSinceUpdate != weighted.AvgDaysSinceUpdate {
+		t.Errorf("AvgDaysSinceUpdate should stay unweighted: %v vs %v", plain.AvgDaysSinceUpdate, weighted.AvgDaysSinceUpdate)
+	}
+	// (30*5 + 1 + 1) / 7
+	if want := 152.0 / 7; math.Abs(weighted.WeightedAvgDaysSinceUpdate-want) > 1e-9 {
+		t.Errorf("WeightedAvgDaysSinceUpdate = %v, want %v", weighted.WeightedAvgDaysSinceUpdate, want)
+	}
+	if plain.WeightedAvgDaysSinceUpdate != 0 {
+		t.Errorf("unweighted path should leave WeightedAvgDaysSinceUpdate unset")
+	}
+	if !reflect.DeepEqual(plain, ComputeFreshnessMetrics(issues, now, 14)) {
+		t.Error("unweighted options should match the default computation")
+	}
+
+	// The config switch reaches label health
+	cfg := DefaultLabelHealthConfig()
+	for i := range issues {
+		issues[i].Labels = []string{"api"}
+	}
+	base := ComputeLabelHealthForLabel("api", issues, cfg, now, nil)
+	cfg.FreshnessPriorityWeighted = true
+	if got := ComputeLabelHealthForLabel("api", issues, cfg, now, nil); got.Freshness.FreshnessScore >= base.Freshness.FreshnessScore {
+		t.Errorf("weighted label freshness %d should be below %d", got.Freshness.FreshnessScore, base.Freshness.FreshnessScore)
+	}
+}
+
+func TestComputeFreshnessMetricsDecayCurves(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	const threshold = 10
+
+	tests := []struct {
+		decay string
+		want  [3]int // Score at 0x, 1x and 2x threshold staleness
+	}{
+		{"", [3]int{100, 50, 0}}, // Default is linear
+		{FreshnessDecayLinear, [3]int{100, 50, 0}},
+		{FreshnessDecayExponential, [3]int{100, 50, 25}},
+		{FreshnessDecayStep, [3]int{100, StepDecayStaleScore, StepDecayStaleScore}},
+	}
+	for _, tt := range tests {
+		for i, mult := range []int{0, 1, 2} {
+			issues := []model.Issue{{ID: "1", UpdatedAt: now.Add(-time.Duration(mult*threshold) * 24 * time.Hour), Status: model.StatusOpen}}
+			f := ComputeFreshnessMetricsWithDecay(issues, now, threshold, tt.decay)
+			if f.FreshnessScore != tt.want[i] {
+				t.Errorf("decay %q at %dx threshold: score = %d, want %d", tt.decay, mult, f.FreshnessScore, tt.want[i])
+			}
+		}
+	}
+
+	// The linear default is unchanged through the config path
+	issues := []model.Issue{{ID: "1", UpdatedAt: now.Add(-5 * 24 * time.Hour), Status: model.StatusOpen}}
+	if got, want := ComputeFreshnessMetricsWithDecay(issues, now, threshold, DefaultLabelHealthConfig().FreshnessDecay), ComputeFreshnessMetrics(issues, now, threshold); !reflect.DeepEqual(got, want) {
+		t.Errorf("default config freshness = %+v, want %+v", got, want)
+	}
+}
+
+func TestComputeFreshnessMetricsAgeBuckets(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(d float64) time.Time { return now.Add(-time.Duration(d * 24 * float64(time.Hour))) }
+
+	issues := []model.Issue{
+		{ID: "fresh", UpdatedAt: daysAgo(0)},
+		{ID: "future", UpdatedAt: now.Add(time.Hour)}, // Clock skew counts as fresh
+		{ID: "edge-7", UpdatedAt: daysAgo(7.5)},       // 7 whole days: still 0-7
+		{ID: "edge-8", UpdatedAt: daysAgo(8)},
+		{ID: "edge-14", UpdatedAt: daysAgo(14)},
+		{ID: "mid", UpdatedAt: daysAgo(20), Status: model.StatusClosed},
+		{ID: "edge-30", UpdatedAt: daysAgo(30)},
+		{ID: "edge-31", UpdatedAt: daysAgo(31)},
+		{ID: "ancient", UpdatedAt: daysAgo(400)},
+		{ID: "never", CreatedAt: daysAgo(90)}, // No UpdatedAt: not counted
+	}
+
+	f := ComputeFreshnessMetrics(issues, now, 14)
+	want := map[string]int{"0-7": 3, "8-14": 2, "15-30": 2, "31+": 2}
+	if !reflect.DeepEqual(f.AgeBuckets, want) {
+		t.Errorf("AgeBuckets = %v, want %v", f.AgeBuckets, want)
+	}
+	sum := 0
+	for _, n := range f.AgeBuckets {
+		sum += n
+	}
+	if sum != len(issues)-1 {
+		t.Errorf("bucket sum = %d, want %d (issues with UpdatedAt)", sum, len(issues)-1)
+	}
+
+	// Custom edges, with every bucket present even when empty
+	custom := ComputeFreshnessMetricsWithOptions(issues, now, 14, FreshnessOptions{AgeBucketEdges: []int{1, 90}})
+	want = map[string]int{"0-1": 2, "2-90": 6, "91+": 1}
+	if !reflect.DeepEqual(custom.AgeBuckets, want) {
+		t.Errorf("custom AgeBuckets = %v, want %v", custom.AgeBuckets, want)
+	}
+	empty := ComputeFreshnessMetrics(nil, now, 14)
+	if want := map[string]int{"0-7": 0, "8-14": 0, "15-30": 0, "31+": 0}; !reflect.DeepEqual(empty.AgeBuckets, want) {
+		t.Errorf("empty AgeBuckets = %v, want %v", empty.AgeBuckets, want)
+	}
+
+	// The config edges reach label health
+	cfg := DefaultLabelHealthConfig()
+	cfg.AgeBucketEdges = []int{1, 90}
+	for i := range issues {
+		issues[i].Labels = []string{"api"}
+	}
+	if got := ComputeLabelHealthForLabel("api", issues, cfg, now, nil).Freshness.AgeBuckets; !reflect.DeepEqual(got, custom.AgeBuckets) {
+		t.Errorf("label AgeBuckets = %v, want %v", got, custom.AgeBuckets)
+	}
+}
+
+func TestAgeBucketLabels(t *testing.T) {
+	if got, want := AgeBucketLabels(nil), []string{"0-7", "8-14", "15-30", "31+"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default labels = %v, want %v", got, want)
+	}
+	if got, want := AgeBucketLabels([]int{3}), []string{"0-3", "4+"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("labels = %v, want %v", got, want)
+	}
+}
+
 // ============================================================================
 // Label Subgraph Extraction Tests (bv-113)
 // ============================================================================
 
+func TestComputeFlowScore(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	tests := []struct {
+		name       string
+		flow       FlowMetrics
+		issueCount int
+		want       int
+	}{
+		{"isolated", FlowMetrics{}, 4, 100},
+		// One blocker already costs a quarter of the blocked penalty plus the full inflow penalty
+		{"one incoming", FlowMetrics{IncomingDeps: 1, BlockedByExternal: 1}, 4, 55},
+		{"provider", FlowMetrics{OutgoingDeps: 3, BlockingExternal: 2}, 4, 90},
+		{"balanced", FlowMetrics{IncomingDeps: 2, OutgoingDeps: 2, BlockedByExternal: 1, BlockingExternal: 1}, 2, 70},
+		{"mostly outgoing", FlowMetrics{IncomingDeps: 1, OutgoingDeps: 3, BlockedByExternal: 1}, 4, 80},
+		{"fully blocked", FlowMetrics{IncomingDeps: 6, BlockedByExternal: 4}, 4, 10},
+		{"no issues", FlowMetrics{IncomingDeps: 1}, 0, 70},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeFlowScore(tt.flow, tt.issueCount, cfg); got != tt.want {
+				t.Errorf("ComputeFlowScore(%+v, %d) = %d, want %d", tt.flow, tt.issueCount, got, tt.want)
+			}
+		})
+	}
+
+	cfg.FlowBlockedPenalty = 100
+	cfg.FlowInflowPenalty = 0 // falls back to the default
+	if got := ComputeFlowScore(FlowMetrics{IncomingDeps: 1, BlockedByExternal: 2}, 4, cfg); got != 20 {
+		t.Errorf("custom penalties: got %d, want 20", got)
+	}
+}
+
+func TestComputeLabelHealthForLabel_FlowDirection(t *testing.T) {
+	now := time.Now()
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// infra blocks every app issue; nothing blocks infra
+	issues := []model.Issue{
+		{ID: "I1", Status: model.StatusOpen, Labels: []string{"infra"}, UpdatedAt: now},
+		{ID: "I2", Status: model.StatusOpen, Labels: []string{"infra"}, UpdatedAt: now},
+		{ID: "A1", Status: model.StatusOpen, Labels: []string{"app"}, UpdatedAt: now, Dependencies: blocks("I1")},
+		{ID: "A2", Status: model.StatusOpen, Labels: []string{"app"}, UpdatedAt: now, Dependencies: blocks("I1")},
+		{ID: "A3", Status: model.StatusOpen, Labels: []string{"app"}, UpdatedAt: now, Dependencies: blocks("I2")},
+		// A closed blocker no longer holds anything up
+		{ID: "OLD", Status: model.StatusClosed, Labels: []string{"infra"}, UpdatedAt: now},
+		{ID: "A4", Status: model.StatusOpen, Labels: []string{"app"}, UpdatedAt: now, Dependencies: blocks("OLD")},
+	}
+	cfg := DefaultLabelHealthConfig()
+
+	infra := ComputeLabelHealthForLabel("infra", issues, cfg, now, nil).Flow
+	app := ComputeLabelHealthForLabel("app", issues, cfg, now, nil).Flow
+
+	if infra.OutgoingDeps != 3 || infra.IncomingDeps != 0 || infra.BlockingExternal != 2 || !reflect.DeepEqual(infra.OutgoingLabels, []string{"app"}) {
+		t.Errorf("infra flow = %+v, want 3 outgoing deps to app from 2 issues", infra)
+	}
+	if app.IncomingDeps != 3 || app.OutgoingDeps != 0 || app.BlockedByExternal != 3 || !reflect.DeepEqual(app.IncomingLabels, []string{"infra"}) {
+		t.Errorf("app flow = %+v, want 3 incoming deps from infra on 3 issues", app)
+	}
+	// infra: outflow penalty only; app: 3/4 blocked plus full inflow
+	if infra.FlowScore != 90 || app.FlowScore != 25 {
+		t.Errorf("flow scores infra=%d app=%d, want 90 and 25", infra.FlowScore, app.FlowScore)
+	}
+
+	cfg.IncludeClosedInFlow = true
+	if app := ComputeLabelHealthForLabel("app", issues, cfg, now, nil).Flow; app.BlockedByExternal != 4 || app.FlowScore != 10 {
+		t.Errorf("with closed issues, app flow = %+v, want all 4 blocked and score 10", app)
+	}
+}
+
 func TestComputeLabelSubgraphEmpty(t *testing.T) {
 	// Empty issues
 	sg := ComputeLabelSubgraph([]model.Issue{}, "api")
@@ -2595,3 +3234,429 @@ func TestBlockageCascadeAnalysis_GetMostImpactfulCascade(t *testing.T) {
 		t.Errorf("Expected 'high' label, got %s", cascade.SourceLabel)
 	}
 }
+
+func TestComputeLabelHealthForLabel_PerLabelStaleOverrides(t *testing.T) {
+	now := time.Now()
+	cfg := DefaultLabelHealthConfig()
+	cfg.PerLabelStaleDays = map[string]int{
+		"backlog":  90,
+		"incident": 2,
+	}
+
+	issues := []model.Issue{
+		// 60 days old: stale under the global 14-day threshold, fine under 90
+		{ID: "bl-1", Labels: []string{"backlog"}, Status: model.StatusOpen, UpdatedAt: now.Add(-60 * 24 * time.Hour)},
+		{ID: "bl-2", Labels: []string{"backlog"}, Status: model.StatusOpen, UpdatedAt: now.Add(-45 * 24 * time.Hour)},
+		// 3 days old: fresh under the global threshold, stale under 2
+		{ID: "inc-1", Labels: []string{"incident"}, Status: model.StatusOpen, UpdatedAt: now.Add(-3 * 24 * time.Hour)},
+		{ID: "inc-2", Labels: []string{"incident"}, Status: model.StatusOpen, UpdatedAt: now.Add(-4 * 24 * time.Hour)},
+	}
+
+	backlog := ComputeLabelHealthForLabel("backlog", issues, cfg, now, nil)
+	if backlog.Freshness.StaleThresholdDays != 90 {
+		t.Errorf("backlog threshold = %d, want 90", backlog.Freshness.StaleThresholdDays)
+	}
+	if backlog.Freshness.StaleCount != 0 {
+		t.Errorf("backlog stale count = %d, want 0", backlog.Freshness.StaleCount)
+	}
+	if backlog.Freshness.FreshnessScore < 50 {
+		t.Errorf("backlog freshness score = %d, want >= 50", backlog.Freshness.FreshnessScore)
+	}
+
+	incident := ComputeLabelHealthForLabel("incident", issues, cfg, now, nil)
+	if incident.Freshness.StaleThresholdDays != 2 {
+		t.Errorf("incident threshold = %d, want 2", incident.Freshness.StaleThresholdDays)
+	}
+	if incident.Freshness.StaleCount != 2 {
+		t.Errorf("incident stale count = %d, want 2", incident.Freshness.StaleCount)
+	}
+	if incident.Freshness.FreshnessScore >= 50 {
+		t.Errorf("incident freshness score = %d, want < 50", incident.Freshness.FreshnessScore)
+	}
+
+	// Without overrides the global threshold applies to both
+	global := DefaultLabelHealthConfig()
+	if got := ComputeLabelHealthForLabel("backlog", issues, global, now, nil).Freshness.StaleCount; got != 2 {
+		t.Errorf("backlog stale count without override = %d, want 2", got)
+	}
+	if got := ComputeLabelHealthForLabel("incident", issues, global, now, nil).Freshness.StaleCount; got != 0 {
+		t.Errorf("incident stale count without override = %d, want 0", got)
+	}
+}
+
+func TestLabelHealthConfig_StaleDaysForLabel(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	cfg.PerLabelStaleDays = map[string]int{"backlog": 90, "broken": 0}
+
+	if got := cfg.StaleDaysForLabel("backlog"); got != 90 {
+		t.Errorf("StaleDaysForLabel(backlog) = %d, want 90", got)
+	}
+	if got := cfg.StaleDaysForLabel("broken"); got != DefaultStaleThresholdDays {
+		t.Errorf("StaleDaysForLabel(broken) = %d, want default %d", got, DefaultStaleThresholdDays)
+	}
+	if got := cfg.StaleDaysForLabel("other"); got != DefaultStaleThresholdDays {
+		t.Errorf("StaleDaysForLabel(other) = %d, want default %d", got, DefaultStaleThresholdDays)
+	}
+}
+
+func TestComputeAllLabelHealth_ReusesCentralitySnapshot(t *testing.T) {
+	now := time.Now()
+	var issues []model.Issue
+	for i := 0; i < 40; i++ {
+		iss := model.Issue{
+			ID:        fmt.Sprintf("bv-%d", i),
+			Labels:    []string{fmt.Sprintf("label-%d", i%20)},
+			Status:    model.StatusOpen,
+			UpdatedAt: now,
+		}
+		if i > 0 {
+			iss.Dependencies = []*model.Dependency{
+				{IssueID: iss.ID, DependsOnID: fmt.Sprintf("bv-%d", i-1), Type: model.DepBlocks},
+			}
+		}
+		issues = append(issues, iss)
+	}
+
+	analyzer := NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	result := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, &stats)
+	if len(result.Labels) != 20 {
+		t.Fatalf("expected 20 labels, got %d", len(result.Labels))
+	}
+	snap := stats.centralityView()
+	// Per-label calls after the first must hit the memoized snapshot
+	ComputeLabelHealthForLabel("label-3", issues, DefaultLabelHealthConfig(), now, &stats)
+
+	if got := stats.centralityView(); got != snap {
+		t.Error("centrality snapshot was rebuilt; want the memoized one")
+	}
+}
+
+func TestAttentionReasons_MultipleReasons(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	health := NewLabelHealth("backend")
+	health.Health = 30
+	health.Velocity.TrendDirection = "declining"
+	health.Velocity.TrendPercent = -40
+	health.Freshness.StaleCount = 12
+	health.Flow.IncomingLabels = []string{"api", "database", "infra"}
+	health.Criticality.CriticalPathCount = 5
+
+	reasons := AttentionReasons(health, cfg)
+	want := []string{
+		"velocity declining 40%",
+		"12 stale issues",
+		"blocked by 3 external labels",
+		"on 5 critical paths",
+	}
+	if len(reasons) != len(want) {
+		t.Fatalf("AttentionReasons() = %v, want %v", reasons, want)
+	}
+	for i := range want {
+		if reasons[i] != want[i] {
+			t.Errorf("reason[%d] = %q, want %q", i, reasons[i], want[i])
+		}
+	}
+}
+
+func TestAttentionReasons_BelowThresholds(t *testing.T) {
+	cfg := DefaultLabelHealthConfig()
+	health := NewLabelHealth("frontend")
+	health.Velocity.TrendDirection = "declining"
+	health.Velocity.TrendPercent = -12 // below the 25% default
+	health.Freshness.StaleCount = 1
+	health.Flow.IncomingLabels = []string{"api"}
+	health.Criticality.CriticalPathCount = 2
+
+	if reasons := AttentionReasons(health, cfg); len(reasons) != 0 {
+		t.Errorf("expected no reasons, got %v", reasons)
+	}
+
+	// Tightening the config surfaces the same signals
+	cfg.AttentionVelocityDropPct = 10
+	cfg.AttentionStaleCount = 1
+	cfg.AttentionExternalBlockers = 1
+	cfg.AttentionCriticalPaths = 2
+	reasons := AttentionReasons(health, cfg)
+	if len(reasons) != 4 {
+		t.Fatalf("expected 4 reasons with tight config, got %v", reasons)
+	}
+	if reasons[1] != "1 stale issue" || reasons[2] != "blocked by 1 external label" {
+		t.Errorf("unexpected singular phrasing: %v", reasons)
+	}
+}
+
+func TestComputeAllLabelHealth_SummaryReasons(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "s-1", Labels: []string{"stale"}, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "s-2", Labels: []string{"stale"}, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "s-3", Labels: []string{"stale"}, Status: model.StatusOpen, UpdatedAt: old},
+	}
+
+	result := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil)
+	if len(result.Summaries) != 1 {
+		t.Fatalf("expected 1 summary, got %d", len(result.Summaries))
+	}
+	summary := result.Summaries[0]
+	if !summary.NeedsAttention {
+		t.Fatalf("expected stale label to need attention (health %d)", summary.Health)
+	}
+	found := false
+	for _, r := range summary.Reasons {
+		if r == "3 stale issues" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected '3 stale issues' in summary reasons, got %v", summary.Reasons)
+	}
+	if len(result.Labels[0].Reasons) != len(summary.Reasons) {
+		t.Errorf("label reasons %v should match summary reasons %v", result.Labels[0].Reasons, summary.Reasons)
+	}
+}
+
+func TestComputeLabelHealth_TopIssueByPriority(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "api-3", Labels: []string{"api"}, Status: model.StatusOpen, Priority: 3},
+		{ID: "api-closed", Labels: []string{"api"}, Status: model.StatusClosed, Priority: 0},
+		{ID: "api-0", Labels: []string{"api"}, Status: model.StatusOpen, Priority: 0},
+		{ID: "api-1", Labels: []string{"api"}, Status: model.StatusInProgress, Priority: 1},
+	}
+
+	health := ComputeLabelHealthForLabel("api", issues, DefaultLabelHealthConfig(), now, nil)
+	if health.TopIssue != "api-0" {
+		t.Errorf("TopIssue = %q, want the P0 open issue api-0", health.TopIssue)
+	}
+	result := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil)
+	if got := result.Summaries[0].TopIssue; got != "api-0" {
+		t.Errorf("summary TopIssue = %q, want api-0", got)
+	}
+}
+
+func TestComputeLabelHealth_TopIssueTiesAndFallback(t *testing.T) {
+	// Same priority: higher PageRank wins (b is depended on by c), then ID
+	issues := []model.Issue{
+		{ID: "a", Labels: []string{"core"}, Status: model.StatusOpen, Priority: 1},
+		{ID: "b", Labels: []string{"core"}, Status: model.StatusOpen, Priority: 1},
+		{ID: "c", Labels: []string{"other"}, Status: model.StatusOpen, Priority: 2,
+			Dependencies: []*model.Dependency{{IssueID: "c", DependsOnID: "b", Type: model.DepBlocks}}},
+	}
+	if got := ComputeLabelHealthForLabel("core", issues, DefaultLabelHealthConfig(), time.Now(), nil).TopIssue; got != "b" {
+		t.Errorf("TopIssue = %q, want b (higher PageRank)", got)
+	}
+
+	// Nothing open: highest-priority closed issue
+	closed := []model.Issue{
+		{ID: "d-2", Labels: []string{"done"}, Status: model.StatusClosed, Priority: 2},
+		{ID: "d-1", Labels: []string{"done"}, Status: model.StatusClosed, Priority: 1},
+	}
+	if got := ComputeLabelHealthForLabel("done", closed, DefaultLabelHealthConfig(), time.Now(), nil).TopIssue; got != "d-1" {
+		t.Errorf("TopIssue = %q, want d-1", got)
+	}
+}
+
+func TestComputeLabelHealth_BottleneckIsArticulationPoint(t *testing.T) {
+	// x1..x3 all wait on hub, which waits on y1..y3; y1 also waits on z.
+	// Every path between the two sides runs through hub, while y1 only
+	// carries a handful of paths to z.
+	dep := func(id string, on ...string) model.Issue {
+		issue := model.Issue{ID: id, Labels: []string{"core"}, Status: model.StatusOpen}
+		for _, d := range on {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: d, Type: model.DepBlocks})
+		}
+		return issue
+	}
+	issues := []model.Issue{
+		dep("x1", "hub"), dep("x2", "hub"), dep("x3", "hub"),
+		dep("hub", "y1", "y2", "y3"),
+		dep("y1", "z"), dep("y2"), dep("y3"), dep("z"),
+	}
+	stats := NewAnalyzer(issues).Analyze()
+	if stats.GetBetweennessScore("y1") <= 0 {
+		t.Fatalf("fixture should give y1 non-zero betweenness")
+	}
+
+	health := ComputeLabelHealthForLabel("core", issues, DefaultLabelHealthConfig(), time.Now(), &stats)
+	crit := health.Criticality
+	if want := []string{"hub"}; fmt.Sprint(crit.BottleneckIssueIDs) != fmt.Sprint(want) {
+		t.Errorf("BottleneckIssueIDs = %v, want %v", crit.BottleneckIssueIDs, want)
+	}
+	if crit.BottleneckCount != len(crit.BottleneckIssueIDs) {
+		t.Errorf("BottleneckCount = %d, want %d", crit.BottleneckCount, len(crit.BottleneckIssueIDs))
+	}
+
+	// A wider percentile also admits y1, ordered after hub
+	cfg := DefaultLabelHealthConfig()
+	cfg.BottleneckPercentile = 25
+	crit = ComputeLabelHealthForLabel("core", issues, cfg, time.Now(), &stats).Criticality
+	if want := []string{"hub", "y1"}; fmt.Sprint(crit.BottleneckIssueIDs) != fmt.Sprint(want) {
+		t.Errorf("25th percentile: BottleneckIssueIDs = %v, want %v", crit.BottleneckIssueIDs, want)
+	}
+}
+
+func TestComputeAllLabelHealth_LabelFilters(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "a1", Labels: []string{"area/api"}, Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: "c1", Type: model.DepBlocks}}},
+		{ID: "a2", Labels: []string{"area/ui"}, Status: model.StatusOpen},
+		{ID: "a3", Labels: []string{"area/legacy"}, Status: model.StatusOpen},
+		{ID: "c1", Labels: []string{"core"}, Status: model.StatusOpen},
+	}
+	labelsOf := func(result LabelAnalysisResult) []string {
+		var out []string
+		for _, h := range result.Labels {
+			out = append(out, h.Label)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		cfg  func(*LabelHealthConfig)
+		want []string
+	}{
+		{"no filter", func(*LabelHealthConfig) {}, []string{"area/api", "area/legacy", "area/ui", "core"}},
+		{"regex include", func(c *LabelHealthConfig) { c.IncludePattern = "^area/" }, []string{"area/api", "area/legacy", "area/ui"}},
+		{"exclude overrides include", func(c *LabelHealthConfig) {
+			c.IncludePattern = "^area/"
+			c.IncludeLabels = []string{"area/legacy", "core"}
+			c.ExcludeLabels = []string{"area/legacy"}
+		}, []string{"area/api", "area/ui", "core"}},
+		{"exclude only", func(c *LabelHealthConfig) { c.ExcludeLabels = []string{"core"} }, []string{"area/api", "area/legacy", "area/ui"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultLabelHealthConfig()
+			tt.cfg(&cfg)
+			result := ComputeAllLabelHealth(issues, cfg, now, nil)
+			if got := labelsOf(result); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("labels = %v, want %v", got, tt.want)
+			}
+			if result.TotalLabels != len(tt.want) || len(result.Summaries) != len(tt.want) {
+				t.Errorf("TotalLabels = %d, summaries = %d, want %d", result.TotalLabels, len(result.Summaries), len(tt.want))
+			}
+		})
+	}
+
+	// An excluded label still counts as a blocking neighbor
+	cfg := DefaultLabelHealthConfig()
+	cfg.ExcludeLabels = []string{"core"}
+	result := ComputeAllLabelHealth(issues, cfg, now, nil)
+	api := result.Labels[0]
+	if api.Label != "area/api" || api.Flow.IncomingDeps != 1 || fmt.Sprint(api.Flow.IncomingLabels) != "[core]" {
+		t.Errorf("area/api flow should still see core as a blocker, got %+v", api.Flow)
+	}
+}
+
+func TestComputeAllLabelHealth_UrgencyOrdersAttention(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-60 * 24 * time.Hour)
+	recent := now.Add(-24 * time.Hour)
+	blocks := func(on string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: on, Type: model.DepBlocks}}
+	}
+	// "core" is stale and the rest of the graph waits on it; "attic" is
+	// just as stale but nothing depends on it
+	issues := []model.Issue{
+		{ID: "core-1", Labels: []string{"core"}, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "core-2", Labels: []string{"core"}, Status: model.StatusOpen, UpdatedAt: old, Dependencies: blocks("core-1")},
+		{ID: "app-1", Labels: []string{"app"}, Status: model.StatusOpen, UpdatedAt: recent, Dependencies: blocks("core-2")},
+		{ID: "app-2", Labels: []string{"app"}, Status: model.StatusOpen, UpdatedAt: recent, Dependencies: blocks("core-2")},
+		{ID: "attic-1", Labels: []string{"attic"}, Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "attic-2", Labels: []string{"attic"}, Status: model.StatusOpen, UpdatedAt: old},
+	}
+
+	result := ComputeAllLabelHealth(issues, DefaultLabelHealthConfig(), now, nil)
+	summaries := make(map[string]LabelSummary)
+	for _, s := range result.Summaries {
+		summaries[s.Label] = s
+	}
+	core, attic := summaries["core"], summaries["attic"]
+	if core.UrgencyScore <= attic.UrgencyScore {
+		t.Errorf("core urgency %d should exceed attic urgency %d", core.UrgencyScore, attic.UrgencyScore)
+	}
+	if len(core.UrgencyReasons) == 0 || core.UrgencyReasons[0] != "2 stale issues blocking other work" {
+		t.Errorf("unexpected core urgency reasons: %v", core.UrgencyReasons)
+	}
+	if len(attic.UrgencyReasons) != 0 {
+		t.Errorf("attic should have no urgency reasons, got %v", attic.UrgencyReasons)
+	}
+
+	pos := make(map[string]int)
+	for i, l := range result.AttentionNeeded {
+		pos[l] = i
+	}
+	ci, cok := pos["core"]
+	ai, aok := pos["attic"]
+	if !cok || !aok {
+		t.Fatalf("both stale labels should need attention, got %v", result.AttentionNeeded)
+	}
+	if ci > ai {
+		t.Errorf("core should be listed before attic in AttentionNeeded: %v", result.AttentionNeeded)
+	}
+}
+
+func TestComputeAllLabelHealth_MinIssuesForHealth(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	stale := now.AddDate(0, 0, -90)
+	var issues []model.Issue
+	for i := range 4 {
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("BIG-%d", i), Status: model.StatusOpen, Labels: []string{"big"}, UpdatedAt: stale})
+	}
+	for i := range 2 {
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("TINY-%d", i), Status: model.StatusOpen, Labels: []string{"tiny"}, UpdatedAt: stale})
+	}
+
+	cfg := DefaultLabelHealthConfig()
+	cfg.MinIssuesForHealth = 3
+	result := ComputeAllLabelHealth(issues, cfg, now, nil)
+
+	byLabel := make(map[string]LabelHealth)
+	for _, h := range result.Labels {
+		byLabel[h.Label] = h
+	}
+	tiny, big := byLabel["tiny"], byLabel["big"]
+
+	if !tiny.Insufficient || tiny.HealthLevel != HealthLevelInsufficient {
+		t.Errorf("tiny: insufficient=%v level=%s, want insufficient", tiny.Insufficient, tiny.HealthLevel)
+	}
+	if tiny.IssueCount != 2 || tiny.TopIssue == "" {
+		t.Errorf("tiny should keep its counts and top issue: %+v", tiny)
+	}
+	for name, score := range map[string]int{
+		"velocity":    tiny.Velocity.VelocityScore,
+		"freshness":   tiny.Freshness.FreshnessScore,
+		"flow":        tiny.Flow.FlowScore,
+		"criticality": tiny.Criticality.CriticalityScore,
+	} {
+		if score != neutralComponentScore {
+			t.Errorf("tiny %s score = %d, want neutral %d", name, score, neutralComponentScore)
+		}
+	}
+	if len(tiny.Reasons) != 0 || cfg.NeedsAttention(tiny) {
+		t.Errorf("tiny should not need attention, reasons %v", tiny.Reasons)
+	}
+
+	if big.Insufficient || big.HealthLevel == HealthLevelInsufficient {
+		t.Errorf("big has enough issues, got insufficient (level %s)", big.HealthLevel)
+	}
+	if big.Freshness.FreshnessScore == neutralComponentScore || big.Freshness.StaleCount != 4 {
+		t.Errorf("big should be scored normally, freshness %+v", big.Freshness)
+	}
+
+	if result.InsufficientCount != 1 {
+		t.Errorf("InsufficientCount = %d, want 1", result.InsufficientCount)
+	}
+	if got := result.HealthyCount + result.WarningCount + result.CriticalCount; got != 1 {
+		t.Errorf("tallied %d labels by health level, want only big", got)
+	}
+	if slices.Contains(result.AttentionNeeded, "tiny") {
+		t.Errorf("tiny should not be in AttentionNeeded: %v", result.AttentionNeeded)
+	}
+}
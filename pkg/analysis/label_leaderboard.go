@@ -0,0 +1,64 @@
+package analysis
+
+import "sort"
+
+// LabelVelocityEntry is one label's place on a velocity leaderboard
+type LabelVelocityEntry struct {
+	Label          string  `json:"label"`
+	AvgDaysToClose float64 `json:"avg_days_to_close"`
+	SampleSize     int     `json:"sample_size"`  // Closures averaged into AvgDaysToClose
+	ClosedCount    int     `json:"closed_count"` // All closed issues with the label
+}
+
+// VelocityLeaderboardOptions tunes VelocityLeaderboardWithOptions
+type VelocityLeaderboardOptions struct {
+	N          int // Entries per list; <= 0 keeps every eligible label
+	MinSamples int // Skip labels averaging fewer closures, so one outlier can't top a list
+}
+
+// VelocityLeaderboard ranks labels by AvgDaysToClose, returning up to n of
+// the fastest-closing and n of the slowest-closing. Labels without any
+// timed closure, and labels excluded from velocity, are left out of both
+// lists.
+func VelocityLeaderboard(result LabelAnalysisResult, n int) (fastest, slowest []LabelVelocityEntry) {
+	return VelocityLeaderboardWithOptions(result, VelocityLeaderboardOptions{N: n})
+}
+
+// VelocityLeaderboardWithOptions is VelocityLeaderboard with a minimum
+// sample size. Ties are broken by closed count, higher first, then label.
+func VelocityLeaderboardWithOptions(result LabelAnalysisResult, opts VelocityLeaderboardOptions) (fastest, slowest []LabelVelocityEntry) {
+	minSamples := max(opts.MinSamples, 1)
+
+	var entries []LabelVelocityEntry
+	for _, h := range result.Labels {
+		if h.Velocity.Excluded || h.Velocity.CloseSampleCount < minSamples {
+			continue
+		}
+		entries = append(entries, LabelVelocityEntry{
+			Label:          h.Label,
+			AvgDaysToClose: h.Velocity.AvgDaysToClose,
+			SampleSize:     h.Velocity.CloseSampleCount,
+			ClosedCount:    h.ClosedCount,
+		})
+	}
+
+	rank := func(slowFirst bool) []LabelVelocityEntry {
+		ranked := make([]LabelVelocityEntry, len(entries))
+		copy(ranked, entries)
+		sort.Slice(ranked, func(i, j int) bool {
+			a, b := ranked[i], ranked[j]
+			if a.AvgDaysToClose != b.AvgDaysToClose {
+				return (a.AvgDaysToClose < b.AvgDaysToClose) != slowFirst
+			}
+			if a.ClosedCount != b.ClosedCount {
+				return a.ClosedCount > b.ClosedCount
+			}
+			return a.Label < b.Label
+		})
+		if opts.N > 0 && len(ranked) > opts.N {
+			ranked = ranked[:opts.N]
+		}
+		return ranked
+	}
+	return rank(false), rank(true)
+}
@@ -0,0 +1,170 @@
+package analysis
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func leaderboardResult() LabelAnalysisResult {
+	label := func(name string, avgDays float64, samples, closed int) LabelHealth {
+		h := NewLabelHealth(name)
+		h.Velocity.AvgDaysToClose = avgDays
+		h.Velocity.CloseSampleCount = samples
+		h.ClosedCount = closed
+		return h
+	}
+	return LabelAnalysisResult{Labels: []LabelHealth{
+		label("api", 4, 10, 10),
+		label("ui", 2, 6, 6),
+		label("docs", 2, 8, 9), // ties ui on speed, more closed
+		label("infra", 12, 5, 5),
+		label("fluke", 0.5, 1, 1), // a single quick close
+		label("idle", 0, 0, 0),
+		label("untimed", 0, 0, 3), // closed without timestamps
+	}}
+}
+
+func entryLabels(entries []LabelVelocityEntry) []string {
+	labels := []string{}
+	for _, e := range entries {
+		labels = append(labels, e.Label)
+	}
+	return labels
+}
+
+func TestVelocityLeaderboard(t *testing.T) {
+	fastest, slowest := VelocityLeaderboard(leaderboardResult(), 3)
+
+	if got, want := entryLabels(fastest), []string{"fluke", "docs", "ui"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fastest = %v, want %v", got, want)
+	}
+	if got, want := entryLabels(slowest), []string{"infra", "api", "docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("slowest = %v, want %v", got, want)
+	}
+	want := LabelVelocityEntry{Label: "infra", AvgDaysToClose: 12, SampleSize: 5, ClosedCount: 5}
+	if slowest[0] != want {
+		t.Errorf("slowest[0] = %+v, want %+v", slowest[0], want)
+	}
+
+	all, _ := VelocityLeaderboard(leaderboardResult(), 0)
+	for _, e := range all {
+		if e.Label == "idle" || e.Label == "untimed" {
+			t.Errorf("label %q has no timed closures and should be excluded", e.Label)
+		}
+	}
+	if len(all) != 5 {
+		t.Errorf("n=0 should list all 5 eligible labels, got %v", entryLabels(all))
+	}
+}
+
+func TestVelocityLeaderboard_MinSamples(t *testing.T) {
+	fastest, slowest := VelocityLeaderboardWithOptions(leaderboardResult(), VelocityLeaderboardOptions{N: 2, MinSamples: 6})
+
+	if got, want := entryLabels(fastest), []string{"docs", "ui"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fastest = %v, want %v", got, want)
+	}
+	// infra (5 samples) and fluke (1) fall below the minimum
+	if got, want := entryLabels(slowest), []string{"api", "docs"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("slowest = %v, want %v", got, want)
+	}
+
+	none, _ := VelocityLeaderboardWithOptions(leaderboardResult(), VelocityLeaderboardOptions{MinSamples: 100})
+	if len(none) != 0 {
+		t.Errorf("expected no labels with 100+ samples, got %v", entryLabels(none))
+	}
+}
+
+func TestComputeVelocityMetrics_CloseSampleCount(t *testing.T) {
+	now := time.Now()
+	created := now.Add(-10 * 24 * time.Hour)
+	closed := now.Add(-2 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "a", Status: model.StatusClosed, CreatedAt: created, ClosedAt: &closed},
+		{ID: "b", Status: model.StatusClosed, CreatedAt: created, ClosedAt: &closed},
+		{ID: "c", Status: model.StatusClosed, CreatedAt: created}, // no close time
+		{ID: "d", Status: model.StatusOpen, CreatedAt: created},
+	}
+	if got := ComputeVelocityMetrics(issues, now).CloseSampleCount; got != 2 {
+		t.Errorf("CloseSampleCount = %d, want 2", got)
+	}
+}
+
+func TestExcludeFromVelocity(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	closed := func(id, label string, created, closedAt time.Time) model.Issue {
+		return model.Issue{ID: id, Labels: []string{label}, Status: model.StatusClosed,
+			CreatedAt: created, UpdatedAt: closedAt, ClosedAt: &closedAt}
+	}
+	var issues []model.Issue
+	// The bot closes its PR-tracking issues within an hour
+	for i := range 6 {
+		opened := now.AddDate(0, 0, -i-1)
+		issues = append(issues, closed(fmt.Sprintf("bot-%d", i), "dependabot", opened, opened.Add(time.Hour)))
+	}
+	issues = append(issues,
+		closed("api-1", "api", now.AddDate(0, 0, -20), now.AddDate(0, 0, -10)),
+		closed("api-2", "api", now.AddDate(0, 0, -15), now.AddDate(0, 0, -5)),
+		closed("ui-1", "ui", now.AddDate(0, 0, -30), now.AddDate(0, 0, -2)),
+		model.Issue{ID: "ui-2", Labels: []string{"ui"}, Status: model.StatusOpen, CreatedAt: now.AddDate(0, 0, -3), UpdatedAt: now.AddDate(0, 0, -1)},
+	)
+
+	cfg := DefaultLabelHealthConfig()
+	plain := ComputeAllLabelHealth(issues, cfg, now, nil)
+	fastest, _ := VelocityLeaderboard(plain, 1)
+	if got := entryLabels(fastest); !reflect.DeepEqual(got, []string{"dependabot"}) {
+		t.Fatalf("without exclusion the bot should lead, got %v", got)
+	}
+
+	cfg.ExcludeFromVelocity = []string{"dependabot"}
+	result := ComputeAllLabelHealth(issues, cfg, now, nil)
+
+	var bot, api LabelHealth
+	for _, h := range result.Labels {
+		switch h.Label {
+		case "dependabot":
+			bot = h
+		case "api":
+			api = h
+		}
+	}
+	if !bot.Velocity.Excluded || api.Velocity.Excluded {
+		t.Errorf("Excluded flags: dependabot=%v api=%v, want true/false", bot.Velocity.Excluded, api.Velocity.Excluded)
+	}
+	if bot.Velocity.ClosedLast30Days != 6 {
+		t.Errorf("excluded velocity should still be reported, ClosedLast30Days = %d", bot.Velocity.ClosedLast30Days)
+	}
+
+	// Velocity's weight is shared out over the other three components
+	b := bot.Breakdown
+	if b.Velocity.Weight != 0 || b.Velocity.Contribution != 0 {
+		t.Errorf("velocity should carry no weight, got %+v", b.Velocity)
+	}
+	if sum := b.Freshness.Weight + b.Flow.Weight + b.Criticality.Weight; sum < 0.999 || sum > 1.001 {
+		t.Errorf("remaining weights sum to %v, want 1", sum)
+	}
+	if b.Freshness.Weight != b.Flow.Weight || b.Flow.Weight != b.Criticality.Weight {
+		t.Errorf("equal default weights should stay equal, got %+v", b)
+	}
+	if want := clampScore(int(b.Freshness.Contribution + b.Flow.Contribution + b.Criticality.Contribution + 0.5)); bot.Health != want {
+		t.Errorf("health = %d, want %d from freshness/flow/criticality", bot.Health, want)
+	}
+	if api.Breakdown.Velocity.Weight != cfg.VelocityWeight {
+		t.Errorf("other labels keep the velocity weight, got %v", api.Breakdown.Velocity.Weight)
+	}
+
+	fastest, slowest := VelocityLeaderboard(result, 0)
+	for _, list := range [][]LabelVelocityEntry{fastest, slowest} {
+		for _, e := range list {
+			if e.Label == "dependabot" {
+				t.Errorf("excluded label on leaderboard: %v", entryLabels(list))
+			}
+		}
+	}
+	if got := entryLabels(fastest); !reflect.DeepEqual(got, []string{"api", "ui"}) {
+		t.Errorf("fastest = %v, want [api ui]", got)
+	}
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelMergePlan previews folding one or more labels into a target label.
+// It is analysis only: applying the rename is left to the issue tracker.
+type LabelMergePlan struct {
+	From []string `json:"from"` // Source labels, deduplicated and sorted (the target itself is dropped)
+	To   string   `json:"to"`
+
+	AffectedCount int      `json:"affected_count"` // Issues carrying at least one source label
+	GainCount     int      `json:"gain_count"`     // Issues that would newly carry the target
+	KeepCount     int      `json:"keep_count"`     // Issues already carrying the target
+	MergedCount   int      `json:"merged_count"`   // Issues carrying the target after the merge
+	Gaining       []string `json:"gaining"`        // IDs of the issues counted in GainCount
+	Overlap       []string `json:"overlap"`        // Issues carrying two or more merged labels, counted once
+
+	// Co-occurrence of other labels with the target, before and after
+	CooccurrenceChanges []LabelCooccurrenceChange `json:"cooccurrence_changes"`
+
+	SeparateHealth map[string]int `json:"separate_health"` // Current health of each merged label in use
+	MergedHealth   LabelHealth    `json:"merged_health"`   // Estimated health of the target after the merge
+}
+
+// LabelCooccurrenceChange is how often a label appears alongside the merge
+// target before and after the merge.
+type LabelCooccurrenceChange struct {
+	Label  string `json:"label"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+}
+
+// PlanLabelMerge previews merging the from labels into to, scoring health
+// with the default config as of now. The input issues are not modified.
+func PlanLabelMerge(issues []model.Issue, from []string, to string) LabelMergePlan {
+	return PlanLabelMergeWithConfig(issues, from, to, DefaultLabelHealthConfig(), time.Now().UTC())
+}
+
+// PlanLabelMergeWithConfig is PlanLabelMerge with an explicit health config
+// and reference time.
+func PlanLabelMergeWithConfig(issues []model.Issue, from []string, to string, cfg LabelHealthConfig, now time.Time) LabelMergePlan {
+	sources := make(map[string]bool, len(from))
+	for _, l := range from {
+		if l != "" && l != to {
+			sources[l] = true
+		}
+	}
+	plan := LabelMergePlan{
+		From:                make([]string, 0, len(sources)),
+		To:                  to,
+		Gaining:             []string{},
+		Overlap:             []string{},
+		CooccurrenceChanges: []LabelCooccurrenceChange{},
+		SeparateHealth:      make(map[string]int),
+	}
+	for l := range sources {
+		plan.From = append(plan.From, l)
+	}
+	sort.Strings(plan.From)
+
+	// Rewrite labels on copies so callers' slices stay untouched
+	merged := make([]model.Issue, len(issues))
+	for i, iss := range issues {
+		merged[i] = iss
+		hasTarget := HasLabel(iss, to)
+		if hasTarget {
+			plan.KeepCount++
+		}
+
+		matched := 0
+		for _, l := range iss.Labels {
+			if sources[l] {
+				matched++
+			}
+		}
+		if matched == 0 {
+			continue
+		}
+		plan.AffectedCount++
+		if hasTarget || matched > 1 {
+			plan.Overlap = append(plan.Overlap, iss.ID)
+		}
+		if !hasTarget {
+			plan.GainCount++
+			plan.Gaining = append(plan.Gaining, iss.ID)
+		}
+
+		labels := make([]string, 0, len(iss.Labels))
+		seen := make(map[string]bool, len(iss.Labels))
+		for _, l := range iss.Labels {
+			if sources[l] {
+				l = to
+			}
+			if !seen[l] {
+				seen[l] = true
+				labels = append(labels, l)
+			}
+		}
+		merged[i].Labels = labels
+	}
+	plan.MergedCount = plan.KeepCount + plan.GainCount
+	sort.Strings(plan.Gaining)
+	sort.Strings(plan.Overlap)
+
+	before := GetLabelCooccurrence(issues)[to]
+	after := GetLabelCooccurrence(merged)[to]
+	changed := make(map[string]bool)
+	for l := range before {
+		changed[l] = true
+	}
+	for l := range after {
+		changed[l] = true
+	}
+	for l := range changed {
+		if sources[l] || before[l] == after[l] {
+			continue
+		}
+		plan.CooccurrenceChanges = append(plan.CooccurrenceChanges, LabelCooccurrenceChange{
+			Label:  l,
+			Before: before[l],
+			After:  after[l],
+		})
+	}
+	sort.Slice(plan.CooccurrenceChanges, func(i, j int) bool {
+		return plan.CooccurrenceChanges[i].Label < plan.CooccurrenceChanges[j].Label
+	})
+
+	// Labels don't shape the dependency graph, so one analysis serves both sides
+	stats := NewAnalyzer(issues).Analyze()
+	for _, l := range append([]string{to}, plan.From...) {
+		if len(GetLabelIssues(issues, l)) > 0 {
+			plan.SeparateHealth[l] = ComputeLabelHealthForLabel(l, issues, cfg, now, &stats).Health
+		}
+	}
+	plan.MergedHealth = ComputeLabelHealthForLabel(to, merged, cfg, now, &stats)
+	return plan
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func labelMergeIssues(now time.Time) []model.Issue {
+	day := 24 * time.Hour
+	return []model.Issue{
+		// frontend: recently active and closing work
+		{ID: "F1", Status: model.StatusOpen, Labels: []string{"frontend"}, CreatedAt: now.Add(-5 * day), UpdatedAt: now.Add(-1 * day)},
+		{ID: "F2", Status: model.StatusClosed, Labels: []string{"frontend"}, CreatedAt: now.Add(-6 * day), UpdatedAt: now.Add(-2 * day), ClosedAt: ptrTime(now.Add(-2 * day))},
+		{ID: "FC", Status: model.StatusOpen, Labels: []string{"frontend", "css"}, CreatedAt: now.Add(-3 * day), UpdatedAt: now.Add(-1 * day)},
+		// ui: long stale, nothing closed
+		{ID: "U1", Status: model.StatusOpen, Labels: []string{"ui"}, CreatedAt: now.Add(-200 * day), UpdatedAt: now.Add(-120 * day)},
+		{ID: "U2", Status: model.StatusOpen, Labels: []string{"ui", "frontend"}, CreatedAt: now.Add(-200 * day), UpdatedAt: now.Add(-90 * day)},
+		{ID: "U3", Status: model.StatusOpen, Labels: []string{"css", "ui"}, CreatedAt: now.Add(-200 * day), UpdatedAt: now.Add(-100 * day)},
+		{ID: "X", Status: model.StatusOpen, Labels: []string{"backend"}, CreatedAt: now.Add(-2 * day), UpdatedAt: now.Add(-1 * day)},
+	}
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
+
+func TestPlanLabelMerge_Counts(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := labelMergeIssues(now)
+
+	plan := PlanLabelMergeWithConfig(issues, []string{"frontend", "ui", "frontend"}, "ui", DefaultLabelHealthConfig(), now)
+
+	if !reflect.DeepEqual(plan.From, []string{"frontend"}) || plan.To != "ui" {
+		t.Errorf("from/to = %v/%q, want [frontend]/ui", plan.From, plan.To)
+	}
+	// U2 already carries ui, so only F1, F2 and FC gain it
+	if plan.AffectedCount != 4 || plan.GainCount != 3 || plan.KeepCount != 3 || plan.MergedCount != 6 {
+		t.Errorf("affected=%d gain=%d keep=%d merged=%d, want 4/3/3/6",
+			plan.AffectedCount, plan.GainCount, plan.KeepCount, plan.MergedCount)
+	}
+	if !reflect.DeepEqual(plan.Gaining, []string{"F1", "F2", "FC"}) {
+		t.Errorf("gaining = %v, want [F1 F2 FC]", plan.Gaining)
+	}
+	if !reflect.DeepEqual(plan.Overlap, []string{"U2"}) {
+		t.Errorf("overlap = %v, want [U2]", plan.Overlap)
+	}
+	if plan.MergedHealth.IssueCount != 6 {
+		t.Errorf("merged health issue count = %d, want 6 (no double counting)", plan.MergedHealth.IssueCount)
+	}
+
+	// css met ui on U3 only; FC brings it in as well
+	want := []LabelCooccurrenceChange{{Label: "css", Before: 1, After: 2}}
+	if !reflect.DeepEqual(plan.CooccurrenceChanges, want) {
+		t.Errorf("cooccurrence changes = %+v, want %+v", plan.CooccurrenceChanges, want)
+	}
+}
+
+func TestPlanLabelMerge_HealthEstimate(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := labelMergeIssues(now)
+
+	plan := PlanLabelMergeWithConfig(issues, []string{"frontend"}, "ui", DefaultLabelHealthConfig(), now)
+
+	frontend, ok := plan.SeparateHealth["frontend"]
+	ui, ok2 := plan.SeparateHealth["ui"]
+	if !ok || !ok2 || len(plan.SeparateHealth) != 2 {
+		t.Fatalf("separate health = %v, want frontend and ui", plan.SeparateHealth)
+	}
+	if frontend <= ui {
+		t.Fatalf("fixture should make frontend healthier than ui: frontend=%d ui=%d", frontend, ui)
+	}
+	merged := plan.MergedHealth.Health
+	if merged == frontend || merged == ui {
+		t.Errorf("merged health %d should differ from frontend=%d and ui=%d", merged, frontend, ui)
+	}
+	if plan.MergedHealth.Label != "ui" {
+		t.Errorf("merged health label = %q, want ui", plan.MergedHealth.Label)
+	}
+}
+
+func TestPlanLabelMerge_DoesNotMutateInput(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := labelMergeIssues(now)
+	before := labelMergeIssues(now)
+
+	PlanLabelMergeWithConfig(issues, []string{"frontend", "css"}, "ui", DefaultLabelHealthConfig(), now)
+
+	for i := range issues {
+		if !reflect.DeepEqual(issues[i].Labels, before[i].Labels) {
+			t.Errorf("%s labels changed to %v, want %v", issues[i].ID, issues[i].Labels, before[i].Labels)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// LabelSpotlightTopIssues is how many issues LabelSpotlight.TopIssues holds
+const LabelSpotlightTopIssues = 5
+
+// LabelSpotlight gathers everything known about one label in a single report
+type LabelSpotlight struct {
+	Label       string    `json:"label"`
+	GeneratedAt time.Time `json:"generated_at"`
+
+	Health LabelHealth `json:"health"`
+
+	// CoOccurring lists labels sharing issues with this one, most shared first
+	CoOccurring []LabelCooccurrence `json:"co_occurring"`
+
+	// Inbound holds the cross-label dependencies where another label blocks
+	// this one, Outbound those where this label blocks another; both are
+	// taken from ComputeCrossLabelFlow
+	Inbound  []LabelDependency `json:"inbound"`
+	Outbound []LabelDependency `json:"outbound"`
+
+	// StaleIssues lists the issues counted in Health.Freshness.StaleCount,
+	// least recently updated first
+	StaleIssues []string `json:"stale_issues"`
+
+	// TopIssues holds up to LabelSpotlightTopIssues open issues, ranked the
+	// way Health.TopIssue is chosen
+	TopIssues []string `json:"top_issues"`
+}
+
+// LabelCooccurrence is how many issues carry both the spotlight label and Label
+type LabelCooccurrence struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ComputeLabelSpotlight builds the spotlight report for label. stats may be
+// nil, in which case the graph is analyzed here. A label no issue carries is
+// an error.
+func ComputeLabelSpotlight(label string, issues []model.Issue, cfg LabelHealthConfig, now time.Time, stats *GraphStats) (LabelSpotlight, error) {
+	labeled := GetLabelIssues(issues, label)
+	if len(labeled) == 0 {
+		return LabelSpotlight{}, fmt.Errorf("label %q has no issues", label)
+	}
+	if stats == nil {
+		s := NewAnalyzer(issues).Analyze()
+		stats = &s
+	}
+
+	spotlight := LabelSpotlight{
+		Label:       label,
+		GeneratedAt: now,
+		Health:      ComputeLabelHealthForLabel(label, issues, cfg, now, stats),
+		CoOccurring: []LabelCooccurrence{},
+		Inbound:     []LabelDependency{},
+		Outbound:    []LabelDependency{},
+	}
+
+	for other, count := range GetLabelCooccurrence(issues)[label] {
+		spotlight.CoOccurring = append(spotlight.CoOccurring, LabelCooccurrence{Label: other, Count: count})
+	}
+	sort.Slice(spotlight.CoOccurring, func(i, j int) bool {
+		a, b := spotlight.CoOccurring[i], spotlight.CoOccurring[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Label < b.Label
+	})
+
+	for _, dep := range ComputeCrossLabelFlow(issues, cfg).Dependencies {
+		switch label {
+		case dep.ToLabel:
+			spotlight.Inbound = append(spotlight.Inbound, dep)
+		case dep.FromLabel:
+			spotlight.Outbound = append(spotlight.Outbound, dep)
+		}
+	}
+
+	spotlight.StaleIssues = staleLabelIssues(labeled, now, spotlight.Health.Freshness.StaleThresholdDays, cfg.freshnessOptions().ExcludeClosed)
+	spotlight.TopIssues = topLabelIssues(labeled, stats.centralityView().pageRank, LabelSpotlightTopIssues)
+	return spotlight, nil
+}
+
+// staleLabelIssues returns the IDs of issues not updated for staleDays or
+// more, by the same test ComputeFreshnessMetricsWithOptions counts
+func staleLabelIssues(labeled []model.Issue, now time.Time, staleDays int, excludeClosed bool) []string {
+	var stale []model.Issue
+	for _, iss := range labeled {
+		if excludeClosed && isClosedLikeStatus(iss.Status) {
+			continue
+		}
+		if !iss.UpdatedAt.IsZero() && now.Sub(iss.UpdatedAt).Hours()/24.0 >= float64(staleDays) {
+			stale = append(stale, iss)
+		}
+	}
+	sort.Slice(stale, func(i, j int) bool {
+		if !stale[i].UpdatedAt.Equal(stale[j].UpdatedAt) {
+			return stale[i].UpdatedAt.Before(stale[j].UpdatedAt)
+		}
+		return stale[i].ID < stale[j].ID
+	})
+	ids := make([]string, 0, len(stale))
+	for _, iss := range stale {
+		ids = append(ids, iss.ID)
+	}
+	return ids
+}
+
+// topLabelIssues returns up to n open issues ordered by issueOutranks
+func topLabelIssues(labeled []model.Issue, pageRank map[string]float64, n int) []string {
+	var open []model.Issue
+	for _, iss := range labeled {
+		if !isClosedLikeStatus(iss.Status) {
+			open = append(open, iss)
+		}
+	}
+	sort.Slice(open, func(i, j int) bool { return issueOutranks(&open[i], &open[j], pageRank) })
+	ids := make([]string, 0, min(n, len(open)))
+	for _, iss := range open[:min(n, len(open))] {
+		ids = append(ids, iss.ID)
+	}
+	return ids
+}
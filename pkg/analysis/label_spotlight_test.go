@@ -0,0 +1,112 @@
+package analysis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// spotlightIssues centers on "api": ui depends on api, api depends on db,
+// and two api issues also carry "backend"
+func spotlightIssues(now time.Time) []model.Issue {
+	daysAgo := func(d int) time.Time { return now.AddDate(0, 0, -d) }
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	closedAt := daysAgo(3)
+	return []model.Issue{
+		{ID: "API-1", Status: model.StatusOpen, Priority: 1, Labels: []string{"api", "backend"}, CreatedAt: daysAgo(40), UpdatedAt: daysAgo(30),
+			Dependencies: blocks("DB-1")},
+		{ID: "API-2", Status: model.StatusInProgress, Priority: 0, Labels: []string{"api", "backend"}, CreatedAt: daysAgo(10), UpdatedAt: daysAgo(1)},
+		{ID: "API-3", Status: model.StatusOpen, Priority: 1, Labels: []string{"api", "docs"}, CreatedAt: daysAgo(60), UpdatedAt: daysAgo(50)},
+		{ID: "API-4", Status: model.StatusClosed, Priority: 0, Labels: []string{"api"}, CreatedAt: daysAgo(9), UpdatedAt: daysAgo(3), ClosedAt: &closedAt},
+		{ID: "DB-1", Status: model.StatusOpen, Priority: 2, Labels: []string{"db"}, CreatedAt: daysAgo(20), UpdatedAt: daysAgo(2)},
+		{ID: "UI-1", Status: model.StatusOpen, Priority: 2, Labels: []string{"ui"}, CreatedAt: daysAgo(5), UpdatedAt: daysAgo(1),
+			Dependencies: blocks("API-2")},
+	}
+}
+
+func TestComputeLabelSpotlight(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := spotlightIssues(now)
+	cfg := DefaultLabelHealthConfig()
+	stats := NewAnalyzer(issues).Analyze()
+
+	spot, err := ComputeLabelSpotlight("api", issues, cfg, now, &stats)
+	if err != nil {
+		t.Fatalf("ComputeLabelSpotlight: %v", err)
+	}
+
+	if want := ComputeLabelHealthForLabel("api", issues, cfg, now, &stats); !reflect.DeepEqual(spot.Health, want) {
+		t.Errorf("health = %+v\nwant %+v", spot.Health, want)
+	}
+
+	wantCooc := []LabelCooccurrence{{Label: "backend", Count: 2}, {Label: "docs", Count: 1}}
+	if !reflect.DeepEqual(spot.CoOccurring, wantCooc) {
+		t.Errorf("co-occurring = %+v, want %+v", spot.CoOccurring, wantCooc)
+	}
+	for _, c := range spot.CoOccurring {
+		if GetLabelCooccurrence(issues)["api"][c.Label] != c.Count {
+			t.Errorf("co-occurrence with %s = %d, disagrees with GetLabelCooccurrence", c.Label, c.Count)
+		}
+	}
+
+	// Flow detail is the slice of the full flow touching api
+	var wantIn, wantOut []LabelDependency
+	for _, dep := range ComputeCrossLabelFlow(issues, cfg).Dependencies {
+		if dep.ToLabel == "api" {
+			wantIn = append(wantIn, dep)
+		}
+		if dep.FromLabel == "api" {
+			wantOut = append(wantOut, dep)
+		}
+	}
+	if !reflect.DeepEqual(spot.Inbound, wantIn) || len(wantIn) != 1 || wantIn[0].FromLabel != "db" {
+		t.Errorf("inbound = %+v, want the db -> api dependency %+v", spot.Inbound, wantIn)
+	}
+	if !reflect.DeepEqual(spot.Outbound, wantOut) || len(wantOut) != 1 || wantOut[0].ToLabel != "ui" {
+		t.Errorf("outbound = %+v, want the api -> ui dependency %+v", spot.Outbound, wantOut)
+	}
+
+	// Stale list agrees with the freshness count, oldest first
+	if want := []string{"API-3", "API-1"}; !reflect.DeepEqual(spot.StaleIssues, want) {
+		t.Errorf("stale issues = %v, want %v", spot.StaleIssues, want)
+	}
+	if len(spot.StaleIssues) != spot.Health.Freshness.StaleCount {
+		t.Errorf("%d stale issues listed, freshness counts %d", len(spot.StaleIssues), spot.Health.Freshness.StaleCount)
+	}
+
+	// Open issues only, led by the label's top issue
+	if want := []string{"API-2", "API-1", "API-3"}; !reflect.DeepEqual(spot.TopIssues, want) {
+		t.Errorf("top issues = %v, want %v", spot.TopIssues, want)
+	}
+	if spot.TopIssues[0] != spot.Health.TopIssue {
+		t.Errorf("top issues start with %s, health top issue is %s", spot.TopIssues[0], spot.Health.TopIssue)
+	}
+}
+
+func TestComputeLabelSpotlight_UnknownLabel(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	_, err := ComputeLabelSpotlight("nope", spotlightIssues(now), DefaultLabelHealthConfig(), now, nil)
+	if err == nil || !strings.Contains(err.Error(), `"nope"`) {
+		t.Errorf("err = %v, want an error naming the label", err)
+	}
+}
+
+func TestComputeLabelSpotlight_EmptyLists(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	closedAt := now
+	issues := []model.Issue{
+		{ID: "X-1", Status: model.StatusClosed, Labels: []string{"solo"}, UpdatedAt: now, ClosedAt: &closedAt},
+	}
+	spot, err := ComputeLabelSpotlight("solo", issues, DefaultLabelHealthConfig(), now, nil)
+	if err != nil {
+		t.Fatalf("ComputeLabelSpotlight: %v", err)
+	}
+	if spot.CoOccurring == nil || spot.Inbound == nil || spot.Outbound == nil || spot.StaleIssues == nil || spot.TopIssues == nil {
+		t.Errorf("expected empty, non-nil lists for JSON: %+v", spot)
+	}
+}
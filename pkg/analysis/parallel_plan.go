@@ -0,0 +1,237 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Effort units reported by ParallelPlan.EffortUnit
+const (
+	EffortUnitMinutes = "minutes" // some scheduled issue has EstimatedMinutes
+	EffortUnitIssues  = "issues"  // no estimates; each issue counts as 1
+)
+
+// ParallelTrack is an ordered sequence of issues for one worker
+type ParallelTrack struct {
+	TrackID  string   `json:"track_id"`
+	IssueIDs []string `json:"issue_ids"`          // In execution order
+	Effort   float64  `json:"effort"`             // Total effort in ParallelPlan.EffortUnit
+	Critical bool     `json:"critical,omitempty"` // Track holds the critical path
+}
+
+// ParallelPlan schedules open issues across parallel tracks
+type ParallelPlan struct {
+	Tracks       []ParallelTrack `json:"tracks"`
+	Rounds       map[string]int  `json:"rounds"`                  // Earliest round (0 = now) each issue can start
+	CriticalPath []string        `json:"critical_path,omitempty"` // Longest blocker chain, root first
+	EffortUnit   string          `json:"effort_unit"`
+	Cycles       [][]string      `json:"cycles,omitempty"`      // Dependency cycles preventing scheduling
+	Unscheduled  []string        `json:"unscheduled,omitempty"` // Issues in or behind a cycle
+}
+
+// BuildParallelTracks layers the blocking dependency graph of the open
+// issues into rounds and spreads them over at most maxTracks tracks.
+//
+// The critical path gets a track of its own. Remaining issues are taken in
+// round, priority, ID order and placed on whichever track lets them start
+// soonest, preferring a track that already holds one of their dependencies,
+// so independent chains stay on separate tracks. Effort is EstimatedMinutes
+// when any issue has an estimate, with unestimated issues counted at the
+// median estimate; without estimates each issue counts as 1.
+// Issues in or behind a dependency cycle are reported, not scheduled.
+func BuildParallelTracks(issues []model.Issue, maxTracks int) ParallelPlan {
+	if maxTracks < 1 {
+		maxTracks = 1
+	}
+	plan := ParallelPlan{
+		Tracks:     []ParallelTrack{},
+		Rounds:     make(map[string]int),
+		EffortUnit: EffortUnitIssues,
+	}
+
+	var open []model.Issue
+	for _, issue := range issues {
+		if !isClosedLikeStatus(issue.Status) {
+			open = append(open, issue)
+		}
+	}
+	if len(open) == 0 {
+		return plan
+	}
+
+	a := NewAnalyzer(open)
+	deps := a.dependencyLists()
+
+	for _, cycle := range findCyclesSafe(a.g, len(open)) {
+		ids := make([]string, len(cycle))
+		for i, n := range cycle {
+			ids[i] = a.nodeToID[n.ID()]
+		}
+		plan.Cycles = append(plan.Cycles, ids)
+	}
+
+	// Layer the DAG (Kahn); whatever never becomes free is in or behind a cycle
+	remaining := make(map[string]int, len(a.issueMap))
+	dependents := make(map[string][]string)
+	var layer []string
+	for id := range a.issueMap {
+		remaining[id] = len(deps[id])
+		for _, dep := range deps[id] {
+			dependents[dep] = append(dependents[dep], id)
+		}
+		if remaining[id] == 0 {
+			layer = append(layer, id)
+		}
+	}
+	for round := 0; len(layer) > 0; round++ {
+		var next []string
+		for _, id := range layer {
+			plan.Rounds[id] = round
+			for _, d := range dependents[id] {
+				remaining[d]--
+				if remaining[d] == 0 {
+					next = append(next, d)
+				}
+			}
+		}
+		layer = next
+	}
+	for id := range a.issueMap {
+		if _, ok := plan.Rounds[id]; !ok {
+			plan.Unscheduled = append(plan.Unscheduled, id)
+		}
+	}
+	sort.Strings(plan.Unscheduled)
+
+	scheduled := make([]model.Issue, 0, len(plan.Rounds))
+	for id := range plan.Rounds {
+		scheduled = append(scheduled, a.issueMap[id])
+	}
+	useMinutes := hasEstimates(scheduled)
+	medianMinutes := computeMedianEstimatedMinutes(scheduled)
+	if useMinutes {
+		plan.EffortUnit = EffortUnitMinutes
+	}
+	effort := func(id string) float64 {
+		if useMinutes {
+			return effortMinutes(a.issueMap[id], medianMinutes)
+		}
+		return 1
+	}
+
+	scheduledDeps := make(map[string][]string, len(plan.Rounds))
+	for id := range plan.Rounds {
+		if len(deps[id]) > 0 {
+			scheduledDeps[id] = deps[id]
+		}
+	}
+	if chains := longestChains(scheduledDeps, 1); len(chains) > 0 {
+		plan.CriticalPath = chains[0]
+	}
+
+	// Simulated timeline: finish[id] is when an issue completes, free[i]
+	// when track i is next idle
+	var tracks []ParallelTrack
+	var free []float64
+	if len(plan.CriticalPath) > 0 {
+		tracks = append(tracks, ParallelTrack{Critical: true})
+		free = append(free, 0)
+	}
+	// With a single track the rest queues up behind the critical path
+	first := len(tracks)
+	if first >= maxTracks {
+		first = maxTracks - 1
+	}
+	for len(tracks) < maxTracks {
+		tracks = append(tracks, ParallelTrack{})
+		free = append(free, 0)
+	}
+
+	// Round order guarantees every dependency comes before its dependents
+	pending := make([]string, 0, len(plan.Rounds))
+	for id := range plan.Rounds {
+		pending = append(pending, id)
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		ri, rj := plan.Rounds[pending[i]], plan.Rounds[pending[j]]
+		if ri != rj {
+			return ri < rj
+		}
+		pi, pj := a.issueMap[pending[i]].Priority, a.issueMap[pending[j]].Priority
+		if pi != pj {
+			return pi < pj
+		}
+		return pending[i] < pending[j]
+	})
+
+	finish := make(map[string]float64, len(plan.Rounds))
+	trackOf := make(map[string]int, len(plan.Rounds))
+	// place schedules id on the given track, or on whichever track lets it
+	// start soonest when track is -1. Its dependencies must already be placed;
+	// it starts once the latest of them finishes, whatever track they are on.
+	place := func(id string, track int) {
+		ready := 0.0
+		holdsDep := make(map[int]bool)
+		for _, dep := range deps[id] {
+			if finish[dep] > ready {
+				ready = finish[dep]
+			}
+			holdsDep[trackOf[dep]] = true
+		}
+
+		best, bestStart := track, 0.0
+		if track >= 0 {
+			bestStart = max(free[track], ready)
+		} else {
+			for i := first; i < len(tracks); i++ {
+				start := max(free[i], ready)
+				if best == -1 || start < bestStart || (start == bestStart && holdsDep[i] && !holdsDep[best]) {
+					best, bestStart = i, start
+				}
+			}
+		}
+
+		tracks[best].IssueIDs = append(tracks[best].IssueIDs, id)
+		tracks[best].Effort += effort(id)
+		finish[id] = bestStart + effort(id)
+		free[best] = finish[id]
+		trackOf[id] = best
+	}
+
+	// The critical path goes first; any off-path dependency of one of its
+	// issues is placed just before that issue so the wait is accounted for
+	for _, id := range plan.CriticalPath {
+		needed := make(map[string]bool)
+		stack := append([]string(nil), deps[id]...)
+		for len(stack) > 0 {
+			dep := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if _, placed := trackOf[dep]; placed || needed[dep] {
+				continue
+			}
+			needed[dep] = true
+			stack = append(stack, deps[dep]...)
+		}
+		for _, dep := range pending {
+			if needed[dep] {
+				place(dep, -1)
+			}
+		}
+		place(id, 0)
+	}
+	for _, id := range pending {
+		if _, placed := trackOf[id]; !placed {
+			place(id, -1)
+		}
+	}
+
+	for _, t := range tracks {
+		if len(t.IssueIDs) == 0 {
+			continue
+		}
+		t.TrackID = generateTrackID(len(plan.Tracks) + 1)
+		plan.Tracks = append(plan.Tracks, t)
+	}
+	return plan
+}
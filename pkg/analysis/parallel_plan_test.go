@@ -0,0 +1,215 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// trackOf returns the index of the track holding id, or -1
+func trackOf(plan analysis.ParallelPlan, id string) int {
+	for i, t := range plan.Tracks {
+		for _, tid := range t.IssueIDs {
+			if tid == id {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func TestBuildParallelTracks_IndependentChains(t *testing.T) {
+	// A1 <- A2 <- A3 and B1 <- B2 are unrelated work streams
+	issues := []model.Issue{
+		blockedIssue("A1"),
+		blockedIssue("A2", "A1"),
+		blockedIssue("A3", "A2"),
+		blockedIssue("B1"),
+		blockedIssue("B2", "B1"),
+	}
+	plan := analysis.BuildParallelTracks(issues, 3)
+
+	if want := []string{"A1", "A2", "A3"}; !reflect.DeepEqual(plan.CriticalPath, want) {
+		t.Errorf("CriticalPath = %v, want %v", plan.CriticalPath, want)
+	}
+	if len(plan.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %+v", plan.Tracks)
+	}
+	if !plan.Tracks[0].Critical || !reflect.DeepEqual(plan.Tracks[0].IssueIDs, []string{"A1", "A2", "A3"}) {
+		t.Errorf("first track should be the critical path, got %+v", plan.Tracks[0])
+	}
+	if !reflect.DeepEqual(plan.Tracks[1].IssueIDs, []string{"B1", "B2"}) {
+		t.Errorf("second chain should share one track, got %+v", plan.Tracks[1])
+	}
+	if plan.Tracks[0].TrackID != "track-A" || plan.Tracks[1].TrackID != "track-B" {
+		t.Errorf("unexpected track IDs: %q, %q", plan.Tracks[0].TrackID, plan.Tracks[1].TrackID)
+	}
+
+	wantRounds := map[string]int{"A1": 0, "A2": 1, "A3": 2, "B1": 0, "B2": 1}
+	if !reflect.DeepEqual(plan.Rounds, wantRounds) {
+		t.Errorf("Rounds = %v, want %v", plan.Rounds, wantRounds)
+	}
+	if plan.EffortUnit != analysis.EffortUnitIssues {
+		t.Errorf("EffortUnit = %q, want %q", plan.EffortUnit, analysis.EffortUnitIssues)
+	}
+}
+
+func TestBuildParallelTracks_SharedDependency(t *testing.T) {
+	// S blocks both X and Y; X and Y can only start once S is done
+	issues := []model.Issue{
+		blockedIssue("S"),
+		blockedIssue("X", "S"),
+		blockedIssue("Y", "S"),
+	}
+	plan := analysis.BuildParallelTracks(issues, 2)
+
+	if plan.Rounds["S"] != 0 || plan.Rounds["X"] != 1 || plan.Rounds["Y"] != 1 {
+		t.Errorf("unexpected rounds: %v", plan.Rounds)
+	}
+	if trackOf(plan, "X") == trackOf(plan, "Y") {
+		t.Errorf("X and Y should run in parallel: %+v", plan.Tracks)
+	}
+	for _, track := range plan.Tracks {
+		for i := 1; i < len(track.IssueIDs); i++ {
+			if plan.Rounds[track.IssueIDs[i]] < plan.Rounds[track.IssueIDs[i-1]] {
+				t.Errorf("track %s out of dependency order: %v", track.TrackID, track.IssueIDs)
+			}
+		}
+	}
+}
+
+func TestBuildParallelTracks_BalancesByEffort(t *testing.T) {
+	minutes := func(id string, m int) model.Issue {
+		issue := blockedIssue(id)
+		issue.EstimatedMinutes = &m
+		return issue
+	}
+	issues := []model.Issue{minutes("big", 300), minutes("s1", 60), minutes("s2", 60), minutes("s3", 60)}
+	plan := analysis.BuildParallelTracks(issues, 2)
+
+	if plan.EffortUnit != analysis.EffortUnitMinutes {
+		t.Fatalf("EffortUnit = %q, want minutes", plan.EffortUnit)
+	}
+	if len(plan.Tracks) != 2 {
+		t.Fatalf("expected 2 tracks, got %+v", plan.Tracks)
+	}
+	big := trackOf(plan, "big")
+	for _, id := range []string{"s1", "s2", "s3"} {
+		if trackOf(plan, id) == big {
+			t.Errorf("%s should not queue behind the 300-minute issue: %+v", id, plan.Tracks)
+		}
+	}
+}
+
+func TestBuildParallelTracks_MixedEstimates(t *testing.T) {
+	minutes := func(id string, m int) model.Issue {
+		issue := blockedIssue(id)
+		issue.EstimatedMinutes = &m
+		return issue
+	}
+	// "unsized" has no estimate and counts at the 60-minute median
+	issues := []model.Issue{minutes("big", 300), minutes("s1", 60), minutes("s2", 60), blockedIssue("unsized")}
+	plan := analysis.BuildParallelTracks(issues, 2)
+
+	if plan.EffortUnit != analysis.EffortUnitMinutes {
+		t.Fatalf("EffortUnit = %q, want minutes with partial estimates", plan.EffortUnit)
+	}
+	total := 0.0
+	for _, track := range plan.Tracks {
+		total += track.Effort
+	}
+	if total != 480 {
+		t.Errorf("total effort = %g, want 480 (300+60+60 plus the 60-minute median)", total)
+	}
+	big := trackOf(plan, "big")
+	for _, id := range []string{"s1", "s2", "unsized"} {
+		if trackOf(plan, id) == big {
+			t.Errorf("%s should not queue behind the 300-minute issue: %+v", id, plan.Tracks)
+		}
+	}
+}
+
+func TestBuildParallelTracks_CriticalPathWaitsOnOtherTracks(t *testing.T) {
+	minutes := func(id string, m int, deps ...string) model.Issue {
+		issue := blockedIssue(id, deps...)
+		issue.EstimatedMinutes = &m
+		return issue
+	}
+	// C is on the critical path A -> B -> C but also waits on the 100-minute
+	// X, so C finishes at 110 rather than 30. Its off-path dependent then
+	// starts at 110 on either track, and X's track is tried first; with the
+	// critical track's own effort as C's finish it would have gone to Z's
+	// track at 40.
+	issues := []model.Issue{
+		minutes("A", 10),
+		minutes("B", 10, "A"),
+		minutes("X", 100),
+		minutes("C", 10, "B", "X"),
+		minutes("E1", 10, "C"),
+		minutes("E2", 10, "C"),
+		minutes("Z", 40),
+	}
+	plan := analysis.BuildParallelTracks(issues, 3)
+
+	if len(plan.CriticalPath) != 4 || plan.CriticalPath[2] != "C" {
+		t.Fatalf("CriticalPath = %v, want A B C and one of E1/E2", plan.CriticalPath)
+	}
+	offPath := "E2"
+	if plan.CriticalPath[3] == "E2" {
+		offPath = "E1"
+	}
+	if trackOf(plan, offPath) != trackOf(plan, "X") {
+		t.Errorf("%s should follow X once C finishes at 110, got %+v", offPath, plan.Tracks)
+	}
+}
+
+func TestBuildParallelTracks_SingleTrack(t *testing.T) {
+	issues := []model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C"),
+	}
+	plan := analysis.BuildParallelTracks(issues, 1)
+
+	if len(plan.Tracks) != 1 {
+		t.Fatalf("expected 1 track, got %+v", plan.Tracks)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(plan.Tracks[0].IssueIDs, want) {
+		t.Errorf("IssueIDs = %v, want %v", plan.Tracks[0].IssueIDs, want)
+	}
+}
+
+func TestBuildParallelTracks_CyclesReported(t *testing.T) {
+	done := blockedIssue("done")
+	done.Status = model.StatusClosed
+	issues := []model.Issue{
+		blockedIssue("A", "B"),
+		blockedIssue("B", "A"),
+		blockedIssue("C", "A"),
+		blockedIssue("D", "done"),
+		done,
+	}
+	plan := analysis.BuildParallelTracks(issues, 2)
+
+	if len(plan.Cycles) != 1 {
+		t.Errorf("expected 1 cycle, got %v", plan.Cycles)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(plan.Unscheduled, want) {
+		t.Errorf("Unscheduled = %v, want %v", plan.Unscheduled, want)
+	}
+	if want := map[string]int{"D": 0}; !reflect.DeepEqual(plan.Rounds, want) {
+		t.Errorf("Rounds = %v, want %v", plan.Rounds, want)
+	}
+	if len(plan.Tracks) != 1 || plan.Tracks[0].IssueIDs[0] != "D" {
+		t.Errorf("only D should be scheduled, got %+v", plan.Tracks)
+	}
+}
+
+func TestBuildParallelTracks_Empty(t *testing.T) {
+	plan := analysis.BuildParallelTracks(nil, 3)
+	if len(plan.Tracks) != 0 || len(plan.Rounds) != 0 || plan.CriticalPath != nil {
+		t.Errorf("expected empty plan, got %+v", plan)
+	}
+}
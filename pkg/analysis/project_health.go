@@ -0,0 +1,127 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ProjectHealthScore is a single top-line health score for the whole
+// project, rolled up from label health (e.g. for a status badge). Unlike the
+// triage ProjectHealth, which reports raw counts, it is a verdict.
+type ProjectHealthScore struct {
+	Health      int    `json:"health"`       // Composite score 0-100
+	HealthLevel string `json:"health_level"` // healthy, warning, critical or insufficient
+
+	// Issue-weighted averages over the scored labels
+	LabelScore     int `json:"label_score"`
+	VelocityScore  int `json:"velocity_score"`
+	FreshnessScore int `json:"freshness_score"`
+
+	// CriticalShare is the fraction of labeled issues in critical labels
+	CriticalShare float64 `json:"critical_share"`
+
+	// BlockedRatio is blocked over open issues, summed across labels
+	BlockedRatio float64 `json:"blocked_ratio"`
+
+	CycleCount   int      `json:"cycle_count"`
+	LabelsScored int      `json:"labels_scored"`     // Labels with enough issues to score
+	Reasons      []string `json:"reasons,omitempty"` // What is pulling the score down
+}
+
+// Project health weights. The components sum to 1; the penalties are taken
+// off afterwards.
+const (
+	projectLabelWeight     = 0.5
+	projectVelocityWeight  = 0.2
+	projectFreshnessWeight = 0.2
+	projectFlowWeight      = 0.1
+
+	projectCriticalPenalty = 20 // Points off when every labeled issue is in a critical label
+	projectCyclePenalty    = 10 // Points off when the graph has any cycle
+)
+
+// Thresholds for listing a component in ProjectHealthScore.Reasons
+const (
+	projectLowScore        = WarningThreshold
+	projectHighBlockedRate = 0.3
+)
+
+// ComputeProjectHealth rolls a label analysis up into one project score.
+// Each label counts in proportion to its issue count, so one large critical
+// label outweighs several small healthy ones. Labels too small to score are
+// left out; with none left the level is HealthLevelInsufficient.
+func ComputeProjectHealth(result LabelAnalysisResult) ProjectHealthScore {
+	ph := ProjectHealthScore{CycleCount: result.CycleCount}
+
+	var totalWeight, healthSum, criticalWeight float64
+	var velocityWeight, velocitySum, freshnessSum float64
+	var open, blocked int
+	var critical []string
+	for _, h := range result.Labels {
+		if h.Insufficient || h.IssueCount == 0 {
+			continue
+		}
+		w := float64(h.IssueCount)
+		ph.LabelsScored++
+		totalWeight += w
+		healthSum += w * float64(h.Health)
+		freshnessSum += w * float64(h.Freshness.FreshnessScore)
+		if !h.Velocity.Excluded {
+			velocityWeight += w
+			velocitySum += w * float64(h.Velocity.VelocityScore)
+		}
+		if h.HealthLevel == HealthLevelCritical {
+			criticalWeight += w
+			critical = append(critical, h.Label)
+		}
+		open += h.OpenCount
+		blocked += h.Blocked
+	}
+
+	if ph.LabelsScored == 0 {
+		ph.HealthLevel = HealthLevelInsufficient
+		ph.Reasons = []string{"no labels with enough issues to score"}
+		return ph
+	}
+
+	ph.LabelScore = int(math.Round(healthSum / totalWeight))
+	ph.FreshnessScore = int(math.Round(freshnessSum / totalWeight))
+	ph.VelocityScore = ph.LabelScore // Every label excluded: velocity neither helps nor hurts
+	if velocityWeight > 0 {
+		ph.VelocityScore = int(math.Round(velocitySum / velocityWeight))
+	}
+	ph.CriticalShare = criticalWeight / totalWeight
+	if open > 0 {
+		ph.BlockedRatio = float64(blocked) / float64(open)
+	}
+
+	score := projectLabelWeight*float64(ph.LabelScore) +
+		projectVelocityWeight*float64(ph.VelocityScore) +
+		projectFreshnessWeight*float64(ph.FreshnessScore) +
+		projectFlowWeight*100*(1-math.Min(ph.BlockedRatio, 1))
+	score -= projectCriticalPenalty * ph.CriticalShare
+	if ph.CycleCount > 0 {
+		score -= projectCyclePenalty
+	}
+	ph.Health = clampScore(int(math.Round(score)))
+	ph.HealthLevel = HealthLevelFromScore(ph.Health)
+
+	if len(critical) > 0 {
+		ph.Reasons = append(ph.Reasons, fmt.Sprintf("%d critical %s holding %.0f%% of labeled issues: %s",
+			len(critical), pluralize(len(critical), "label"), ph.CriticalShare*100, strings.Join(critical, ", ")))
+	}
+	if ph.VelocityScore < projectLowScore && velocityWeight > 0 {
+		ph.Reasons = append(ph.Reasons, fmt.Sprintf("low velocity (score %d)", ph.VelocityScore))
+	}
+	if ph.FreshnessScore < projectLowScore {
+		ph.Reasons = append(ph.Reasons, fmt.Sprintf("stale work (freshness score %d)", ph.FreshnessScore))
+	}
+	if ph.BlockedRatio >= projectHighBlockedRate {
+		ph.Reasons = append(ph.Reasons, fmt.Sprintf("%.0f%% of open issues blocked", ph.BlockedRatio*100))
+	}
+	if ph.CycleCount > 0 {
+		ph.Reasons = append(ph.Reasons, fmt.Sprintf("%d dependency %s", ph.CycleCount, pluralize(ph.CycleCount, "cycle")))
+	}
+	return ph
+}
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// projectLabelIssues returns n issues for label: fresh with recent closures
+// when healthy, otherwise stale and blocked on the first of them
+func projectLabelIssues(label string, n int, healthy bool, now time.Time) []model.Issue {
+	var issues []model.Issue
+	for i := range n {
+		iss := model.Issue{
+			ID:        fmt.Sprintf("%s-%d", label, i),
+			Labels:    []string{label},
+			Status:    model.StatusOpen,
+			CreatedAt: now.AddDate(0, 0, -30),
+			UpdatedAt: now.AddDate(0, 0, -1),
+		}
+		switch {
+		case healthy && i%2 == 0:
+			closedAt := now.AddDate(0, 0, -i%7-1)
+			iss.Status, iss.ClosedAt = model.StatusClosed, &closedAt
+		case !healthy:
+			iss.CreatedAt, iss.UpdatedAt = now.AddDate(0, 0, -120), now.AddDate(0, 0, -90)
+			if i > 0 {
+				iss.Status = model.StatusBlocked
+				iss.Dependencies = []*model.Dependency{{DependsOnID: fmt.Sprintf("%s-0", label), Type: model.DepBlocks}}
+			}
+		}
+		issues = append(issues, iss)
+	}
+	return issues
+}
+
+func TestComputeProjectHealth_WeightsLargeLabels(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	cfg := DefaultLabelHealthConfig()
+
+	// Mostly healthy: two large healthy labels and one small critical one
+	var good []model.Issue
+	good = append(good, projectLabelIssues("api", 20, true, now)...)
+	good = append(good, projectLabelIssues("web", 16, true, now)...)
+	good = append(good, projectLabelIssues("legacy", 3, false, now)...)
+	healthy := ComputeProjectHealth(ComputeAllLabelHealth(good, cfg, now, nil))
+
+	// A critical label holds most of the issues
+	var bad []model.Issue
+	bad = append(bad, projectLabelIssues("api", 3, true, now)...)
+	bad = append(bad, projectLabelIssues("core", 24, false, now)...)
+	unhealthy := ComputeProjectHealth(ComputeAllLabelHealth(bad, cfg, now, nil))
+
+	if healthy.HealthLevel != HealthLevelHealthy {
+		t.Errorf("mostly-healthy project scored %d (%s), want healthy; reasons %v", healthy.Health, healthy.HealthLevel, healthy.Reasons)
+	}
+	if unhealthy.HealthLevel != HealthLevelCritical {
+		t.Errorf("project with a large critical label scored %d (%s), want critical", unhealthy.Health, unhealthy.HealthLevel)
+	}
+	if healthy.Health-unhealthy.Health < 30 {
+		t.Errorf("healthy %d should be well above unhealthy %d", healthy.Health, unhealthy.Health)
+	}
+
+	if unhealthy.CriticalShare <= 0.8 || unhealthy.BlockedRatio <= 0.5 {
+		t.Errorf("unhealthy critical share %.2f, blocked ratio %.2f", unhealthy.CriticalShare, unhealthy.BlockedRatio)
+	}
+	if len(unhealthy.Reasons) == 0 || !strings.Contains(unhealthy.Reasons[0], "core") {
+		t.Errorf("reasons should name the critical label first: %v", unhealthy.Reasons)
+	}
+	if healthy.LabelsScored != 3 || unhealthy.LabelsScored != 2 {
+		t.Errorf("labels scored = %d and %d, want 3 and 2", healthy.LabelsScored, unhealthy.LabelsScored)
+	}
+}
+
+func TestComputeProjectHealth_IssueWeighting(t *testing.T) {
+	label := func(name string, issues, health int, level string) LabelHealth {
+		return LabelHealth{Label: name, IssueCount: issues, OpenCount: issues, Health: health, HealthLevel: level,
+			Velocity: VelocityMetrics{VelocityScore: health}, Freshness: FreshnessMetrics{FreshnessScore: health}}
+	}
+	big := label("big", 30, 90, HealthLevelHealthy)
+	small := label("small", 3, 20, HealthLevelCritical)
+	skipped := label("tiny", 1, 0, HealthLevelInsufficient)
+	skipped.Insufficient = true
+
+	got := ComputeProjectHealth(LabelAnalysisResult{Labels: []LabelHealth{big, small, skipped}})
+	if want := 84; got.LabelScore != want { // (30*90 + 3*20) / 33
+		t.Errorf("label score = %d, want issue-weighted %d", got.LabelScore, want)
+	}
+	flipped := ComputeProjectHealth(LabelAnalysisResult{Labels: []LabelHealth{
+		label("big", 30, 20, HealthLevelCritical), label("small", 3, 90, HealthLevelHealthy),
+	}})
+	if flipped.Health >= got.Health-40 {
+		t.Errorf("a large critical label scored %d, should be far below %d", flipped.Health, got.Health)
+	}
+
+	withCycle := ComputeProjectHealth(LabelAnalysisResult{Labels: []LabelHealth{big, small}, CycleCount: 2})
+	if withCycle.Health != got.Health-projectCyclePenalty {
+		t.Errorf("cycles: health %d, want %d", withCycle.Health, got.Health-projectCyclePenalty)
+	}
+	if r := withCycle.Reasons; len(r) == 0 || r[len(r)-1] != "2 dependency cycles" {
+		t.Errorf("reasons should mention the cycles: %v", r)
+	}
+}
+
+func TestComputeProjectHealth_NothingToScore(t *testing.T) {
+	got := ComputeProjectHealth(LabelAnalysisResult{})
+	if got.HealthLevel != HealthLevelInsufficient || got.Health != 0 || len(got.Reasons) != 1 {
+		t.Errorf("empty analysis = %+v, want insufficient", got)
+	}
+}
@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ReadyIssues returns the open and in-progress GetActionableIssues, i.e. the
+// work that can start right now. Only blocking dependency types count;
+// related and discovered-from links never hold an issue back, but a blocked
+// parent holds back its children.
+// Sorted by priority (P0 first), then PageRank descending, then ID.
+//
+// PageRank is read from stats when the caller already has a completed
+// analysis; with nil (or Phase 2 still running) it is computed here.
+func (a *Analyzer) ReadyIssues(stats *GraphStats) []model.Issue {
+	var ready []model.Issue
+	for _, issue := range a.issueMap {
+		if a.isReady(issue) {
+			ready = append(ready, issue)
+		}
+	}
+	if len(ready) == 0 {
+		return nil
+	}
+
+	var pr map[string]float64
+	if stats != nil && stats.IsPhase2Ready() {
+		pr = stats.PageRank()
+	} else {
+		pr = make(map[string]float64, len(a.nodeToID))
+		for nid, rank := range computePageRank(a.g, a.options.DampingFactor, a.options.Tolerance, a.options.MaxIterations) {
+			pr[a.nodeToID[nid]] = rank
+		}
+	}
+
+	sort.Slice(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		if pr[ready[i].ID] != pr[ready[j].ID] {
+			return pr[ready[i].ID] > pr[ready[j].ID]
+		}
+		return ready[i].ID < ready[j].ID
+	})
+	return ready
+}
+
+// IsReady reports whether id is one of the ReadyIssues, without computing
+// the ranking. Unknown IDs are not ready.
+func (a *Analyzer) IsReady(id string) bool {
+	issue, ok := a.issueMap[id]
+	return ok && a.isReady(issue)
+}
+
+// ActionableCount returns the number of ReadyIssues without sorting them.
+func (a *Analyzer) ActionableCount() int {
+	count := 0
+	for _, issue := range a.issueMap {
+		if a.isReady(issue) {
+			count++
+		}
+	}
+	return count
+}
+
+// IsBlocked reports whether an issue that is not closed has at least one
+// open blocking dependency, or sits under a blocked parent. This is the
+// complement of GetActionableIssues among non-closed issues. Unknown IDs are
+// not blocked.
+func (a *Analyzer) IsBlocked(id string) bool {
+	return a.blockedSet()[id]
+}
+
+// BlockedCount returns the number of issues for which IsBlocked holds,
+// whatever their status field says.
+func (a *Analyzer) BlockedCount() int {
+	count := 0
+	for id := range a.issueMap {
+		if a.IsBlocked(id) {
+			count++
+		}
+	}
+	return count
+}
+
+// RootBlockers returns the open issues that ultimately hold up id: its
+// transitive open blockers that have no open blockers themselves, i.e. the
+// frontier that has to move first. Sorted by ID; empty when id is unknown or
+// not blocked. Cycles are cut at already-visited issues, so blockers that
+// only wait on each other yield no root.
+func (a *Analyzer) RootBlockers(id string) []string {
+	roots := []string{}
+	visited := map[string]bool{id: true}
+	stack := a.GetOpenBlockers(id)
+	for len(stack) > 0 {
+		blocker := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[blocker] {
+			continue
+		}
+		visited[blocker] = true
+
+		next := a.GetOpenBlockers(blocker)
+		if len(next) == 0 {
+			roots = append(roots, blocker)
+			continue
+		}
+		stack = append(stack, next...)
+	}
+	sort.Strings(roots)
+	return roots
+}
+
+// isReady narrows GetActionableIssues to open and in-progress work, so
+// "actionable" has a single blocking rule (including blocked parents).
+func (a *Analyzer) isReady(issue model.Issue) bool {
+	if issue.Status != model.StatusOpen && issue.Status != model.StatusInProgress {
+		return false
+	}
+	return !a.blockedSet()[issue.ID]
+}
+
+// ReadyByLabel groups ReadyIssues IDs by label, keeping ReadyIssues order
+// within each label. Unlabeled issues are omitted. stats is passed through
+// to ReadyIssues and may be nil.
+func (a *Analyzer) ReadyByLabel(stats *GraphStats) map[string][]string {
+	byLabel := make(map[string][]string)
+	for _, issue := range a.ReadyIssues(stats) {
+		for _, label := range issue.Labels {
+			byLabel[label] = append(byLabel[label], issue.ID)
+		}
+	}
+	return byLabel
+}
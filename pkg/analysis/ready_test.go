@@ -0,0 +1,249 @@
+package analysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestReadyIssues_UnblockedFrontier(t *testing.T) {
+	// A (closed) <- B <- C <- D: only B is unblocked
+	a := blockedIssue("A")
+	a.Status = model.StatusClosed
+	issues := []model.Issue{
+		a,
+		blockedIssue("B", "A"),
+		blockedIssue("C", "B"),
+		blockedIssue("D", "C"),
+	}
+
+	got := getIDs(analysis.NewAnalyzer(issues).ReadyIssues(nil))
+	if want := []string{"B"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadyIssues() = %v, want %v", got, want)
+	}
+}
+
+func TestReadyIssues_StatusAndRelatedEdges(t *testing.T) {
+	related := blockedIssue("R")
+	related.Dependencies = []*model.Dependency{{IssueID: "R", DependsOnID: "O", Type: model.DepRelated}}
+	inProgress := blockedIssue("P")
+	inProgress.Status = model.StatusInProgress
+	deferred := blockedIssue("F")
+	deferred.Status = model.StatusDeferred
+	closed := blockedIssue("X")
+	closed.Status = model.StatusClosed
+
+	issues := []model.Issue{blockedIssue("O"), related, inProgress, deferred, closed}
+	got := getIDs(analysis.NewAnalyzer(issues).ReadyIssues(nil))
+	if want := []string{"O", "P", "R"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadyIssues() = %v, want %v", got, want)
+	}
+}
+
+func TestReadyIssues_SortedByPriorityThenPageRank(t *testing.T) {
+	hub := blockedIssue("hub")
+	hub.Priority = 2
+	leaf := blockedIssue("leaf")
+	leaf.Priority = 2
+	urgent := blockedIssue("urgent")
+	urgent.Priority = 0
+	issues := []model.Issue{
+		leaf, hub, urgent,
+		blockedIssue("w1", "hub"),
+		blockedIssue("w2", "hub"),
+	}
+	for i := range issues[3:] {
+		issues[3+i].Priority = 3
+	}
+
+	an := analysis.NewAnalyzer(issues)
+	stats := an.Analyze()
+	want := []string{"urgent", "hub", "leaf"}
+	// Precomputed stats must give the same order as computing PageRank here
+	for name, s := range map[string]*analysis.GraphStats{"nil": nil, "stats": &stats} {
+		var got []string
+		for _, issue := range an.ReadyIssues(s) {
+			got = append(got, issue.ID)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ReadyIssues(%s) order = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestReadyByLabel(t *testing.T) {
+	b := blockedIssue("B")
+	b.Labels = []string{"api", "backend"}
+	c := blockedIssue("C", "B")
+	c.Labels = []string{"api"}
+	d := blockedIssue("D")
+	d.Labels = []string{"ui"}
+	issues := []model.Issue{b, c, d, blockedIssue("E")}
+
+	got := analysis.NewAnalyzer(issues).ReadyByLabel(nil)
+	want := map[string][]string{
+		"api":     {"B"},
+		"backend": {"B"},
+		"ui":      {"D"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadyByLabel() = %v, want %v", got, want)
+	}
+}
+
+func TestActionableAndBlockedCounts_Chain(t *testing.T) {
+	// Two chains, E <- F <- G and H <- I, plus an issue with only a related link
+	related := blockedIssue("R")
+	related.Dependencies = []*model.Dependency{{IssueID: "R", DependsOnID: "G", Type: model.DepRelated}}
+	statusBlocked := blockedIssue("S", "H")
+	statusBlocked.Status = model.StatusBlocked
+	issues := []model.Issue{
+		blockedIssue("E"),
+		blockedIssue("F", "E"),
+		blockedIssue("G", "F"),
+		blockedIssue("H"),
+		blockedIssue("I", "H"),
+		statusBlocked,
+		related,
+	}
+	an := analysis.NewAnalyzer(issues)
+
+	// Frontier: E, H and R are actionable; F, G, I and S are blocked
+	if got := an.ActionableCount(); got != 3 {
+		t.Errorf("ActionableCount() = %d, want 3", got)
+	}
+	if got, want := an.ActionableCount(), len(an.ReadyIssues(nil)); got != want {
+		t.Errorf("ActionableCount() = %d, len(ReadyIssues()) = %d", got, want)
+	}
+	if got := an.BlockedCount(); got != 4 {
+		t.Errorf("BlockedCount() = %d, want 4", got)
+	}
+	for id, want := range map[string]bool{"E": false, "F": true, "S": true, "R": false, "missing": false} {
+		if got := an.IsBlocked(id); got != want {
+			t.Errorf("IsBlocked(%q) = %v, want %v", id, got, want)
+		}
+	}
+
+	// Closing the frontier moves it one step down each chain
+	for i := range issues {
+		if issues[i].ID == "E" || issues[i].ID == "H" {
+			issues[i].Status = model.StatusClosed
+		}
+	}
+	an = analysis.NewAnalyzer(issues)
+	if got := an.ActionableCount(); got != 3 { // F, I, R; S keeps its blocked status
+		t.Errorf("after closing frontier: ActionableCount() = %d, want 3", got)
+	}
+	if got := an.BlockedCount(); got != 1 { // G
+		t.Errorf("after closing frontier: BlockedCount() = %d, want 1", got)
+	}
+}
+
+func TestReadyIssues_BlockedParentHoldsBackChildren(t *testing.T) {
+	// P waits on B, so its child C is blocked too; D's parent Q is free
+	child := func(id, parent string) model.Issue {
+		issue := blockedIssue(id)
+		issue.Dependencies = []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}
+		return issue
+	}
+	deferred := blockedIssue("F")
+	deferred.Status = model.StatusDeferred
+	issues := []model.Issue{
+		blockedIssue("B"),
+		blockedIssue("P", "B"),
+		child("C", "P"),
+		blockedIssue("Q"),
+		child("D", "Q"),
+		deferred,
+	}
+	an := analysis.NewAnalyzer(issues)
+
+	if got, want := getIDs(an.ReadyIssues(nil)), []string{"B", "D", "Q"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadyIssues() = %v, want %v", got, want)
+	}
+	if !an.IsBlocked("C") {
+		t.Error("IsBlocked(C) = false, want true under a blocked parent")
+	}
+	if got := an.BlockedCount(); got != 2 {
+		t.Errorf("BlockedCount() = %d, want 2 (P and C)", got)
+	}
+
+	// ReadyIssues is GetActionableIssues narrowed to open and in-progress
+	var actionable []string
+	for _, issue := range an.GetActionableIssues() {
+		if issue.Status == model.StatusOpen || issue.Status == model.StatusInProgress {
+			actionable = append(actionable, issue.ID)
+		}
+	}
+	if !reflect.DeepEqual(actionable, getIDs(an.ReadyIssues(nil))) {
+		t.Errorf("GetActionableIssues (open) = %v, ReadyIssues = %v", actionable, getIDs(an.ReadyIssues(nil)))
+	}
+}
+
+func TestRootBlockers_Chain(t *testing.T) {
+	// A <- B <- C: C waits on B, which waits on A
+	an := analysis.NewAnalyzer([]model.Issue{
+		blockedIssue("A"),
+		blockedIssue("B", "A"),
+		blockedIssue("C", "B"),
+	})
+
+	if got := an.RootBlockers("C"); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("RootBlockers(C) = %v, want [A]", got)
+	}
+	if got := an.RootBlockers("B"); !reflect.DeepEqual(got, []string{"A"}) {
+		t.Errorf("RootBlockers(B) = %v, want [A]", got)
+	}
+	// Unblocked and unknown issues have no root blockers
+	for _, id := range []string{"A", "MISSING"} {
+		if got := an.RootBlockers(id); len(got) != 0 {
+			t.Errorf("RootBlockers(%s) = %v, want none", id, got)
+		}
+	}
+}
+
+func TestRootBlockers_Diamond(t *testing.T) {
+	// R1 and R2 both feed L and M, which both block the tail T; R1 is reached
+	// along several paths but reported once. Closed X is not a blocker.
+	x := blockedIssue("X")
+	x.Status = model.StatusClosed
+	an := analysis.NewAnalyzer([]model.Issue{
+		blockedIssue("R2"),
+		blockedIssue("R1"),
+		x,
+		blockedIssue("L", "R1", "R2"),
+		blockedIssue("M", "R1", "X"),
+		blockedIssue("T", "L", "M"),
+	})
+
+	if got := an.RootBlockers("T"); !reflect.DeepEqual(got, []string{"R1", "R2"}) {
+		t.Errorf("RootBlockers(T) = %v, want [R1 R2]", got)
+	}
+	if got := an.RootBlockers("M"); !reflect.DeepEqual(got, []string{"R1"}) {
+		t.Errorf("RootBlockers(M) = %v, want [R1]", got)
+	}
+}
+
+func TestRootBlockers_Cycle(t *testing.T) {
+	// T waits on A; A and B block each other, and B also waits on root R
+	an := analysis.NewAnalyzer([]model.Issue{
+		blockedIssue("R"),
+		blockedIssue("A", "B"),
+		blockedIssue("B", "A", "R"),
+		blockedIssue("T", "A"),
+	})
+	if got := an.RootBlockers("T"); !reflect.DeepEqual(got, []string{"R"}) {
+		t.Errorf("RootBlockers(T) = %v, want [R]", got)
+	}
+
+	// A pure cycle has no frontier to move first
+	an = analysis.NewAnalyzer([]model.Issue{
+		blockedIssue("A", "B"),
+		blockedIssue("B", "A"),
+	})
+	if got := an.RootBlockers("A"); len(got) != 0 {
+		t.Errorf("RootBlockers(A) in a pure cycle = %v, want none", got)
+	}
+}
@@ -0,0 +1,160 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SubgraphBoundary controls what happens to edges between a subgraph's issues
+// and issues outside it.
+type SubgraphBoundary int
+
+const (
+	// SubgraphDropBoundary drops edges to outside issues (the induced subgraph)
+	SubgraphDropBoundary SubgraphBoundary = iota
+	// SubgraphKeepBoundary keeps issues one blocking edge away, in either
+	// direction, as boundary nodes. Only edges touching the set are kept, so
+	// boundary nodes never link to each other.
+	SubgraphKeepBoundary
+)
+
+// Subgraph returns an analyzer over the subgraph induced by issueIDs, for
+// computing centrality relative to just those issues. Unknown IDs are
+// ignored. The analyzer keeps this one's configuration and PageRank options.
+func (a *Analyzer) Subgraph(issueIDs []string) *Analyzer {
+	return a.SubgraphWithBoundary(issueIDs, SubgraphDropBoundary)
+}
+
+// SubgraphWithBoundary is Subgraph with control over edges leaving the set.
+func (a *Analyzer) SubgraphWithBoundary(issueIDs []string, boundary SubgraphBoundary) *Analyzer {
+	members := make(map[string]bool, len(issueIDs))
+	for _, id := range issueIDs {
+		if _, ok := a.issueMap[id]; ok {
+			members[id] = true
+		}
+	}
+
+	included := make(map[string]bool, len(members))
+	for id := range members {
+		included[id] = true
+	}
+	if boundary == SubgraphKeepBoundary {
+		for id := range members {
+			u := a.idToNode[id]
+			for from := a.g.From(u); from.Next(); {
+				included[a.nodeToID[from.Node().ID()]] = true
+			}
+			for to := a.g.To(u); to.Next(); {
+				included[a.nodeToID[to.Node().ID()]] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(included))
+	for id := range included {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	issues := make([]model.Issue, 0, len(ids))
+	for _, id := range ids {
+		issue := a.issueMap[id]
+		if !members[id] {
+			// Boundary nodes keep only their links into the set
+			var deps []*model.Dependency
+			for _, dep := range issue.Dependencies {
+				if dep != nil && members[dep.DependsOnID] {
+					deps = append(deps, dep)
+				}
+			}
+			issue.Dependencies = deps
+		}
+		issues = append(issues, issue)
+	}
+
+	sub := NewAnalyzer(issues)
+	sub.config = a.config
+	sub.options = a.options
+	return sub
+}
+
+// Subgraph is a slice of the dependency graph around one issue: the issues
+// within reach of it and the blocking edges among them.
+type Subgraph struct {
+	Root     string         `json:"root"`
+	Depth    int            `json:"depth"`    // Requested hop limit; negative means unlimited
+	Issues   []model.Issue  `json:"issues"`   // Sorted by ID, Root included
+	Distance map[string]int `json:"distance"` // Hops from Root to each issue
+	Edges    []SubgraphEdge `json:"edges"`    // Induced edges, sorted by From then To
+}
+
+// SubgraphEdge is a blocking dependency: From depends on To
+type SubgraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// FocusSubgraph returns the issues within depth hops of id, following
+// dependency edges in both directions, plus every edge between them. Depth 0
+// returns just the issue and a negative depth its whole connected component.
+// An unknown id yields an empty subgraph.
+func (a *Analyzer) FocusSubgraph(id string, depth int) Subgraph {
+	sub := Subgraph{
+		Root:     id,
+		Depth:    depth,
+		Issues:   []model.Issue{},
+		Distance: make(map[string]int),
+		Edges:    []SubgraphEdge{},
+	}
+	root, ok := a.idToNode[id]
+	if !ok {
+		return sub
+	}
+
+	// Breadth-first, so each issue's distance is its shortest hop count and
+	// cycles stop at already-visited nodes
+	sub.Distance[id] = 0
+	frontier := []int64{root}
+	for hops := 1; len(frontier) > 0 && (depth < 0 || hops <= depth); hops++ {
+		var next []int64
+		visit := func(n int64) {
+			nid := a.nodeToID[n]
+			if _, seen := sub.Distance[nid]; !seen {
+				sub.Distance[nid] = hops
+				next = append(next, n)
+			}
+		}
+		for _, u := range frontier {
+			for from := a.g.From(u); from.Next(); {
+				visit(from.Node().ID())
+			}
+			for to := a.g.To(u); to.Next(); {
+				visit(to.Node().ID())
+			}
+		}
+		frontier = next
+	}
+
+	ids := make([]string, 0, len(sub.Distance))
+	for nid := range sub.Distance {
+		ids = append(ids, nid)
+	}
+	sort.Strings(ids)
+	for _, nid := range ids {
+		sub.Issues = append(sub.Issues, a.issueMap[nid])
+		for to := a.g.From(a.idToNode[nid]); to.Next(); {
+			toID := a.nodeToID[to.Node().ID()]
+			if _, ok := sub.Distance[toID]; ok {
+				sub.Edges = append(sub.Edges, SubgraphEdge{From: nid, To: toID})
+			}
+		}
+	}
+	sort.Slice(sub.Edges, func(i, j int) bool {
+		if sub.Edges[i].From != sub.Edges[j].From {
+			return sub.Edges[i].From < sub.Edges[j].From
+		}
+		return sub.Edges[i].To < sub.Edges[j].To
+	})
+	return sub
+}
@@ -0,0 +1,189 @@
+package analysis_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// embeddedLabelIssues builds a "core" label (A, B, C with C depending on B)
+// inside a larger graph where five outside issues depend on A and A itself
+// depends on the outside issue BASE.
+func embeddedLabelIssues() []model.Issue {
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"core"}, Dependencies: blocks("BASE")},
+		{ID: "B", Status: model.StatusOpen, Labels: []string{"core"}},
+		{ID: "C", Status: model.StatusOpen, Labels: []string{"core"}, Dependencies: blocks("B")},
+		{ID: "BASE", Status: model.StatusOpen},
+	}
+	for i := 0; i < 5; i++ {
+		// Outside dependents of A; the first also depends on BASE
+		deps := blocks("A")
+		if i == 0 {
+			deps = blocks("A", "BASE")
+		}
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("EXT-%d", i), Status: model.StatusOpen, Dependencies: deps})
+	}
+	return issues
+}
+
+func TestAnalyzerSubgraph_PageRankDiffersFromGlobal(t *testing.T) {
+	analyzer := analysis.NewAnalyzer(embeddedLabelIssues())
+	global := analyzer.Analyze()
+	local := analyzer.Subgraph([]string{"A", "B", "C", "MISSING"}).Analyze()
+
+	if local.NodeCount != 3 || local.EdgeCount != 1 {
+		t.Fatalf("induced subgraph = %d nodes / %d edges, want 3 / 1", local.NodeCount, local.EdgeCount)
+	}
+	if _, ok := local.PageRankValue("EXT-0"); ok {
+		t.Error("outside issues should not be in the induced subgraph")
+	}
+
+	globalA, _ := global.PageRankValue("A")
+	globalB, _ := global.PageRankValue("B")
+	localA, _ := local.PageRankValue("A")
+	localB, _ := local.PageRankValue("B")
+	if globalA <= globalB {
+		t.Errorf("globally A (%.4f) should outrank B (%.4f): five outside issues depend on it", globalA, globalB)
+	}
+	if localB <= localA {
+		t.Errorf("within the label B (%.4f) should outrank A (%.4f)", localB, localA)
+	}
+}
+
+func TestAnalyzerSubgraph_BoundaryNodes(t *testing.T) {
+	analyzer := analysis.NewAnalyzer(embeddedLabelIssues())
+	stats := analyzer.SubgraphWithBoundary([]string{"A", "B", "C"}, analysis.SubgraphKeepBoundary).Analyze()
+
+	// A, B, C plus BASE and the five EXT dependents of A
+	if stats.NodeCount != 9 {
+		t.Errorf("NodeCount = %d, want 9", stats.NodeCount)
+	}
+	// C->B, A->BASE and EXT-i->A; EXT-0->BASE joins two boundary nodes and is dropped
+	if stats.EdgeCount != 7 {
+		t.Errorf("EdgeCount = %d, want 7", stats.EdgeCount)
+	}
+	if stats.OutDegree["EXT-0"] != 1 {
+		t.Errorf("boundary node EXT-0 should keep only its edge into the set, out-degree %d", stats.OutDegree["EXT-0"])
+	}
+}
+
+func TestComputeLabelHealthForLabel_SubgraphCentrality(t *testing.T) {
+	issues := embeddedLabelIssues()
+	now := time.Now()
+
+	cfg := analysis.DefaultLabelHealthConfig()
+	global := analysis.ComputeLabelHealthForLabel("core", issues, cfg, now, nil)
+
+	cfg.SubgraphCentrality = true
+	local := analysis.ComputeLabelHealthForLabel("core", issues, cfg, now, nil)
+
+	if global.Criticality.AvgPageRank == local.Criticality.AvgPageRank {
+		t.Errorf("subgraph centrality should change AvgPageRank, both %.4f", local.Criticality.AvgPageRank)
+	}
+	// Three issues share the whole subgraph's rank mass
+	if got := local.Criticality.AvgPageRank; got < 0.33 || got > 0.34 {
+		t.Errorf("subgraph AvgPageRank = %.4f, want ~1/3", got)
+	}
+	if local.IssueCount != 3 {
+		t.Errorf("IssueCount = %d, want 3", local.IssueCount)
+	}
+}
+
+// focusIssues builds hub H with dependents A, B and dependencies C, D; D
+// leads on through E to the cycle X <-> Y. Z is a separate component.
+func focusIssues() []model.Issue {
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	return []model.Issue{
+		{ID: "H", Status: model.StatusOpen, Dependencies: blocks("C", "D")},
+		{ID: "A", Status: model.StatusOpen, Dependencies: blocks("H")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blocks("H", "C")},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blocks("E")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: blocks("X")},
+		{ID: "X", Status: model.StatusOpen, Dependencies: blocks("Y")},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: blocks("X")},
+		{ID: "Z", Status: model.StatusOpen},
+	}
+}
+
+func focusIDs(sub analysis.Subgraph) []string {
+	ids := make([]string, len(sub.Issues))
+	for i, issue := range sub.Issues {
+		ids[i] = issue.ID
+	}
+	return ids
+}
+
+func TestFocusSubgraph_DepthOneIsImmediateNeighbors(t *testing.T) {
+	sub := analysis.NewAnalyzer(focusIssues()).FocusSubgraph("H", 1)
+
+	if got, want := focusIDs(sub), []string{"A", "B", "C", "D", "H"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %v, want %v", got, want)
+	}
+	// B->C joins two neighbors, so it is induced too
+	wantEdges := []analysis.SubgraphEdge{
+		{From: "A", To: "H"}, {From: "B", To: "C"}, {From: "B", To: "H"}, {From: "H", To: "C"}, {From: "H", To: "D"},
+	}
+	if !reflect.DeepEqual(sub.Edges, wantEdges) {
+		t.Errorf("edges = %v, want %v", sub.Edges, wantEdges)
+	}
+	if sub.Distance["H"] != 0 || sub.Distance["A"] != 1 || sub.Distance["D"] != 1 {
+		t.Errorf("unexpected distances %v", sub.Distance)
+	}
+}
+
+func TestFocusSubgraph_UnlimitedDepthIsComponent(t *testing.T) {
+	analyzer := analysis.NewAnalyzer(focusIssues())
+
+	want := []string{"A", "B", "C", "D", "E", "H", "X", "Y"}
+	for _, root := range []string{"H", "Y"} {
+		sub := analyzer.FocusSubgraph(root, -1)
+		if got := focusIDs(sub); !reflect.DeepEqual(got, want) {
+			t.Errorf("from %s: issues = %v, want %v", root, got, want)
+		}
+		if len(sub.Edges) != 9 {
+			t.Errorf("from %s: %d edges, want 9", root, len(sub.Edges))
+		}
+	}
+	if d := analyzer.FocusSubgraph("H", -1).Distance["Y"]; d != 4 {
+		t.Errorf("distance H->Y = %d, want 4", d)
+	}
+}
+
+func TestFocusSubgraph_DepthZeroAndUnknown(t *testing.T) {
+	analyzer := analysis.NewAnalyzer(focusIssues())
+
+	sub := analyzer.FocusSubgraph("H", 0)
+	if got := focusIDs(sub); !reflect.DeepEqual(got, []string{"H"}) || len(sub.Edges) != 0 {
+		t.Errorf("depth 0 = %v with %d edges, want just H", got, len(sub.Edges))
+	}
+
+	// Starting inside the X <-> Y cycle still terminates
+	sub = analyzer.FocusSubgraph("X", 5)
+	if got := focusIDs(sub); !reflect.DeepEqual(got, []string{"A", "B", "C", "D", "E", "H", "X", "Y"}) {
+		t.Errorf("depth 5 from X = %v", got)
+	}
+
+	sub = analyzer.FocusSubgraph("MISSING", -1)
+	if len(sub.Issues) != 0 || len(sub.Edges) != 0 {
+		t.Errorf("unknown id should give an empty subgraph, got %+v", sub)
+	}
+}
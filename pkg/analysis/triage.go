@@ -331,6 +331,15 @@ func ComputeTriageWithOptions(issues []model.Issue, opts TriageOptions) TriageRe
 
 // ComputeTriageWithOptionsAndTime generates triage with a deterministic clock (testing).
 func ComputeTriageWithOptionsAndTime(issues []model.Issue, opts TriageOptions, now time.Time) TriageResult {
+	analyzer, stats := AnalyzeForTriage(issues, opts)
+	return ComputeTriageFromAnalyzer(analyzer, stats, issues, opts, now)
+}
+
+// AnalyzeForTriage builds the analyzer and stats that triage scoring needs,
+// honoring opts.UseFastConfig and opts.WaitForPhase2. Callers that need more
+// than the TriageResult (e.g. UnblockImpact for the top pick) can pass the
+// results to ComputeTriageFromAnalyzer and keep using the analyzer.
+func AnalyzeForTriage(issues []model.Issue, opts TriageOptions) (*Analyzer, *GraphStats) {
 	// Build analyzer and stats
 	analyzer := NewAnalyzer(issues)
 
@@ -363,7 +372,7 @@ func ComputeTriageWithOptionsAndTime(issues []model.Issue, opts TriageOptions, n
 		stats.WaitForPhase2()
 	}
 
-	return ComputeTriageFromAnalyzer(analyzer, stats, issues, opts, now)
+	return analyzer, stats
 }
 
 // ComputeTriageFromAnalyzer generates triage reusing an existing analyzer and stats.
@@ -617,12 +626,21 @@ func buildUnblocksMap(analyzer *Analyzer) map[string][]string {
 	return unblocksMap
 }
 
+// CountByStatus tallies issues by status, keyed by the status string
+func CountByStatus(issues []model.Issue) map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[string(issue.Status)]++
+	}
+	return counts
+}
+
 // computeCounts tallies issues by various dimensions
 // Deprecated: Use computeCountsWithContext for better performance via caching.
 func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 	counts := HealthCounts{
 		Total:      len(issues),
-		ByStatus:   make(map[string]int),
+		ByStatus:   CountByStatus(issues),
 		ByType:     make(map[string]int),
 		ByPriority: make(map[int]int),
 	}
@@ -634,7 +652,6 @@ func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 	}
 
 	for _, issue := range issues {
-		counts.ByStatus[string(issue.Status)]++
 		counts.ByType[string(issue.IssueType)]++
 		counts.ByPriority[issue.Priority]++
 
@@ -658,13 +675,12 @@ func computeCounts(issues []model.Issue, analyzer *Analyzer) HealthCounts {
 func computeCountsWithContext(issues []model.Issue, ctx *TriageContext) HealthCounts {
 	counts := HealthCounts{
 		Total:      len(issues),
-		ByStatus:   make(map[string]int),
+		ByStatus:   CountByStatus(issues),
 		ByType:     make(map[string]int),
 		ByPriority: make(map[int]int),
 	}
 
 	for _, issue := range issues {
-		counts.ByStatus[string(issue.Status)]++
 		counts.ByType[string(issue.IssueType)]++
 		counts.ByPriority[issue.Priority]++
 
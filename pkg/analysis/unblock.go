@@ -0,0 +1,62 @@
+package analysis
+
+import "sort"
+
+// UnblockCandidate is an open issue ranked by how much blocked work closing
+// it would release.
+type UnblockCandidate struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Priority int      `json:"priority"`
+	Impact   int      `json:"impact"`   // Issues that become actionable, including cascades
+	Unblocks []string `json:"unblocks"` // Direct dependents that become actionable
+}
+
+// UnblockImpact returns how many currently blocked issues would become
+// actionable if issueID closed. Closing it can cascade: a dependent whose
+// last open blocker was issueID is treated as done in turn, so a chain
+// behind it counts in full. A dependent that is also blocked by another
+// open issue stays blocked and is not counted. Closed or unknown issues
+// have no impact.
+func (a *Analyzer) UnblockImpact(issueID string) int {
+	issue, ok := a.issueMap[issueID]
+	if !ok || isClosedLikeStatus(issue.Status) {
+		return 0
+	}
+	return a.countTransitiveUnblocks(issueID)
+}
+
+// TopUnblockers returns the n open issues with the highest UnblockImpact,
+// highest first and ties broken by ID. Issues that unblock nothing are
+// omitted; n <= 0 returns every candidate.
+func (a *Analyzer) TopUnblockers(n int) []UnblockCandidate {
+	var candidates []UnblockCandidate
+	for id, issue := range a.issueMap {
+		if isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		impact := a.countTransitiveUnblocks(id)
+		if impact == 0 {
+			continue
+		}
+		candidates = append(candidates, UnblockCandidate{
+			ID:       id,
+			Title:    issue.Title,
+			Priority: issue.Priority,
+			Impact:   impact,
+			Unblocks: a.computeUnblocks(id),
+		})
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Impact != candidates[j].Impact {
+			return candidates[i].Impact > candidates[j].Impact
+		}
+		return candidates[i].ID < candidates[j].ID
+	})
+
+	if n > 0 && len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
@@ -0,0 +1,96 @@
+package analysis_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// fanOutIssues builds a hub that blocks five leaves. LEAF-0 is also blocked
+// by OTHER, and LEAF-1 blocks a two-issue chain.
+func fanOutIssues() []model.Issue {
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	issues := []model.Issue{
+		{ID: "HUB", Title: "Hub", Status: model.StatusOpen},
+		{ID: "OTHER", Title: "Other", Status: model.StatusOpen},
+		{ID: "CHAIN-1", Status: model.StatusOpen, Dependencies: blocks("LEAF-1")},
+		{ID: "CHAIN-2", Status: model.StatusOpen, Dependencies: blocks("CHAIN-1")},
+		{ID: "DONE", Status: model.StatusClosed, Dependencies: blocks("HUB")},
+	}
+	for i := 0; i < 5; i++ {
+		deps := blocks("HUB")
+		if i == 0 {
+			deps = blocks("HUB", "OTHER")
+		}
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("LEAF-%d", i), Status: model.StatusOpen, Dependencies: deps})
+	}
+	return issues
+}
+
+func TestAnalyzerUnblockImpact_FanOut(t *testing.T) {
+	an := analysis.NewAnalyzer(fanOutIssues())
+
+	tests := []struct {
+		id   string
+		want int
+	}{
+		// LEAF-1..4 plus the chain behind LEAF-1; LEAF-0 still waits on OTHER
+		// and the closed DONE needs no unblocking
+		{"HUB", 6},
+		{"OTHER", 0},
+		{"LEAF-1", 2},
+		{"CHAIN-2", 0},
+		{"DONE", 0},
+		{"MISSING", 0},
+	}
+	for _, tt := range tests {
+		if got := an.UnblockImpact(tt.id); got != tt.want {
+			t.Errorf("UnblockImpact(%q) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestAnalyzerUnblockImpact_SharedBlockerClosed(t *testing.T) {
+	issues := fanOutIssues()
+	for i := range issues {
+		if issues[i].ID == "OTHER" {
+			issues[i].Status = model.StatusClosed
+		}
+	}
+	if got := analysis.NewAnalyzer(issues).UnblockImpact("HUB"); got != 7 {
+		t.Errorf("with OTHER closed, HUB should also release LEAF-0: impact %d, want 7", got)
+	}
+}
+
+func TestAnalyzerTopUnblockers(t *testing.T) {
+	an := analysis.NewAnalyzer(fanOutIssues())
+
+	top := an.TopUnblockers(2)
+	if len(top) != 2 {
+		t.Fatalf("TopUnblockers(2) returned %d candidates", len(top))
+	}
+	hub := top[0]
+	if hub.ID != "HUB" || hub.Impact != 6 || hub.Title != "Hub" {
+		t.Errorf("top candidate = %+v, want HUB with impact 6", hub)
+	}
+	if want := []string{"LEAF-1", "LEAF-2", "LEAF-3", "LEAF-4"}; !reflect.DeepEqual(hub.Unblocks, want) {
+		t.Errorf("HUB direct unblocks = %v, want %v", hub.Unblocks, want)
+	}
+	if top[1].ID != "LEAF-1" || top[1].Impact != 2 {
+		t.Errorf("second candidate = %+v, want LEAF-1 with impact 2", top[1])
+	}
+
+	// HUB, LEAF-1 and CHAIN-1 unblock something; nothing else does
+	if all := an.TopUnblockers(0); len(all) != 3 || all[2].ID != "CHAIN-1" {
+		t.Errorf("TopUnblockers(0) = %+v, want HUB, LEAF-1, CHAIN-1", all)
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // Baseline represents a snapshot of project metrics at a point in time
@@ -36,11 +38,88 @@ type Baseline struct {
 	// Stats contains the graph statistics snapshot
 	Stats GraphStats `json:"stats"`
 
+	// StatsVersion records how the blocked and actionable counts in Stats
+	// and LabelStats were computed (see CurrentStatsVersion). Zero in
+	// baselines saved before it was added.
+	StatsVersion int `json:"stats_version,omitempty"`
+
 	// TopMetrics contains top-N items for key metrics
 	TopMetrics TopMetrics `json:"top_metrics"`
 
 	// Cycles stores detected cycles
 	Cycles [][]string `json:"cycles,omitempty"`
+
+	// LabelStats holds graph statistics for each label's subgraph
+	LabelStats map[string]GraphStats `json:"label_stats,omitempty"`
+
+	// LabelHealth holds each label's composite health score. Empty in
+	// baselines saved before label health drift was added.
+	LabelHealth map[string]LabelHealthSummary `json:"label_health,omitempty"`
+
+	// Issues is the issue set the baseline was taken from, trimmed to what
+	// "what changed" diffs need. Empty in baselines saved before it was added.
+	Issues []IssueSnapshot `json:"issues,omitempty"`
+}
+
+// IssueSnapshot is the part of an issue a baseline keeps: enough to diff
+// status, labels and blocking dependencies, without titles, descriptions
+// or comments.
+type IssueSnapshot struct {
+	ID        string       `json:"id"`
+	Status    model.Status `json:"status"`
+	Labels    []string     `json:"labels,omitempty"`
+	BlockedBy []string     `json:"blocked_by,omitempty"` // Targets of blocking dependencies
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	ClosedAt  *time.Time   `json:"closed_at,omitempty"`
+}
+
+// SnapshotIssues trims issues down to the fields stored in a baseline
+func SnapshotIssues(issues []model.Issue) []IssueSnapshot {
+	if len(issues) == 0 {
+		return nil
+	}
+	snaps := make([]IssueSnapshot, len(issues))
+	for i := range issues {
+		iss := &issues[i]
+		snap := IssueSnapshot{
+			ID:        iss.ID,
+			Status:    iss.Status,
+			Labels:    iss.Labels,
+			CreatedAt: iss.CreatedAt,
+			UpdatedAt: iss.UpdatedAt,
+			ClosedAt:  iss.ClosedAt,
+		}
+		for _, dep := range iss.BlockingDependencies() {
+			snap.BlockedBy = append(snap.BlockedBy, dep.DependsOnID)
+		}
+		snaps[i] = snap
+	}
+	return snaps
+}
+
+// IssueList rebuilds the stored issues as model.Issue values carrying only
+// the snapshot fields, with BlockedBy as "blocks" dependencies
+func (b *Baseline) IssueList() []model.Issue {
+	if len(b.Issues) == 0 {
+		return nil
+	}
+	issues := make([]model.Issue, len(b.Issues))
+	for i, snap := range b.Issues {
+		iss := model.Issue{
+			ID:        snap.ID,
+			Status:    snap.Status,
+			Labels:    snap.Labels,
+			CreatedAt: snap.CreatedAt,
+			UpdatedAt: snap.UpdatedAt,
+			ClosedAt:  snap.ClosedAt,
+		}
+		for _, id := range snap.BlockedBy {
+			iss.Dependencies = append(iss.Dependencies, &model.Dependency{IssueID: snap.ID, DependsOnID: id, Type: model.DepBlocks})
+		}
+		issues[i] = iss
+	}
+	return issues
 }
 
 // GraphStats contains basic graph statistics
@@ -53,6 +132,23 @@ type GraphStats struct {
 	BlockedCount    int     `json:"blocked_count"`
 	CycleCount      int     `json:"cycle_count"`
 	ActionableCount int     `json:"actionable_count"`
+
+	// ClosedPerWeek is the average closure velocity over the last 30 days.
+	// Zero in baselines saved before velocity tracking was added.
+	ClosedPerWeek float64 `json:"closed_per_week,omitempty"`
+
+	// Live graph: non-closed issues and the blocking edges between them.
+	// Zero in baselines saved before live tracking was added.
+	LiveNodeCount int     `json:"live_node_count,omitempty"`
+	LiveEdgeCount int     `json:"live_edge_count,omitempty"`
+	LiveDensity   float64 `json:"live_density,omitempty"`
+}
+
+// LabelHealthSummary is a label's health as recorded in a baseline
+type LabelHealthSummary struct {
+	Health      int    `json:"health"`       // Composite health, 0-100
+	HealthLevel string `json:"health_level"` // healthy, warning or critical
+	IssueCount  int    `json:"issue_count"`
 }
 
 // TopMetrics stores top-N items for comparison
@@ -82,6 +178,19 @@ type MetricItem struct {
 // CurrentVersion is the schema version for new baselines
 const CurrentVersion = 1
 
+// CurrentStatsVersion is the StatsVersion for new baselines. Version 2
+// counts blocked and actionable issues from the dependency graph
+// (analysis.Analyzer.BlockedCount and ActionableCount). Earlier baselines
+// counted issues with status "blocked" and every non-closed issue without
+// an open blocker, so their counts can't be compared with current ones.
+const CurrentStatsVersion = 2
+
+// HasCurrentStats reports whether the blocked and actionable counts were
+// computed the same way as a snapshot taken now.
+func (b *Baseline) HasCurrentStats() bool {
+	return b.StatsVersion >= CurrentStatsVersion
+}
+
 // DefaultFilename is the default baseline filename
 const DefaultFilename = "baseline.json"
 
@@ -129,6 +238,12 @@ func Load(path string) (*Baseline, error) {
 	return &baseline, nil
 }
 
+// ID identifies this particular baseline snapshot: its creation time, which
+// changes whenever a baseline is saved. Drift dismissals are tied to it.
+func (b *Baseline) ID() string {
+	return b.CreatedAt.UTC().Format(time.RFC3339Nano)
+}
+
 // Exists checks if a baseline file exists
 func Exists(path string) bool {
 	_, err := os.Stat(path)
@@ -219,6 +334,7 @@ func New(stats GraphStats, top TopMetrics, cycles [][]string, description string
 
 	return &Baseline{
 		Version:       CurrentVersion,
+		StatsVersion:  CurrentStatsVersion,
 		CreatedAt:     time.Now(),
 		CommitSHA:     sha,
 		CommitMessage: msg,
@@ -0,0 +1,103 @@
+package correlation
+
+import (
+	"sort"
+	"strings"
+)
+
+// AuthorStats summarizes one contributor's correlated commits
+type AuthorStats struct {
+	Name       string            `json:"name"`  // Most frequently used display name
+	Email      string            `json:"email"` // Lowercased; identity key
+	Commits    int               `json:"commits"`
+	Beads      int               `json:"beads"` // Distinct beads touched
+	Insertions int               `json:"insertions"`
+	Deletions  int               `json:"deletions"`
+	EventTypes map[EventType]int `json:"event_types,omitempty"`
+}
+
+// AggregateByAuthor rolls correlated commits up into per-author totals.
+// Authors are keyed by email (case-insensitive), falling back to the name
+// when a commit has no email. A commit attributed to several beads counts
+// once toward Commits and line totals. Output is sorted by commits
+// descending, then email.
+func AggregateByAuthor(commits []CorrelatedCommit) []AuthorStats {
+	type accum struct {
+		stats AuthorStats
+		names map[string]int
+		shas  map[string]bool
+		beads map[string]bool
+	}
+	byKey := make(map[string]*accum)
+	var order []string
+
+	for _, c := range commits {
+		key := strings.ToLower(strings.TrimSpace(c.AuthorEmail))
+		if key == "" {
+			key = strings.ToLower(strings.TrimSpace(c.Author))
+		}
+		a, ok := byKey[key]
+		if !ok {
+			a = &accum{
+				stats: AuthorStats{Email: strings.ToLower(strings.TrimSpace(c.AuthorEmail))},
+				names: make(map[string]int),
+				shas:  make(map[string]bool),
+				beads: make(map[string]bool),
+			}
+			byKey[key] = a
+			order = append(order, key)
+		}
+
+		if c.Author != "" {
+			a.names[c.Author]++
+		}
+		if c.BeadID != "" {
+			a.beads[c.BeadID] = true
+		}
+		if c.EventType != "" {
+			if a.stats.EventTypes == nil {
+				a.stats.EventTypes = make(map[EventType]int)
+			}
+			a.stats.EventTypes[c.EventType]++
+		}
+		if a.shas[c.SHA] {
+			continue
+		}
+		a.shas[c.SHA] = true
+		a.stats.Commits++
+		for _, f := range c.Files {
+			a.stats.Insertions += f.Insertions
+			a.stats.Deletions += f.Deletions
+		}
+	}
+
+	result := make([]AuthorStats, 0, len(order))
+	for _, key := range order {
+		a := byKey[key]
+		a.stats.Beads = len(a.beads)
+		a.stats.Name = mostFrequentName(a.names)
+		result = append(result, a.stats)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Commits != result[j].Commits {
+			return result[i].Commits > result[j].Commits
+		}
+		if result[i].Email != result[j].Email {
+			return result[i].Email < result[j].Email
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// mostFrequentName picks the most used spelling, breaking ties alphabetically
+func mostFrequentName(names map[string]int) string {
+	best, bestCount := "", 0
+	for name, n := range names {
+		if n > bestCount || (n == bestCount && name < best) {
+			best, bestCount = name, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,78 @@
+package correlation
+
+import "testing"
+
+func TestAggregateByAuthor(t *testing.T) {
+	commits := []CorrelatedCommit{
+		// Alice and Bob both work on bv-1
+		{BeadID: "bv-1", SHA: "a1", Author: "Alice", AuthorEmail: "alice@example.com", EventType: EventClaimed,
+			Files: []FileChange{{Path: "a.go", Insertions: 10, Deletions: 2}}},
+		{BeadID: "bv-1", SHA: "b1", Author: "Bob", AuthorEmail: "bob@example.com", EventType: EventClosed,
+			Files: []FileChange{{Path: "b.go", Insertions: 5, Deletions: 1}}},
+		// Alice uses two spellings under one email; a2 is attributed to two beads
+		{BeadID: "bv-2", SHA: "a2", Author: "alice s.", AuthorEmail: "Alice@Example.com", EventType: EventClosed,
+			Files: []FileChange{{Path: "c.go", Insertions: 3, Deletions: 3}}},
+		{BeadID: "bv-3", SHA: "a2", Author: "alice s.", AuthorEmail: "alice@example.com", EventType: EventClosed,
+			Files: []FileChange{{Path: "c.go", Insertions: 3, Deletions: 3}}},
+		{BeadID: "bv-3", SHA: "a3", Author: "Alice", AuthorEmail: "alice@example.com",
+			Files: []FileChange{{Path: "d.go", Insertions: 1}}},
+	}
+
+	stats := AggregateByAuthor(commits)
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 authors, got %d: %+v", len(stats), stats)
+	}
+
+	alice := stats[0]
+	if alice.Email != "alice@example.com" {
+		t.Fatalf("expected alice first (most commits), got %+v", alice)
+	}
+	if alice.Name != "Alice" {
+		t.Errorf("display name = %q, want Alice (tied spellings break alphabetically)", alice.Name)
+	}
+	if alice.Commits != 3 {
+		t.Errorf("alice commits = %d, want 3 (fan-out counted once)", alice.Commits)
+	}
+	if alice.Beads != 3 {
+		t.Errorf("alice beads = %d, want 3", alice.Beads)
+	}
+	if alice.Insertions != 14 || alice.Deletions != 5 {
+		t.Errorf("alice lines = +%d -%d, want +14 -5", alice.Insertions, alice.Deletions)
+	}
+	if alice.EventTypes[EventClosed] != 2 || alice.EventTypes[EventClaimed] != 1 {
+		t.Errorf("alice event types = %v", alice.EventTypes)
+	}
+
+	bob := stats[1]
+	if bob.Name != "Bob" || bob.Commits != 1 || bob.Beads != 1 || bob.EventTypes[EventClosed] != 1 {
+		t.Errorf("unexpected bob stats: %+v", bob)
+	}
+}
+
+func TestAggregateByAuthor_NameSpellingsAndOrder(t *testing.T) {
+	commits := []CorrelatedCommit{
+		{SHA: "1", Author: "J. Doe", AuthorEmail: "jd@example.com"},
+		{SHA: "2", Author: "Jane Doe", AuthorEmail: "JD@example.com"},
+		{SHA: "3", Author: "Jane Doe", AuthorEmail: "jd@example.com"},
+		{SHA: "4", Author: "Zed", AuthorEmail: "a@example.com"},
+		{SHA: "5", Author: "Yan", AuthorEmail: "b@example.com"},
+	}
+
+	stats := AggregateByAuthor(commits)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 authors, got %+v", stats)
+	}
+	if stats[0].Email != "jd@example.com" || stats[0].Name != "Jane Doe" || stats[0].Commits != 3 {
+		t.Errorf("expected Jane Doe (most frequent spelling) with 3 commits first, got %+v", stats[0])
+	}
+	// Ties on commit count are broken by email
+	if stats[1].Email != "a@example.com" || stats[2].Email != "b@example.com" {
+		t.Errorf("expected ties ordered by email, got %s then %s", stats[1].Email, stats[2].Email)
+	}
+}
+
+func TestAggregateByAuthor_Empty(t *testing.T) {
+	if got := AggregateByAuthor(nil); len(got) != 0 {
+		t.Errorf("expected no stats, got %+v", got)
+	}
+}
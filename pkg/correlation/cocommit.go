@@ -2,106 +2,205 @@
 package correlation
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
+	"time"
 )
 
-// renamePattern matches git's brace notation for renames: {old => new}
-var renamePattern = regexp.MustCompile(`\{[^}]* => ([^}]*)\}`)
-
 // CoCommitExtractor extracts files that were changed in the same commit as bead changes
 type CoCommitExtractor struct {
-	repoPath string
+	repoPath   string
+	vcs        VCSAdapter
+	codeFiles  CodeFileConfig
+	weights    ConfidenceWeights
+	knownBeads map[string]string // lowercase ID -> canonical ID; nil disables fan-out
+	cachePath  string            // On-disk result cache; empty disables it
+
+	retry GitRetryPolicy
+	run   commandRunner
+	sleep func(time.Duration)
 }
 
-// NewCoCommitExtractor creates a new co-commit extractor
+// NewCoCommitExtractor creates a new git-backed co-commit extractor
 func NewCoCommitExtractor(repoPath string) *CoCommitExtractor {
-	return &CoCommitExtractor{repoPath: repoPath}
+	return NewCoCommitExtractorWithConfig(repoPath, gitAdapter{}, DefaultCodeFileConfig())
+}
+
+// NewCoCommitExtractorWithAdapter creates a co-commit extractor that reads
+// commits through the given VCS adapter (nil means git)
+func NewCoCommitExtractorWithAdapter(repoPath string, vcs VCSAdapter) *CoCommitExtractor {
+	return NewCoCommitExtractorWithConfig(repoPath, vcs, DefaultCodeFileConfig())
+}
+
+// NewCoCommitExtractorWithConfig creates a co-commit extractor with a custom
+// code-file classifier. A nil adapter means git; nil config fields fall back
+// to DefaultCodeFileConfig. Results are not cached on disk unless
+// SetCachePath is called.
+func NewCoCommitExtractorWithConfig(repoPath string, vcs VCSAdapter, codeFiles CodeFileConfig) *CoCommitExtractor {
+	if vcs == nil {
+		vcs = gitAdapter{}
+	}
+	return &CoCommitExtractor{
+		repoPath:  repoPath,
+		vcs:       vcs,
+		codeFiles: codeFiles.withDefaults(),
+		weights:   DefaultConfidenceWeights(),
+		retry:     DefaultGitRetryPolicy(),
+		run:       execCommand,
+		sleep:     time.Sleep,
+	}
+}
+
+// NewCoCommitExtractorWithWeights creates a git-backed co-commit extractor
+// with custom confidence scoring
+func NewCoCommitExtractorWithWeights(repoPath string, weights ConfidenceWeights) *CoCommitExtractor {
+	c := NewCoCommitExtractor(repoPath)
+	c.weights = weights
+	return c
+}
+
+// SetRetryPolicy sets how transient VCS failures (lock contention, slow
+// disks) are retried when reading commits
+func (c *CoCommitExtractor) SetRetryPolicy(policy GitRetryPolicy) {
+	c.retry = policy
+}
+
+// RetryPolicy returns the extractor's retry policy
+func (c *CoCommitExtractor) RetryPolicy() GitRetryPolicy {
+	return c.retry
 }
 
-// codeFileExtensions lists file extensions considered "code files"
-var codeFileExtensions = map[string]bool{
-	".go":    true,
-	".py":    true,
-	".js":    true,
-	".ts":    true,
-	".jsx":   true,
-	".tsx":   true,
-	".rs":    true,
-	".java":  true,
-	".kt":    true,
-	".swift": true,
-	".c":     true,
-	".cpp":   true,
-	".h":     true,
-	".hpp":   true,
-	".rb":    true,
-	".php":   true,
-	".cs":    true,
-	".scala": true,
-	".yaml":  true,
-	".yml":   true,
-	".json":  true,
-	".toml":  true,
-	".md":    true,
-	".sql":   true,
-	".sh":    true,
-	".bash":  true,
-	".zsh":   true,
+// ConfidenceWeights tunes co-commit confidence scoring. The final score is
+// Base, plus IDMentionBonus when the message names the bead, minus
+// ShotgunPenalty for commits touching more than ShotgunThreshold files,
+// SpreadPenalty for commits touching more than SpreadThreshold top-level
+// directories and TestOnlyPenalty when every file is a test, clamped to
+// [0, 1]. The file-count and spread penalties apply independently.
+type ConfidenceWeights struct {
+	Base             float64 // Starting confidence for co-committed files
+	IDMentionBonus   float64 // Commit message references the bead ID
+	ShotgunPenalty   float64 // Commit touches too many files to be focused work
+	ShotgunThreshold int     // File count above which ShotgunPenalty applies
+	SpreadPenalty    float64 // Commit is scattered across unrelated directories
+	SpreadThreshold  int     // Distinct top-level directories above which SpreadPenalty applies
+	TestOnlyPenalty  float64 // Commit contains only test files
 }
 
-// excludedPaths lists path prefixes that should be excluded
-var excludedPaths = []string{
-	".beads/",
-	".bv/",
-	".git/",
-	"node_modules/",
-	"vendor/",
-	"__pycache__/",
-	".venv/",
-	"venv/",
-	"dist/",
-	"build/",
-	".next/",
+// DefaultConfidenceWeights returns the built-in co-commit scoring
+func DefaultConfidenceWeights() ConfidenceWeights {
+	return ConfidenceWeights{
+		Base:             0.95,
+		IDMentionBonus:   0.04,
+		ShotgunPenalty:   0.10,
+		ShotgunThreshold: 20,
+		SpreadPenalty:    0.10,
+		SpreadThreshold:  5,
+		TestOnlyPenalty:  0.05,
+	}
+}
+
+// CodeFileConfig controls which changed files count as code when correlating
+// commits with beads
+type CodeFileConfig struct {
+	// Extensions considered code files, lowercase with the leading dot
+	// (".go"). Extensionless files such as "Makefile" only count when their
+	// base name is listed.
+	Extensions map[string]bool
+
+	// TestSuffixes identify test files; each is matched case-insensitively
+	// anywhere in the path (so "test_" also catches Python test modules)
+	TestSuffixes []string
+
+	// ExcludedDirs lists directories ("vendor/") ignored at any depth
+	ExcludedDirs []string
+}
+
+// DefaultCodeFileConfig returns the built-in code-file classification
+func DefaultCodeFileConfig() CodeFileConfig {
+	return CodeFileConfig{
+		Extensions: map[string]bool{
+			".go":    true,
+			".py":    true,
+			".js":    true,
+			".ts":    true,
+			".jsx":   true,
+			".tsx":   true,
+			".rs":    true,
+			".java":  true,
+			".kt":    true,
+			".swift": true,
+			".c":     true,
+			".cpp":   true,
+			".h":     true,
+			".hpp":   true,
+			".rb":    true,
+			".php":   true,
+			".cs":    true,
+			".scala": true,
+			".yaml":  true,
+			".yml":   true,
+			".json":  true,
+			".toml":  true,
+			".md":    true,
+			".sql":   true,
+			".sh":    true,
+			".bash":  true,
+			".zsh":   true,
+		},
+		TestSuffixes: []string{"_test.go", ".test.js", ".test.ts", ".spec.js", ".spec.ts", "_test.py", "test_"},
+		ExcludedDirs: []string{
+			".beads/",
+			".bv/",
+			".git/",
+			"node_modules/",
+			"vendor/",
+			"__pycache__/",
+			".venv/",
+			"venv/",
+			"dist/",
+			"build/",
+			".next/",
+		},
+	}
+}
+
+// withDefaults fills unset fields from DefaultCodeFileConfig
+func (cfg CodeFileConfig) withDefaults() CodeFileConfig {
+	def := DefaultCodeFileConfig()
+	if cfg.Extensions == nil {
+		cfg.Extensions = def.Extensions
+	}
+	if cfg.TestSuffixes == nil {
+		cfg.TestSuffixes = def.TestSuffixes
+	}
+	if cfg.ExcludedDirs == nil {
+		cfg.ExcludedDirs = def.ExcludedDirs
+	}
+	return cfg
 }
 
+// defaultCodeFiles is used where no extractor config is available
+var defaultCodeFiles = DefaultCodeFileConfig()
+
 // ExtractCoCommittedFiles extracts code files changed in the same commit as a bead event
 func (c *CoCommitExtractor) ExtractCoCommittedFiles(event BeadEvent) ([]FileChange, error) {
-	// Get file list with status
+	// Get file list with status and line stats
 	files, err := c.getFilesChanged(event.CommitSHA)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get line stats
-	stats, err := c.getLineStats(event.CommitSHA)
-	if err != nil {
-		// Non-fatal: continue without stats
-		stats = make(map[string]lineStats)
-	}
-
 	// Filter to code files only
 	var codeFiles []FileChange
 	for _, f := range files {
-		if !isCodeFile(f.Path) {
+		if !c.codeFiles.isCodeFile(f.Path) {
 			continue
 		}
-		if isExcludedPath(f.Path) {
+		if c.codeFiles.isExcludedPath(f.Path) {
 			continue
 		}
-
-		// Add line stats if available
-		if s, ok := stats[f.Path]; ok {
-			f.Insertions = s.insertions
-			f.Deletions = s.deletions
-		}
-
 		codeFiles = append(codeFiles, f)
 	}
 
@@ -111,12 +210,12 @@ func (c *CoCommitExtractor) ExtractCoCommittedFiles(event BeadEvent) ([]FileChan
 // CreateCorrelatedCommit creates a CorrelatedCommit with confidence scoring
 func (c *CoCommitExtractor) CreateCorrelatedCommit(event BeadEvent, files []FileChange) CorrelatedCommit {
 	confidence := c.calculateConfidence(event, files)
-	reason := c.generateReason(event, files, confidence)
+	codes := c.reasonCodes(event, files)
 
 	return CorrelatedCommit{
 		BeadID:      event.BeadID,
 		SHA:         event.CommitSHA,
-		ShortSHA:    shortSHA(event.CommitSHA),
+		ShortSHA:    c.vcs.ShortID(event.CommitSHA),
 		Message:     event.CommitMsg,
 		Author:      event.Author,
 		AuthorEmail: event.AuthorEmail,
@@ -124,150 +223,123 @@ func (c *CoCommitExtractor) CreateCorrelatedCommit(event BeadEvent, files []File
 		Files:       files,
 		Method:      MethodCoCommitted,
 		Confidence:  confidence,
-		Reason:      reason,
+		Reason:      c.describeReasons(event, files, codes),
+		ReasonCodes: codes,
+		EventType:   event.EventType,
 	}
 }
 
-// lineStats holds insertion/deletion counts for a file
-type lineStats struct {
-	insertions int
-	deletions  int
+// multiBeadPenalty lowers confidence when a commit's files are attributed to
+// several beads, since each bead likely owns only part of the change
+const multiBeadPenalty = 0.05
+
+// beadIDPattern matches bead references like bv-12 or BV-a1b2 in commit messages
+var beadIDPattern = regexp.MustCompile(`(?i)\bbv-[a-z0-9]+\b`)
+
+// extractBeadIDs returns every distinct bead ID referenced in text, lowercased,
+// in order of first appearance
+func extractBeadIDs(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range beadIDPattern.FindAllString(text, -1) {
+		id := strings.ToLower(match)
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
 }
 
-// getFilesChanged runs git show --name-status to get changed files
-func (c *CoCommitExtractor) getFilesChanged(sha string) ([]FileChange, error) {
-	cmd := exec.Command("git", "show", "--name-status", "--format=", sha)
-	cmd.Dir = c.repoPath
-
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("git show --name-status failed: %w", err)
+// SetKnownBeads enables multi-bead fan-out: beads referenced in a commit
+// message are attributed the commit's files if their ID is in ids
+func (c *CoCommitExtractor) SetKnownBeads(ids []string) {
+	c.knownBeads = make(map[string]string, len(ids))
+	for _, id := range ids {
+		c.knownBeads[strings.ToLower(id)] = id
 	}
+}
 
-	var files []FileChange
-	scanner := bufio.NewScanner(bytes.NewReader(out))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		// Format: "M\tpath/to/file" or "R100\told\tnew"
-		parts := strings.Split(line, "\t")
-		if len(parts) < 2 {
-			continue
-		}
-
-		action := parts[0]
-		path := parts[1]
-
-		// Handle renames: R100\told\tnew
-		if len(parts) == 3 && strings.HasPrefix(action, "R") {
-			path = parts[2] // Use new name
-			action = "R"
-		}
-
-		// Normalize action to single char
-		if len(action) > 1 {
-			action = string(action[0])
+// referencedBeads returns the event's bead followed by any other known beads
+// mentioned in the commit message
+func (c *CoCommitExtractor) referencedBeads(event BeadEvent) []string {
+	beads := []string{event.BeadID}
+	if c.knownBeads == nil {
+		return beads
+	}
+	for _, id := range extractBeadIDs(event.CommitMsg) {
+		canonical, ok := c.knownBeads[id]
+		if ok && !strings.EqualFold(canonical, event.BeadID) {
+			beads = append(beads, canonical)
 		}
-
-		files = append(files, FileChange{
-			Path:   path,
-			Action: action,
-		})
 	}
-
-	return files, scanner.Err()
+	return beads
 }
 
-// getLineStats runs git show --numstat to get insertion/deletion counts
-func (c *CoCommitExtractor) getLineStats(sha string) (map[string]lineStats, error) {
-	cmd := exec.Command("git", "show", "--numstat", "--format=", sha)
-	cmd.Dir = c.repoPath
-
-	out, err := cmd.Output()
-	if err != nil {
-		return nil, fmt.Errorf("git show --numstat failed: %w", err)
+// CreateCorrelatedCommits creates one CorrelatedCommit per bead the commit
+// belongs to: the event's bead plus, when known beads are set, every other
+// existing bead referenced in the message. All share the file list; when
+// there is more than one bead each loses multiBeadPenalty confidence.
+func (c *CoCommitExtractor) CreateCorrelatedCommits(event BeadEvent, files []FileChange) []CorrelatedCommit {
+	beads := c.referencedBeads(event)
+	if len(beads) == 1 {
+		return []CorrelatedCommit{c.CreateCorrelatedCommit(event, files)}
 	}
 
-	stats := make(map[string]lineStats)
-	scanner := bufio.NewScanner(bytes.NewReader(out))
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		// Format: "42\t10\tpath/to/file" or "-\t-\tbinary/file"
-		parts := strings.Split(line, "\t")
-		if len(parts) < 3 {
-			continue
-		}
-
-		insertions := 0
-		deletions := 0
-
-		// Binary files show "-" instead of numbers
-		if parts[0] != "-" {
-			insertions, _ = strconv.Atoi(parts[0])
-		}
-		if parts[1] != "-" {
-			deletions, _ = strconv.Atoi(parts[1])
-		}
-
-		// Handle renames: path might be "old => new" format
-		path := parts[2]
-		if strings.Contains(path, " => ") {
-			// Extract new path from "old => new" or "{old => new}" format
-			path = extractNewPath(path)
-		}
-
-		stats[path] = lineStats{
-			insertions: insertions,
-			deletions:  deletions,
+	commits := make([]CorrelatedCommit, 0, len(beads))
+	for _, beadID := range beads {
+		e := event
+		e.BeadID = beadID
+		commit := c.CreateCorrelatedCommit(e, files)
+		commit.Confidence -= multiBeadPenalty
+		if commit.Confidence < 0 {
+			commit.Confidence = 0
 		}
+		commit.Reason += fmt.Sprintf("; files shared across %d beads", len(beads))
+		commits = append(commits, commit)
 	}
-
-	return stats, scanner.Err()
+	return commits
 }
 
-// extractNewPath handles git's rename notation in numstat output
-func extractNewPath(path string) string {
-	// Handle "{prefix/}{old => new}{/suffix}" format
-	if strings.Contains(path, "{") {
-		// Complex case: "pkg/{old => new}/file.go"
-		path = renamePattern.ReplaceAllString(path, "$1")
-		// Fix potential double slashes if a segment was removed (e.g. "{old => }")
-		return strings.ReplaceAll(path, "//", "/")
+// getFilesChanged runs the adapter's show command for a commit, retrying
+// transient failures, and parses the changed files
+func (c *CoCommitExtractor) getFilesChanged(id string) ([]FileChange, error) {
+	name, args := c.vcs.ShowCommand(id)
+	out, err := c.runWithRetry(name, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s show %s failed: %w", name, c.vcs.ShortID(id), err)
 	}
 
-	// Simple case: "old => new"
-	if idx := strings.Index(path, " => "); idx != -1 {
-		return path[idx+4:]
+	_, files, err := c.vcs.ParseLog(out)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s output: %w", name, err)
 	}
-
-	return path
+	return files, nil
 }
 
 // calculateConfidence computes the confidence score for a co-commit correlation
 func (c *CoCommitExtractor) calculateConfidence(event BeadEvent, files []FileChange) float64 {
-	// Base confidence for co-committed files
-	confidence := 0.95
+	w := c.weights
+	confidence := w.Base
 
 	// Bonus: commit message mentions bead ID
 	if containsBeadID(event.CommitMsg, event.BeadID) {
-		confidence += 0.04
+		confidence += w.IDMentionBonus
+	}
+
+	// Penalty: shotgun commit
+	if len(files) > w.ShotgunThreshold {
+		confidence -= w.ShotgunPenalty
 	}
 
-	// Penalty: shotgun commit (>20 files)
-	if len(files) > 20 {
-		confidence -= 0.10
+	// Penalty: scattered across many top-level directories
+	if topLevelDirCount(files) > w.SpreadThreshold {
+		confidence -= w.SpreadPenalty
 	}
 
 	// Penalty: only test files
-	if allTestFiles(files) {
-		confidence -= 0.05
+	if c.codeFiles.allTestFiles(files) {
+		confidence -= w.TestOnlyPenalty
 	}
 
 	// Clamp to [0, 1]
@@ -281,29 +353,60 @@ func (c *CoCommitExtractor) calculateConfidence(event BeadEvent, files []FileCha
 	return confidence
 }
 
-// generateReason creates a human-readable explanation for the correlation
-func (c *CoCommitExtractor) generateReason(event BeadEvent, files []FileChange, confidence float64) string {
-	var parts []string
-
-	parts = append(parts, fmt.Sprintf("Co-committed with bead status change to %s", event.EventType))
-
+// reasonCodes lists the factors behind a co-commit correlation, in the
+// order describeReasons explains them
+func (c *CoCommitExtractor) reasonCodes(event BeadEvent, files []FileChange) []ReasonCode {
+	codes := []ReasonCode{ReasonCoCommitted}
 	if containsBeadID(event.CommitMsg, event.BeadID) {
-		parts = append(parts, "commit message references bead ID")
+		codes = append(codes, ReasonIDMention)
 	}
-
-	if len(files) > 20 {
-		parts = append(parts, fmt.Sprintf("large commit (%d files)", len(files)))
+	if len(files) > c.weights.ShotgunThreshold {
+		codes = append(codes, ReasonShotgun)
 	}
-
-	if allTestFiles(files) {
-		parts = append(parts, "contains only test files")
+	if topLevelDirCount(files) > c.weights.SpreadThreshold {
+		codes = append(codes, ReasonSpread)
+	}
+	if c.codeFiles.allTestFiles(files) {
+		codes = append(codes, ReasonTestOnly)
 	}
+	return codes
+}
 
+// describeReasons renders reason codes as the prose used in Reason. Each
+// factor that moved the confidence away from the base carries the
+// adjustment calculateConfidence made for it (e.g. "−0.10"), taken from the
+// extractor's ConfidenceWeights, so the score can be audited.
+func (c *CoCommitExtractor) describeReasons(event BeadEvent, files []FileChange, codes []ReasonCode) string {
+	w := c.weights
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		switch code {
+		case ReasonCoCommitted:
+			parts = append(parts, fmt.Sprintf("Co-committed with bead status change to %s (base %.2f)", event.EventType, w.Base))
+		case ReasonIDMention:
+			parts = append(parts, "commit message references bead ID: "+formatAdjustment(w.IDMentionBonus))
+		case ReasonShotgun:
+			parts = append(parts, fmt.Sprintf("large commit (%d files): %s", len(files), formatAdjustment(-w.ShotgunPenalty)))
+		case ReasonSpread:
+			parts = append(parts, fmt.Sprintf("scattered commit (%d top-level directories): %s", topLevelDirCount(files), formatAdjustment(-w.SpreadPenalty)))
+		case ReasonTestOnly:
+			parts = append(parts, "contains only test files: "+formatAdjustment(-w.TestOnlyPenalty))
+		}
+	}
 	return strings.Join(parts, "; ")
 }
 
+// formatAdjustment renders a confidence adjustment with an explicit sign,
+// using a typographic minus for penalties ("+0.04", "−0.10")
+func formatAdjustment(delta float64) string {
+	if delta < 0 {
+		return fmt.Sprintf("−%.2f", -delta)
+	}
+	return fmt.Sprintf("+%.2f", delta)
+}
+
 // isCodeFile checks if a file path is a code file based on extension
-func isCodeFile(path string) bool {
+func (cfg CodeFileConfig) isCodeFile(path string) bool {
 	// Handle git quoting (e.g. "path/with spaces.go")
 	if len(path) > 2 && path[0] == '"' && path[len(path)-1] == '"' {
 		// Basic unquote: strip quotes.
@@ -315,13 +418,16 @@ func isCodeFile(path string) bool {
 	}
 
 	ext := strings.ToLower(filepath.Ext(path))
-	return codeFileExtensions[ext]
+	if ext == "" {
+		return cfg.Extensions[filepath.Base(path)]
+	}
+	return cfg.Extensions[ext]
 }
 
 // isExcludedPath checks if a path should be excluded
-func isExcludedPath(path string) bool {
+func (cfg CodeFileConfig) isExcludedPath(path string) bool {
 	// Check for direct prefix (fast path for root dirs)
-	for _, prefix := range excludedPaths {
+	for _, prefix := range cfg.ExcludedDirs {
 		if strings.HasPrefix(path, prefix) {
 			return true
 		}
@@ -329,7 +435,7 @@ func isExcludedPath(path string) bool {
 
 	// Check for nested directories (e.g. src/node_modules/...)
 	// We look for "/dirname/" in the path
-	for _, prefix := range excludedPaths {
+	for _, prefix := range cfg.ExcludedDirs {
 		// Only check directory exclusions (ending in /)
 		if strings.HasSuffix(prefix, "/") {
 			// Check for "/prefix" anywhere in path
@@ -350,19 +456,32 @@ func containsBeadID(text, beadID string) bool {
 	return strings.Contains(strings.ToLower(text), strings.ToLower(beadID))
 }
 
+// topLevelDirCount returns how many distinct top-level directories the files
+// touch; files at the repository root count together as one
+func topLevelDirCount(files []FileChange) int {
+	dirs := make(map[string]struct{})
+	for _, f := range files {
+		path := strings.TrimPrefix(strings.Trim(f.Path, `"`), "./")
+		top, _, found := strings.Cut(path, "/")
+		if !found {
+			top = "."
+		}
+		dirs[top] = struct{}{}
+	}
+	return len(dirs)
+}
+
 // allTestFiles returns true if all files are test files
-func allTestFiles(files []FileChange) bool {
+func (cfg CodeFileConfig) allTestFiles(files []FileChange) bool {
 	if len(files) == 0 {
 		return false
 	}
 
-	testPatterns := []string{"_test.go", ".test.js", ".test.ts", ".spec.js", ".spec.ts", "_test.py", "test_"}
-
 	for _, f := range files {
 		isTest := false
 		lowerPath := strings.ToLower(f.Path)
-		for _, pattern := range testPatterns {
-			if strings.Contains(lowerPath, pattern) {
+		for _, pattern := range cfg.TestSuffixes {
+			if strings.Contains(lowerPath, strings.ToLower(pattern)) {
 				isTest = true
 				break
 			}
@@ -374,18 +493,34 @@ func allTestFiles(files []FileChange) bool {
 	return true
 }
 
-// shortSHA returns the first 7 characters of a SHA
-func shortSHA(sha string) string {
-	if len(sha) > 7 {
-		return sha[:7]
+// ExtractAllCoCommits extracts co-committed files for all events with status
+// changes. When the on-disk cache was written at the current HEAD for the
+// same known beads and events, the cached result is returned instead;
+// otherwise the result is recomputed and the cache rewritten.
+func (c *CoCommitExtractor) ExtractAllCoCommits(events []BeadEvent) ([]CorrelatedCommit, error) {
+	head, beadsHash, eventsHash, cacheable := c.cacheKey(events)
+	if cacheable {
+		if commits, ok := c.loadCachedCoCommits(head, beadsHash, eventsHash); ok {
+			return commits, nil
+		}
+	}
+
+	commits, err := c.extractAllCoCommits(events)
+	if err != nil {
+		return nil, err
 	}
-	return sha
+	if cacheable {
+		// Best effort: a read-only checkout just doesn't get cached
+		_ = c.saveCachedCoCommits(head, beadsHash, eventsHash, commits)
+	}
+	return commits, nil
 }
 
-// ExtractAllCoCommits extracts co-committed files for all events with status changes
-func (c *CoCommitExtractor) ExtractAllCoCommits(events []BeadEvent) ([]CorrelatedCommit, error) {
+// extractAllCoCommits does the uncached extraction for ExtractAllCoCommits
+func (c *CoCommitExtractor) extractAllCoCommits(events []BeadEvent) ([]CorrelatedCommit, error) {
 	var commits []CorrelatedCommit
 	fileCache := make(map[string][]FileChange) // Cache file lookups by SHA
+	seen := make(map[string]bool)              // SHA + bead ID already correlated
 
 	for _, event := range events {
 		// Only process status change events
@@ -410,8 +545,14 @@ func (c *CoCommitExtractor) ExtractAllCoCommits(events []BeadEvent) ([]Correlate
 			continue
 		}
 
-		commit := c.CreateCorrelatedCommit(event, files)
-		commits = append(commits, commit)
+		for _, commit := range c.CreateCorrelatedCommits(event, files) {
+			key := commit.SHA + "\x00" + commit.BeadID
+			if seen[key] {
+				continue // Already attributed via another event in this commit
+			}
+			seen[key] = true
+			commits = append(commits, commit)
+		}
 	}
 
 	return commits, nil
@@ -0,0 +1,177 @@
+package correlation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// CoCommitCacheFilename is the co-commit cache file inside .bv/
+const CoCommitCacheFilename = "correlation-cache.json"
+
+// coCommitCacheVersion is bumped whenever the cached format or the
+// extraction logic changes, so stale caches are recomputed
+const coCommitCacheVersion = 3
+
+// CoCommitCachePath returns the co-commit cache location for a repository
+func CoCommitCachePath(repoPath string) string {
+	return filepath.Join(repoPath, ".bv", CoCommitCacheFilename)
+}
+
+// coCommitCacheFile is the on-disk form of a co-commit extraction. The
+// result is reusable while HEAD, the known bead set and the input events
+// are unchanged.
+type coCommitCacheFile struct {
+	Version    int              `json:"version"`
+	HeadSHA    string           `json:"head_sha"`
+	BeadsHash  string           `json:"beads_hash"`
+	EventsHash string           `json:"events_hash"`
+	Commits    []cachedCoCommit `json:"commits"`
+}
+
+// cachedCoCommit stores the bead ID alongside the commit, since
+// CorrelatedCommit does not serialize it
+type cachedCoCommit struct {
+	BeadID string           `json:"bead_id"`
+	Commit CorrelatedCommit `json:"commit"`
+}
+
+// SetCachePath sets where ExtractAllCoCommits persists its results. An empty
+// path disables the on-disk cache.
+func (c *CoCommitExtractor) SetCachePath(path string) {
+	c.cachePath = path
+}
+
+// cacheKey returns the HEAD SHA and the hashes identifying this extraction.
+// ok is false when caching isn't possible (disabled, or HEAD unknown).
+func (c *CoCommitExtractor) cacheKey(events []BeadEvent) (head, beadsHash, eventsHash string, ok bool) {
+	if c.cachePath == "" {
+		return "", "", "", false
+	}
+	// Only git has a HEAD we can cheaply key on
+	if _, isGit := c.vcs.(gitAdapter); !isGit {
+		return "", "", "", false
+	}
+	head, err := getGitHead(c.repoPath)
+	if err != nil || head == "" {
+		return "", "", "", false
+	}
+	return head, c.hashKnownBeads(), c.hashEvents(events), true
+}
+
+// hashKnownBeads hashes the sorted known bead IDs
+func (c *CoCommitExtractor) hashKnownBeads() string {
+	ids := make([]string, 0, len(c.knownBeads))
+	for _, id := range c.knownBeads {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	if c.knownBeads == nil {
+		h.Write([]byte("\x01")) // fan-out disabled differs from an empty set
+	}
+	for _, id := range ids {
+		h.Write([]byte(id))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// hashEvents hashes the status events that drive extraction, together with
+// the scoring and classification settings that shape the result
+func (c *CoCommitExtractor) hashEvents(events []BeadEvent) string {
+	h := sha256.New()
+	for _, e := range events {
+		if e.EventType != EventClaimed && e.EventType != EventClosed {
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00", e.CommitSHA, e.BeadID, e.EventType)
+	}
+	fmt.Fprintf(h, "%+v", c.weights)
+
+	exts := make([]string, 0, len(c.codeFiles.Extensions))
+	for ext, on := range c.codeFiles.Extensions {
+		if on {
+			exts = append(exts, ext)
+		}
+	}
+	sort.Strings(exts)
+	fmt.Fprintf(h, "%v%v%v", exts, c.codeFiles.TestSuffixes, c.codeFiles.ExcludedDirs)
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// loadCachedCoCommits returns the cached commits if the cache file matches
+// the key. A missing, unreadable or corrupt file is treated as a miss.
+func (c *CoCommitExtractor) loadCachedCoCommits(head, beadsHash, eventsHash string) ([]CorrelatedCommit, bool) {
+	file, ok := c.readCoCommitCache()
+	if !ok || file.HeadSHA != head || file.BeadsHash != beadsHash || file.EventsHash != eventsHash {
+		return nil, false
+	}
+	return file.correlatedCommits(), true
+}
+
+// readCoCommitCache reads the cache file whatever its key. ok is false when
+// caching is disabled or the file is missing, corrupt or from another version.
+func (c *CoCommitExtractor) readCoCommitCache() (coCommitCacheFile, bool) {
+	if c.cachePath == "" {
+		return coCommitCacheFile{}, false
+	}
+	data, err := os.ReadFile(c.cachePath)
+	if err != nil {
+		return coCommitCacheFile{}, false
+	}
+	var file coCommitCacheFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Version != coCommitCacheVersion {
+		return coCommitCacheFile{}, false
+	}
+	return file, true
+}
+
+// correlatedCommits returns the cached commits with BeadID restored
+func (f coCommitCacheFile) correlatedCommits() []CorrelatedCommit {
+	commits := make([]CorrelatedCommit, 0, len(f.Commits))
+	for _, cc := range f.Commits {
+		commit := cc.Commit
+		commit.BeadID = cc.BeadID
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// saveCachedCoCommits writes the cache atomically, replacing any previous
+// contents
+func (c *CoCommitExtractor) saveCachedCoCommits(head, beadsHash, eventsHash string, commits []CorrelatedCommit) error {
+	file := coCommitCacheFile{
+		Version:    coCommitCacheVersion,
+		HeadSHA:    head,
+		BeadsHash:  beadsHash,
+		EventsHash: eventsHash,
+		Commits:    make([]cachedCoCommit, 0, len(commits)),
+	}
+	for _, commit := range commits {
+		file.Commits = append(file.Commits, cachedCoCommit{BeadID: commit.BeadID, Commit: commit})
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("encoding co-commit cache: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.cachePath), 0755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	tmp := c.cachePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("writing co-commit cache: %w", err)
+	}
+	if err := os.Rename(tmp, c.cachePath); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("replacing co-commit cache: %w", err)
+	}
+	return nil
+}
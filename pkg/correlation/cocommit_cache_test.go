@@ -0,0 +1,217 @@
+package correlation
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// cacheTestRepo creates a git repo with one commit that closes bv-1 and
+// touches a code file, returning the repo dir, a git runner, and the event.
+func cacheTestRepo(t *testing.T) (string, func(args ...string) string, BeadEvent) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test User")
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "-A")
+	run("commit", "-qm", "close bv-1")
+	sha := run("rev-parse", "HEAD")[:40]
+
+	return dir, run, BeadEvent{BeadID: "bv-1", EventType: EventClosed, CommitSHA: sha, CommitMsg: "close bv-1"}
+}
+
+// rewriteCachedMessages tags every cached commit message so a cache hit is
+// distinguishable from a fresh extraction
+func rewriteCachedMessages(t *testing.T, path, msg string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading cache: %v", err)
+	}
+	var file coCommitCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("decoding cache: %v", err)
+	}
+	for i := range file.Commits {
+		file.Commits[i].Commit.Message = msg
+	}
+	data, _ = json.Marshal(file)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCoCommitCache_HitWhenHeadUnchanged(t *testing.T) {
+	dir, _, event := cacheTestRepo(t)
+	cachePath := CoCommitCachePath(dir)
+
+	c := NewCoCommitExtractor(dir)
+	c.SetCachePath(cachePath)
+	c.SetKnownBeads([]string{"bv-1"})
+	first, err := c.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatalf("first extraction: %v", err)
+	}
+	if len(first) != 1 || first[0].BeadID != "bv-1" {
+		t.Fatalf("first extraction = %+v, want one commit for bv-1", first)
+	}
+	rewriteCachedMessages(t, cachePath, "from cache")
+
+	c2 := NewCoCommitExtractor(dir)
+	c2.SetCachePath(cachePath)
+	c2.SetKnownBeads([]string{"bv-1"})
+	second, err := c2.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatalf("second extraction: %v", err)
+	}
+	if len(second) != 1 || second[0].Message != "from cache" {
+		t.Fatalf("expected cached result, got %+v", second)
+	}
+	if second[0].BeadID != "bv-1" || len(second[0].Files) != 1 || second[0].Files[0].Path != "main.go" {
+		t.Errorf("cached commit lost data: %+v", second[0])
+	}
+
+	// A different known-bead set is a miss
+	c2.SetKnownBeads([]string{"bv-1", "bv-2"})
+	third, err := c2.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(third) != 1 || third[0].Message == "from cache" {
+		t.Errorf("changed bead set should recompute, got %+v", third)
+	}
+}
+
+func TestCoCommitCache_MissWhenHeadChanges(t *testing.T) {
+	dir, run, event := cacheTestRepo(t)
+	cachePath := CoCommitCachePath(dir)
+
+	c := NewCoCommitExtractor(dir)
+	c.SetCachePath(cachePath)
+	if _, err := c.ExtractAllCoCommits([]BeadEvent{event}); err != nil {
+		t.Fatal(err)
+	}
+	rewriteCachedMessages(t, cachePath, "from cache")
+
+	run("commit", "-q", "--allow-empty", "-m", "unrelated")
+
+	commits, err := c.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].Message == "from cache" {
+		t.Fatalf("new HEAD should recompute, got %+v", commits)
+	}
+
+	// The cache was rewritten for the new HEAD
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file coCommitCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	if head := run("rev-parse", "HEAD")[:40]; file.HeadSHA != head {
+		t.Errorf("cache HEAD = %q, want %q", file.HeadSHA, head)
+	}
+}
+
+func TestCoCommitCache_CorruptFileRecomputes(t *testing.T) {
+	dir, _, event := cacheTestRepo(t)
+	cachePath := CoCommitCachePath(dir)
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cachePath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewCoCommitExtractor(dir)
+	c.SetCachePath(cachePath)
+	commits, err := c.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatalf("corrupt cache should not fail extraction: %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("got %d commits, want 1", len(commits))
+	}
+
+	data, _ := os.ReadFile(cachePath)
+	var file coCommitCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Errorf("corrupt cache should be replaced, got %q", data)
+	}
+}
+
+func TestCoCommitCache_OptIn(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCoCommitExtractor(dir)
+	if c.cachePath != "" {
+		t.Errorf("cache should be off by default, got path %q", c.cachePath)
+	}
+
+	corr := NewCorrelator(dir)
+	corr.SetCachePath(CoCommitCachePath(dir))
+	if corr.coCommitter.cachePath != CoCommitCachePath(dir) {
+		t.Errorf("Correlator.SetCachePath should reach the extractor, got %q", corr.coCommitter.cachePath)
+	}
+}
+
+func TestCoCommitCache_OldVersionRecomputes(t *testing.T) {
+	dir, _, event := cacheTestRepo(t)
+	cachePath := CoCommitCachePath(dir)
+
+	c := NewCoCommitExtractor(dir)
+	c.SetCachePath(cachePath)
+	if _, err := c.ExtractAllCoCommits([]BeadEvent{event}); err != nil {
+		t.Fatal(err)
+	}
+	rewriteCachedMessages(t, cachePath, "from cache")
+
+	// Same key, older format: must not be served
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var file coCommitCacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatal(err)
+	}
+	file.Version = coCommitCacheVersion - 1
+	data, _ = json.Marshal(file)
+	if err := os.WriteFile(cachePath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := c.ExtractAllCoCommits([]BeadEvent{event})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].Message == "from cache" {
+		t.Fatalf("old cache version should recompute, got %+v", commits)
+	}
+	if len(commits[0].ReasonCodes) == 0 {
+		t.Errorf("recomputed commit should carry reason codes, got %+v", commits[0])
+	}
+}
@@ -0,0 +1,105 @@
+package correlation
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// ExtractSince extracts co-commits for the bead status changes made after
+// sinceSHA, up to HEAD, so repeated runs (say, on CI after every push) only
+// scan new history. An empty sinceSHA means the HEAD recorded in the on-disk
+// cache, or all history when there is no usable cache. An unknown sinceSHA
+// is an error. Only git repositories are supported.
+//
+// When the range starts at the cached HEAD, the new commits are appended to
+// the cache and its HEAD advanced, so the next call picks up from here.
+func (c *CoCommitExtractor) ExtractSince(sinceSHA string) ([]CorrelatedCommit, error) {
+	if _, isGit := c.vcs.(gitAdapter); !isGit {
+		return nil, fmt.Errorf("incremental co-commit extraction requires git")
+	}
+
+	head, err := c.resolveCommit("HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("resolving HEAD: %w", err)
+	}
+
+	cache, hasCache := c.readCoCommitCache()
+	beadsHash := c.hashKnownBeads()
+	if hasCache && cache.BeadsHash != beadsHash {
+		hasCache = false // Fan-out settings changed; cached commits don't apply
+	}
+	if sinceSHA == "" && hasCache {
+		sinceSHA = cache.HeadSHA
+	}
+
+	since := ""
+	if sinceSHA != "" {
+		since, err = c.resolveCommit(sinceSHA)
+		if err != nil {
+			return nil, fmt.Errorf("unknown since commit %q: %w", sinceSHA, err)
+		}
+	}
+
+	var commits []CorrelatedCommit
+	if since != head {
+		revRange := head
+		if since != "" {
+			revRange = since + ".." + head
+		}
+		events, err := c.beadEventsInRange(revRange)
+		if err != nil {
+			return nil, err
+		}
+		if commits, err = c.extractAllCoCommits(events); err != nil {
+			return nil, err
+		}
+	}
+
+	// Best effort, as in ExtractAllCoCommits. The events hash is left empty:
+	// the cache now tracks a HEAD rather than one set of input events.
+	if c.cachePath != "" && since != head {
+		switch {
+		case since == "":
+			_ = c.saveCachedCoCommits(head, beadsHash, "", commits)
+		case hasCache && cache.HeadSHA == since:
+			_ = c.saveCachedCoCommits(head, beadsHash, "", append(cache.correlatedCommits(), commits...))
+		}
+	}
+	if commits == nil {
+		commits = []CorrelatedCommit{}
+	}
+	return commits, nil
+}
+
+// resolveCommit returns the full SHA of a revision that names a commit
+func (c *CoCommitExtractor) resolveCommit(rev string) (string, error) {
+	out, err := c.runWithRetry("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("not a commit in %s", c.repoPath)
+	}
+	sha := strings.TrimSpace(string(out))
+	if sha == "" {
+		return "", fmt.Errorf("not a commit in %s", c.repoPath)
+	}
+	return sha, nil
+}
+
+// beadEventsInRange reads bead lifecycle events from the beads file changes
+// in a git revision range, oldest first
+func (c *CoCommitExtractor) beadEventsInRange(revRange string) ([]BeadEvent, error) {
+	e := NewExtractor(c.repoPath)
+	args := insertBefore(e.buildGitLogArgs(ExtractOptions{}), "--", revRange)
+	args = append([]string{"-c", "color.ui=false"}, args...)
+
+	out, err := c.runWithRetry("git", args...)
+	if err != nil {
+		return nil, fmt.Errorf("git log %s failed: %w", revRange, err)
+	}
+	events, err := e.parseGitLogOutput(bytes.NewReader(out), "")
+	if err != nil {
+		return nil, fmt.Errorf("parsing git log output: %w", err)
+	}
+	reverseEvents(events)
+	return events, nil
+}
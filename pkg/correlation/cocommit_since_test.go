@@ -0,0 +1,185 @@
+package correlation
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLogCommit is one commit in a fakeLogRepo: it moves bead from one
+// status to another and touches file
+type fakeLogCommit struct {
+	sha      string
+	bead     string
+	from, to string
+	file     string
+}
+
+// fakeLogRepo answers the git commands ExtractSince runs from an in-memory
+// history, honouring revision ranges the way git log would
+type fakeLogRepo struct {
+	commits []fakeLogCommit // Oldest first
+	ranges  []string        // Revision range of each git log call
+	shown   []string        // Commits whose files were looked up
+}
+
+// fakeSHA returns a distinct 40-hex SHA for n in 1..15
+func fakeSHA(n int) string { return strings.Repeat(fmt.Sprintf("%x", n), 40) }
+
+func (r *fakeLogRepo) run(dir, name string, args ...string) ([]byte, error) {
+	switch {
+	case slices.Contains(args, "rev-parse"):
+		rev := strings.TrimSuffix(args[len(args)-1], "^{commit}")
+		if rev == "HEAD" {
+			return []byte(r.commits[len(r.commits)-1].sha + "\n"), nil
+		}
+		for _, c := range r.commits {
+			if strings.HasPrefix(c.sha, rev) {
+				return []byte(c.sha + "\n"), nil
+			}
+		}
+		return nil, errors.New("exit status 1")
+
+	case slices.Contains(args, "log"):
+		revRange := args[slices.Index(args, "--")-1]
+		r.ranges = append(r.ranges, revRange)
+		since, _, _ := strings.Cut(revRange, "..")
+		if !strings.Contains(revRange, "..") {
+			since = ""
+		}
+		var out strings.Builder
+		for i := len(r.commits) - 1; i >= 0 && r.commits[i].sha != since; i-- { // Newest first
+			c := r.commits[i]
+			ts := time.Date(2025, 1, 1+i, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
+			fmt.Fprintf(&out, "%s\x00%s\x00Dev\x00dev@example.com\x00Work on %s\n\n", c.sha, ts, c.bead)
+			fmt.Fprintf(&out, "-{\"id\":%q,\"status\":%q}\n", c.bead, c.from)
+			fmt.Fprintf(&out, "+{\"id\":%q,\"status\":%q}\n", c.bead, c.to)
+		}
+		return []byte(out.String()), nil
+
+	case slices.Contains(args, "show"):
+		id := args[len(args)-1]
+		r.shown = append(r.shown, id)
+		for _, c := range r.commits {
+			if c.sha == id {
+				return []byte("3\t1\t" + c.file + "\n"), nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("unexpected command %s %v", name, args)
+}
+
+func newFakeLogRepo() *fakeLogRepo {
+	return &fakeLogRepo{commits: []fakeLogCommit{
+		{sha: fakeSHA(1), bead: "bv-1", from: "open", to: "in_progress", file: "pkg/a.go"},
+		{sha: fakeSHA(2), bead: "bv-1", from: "in_progress", to: "closed", file: "pkg/a.go"},
+		{sha: fakeSHA(3), bead: "bv-2", from: "open", to: "in_progress", file: "pkg/b.go"},
+		{sha: fakeSHA(4), bead: "bv-2", from: "in_progress", to: "closed", file: "pkg/b.go"},
+	}}
+}
+
+func sinceTestExtractor(repo *fakeLogRepo) *CoCommitExtractor {
+	c := NewCoCommitExtractor("/test/repo")
+	c.run = repo.run
+	c.sleep = func(time.Duration) {}
+	return c
+}
+
+func commitSHAs(commits []CorrelatedCommit) []string {
+	shas := make([]string, 0, len(commits))
+	for _, c := range commits {
+		shas = append(shas, c.SHA)
+	}
+	return shas
+}
+
+func TestExtractSince_OnlyProcessesNewCommits(t *testing.T) {
+	repo := newFakeLogRepo()
+	c := sinceTestExtractor(repo)
+
+	commits, err := c.ExtractSince(fakeSHA(2)[:8])
+	if err != nil {
+		t.Fatalf("ExtractSince: %v", err)
+	}
+	if want := []string{fakeSHA(2) + ".." + fakeSHA(4)}; !reflect.DeepEqual(repo.ranges, want) {
+		t.Errorf("git log ranges = %v, want %v", repo.ranges, want)
+	}
+	if want := []string{fakeSHA(3), fakeSHA(4)}; !reflect.DeepEqual(repo.shown, want) {
+		t.Errorf("looked up files for %v, want only %v", repo.shown, want)
+	}
+	if want := []string{fakeSHA(3), fakeSHA(4)}; !reflect.DeepEqual(commitSHAs(commits), want) {
+		t.Errorf("correlated %v, want %v", commitSHAs(commits), want)
+	}
+	for _, commit := range commits {
+		if commit.BeadID != "bv-2" {
+			t.Errorf("commit %s attributed to %s, want bv-2", commit.ShortSHA, commit.BeadID)
+		}
+	}
+}
+
+func TestExtractSince_AtHeadIsEmpty(t *testing.T) {
+	repo := newFakeLogRepo()
+	commits, err := sinceTestExtractor(repo).ExtractSince(fakeSHA(4))
+	if err != nil {
+		t.Fatalf("ExtractSince: %v", err)
+	}
+	if len(commits) != 0 || len(repo.ranges) != 0 {
+		t.Errorf("got %d commits and %d log calls, want none", len(commits), len(repo.ranges))
+	}
+}
+
+func TestExtractSince_UnknownSHA(t *testing.T) {
+	repo := newFakeLogRepo()
+	_, err := sinceTestExtractor(repo).ExtractSince("deadbeef")
+	if err == nil || !strings.Contains(err.Error(), `unknown since commit "deadbeef"`) {
+		t.Fatalf("err = %v, want an unknown since commit error", err)
+	}
+	if len(repo.ranges) != 0 {
+		t.Errorf("git log ran %d times for an unknown commit", len(repo.ranges))
+	}
+}
+
+func TestExtractSince_DefaultsToCachedHead(t *testing.T) {
+	repo := newFakeLogRepo()
+	c := sinceTestExtractor(repo)
+	c.SetCachePath(filepath.Join(t.TempDir(), CoCommitCacheFilename))
+
+	// No cache yet: the whole history is scanned and cached at HEAD
+	first, err := c.ExtractSince("")
+	if err != nil {
+		t.Fatalf("first ExtractSince: %v", err)
+	}
+	if len(first) != 4 || repo.ranges[0] != fakeSHA(4) {
+		t.Fatalf("first run got %d commits over %v, want 4 over full history", len(first), repo.ranges)
+	}
+
+	repo.commits = append(repo.commits, fakeLogCommit{sha: fakeSHA(5), bead: "bv-3", from: "open", to: "closed", file: "pkg/c.go"})
+	repo.shown = nil
+	second, err := c.ExtractSince("")
+	if err != nil {
+		t.Fatalf("second ExtractSince: %v", err)
+	}
+	if repo.ranges[1] != fakeSHA(4)+".."+fakeSHA(5) {
+		t.Errorf("second run scanned %s, want from the cached HEAD", repo.ranges[1])
+	}
+	if want := []string{fakeSHA(5)}; !reflect.DeepEqual(commitSHAs(second), want) || !reflect.DeepEqual(repo.shown, want) {
+		t.Errorf("second run correlated %v (looked up %v), want only %v", commitSHAs(second), repo.shown, want)
+	}
+
+	cache, ok := c.readCoCommitCache()
+	if !ok || cache.HeadSHA != fakeSHA(5) || len(cache.Commits) != 5 {
+		t.Errorf("cache head %s with %d commits, want %s with 5", cache.HeadSHA, len(cache.Commits), fakeSHA(5))
+	}
+}
+
+func TestExtractSince_RequiresGit(t *testing.T) {
+	c := NewCoCommitExtractorWithAdapter("/test/repo", jjAdapter{})
+	if _, err := c.ExtractSince(""); err == nil {
+		t.Error("expected an error for a non-git adapter")
+	}
+}
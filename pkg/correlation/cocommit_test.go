@@ -1,6 +1,9 @@
 package correlation
 
 import (
+	"fmt"
+	"math"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -40,7 +43,7 @@ func TestIsCodeFile(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.path, func(t *testing.T) {
-			got := isCodeFile(tt.path)
+			got := defaultCodeFiles.isCodeFile(tt.path)
 			if got != tt.want {
 				t.Errorf("isCodeFile(%q) = %v, want %v", tt.path, got, tt.want)
 			}
@@ -72,7 +75,7 @@ func TestIsExcludedPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.path, func(t *testing.T) {
-			got := isExcludedPath(tt.path)
+			got := defaultCodeFiles.isExcludedPath(tt.path)
 			if got != tt.want {
 				t.Errorf("isExcludedPath(%q) = %v, want %v", tt.path, got, tt.want)
 			}
@@ -150,7 +153,7 @@ func TestAllTestFiles(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := allTestFiles(tt.files)
+			got := defaultCodeFiles.allTestFiles(tt.files)
 			if got != tt.want {
 				t.Errorf("allTestFiles() = %v, want %v", got, tt.want)
 			}
@@ -171,9 +174,9 @@ func TestShortSHA(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.sha, func(t *testing.T) {
-			got := shortSHA(tt.sha)
+			got := gitAdapter{}.ShortID(tt.sha)
 			if got != tt.want {
-				t.Errorf("shortSHA(%q) = %q, want %q", tt.sha, got, tt.want)
+				t.Errorf("ShortID(%q) = %q, want %q", tt.sha, got, tt.want)
 			}
 		})
 	}
@@ -196,7 +199,7 @@ func TestExtractNewPath(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
-			got := extractNewPath(tt.input)
+			got := gitAdapter{}.extractNewPath(tt.input)
 			if got != tt.want {
 				t.Errorf("extractNewPath(%q) = %q, want %q", tt.input, got, tt.want)
 			}
@@ -281,7 +284,7 @@ func TestGenerateReason(t *testing.T) {
 
 	files := []FileChange{{Path: "file.go"}}
 
-	reason := c.generateReason(event, files, 0.99)
+	reason := c.describeReasons(event, files, c.reasonCodes(event, files))
 
 	if reason == "" {
 		t.Error("reason should not be empty")
@@ -298,6 +301,38 @@ func TestGenerateReason(t *testing.T) {
 	}
 }
 
+func TestCreateCorrelatedCommit_ReasonCodes(t *testing.T) {
+	c := NewCoCommitExtractor("/test/repo")
+
+	event := BeadEvent{
+		BeadID:    "bv-123",
+		EventType: EventClosed,
+		CommitSHA: "abc123def456",
+		CommitMsg: "fix: resolve bv-123",
+	}
+	commit := c.CreateCorrelatedCommit(event, []FileChange{{Path: "pkg/auth/login.go"}})
+
+	want := []ReasonCode{ReasonCoCommitted, ReasonIDMention}
+	if !reflect.DeepEqual(commit.ReasonCodes, want) {
+		t.Errorf("ReasonCodes = %v, want %v", commit.ReasonCodes, want)
+	}
+	// The prose is derived from the same codes
+	if !strings.Contains(commit.Reason, "closed") || !strings.Contains(commit.Reason, "bead ID") {
+		t.Errorf("reason should mention the closed status and bead ID, got: %s", commit.Reason)
+	}
+
+	// A test-only commit that doesn't name the bead
+	event.CommitMsg = "add coverage"
+	commit = c.CreateCorrelatedCommit(event, []FileChange{{Path: "pkg/auth/login_test.go"}})
+	want = []ReasonCode{ReasonCoCommitted, ReasonTestOnly}
+	if !reflect.DeepEqual(commit.ReasonCodes, want) {
+		t.Errorf("test-only ReasonCodes = %v, want %v", commit.ReasonCodes, want)
+	}
+	if strings.Contains(commit.Reason, "bead ID") || !strings.Contains(commit.Reason, "only test files") {
+		t.Errorf("reason should match its codes, got: %s", commit.Reason)
+	}
+}
+
 func TestCreateCorrelatedCommit(t *testing.T) {
 	c := NewCoCommitExtractor("/test/repo")
 	now := time.Now()
@@ -392,7 +427,7 @@ func TestGenerateReason_LargeCommit(t *testing.T) {
 		files[i] = FileChange{Path: "file" + string(rune('a'+i)) + ".go"}
 	}
 
-	reason := c.generateReason(event, files, 0.85)
+	reason := c.describeReasons(event, files, c.reasonCodes(event, files))
 
 	if !strings.Contains(reason, "large commit") {
 		t.Errorf("reason should mention large commit, got: %s", reason)
@@ -413,13 +448,40 @@ func TestGenerateReason_OnlyTestFiles(t *testing.T) {
 		{Path: "login_test.go"},
 	}
 
-	reason := c.generateReason(event, files, 0.90)
+	reason := c.describeReasons(event, files, c.reasonCodes(event, files))
 
 	if !strings.Contains(reason, "test files") {
 		t.Errorf("reason should mention test files, got: %s", reason)
 	}
 }
 
+func TestGenerateReason_ListsAdjustments(t *testing.T) {
+	weights := DefaultConfidenceWeights()
+	weights.ShotgunPenalty = 0.12
+	c := NewCoCommitExtractorWithWeights("/test/repo", weights)
+
+	event := BeadEvent{BeadID: "bv-123", EventType: EventClosed, CommitMsg: "more tests"}
+	files := make([]FileChange, 25)
+	for i := range files {
+		files[i] = FileChange{Path: fmt.Sprintf("pkg/auth/case%d_test.go", i)}
+	}
+
+	reason := c.describeReasons(event, files, c.reasonCodes(event, files))
+	for _, want := range []string{"(base 0.95)", "large commit (25 files): −0.12", "contains only test files: −0.05"} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason should contain %q, got: %s", want, reason)
+		}
+	}
+	if got, want := c.calculateConfidence(event, files), 0.95-0.12-0.05; math.Abs(got-want) > 1e-9 {
+		t.Errorf("confidence = %v, want %v to match the listed adjustments", got, want)
+	}
+
+	event.CommitMsg = "fix bv-123"
+	if reason := c.describeReasons(event, files[:1], c.reasonCodes(event, files[:1])); !strings.Contains(reason, "references bead ID: +0.04") {
+		t.Errorf("reason should list the ID mention bonus, got: %s", reason)
+	}
+}
+
 func TestCalculateConfidence_Combined(t *testing.T) {
 	c := NewCoCommitExtractor("/test/repo")
 
@@ -450,7 +512,7 @@ func TestExtractNewPath_DoubleSlashBug(t *testing.T) {
 	// We expect "pkg/file.go"
 	expected := "pkg/file.go"
 
-	got := extractNewPath(input)
+	got := gitAdapter{}.extractNewPath(input)
 
 	if got != expected {
 		t.Errorf("extractNewPath(%q) = %q; want %q", input, got, expected)
@@ -470,9 +532,230 @@ func TestExtractNewPath_ComplexCases(t *testing.T) {
 	}
 
 	for _, tc := range cases {
-		got := extractNewPath(tc.input)
+		got := gitAdapter{}.extractNewPath(tc.input)
 		if got != tc.expected {
 			t.Errorf("extractNewPath(%q) = %q; want %q", tc.input, got, tc.expected)
 		}
 	}
 }
+
+func TestCodeFileConfig_CustomExtensions(t *testing.T) {
+	cfg := CodeFileConfig{
+		Extensions:   map[string]bool{".kt": true, "Makefile": true},
+		TestSuffixes: []string{".spec.kt"},
+	}.withDefaults()
+
+	codeTests := []struct {
+		path string
+		want bool
+	}{
+		{"app/src/main/Login.kt", true},
+		{"app/src/main/Login.KT", true},
+		{"Makefile", true},
+		{"pkg/auth/login.go", false}, // Not registered by this project
+		{"Dockerfile", false},
+	}
+	for _, tt := range codeTests {
+		if got := cfg.isCodeFile(tt.path); got != tt.want {
+			t.Errorf("isCodeFile(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	specs := []FileChange{{Path: "app/src/test/Login.spec.kt"}, {Path: "app/src/test/Session.Spec.kt"}}
+	if !cfg.allTestFiles(specs) {
+		t.Error("expected .spec.kt files to be classified as tests")
+	}
+	if defaultCodeFiles.allTestFiles(specs) {
+		t.Error("default config should not know about .spec.kt")
+	}
+	if cfg.allTestFiles(append(specs, FileChange{Path: "app/src/main/Login.kt"})) {
+		t.Error("mixed commit should not be all tests")
+	}
+
+	// ExcludedDirs was left unset and falls back to the defaults
+	if !cfg.isExcludedPath("app/build/generated/Foo.kt") {
+		t.Error("expected default excluded dirs to apply")
+	}
+}
+
+func TestCodeFileConfig_ConfidencePenalty(t *testing.T) {
+	cfg := CodeFileConfig{
+		Extensions:   map[string]bool{".kt": true},
+		TestSuffixes: []string{".spec.kt"},
+		ExcludedDirs: []string{"generated/"},
+	}
+	c := NewCoCommitExtractorWithConfig("/test/repo", nil, cfg)
+	event := BeadEvent{BeadID: "bv-1", EventType: EventClosed}
+	files := []FileChange{{Path: "Login.spec.kt"}}
+
+	if got := c.calculateConfidence(event, files); got > 0.9001 || got < 0.8999 {
+		t.Errorf("expected only-tests penalty (0.90), got %v", got)
+	}
+	if !strings.Contains(c.describeReasons(event, files, c.reasonCodes(event, files)), "only test files") {
+		t.Error("expected reason to mention only test files")
+	}
+	if got := NewCoCommitExtractor("/test/repo").calculateConfidence(event, files); got != 0.95 {
+		t.Errorf("default config should not penalize .spec.kt, got %v", got)
+	}
+	if !c.codeFiles.isExcludedPath("app/generated/R.kt") || c.codeFiles.isExcludedPath("vendor/lib.kt") {
+		t.Error("custom ExcludedDirs should replace the defaults")
+	}
+}
+
+func TestExtractBeadIDs(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"fix bv-12 and bv-34", []string{"bv-12", "bv-34"}},
+		{"BV-12: follow-up to bv-12", []string{"bv-12"}},
+		{"refs bv-a1b2, bv-99", []string{"bv-a1b2", "bv-99"}},
+		{"chore: update deps", nil},
+		{"abv-12 is not a reference", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := extractBeadIDs(tt.text)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("extractBeadIDs(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCorrelatedCommits_MultiBead(t *testing.T) {
+	c := NewCoCommitExtractor("/test/repo")
+	c.SetKnownBeads([]string{"bv-12", "bv-34", "bv-56"})
+
+	event := BeadEvent{
+		BeadID:    "bv-12",
+		EventType: EventClosed,
+		CommitSHA: "abc123def456",
+		CommitMsg: "fix bv-12 and bv-34 (see also bv-999)",
+	}
+	files := []FileChange{{Path: "pkg/auth/login.go"}, {Path: "pkg/auth/session.go"}}
+
+	commits := c.CreateCorrelatedCommits(event, files)
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits (unknown bv-999 skipped), got %d", len(commits))
+	}
+	single := c.CreateCorrelatedCommit(event, files)
+	for i, want := range []string{"bv-12", "bv-34"} {
+		got := commits[i]
+		if got.BeadID != want {
+			t.Errorf("commits[%d].BeadID = %s, want %s", i, got.BeadID, want)
+		}
+		if got.SHA != event.CommitSHA || len(got.Files) != len(files) {
+			t.Errorf("commits[%d] should share the commit and file list: %+v", i, got)
+		}
+		if got.Confidence >= single.Confidence {
+			t.Errorf("commits[%d] confidence %v should be below single-bead %v", i, got.Confidence, single.Confidence)
+		}
+		if !strings.Contains(got.Reason, "2 beads") {
+			t.Errorf("commits[%d] reason should mention the split: %q", i, got.Reason)
+		}
+	}
+}
+
+func TestCreateCorrelatedCommits_WithoutKnownBeads(t *testing.T) {
+	c := NewCoCommitExtractor("/test/repo")
+	event := BeadEvent{BeadID: "bv-12", EventType: EventClosed, CommitSHA: "abc123", CommitMsg: "fix bv-12 and bv-34"}
+
+	commits := c.CreateCorrelatedCommits(event, []FileChange{{Path: "main.go"}})
+	if len(commits) != 1 || commits[0].BeadID != "bv-12" {
+		t.Fatalf("expected a single commit for the event bead without known beads, got %+v", commits)
+	}
+	if commits[0].Confidence != c.CreateCorrelatedCommit(event, []FileChange{{Path: "main.go"}}).Confidence {
+		t.Error("single-bead commits should not be penalized")
+	}
+}
+
+func TestConfidenceWeights_ShotgunThreshold(t *testing.T) {
+	event := BeadEvent{BeadID: "bv-1", EventType: EventClosed, CommitMsg: "refactor"}
+	files := make([]FileChange, 12)
+	for i := range files {
+		files[i] = FileChange{Path: fmt.Sprintf("pkg/mod%d/file.go", i)}
+	}
+
+	def := NewCoCommitExtractor("/test/repo")
+	if got := def.calculateConfidence(event, files); got != 0.95 {
+		t.Errorf("12 files should not be penalized under the default threshold, got %v", got)
+	}
+
+	weights := DefaultConfidenceWeights()
+	weights.ShotgunThreshold = 10
+	custom := NewCoCommitExtractorWithWeights("/test/repo", weights)
+	if got := custom.calculateConfidence(event, files); got > 0.8501 || got < 0.8499 {
+		t.Errorf("12 files should be penalized with threshold 10, got %v", got)
+	}
+	if !strings.Contains(custom.describeReasons(event, files, custom.reasonCodes(event, files)), "large commit (12 files)") {
+		t.Error("reason should flag the large commit under the custom threshold")
+	}
+}
+
+func TestConfidenceWeights_DirectorySpread(t *testing.T) {
+	event := BeadEvent{BeadID: "bv-1", EventType: EventClosed, CommitMsg: "refactor"}
+	c := NewCoCommitExtractor("/test/repo")
+
+	// 25 files in one package: only the file-count penalty
+	focused := make([]FileChange, 25)
+	for i := range focused {
+		focused[i] = FileChange{Path: fmt.Sprintf("pkg/ui/file%d.go", i)}
+	}
+	// 8 files in 8 unrelated top-level directories: only the spread penalty
+	scattered := make([]FileChange, 8)
+	for i := range scattered {
+		scattered[i] = FileChange{Path: fmt.Sprintf("dir%d/file.go", i)}
+	}
+
+	if got := topLevelDirCount(focused); got != 1 {
+		t.Errorf("focused commit spread = %d, want 1", got)
+	}
+	if got := topLevelDirCount(scattered); got != 8 {
+		t.Errorf("scattered commit spread = %d, want 8", got)
+	}
+
+	focusedConf := c.calculateConfidence(event, focused)
+	scatteredConf := c.calculateConfidence(event, scattered)
+	if focusedConf < 0.8499 || focusedConf > 0.8501 {
+		t.Errorf("focused 25-file commit = %v, want 0.85 (shotgun penalty only)", focusedConf)
+	}
+	if scatteredConf < 0.8499 || scatteredConf > 0.8501 {
+		t.Errorf("scattered 8-file commit = %v, want 0.85 (spread penalty only)", scatteredConf)
+	}
+	if !strings.Contains(c.describeReasons(event, scattered, c.reasonCodes(event, scattered)), "scattered commit (8 top-level directories)") {
+		t.Error("reason should flag the directory spread")
+	}
+	if strings.Contains(c.describeReasons(event, focused, c.reasonCodes(event, focused)), "scattered") {
+		t.Error("a single-package commit should not be flagged as scattered")
+	}
+
+	// Raising the spread threshold lifts the penalty
+	weights := DefaultConfidenceWeights()
+	weights.SpreadThreshold = 8
+	if got := NewCoCommitExtractorWithWeights("/test/repo", weights).calculateConfidence(event, scattered); got != 0.95 {
+		t.Errorf("8 directories should not be penalized with threshold 8, got %v", got)
+	}
+
+	// Root-level files count as a single directory
+	root := []FileChange{{Path: "main.go"}, {Path: "go.mod"}, {Path: "cmd/bv/main.go"}}
+	if got := topLevelDirCount(root); got != 2 {
+		t.Errorf("root files spread = %d, want 2", got)
+	}
+}
+
+func TestConfidenceWeights_Clamped(t *testing.T) {
+	event := BeadEvent{BeadID: "bv-1", CommitMsg: "fix bv-1"}
+	files := []FileChange{{Path: "main.go"}}
+
+	high := NewCoCommitExtractorWithWeights("/test/repo", ConfidenceWeights{Base: 0.9, IDMentionBonus: 0.5})
+	if got := high.calculateConfidence(event, files); got != 1.0 {
+		t.Errorf("expected confidence clamped to 1, got %v", got)
+	}
+
+	low := NewCoCommitExtractorWithWeights("/test/repo", ConfidenceWeights{Base: 0.1, ShotgunPenalty: 0.5, ShotgunThreshold: 0})
+	if got := low.calculateConfidence(event, files); got != 0.0 {
+		t.Errorf("expected confidence clamped to 0, got %v", got)
+	}
+}
@@ -29,6 +29,13 @@ func NewCorrelator(repoPath string, beadsFilePath ...string) *Correlator {
 	}
 }
 
+// SetCachePath persists co-commit extraction results at path (typically
+// CoCommitCachePath(repoPath)) so unchanged repositories skip the scan.
+// Caching is off until this is called; an empty path turns it off again.
+func (c *Correlator) SetCachePath(path string) {
+	c.coCommitter.SetCachePath(path)
+}
+
 // CorrelatorOptions controls how the history report is generated
 type CorrelatorOptions struct {
 	BeadID string     // Filter to single bead ID (empty = all)
@@ -53,7 +60,12 @@ func (c *Correlator) GenerateReport(beads []BeadInfo, opts CorrelatorOptions) (*
 		return nil, fmt.Errorf("extracting events: %w", err)
 	}
 
-	// Extract co-committed files
+	// Extract co-committed files, fanning out to other beads named in messages
+	ids := make([]string, 0, len(beads))
+	for _, bead := range beads {
+		ids = append(ids, bead.ID)
+	}
+	c.coCommitter.SetKnownBeads(ids)
 	commits, err := c.coCommitter.ExtractAllCoCommits(events)
 	if err != nil {
 		return nil, fmt.Errorf("extracting co-commits: %w", err)
@@ -340,7 +340,7 @@ func (m *ExplicitMatcher) CreateCorrelatedCommit(match ExplicitMatch, coCommitte
 	return CorrelatedCommit{
 		BeadID:      match.BeadID,
 		SHA:         match.CommitSHA,
-		ShortSHA:    shortSHA(match.CommitSHA),
+		ShortSHA:    gitAdapter{}.ShortID(match.CommitSHA),
 		Message:     match.Message,
 		Author:      match.Author,
 		AuthorEmail: match.AuthorEmail,
@@ -349,6 +349,7 @@ func (m *ExplicitMatcher) CreateCorrelatedCommit(match ExplicitMatch, coCommitte
 		Method:      MethodExplicitID,
 		Confidence:  match.Confidence,
 		Reason:      reason,
+		ReasonCodes: []ReasonCode{ReasonIDMention},
 	}
 }
 
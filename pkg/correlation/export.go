@@ -0,0 +1,90 @@
+package correlation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// correlatedCommitCSVHeader lists the columns written by WriteCorrelatedCommitsCSV
+var correlatedCommitCSVHeader = []string{
+	"bead_id", "sha", "short_sha", "method", "confidence", "author",
+	"files_changed", "insertions", "deletions", "event_type",
+}
+
+// correlatedCommitRecord is the JSON export form of a CorrelatedCommit. The
+// outer BeadID shadows the embedded one, which is hidden from JSON.
+type correlatedCommitRecord struct {
+	BeadID string `json:"bead_id"`
+	CorrelatedCommit
+}
+
+// CorrelatedCommitsFromHistories flattens the commits of every history into
+// one list with BeadID set, ordered by bead ID, then timestamp, then SHA.
+func CorrelatedCommitsFromHistories(histories map[string]BeadHistory) []CorrelatedCommit {
+	var commits []CorrelatedCommit
+	for beadID, history := range histories {
+		for _, commit := range history.Commits {
+			commit.BeadID = beadID
+			commits = append(commits, commit)
+		}
+	}
+	sort.Slice(commits, func(i, j int) bool {
+		if commits[i].BeadID != commits[j].BeadID {
+			return commits[i].BeadID < commits[j].BeadID
+		}
+		if !commits[i].Timestamp.Equal(commits[j].Timestamp) {
+			return commits[i].Timestamp.Before(commits[j].Timestamp)
+		}
+		return commits[i].SHA < commits[j].SHA
+	})
+	return commits
+}
+
+// WriteCorrelatedCommitsJSON writes commits as an indented JSON array. Each
+// element carries every CorrelatedCommit field plus bead_id.
+func WriteCorrelatedCommitsJSON(w io.Writer, commits []CorrelatedCommit) error {
+	records := make([]correlatedCommitRecord, len(commits))
+	for i, commit := range commits {
+		records[i] = correlatedCommitRecord{BeadID: commit.BeadID, CorrelatedCommit: commit}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(records)
+}
+
+// WriteCorrelatedCommitsCSV writes one row per commit under a header row.
+// Confidence is written with three decimals, and the file list is summarized
+// as a count with summed insertions and deletions.
+func WriteCorrelatedCommitsCSV(w io.Writer, commits []CorrelatedCommit) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(correlatedCommitCSVHeader); err != nil {
+		return err
+	}
+	for _, commit := range commits {
+		insertions, deletions := 0, 0
+		for _, f := range commit.Files {
+			insertions += f.Insertions
+			deletions += f.Deletions
+		}
+		row := []string{
+			commit.BeadID,
+			commit.SHA,
+			commit.ShortSHA,
+			string(commit.Method),
+			strconv.FormatFloat(commit.Confidence, 'f', 3, 64),
+			commit.Author,
+			strconv.Itoa(len(commit.Files)),
+			strconv.Itoa(insertions),
+			strconv.Itoa(deletions),
+			string(commit.EventType),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
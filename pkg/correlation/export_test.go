@@ -0,0 +1,130 @@
+package correlation
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func exportFixtureCommits() []CorrelatedCommit {
+	ts := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []CorrelatedCommit{
+		{
+			BeadID:      "bv-1",
+			SHA:         "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			ShortSHA:    "aaaaaaa",
+			Message:     "Fix login, finally",
+			Author:      "Dev, Jr.",
+			AuthorEmail: "dev@example.com",
+			Timestamp:   ts,
+			Files: []FileChange{
+				{Path: "auth/login.go", Action: "M", Insertions: 10, Deletions: 2},
+				{Path: "auth/login_test.go", Action: "A", Insertions: 30},
+			},
+			Method:      MethodCoCommitted,
+			Confidence:  0.95,
+			Reason:      "Co-committed with status change",
+			ReasonCodes: []ReasonCode{ReasonCoCommitted},
+			EventType:   EventClosed,
+		},
+		{
+			BeadID:     "bv-2",
+			SHA:        "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			ShortSHA:   "bbbbbbb",
+			Message:    "bv-2: tidy",
+			Author:     "Other",
+			Timestamp:  ts.Add(time.Hour),
+			Method:     MethodExplicitID,
+			Confidence: 2.0 / 3,
+			Reason:     "Message references bv-2",
+		},
+	}
+}
+
+func TestWriteCorrelatedCommitsJSON_RoundTrip(t *testing.T) {
+	commits := exportFixtureCommits()
+
+	var buf bytes.Buffer
+	if err := WriteCorrelatedCommitsJSON(&buf, commits); err != nil {
+		t.Fatalf("WriteCorrelatedCommitsJSON: %v", err)
+	}
+
+	var records []correlatedCommitRecord
+	if err := json.Unmarshal(buf.Bytes(), &records); err != nil {
+		t.Fatalf("decoding JSON back: %v\n%s", err, buf.String())
+	}
+	if len(records) != len(commits) {
+		t.Fatalf("got %d records, want %d", len(records), len(commits))
+	}
+	for i, rec := range records {
+		got := rec.CorrelatedCommit
+		got.BeadID = rec.BeadID
+		if !reflect.DeepEqual(got, commits[i]) {
+			t.Errorf("record %d = %+v, want %+v", i, got, commits[i])
+		}
+	}
+
+	// bead_id is present even though CorrelatedCommit hides it
+	var raw []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw[0]["bead_id"] != "bv-1" {
+		t.Errorf("bead_id = %v, want bv-1", raw[0]["bead_id"])
+	}
+
+	buf.Reset()
+	if err := WriteCorrelatedCommitsJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("empty export = %q, want []", got)
+	}
+}
+
+func TestWriteCorrelatedCommitsCSV_RoundTrip(t *testing.T) {
+	commits := exportFixtureCommits()
+
+	var buf bytes.Buffer
+	if err := WriteCorrelatedCommitsCSV(&buf, commits); err != nil {
+		t.Fatalf("WriteCorrelatedCommitsCSV: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV back: %v", err)
+	}
+	want := [][]string{
+		{"bead_id", "sha", "short_sha", "method", "confidence", "author", "files_changed", "insertions", "deletions", "event_type"},
+		{"bv-1", commits[0].SHA, "aaaaaaa", "co_committed", "0.950", "Dev, Jr.", "2", "40", "2", "closed"},
+		{"bv-2", commits[1].SHA, "bbbbbbb", "explicit_id", "0.667", "Other", "0", "0", "0", ""},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("CSV rows =\n%v\nwant\n%v", records, want)
+	}
+}
+
+func TestCorrelatedCommitsFromHistories(t *testing.T) {
+	ts := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	histories := map[string]BeadHistory{
+		"bv-2": {BeadID: "bv-2", Commits: []CorrelatedCommit{
+			{SHA: "c2", Timestamp: ts.Add(2 * time.Hour)},
+			{SHA: "c1", Timestamp: ts},
+		}},
+		"bv-1": {BeadID: "bv-1", Commits: []CorrelatedCommit{{SHA: "c3", Timestamp: ts.Add(time.Hour)}}},
+		"bv-3": {BeadID: "bv-3"},
+	}
+
+	commits := CorrelatedCommitsFromHistories(histories)
+	var got []string
+	for _, c := range commits {
+		got = append(got, c.BeadID+"/"+c.SHA)
+	}
+	want := []string{"bv-1/c3", "bv-2/c1", "bv-2/c2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattened = %v, want %v", got, want)
+	}
+}
@@ -247,7 +247,7 @@ func (nb *NetworkBuilder) addSharedCommitEdges(network *ImpactNetwork) {
 					edgeSet[key] = true
 				}
 				if len(edgeDetails[key]) < 5 { // Keep up to 5 sample SHAs
-					edgeDetails[key] = append(edgeDetails[key], shortSHA(sha))
+					edgeDetails[key] = append(edgeDetails[key], gitAdapter{}.ShortID(sha))
 				}
 			}
 		}
@@ -450,7 +450,7 @@ func (od *OrphanDetector) checkAuthor(candidate *OrphanCandidate, beadScores map
 
 // getCommitFiles returns files changed in a commit.
 func (od *OrphanDetector) getCommitFiles(sha string) []string {
-	cocommit := &CoCommitExtractor{repoPath: od.repoPath}
+	cocommit := NewCoCommitExtractor(od.repoPath)
 	fileChanges, err := cocommit.getFilesChanged(sha)
 	if err != nil {
 		return nil
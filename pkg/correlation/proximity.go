@@ -0,0 +1,120 @@
+package correlation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Commit is a candidate code commit for time-proximity correlation
+type Commit struct {
+	SHA         string
+	Message     string
+	Author      string
+	AuthorEmail string
+	Timestamp   time.Time
+	Files       []FileChange
+}
+
+// DefaultProximityWindow is how far either side of a bead event
+// CorrelateByTimeProximity looks for untagged commits
+const DefaultProximityWindow = 30 * time.Minute
+
+const (
+	// proximityMaxConfidence is the confidence of a commit landing at the
+	// same instant as the event, before any file-overlap bonus
+	proximityMaxConfidence = 0.60
+	// proximityOverlapBonus is added in proportion to the fraction of the
+	// commit's files the bead already touched in correlated commits
+	proximityOverlapBonus = 0.30
+)
+
+// CorrelateByTimeProximity links commits that don't mention a bead but were
+// made by the event's author within ±window of the event. Confidence decays
+// linearly with time distance and rises when the commit touches files the
+// bead was already correlated with (see SetSeenCommits). Commits already
+// correlated elsewhere are skipped, and nothing is returned if the event's
+// own commit is among the candidates (the co-commit extractor handles it).
+func (t *TemporalCorrelator) CorrelateByTimeProximity(event BeadEvent, commits []Commit, window time.Duration) []CorrelatedCommit {
+	if window <= 0 || event.BeadID == "" {
+		return nil
+	}
+	for _, c := range commits {
+		if c.SHA == event.CommitSHA {
+			return nil
+		}
+	}
+
+	known := t.beadFiles[event.BeadID]
+
+	var result []CorrelatedCommit
+	for _, c := range commits {
+		if t.seenCommits[c.SHA] || !sameAuthor(event, c) {
+			continue
+		}
+		distance := c.Timestamp.Sub(event.Timestamp)
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance > window {
+			continue
+		}
+
+		overlap := fileOverlap(c.Files, known)
+		confidence := proximityMaxConfidence*(1-float64(distance)/float64(window)) + proximityOverlapBonus*overlap
+		// Circumstantial evidence never outranks a direct co-commit
+		if base := t.coCommitter.weights.Base; confidence > base {
+			confidence = base
+		}
+
+		reason := fmt.Sprintf("By %s within %s of the bead being %s", c.Author, distance.Round(time.Minute), event.EventType)
+		if overlap > 0 {
+			reason += fmt.Sprintf("; %.0f%% of files previously linked to this bead", overlap*100)
+		}
+
+		result = append(result, CorrelatedCommit{
+			BeadID:      event.BeadID,
+			SHA:         c.SHA,
+			ShortSHA:    t.coCommitter.vcs.ShortID(c.SHA),
+			Message:     c.Message,
+			Author:      c.Author,
+			AuthorEmail: c.AuthorEmail,
+			Timestamp:   c.Timestamp,
+			Files:       c.Files,
+			Method:      MethodTimeProximity,
+			Confidence:  confidence,
+			Reason:      reason,
+			ReasonCodes: []ReasonCode{ReasonTimeProximity},
+			EventType:   event.EventType,
+		})
+	}
+
+	// Highest confidence (closest) first
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Confidence > result[j].Confidence
+	})
+	return result
+}
+
+// sameAuthor matches by email when both sides have one, otherwise by name
+func sameAuthor(event BeadEvent, c Commit) bool {
+	if event.AuthorEmail != "" && c.AuthorEmail != "" {
+		return strings.EqualFold(event.AuthorEmail, c.AuthorEmail)
+	}
+	return event.Author != "" && strings.EqualFold(event.Author, c.Author)
+}
+
+// fileOverlap returns the fraction of files whose path is in known
+func fileOverlap(files []FileChange, known map[string]bool) float64 {
+	if len(files) == 0 || len(known) == 0 {
+		return 0
+	}
+	hits := 0
+	for _, f := range files {
+		if known[f.Path] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(files))
+}
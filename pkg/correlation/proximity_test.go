@@ -0,0 +1,107 @@
+package correlation
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func proximityEvent(at time.Time) BeadEvent {
+	return BeadEvent{
+		BeadID:      "bv-42",
+		EventType:   EventClosed,
+		Timestamp:   at,
+		CommitSHA:   "beadsonly000",
+		CommitMsg:   "close bv-42",
+		Author:      "Dev",
+		AuthorEmail: "dev@example.com",
+	}
+}
+
+func TestCorrelateByTimeProximity_WithinWindow(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tc := NewTemporalCorrelator("/test/repo")
+
+	commits := []Commit{
+		{SHA: "near000000001", Author: "Dev", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(-10 * time.Minute), Files: []FileChange{{Path: "pkg/auth/login.go"}}},
+		{SHA: "outside000002", Author: "Dev", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(31 * time.Minute), Files: []FileChange{{Path: "pkg/auth/login.go"}}},
+		{SHA: "otherauthor03", Author: "Someone", AuthorEmail: "else@example.com", Timestamp: closedAt, Files: []FileChange{{Path: "pkg/auth/login.go"}}},
+	}
+
+	got := tc.CorrelateByTimeProximity(proximityEvent(closedAt), commits, DefaultProximityWindow)
+	if len(got) != 1 {
+		t.Fatalf("expected only the in-window commit by the same author, got %d: %+v", len(got), got)
+	}
+	c := got[0]
+	if c.SHA != "near000000001" || c.BeadID != "bv-42" || c.Method != MethodTimeProximity {
+		t.Errorf("unexpected correlation: %+v", c)
+	}
+	if c.Confidence <= 0 || c.Confidence >= DefaultConfidenceWeights().Base {
+		t.Errorf("confidence %v should be positive and below the co-commit baseline", c.Confidence)
+	}
+	if !strings.Contains(c.Reason, "10m") {
+		t.Errorf("reason should mention the time distance, got %q", c.Reason)
+	}
+}
+
+func TestCorrelateByTimeProximity_JustOutsideWindow(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tc := NewTemporalCorrelator("/test/repo")
+
+	commits := []Commit{
+		{SHA: "late000000001", Author: "Dev", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(DefaultProximityWindow + time.Second)},
+		{SHA: "early00000002", Author: "Dev", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(-DefaultProximityWindow - time.Second)},
+	}
+	if got := tc.CorrelateByTimeProximity(proximityEvent(closedAt), commits, DefaultProximityWindow); len(got) != 0 {
+		t.Errorf("expected no correlation outside the window, got %+v", got)
+	}
+}
+
+func TestCorrelateByTimeProximity_DecayAndOverlap(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tc := NewTemporalCorrelator("/test/repo")
+	tc.SetSeenCommits([]CorrelatedCommit{{
+		BeadID: "bv-42",
+		SHA:    "earlier000000",
+		Files:  []FileChange{{Path: "pkg/auth/login.go"}},
+	}})
+
+	commits := []Commit{
+		{SHA: "close00000001", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(2 * time.Minute), Files: []FileChange{{Path: "docs/notes.md"}}},
+		{SHA: "far0000000002", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(25 * time.Minute), Files: []FileChange{{Path: "docs/notes.md"}}},
+		{SHA: "overlap000003", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(25 * time.Minute), Files: []FileChange{{Path: "pkg/auth/login.go"}}},
+		{SHA: "earlier000000", AuthorEmail: "dev@example.com", Timestamp: closedAt},
+	}
+	got := tc.CorrelateByTimeProximity(proximityEvent(closedAt), commits, DefaultProximityWindow)
+	conf := make(map[string]float64)
+	for _, c := range got {
+		conf[c.SHA] = c.Confidence
+	}
+
+	if _, ok := conf["earlier000000"]; ok {
+		t.Error("commits already correlated should be skipped")
+	}
+	if conf["close00000001"] <= conf["far0000000002"] {
+		t.Errorf("confidence should decay with distance: close=%v far=%v", conf["close00000001"], conf["far0000000002"])
+	}
+	if conf["overlap000003"] <= conf["far0000000002"] {
+		t.Errorf("overlapping files should raise confidence: overlap=%v far=%v", conf["overlap000003"], conf["far0000000002"])
+	}
+	for sha, c := range conf {
+		if c > DefaultConfidenceWeights().Base {
+			t.Errorf("%s confidence %v exceeds the co-commit baseline", sha, c)
+		}
+	}
+}
+
+func TestCorrelateByTimeProximity_EventCommitPresent(t *testing.T) {
+	closedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	event := proximityEvent(closedAt)
+	commits := []Commit{
+		{SHA: event.CommitSHA, AuthorEmail: "dev@example.com", Timestamp: closedAt},
+		{SHA: "near000000001", AuthorEmail: "dev@example.com", Timestamp: closedAt.Add(time.Minute)},
+	}
+	if got := NewTemporalCorrelator("/test/repo").CorrelateByTimeProximity(event, commits, DefaultProximityWindow); got != nil {
+		t.Errorf("events with a matching commit should be left to co-commit extraction, got %+v", got)
+	}
+}
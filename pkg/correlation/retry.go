@@ -0,0 +1,86 @@
+package correlation
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// GitRetryPolicy bounds how the co-commit extractor retries VCS commands
+// that fail for transient reasons, such as another git process holding
+// index.lock. Attempt n waits BaseDelay * 2^(n-1) before running again.
+type GitRetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first; <= 1 disables retries
+	BaseDelay   time.Duration // Delay before the first retry, doubled for each later one
+}
+
+// DefaultGitRetryPolicy returns the built-in policy: three attempts, starting
+// at 100ms
+func DefaultGitRetryPolicy() GitRetryPolicy {
+	return GitRetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond}
+}
+
+// commandRunner runs a program in dir and returns its stdout. Errors from a
+// failed exit should include the program's stderr so they can be classified.
+type commandRunner func(dir, name string, args ...string) ([]byte, error)
+
+// execCommand is the default commandRunner
+func execCommand(dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return out, err
+}
+
+// transientGitErrors are stderr fragments for failures worth retrying: lock
+// contention with a concurrent git process, or a briefly unavailable disk
+var transientGitErrors = []string{
+	".lock': file exists",
+	"another git process seems to be running",
+	"cannot lock ref",
+	"unable to create temporary file",
+	"resource temporarily unavailable",
+}
+
+// isTransientGitError reports whether err looks like a transient failure.
+// Anything unrecognized, such as "not a git repository" or an unknown
+// revision, is treated as permanent.
+func isTransientGitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, fragment := range transientGitErrors {
+		if strings.Contains(msg, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// runWithRetry runs a command through the extractor's runner, retrying
+// transient failures with exponential backoff as set by its retry policy
+func (c *CoCommitExtractor) runWithRetry(name string, args ...string) ([]byte, error) {
+	attempts := max(c.retry.MaxAttempts, 1)
+	delay := c.retry.BaseDelay
+
+	var out []byte
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		out, err = c.run(c.repoPath, name, args...)
+		if err == nil || !isTransientGitError(err) {
+			return out, err
+		}
+		if attempt < attempts && delay > 0 {
+			c.sleep(delay)
+			delay *= 2
+		}
+	}
+	return out, fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
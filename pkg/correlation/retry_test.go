@@ -0,0 +1,97 @@
+package correlation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeRunner fails with the queued errors, one per call, then returns output
+type fakeRunner struct {
+	failures []error
+	output   string
+	calls    int
+}
+
+func (f *fakeRunner) run(dir, name string, args ...string) ([]byte, error) {
+	f.calls++
+	if f.calls <= len(f.failures) {
+		return nil, f.failures[f.calls-1]
+	}
+	return []byte(f.output), nil
+}
+
+func retryTestExtractor(runner *fakeRunner) (*CoCommitExtractor, *[]time.Duration) {
+	c := NewCoCommitExtractor("/test/repo")
+	c.run = runner.run
+	var slept []time.Duration
+	c.sleep = func(d time.Duration) { slept = append(slept, d) }
+	return c, &slept
+}
+
+func TestCoCommitRetry_TransientFailuresThenSuccess(t *testing.T) {
+	lockErr := errors.New("exit status 128: fatal: Unable to create '/test/repo/.git/index.lock': File exists.")
+	runner := &fakeRunner{
+		failures: []error{lockErr, lockErr},
+		output:   "10\t2\tpkg/auth/login.go\n",
+	}
+	c, slept := retryTestExtractor(runner)
+	c.SetRetryPolicy(GitRetryPolicy{MaxAttempts: 4, BaseDelay: 50 * time.Millisecond})
+
+	files, err := c.ExtractCoCommittedFiles(BeadEvent{BeadID: "bv-1", CommitSHA: "abc1234"})
+	if err != nil {
+		t.Fatalf("expected the scan to succeed after retries, got %v", err)
+	}
+	if len(files) != 1 || files[0].Path != "pkg/auth/login.go" || files[0].Insertions != 10 {
+		t.Errorf("files = %+v, want pkg/auth/login.go (+10)", files)
+	}
+	if runner.calls != 3 {
+		t.Errorf("runner called %d times, want 3", runner.calls)
+	}
+	if want := []time.Duration{50 * time.Millisecond, 100 * time.Millisecond}; !reflect.DeepEqual(*slept, want) {
+		t.Errorf("backoff delays = %v, want %v", *slept, want)
+	}
+}
+
+func TestCoCommitRetry_NotARepoFailsFast(t *testing.T) {
+	runner := &fakeRunner{
+		failures: []error{errors.New("exit status 128: fatal: not a git repository (or any of the parent directories): .git")},
+		output:   "10\t2\tmain.go\n",
+	}
+	c, slept := retryTestExtractor(runner)
+
+	_, err := c.ExtractCoCommittedFiles(BeadEvent{BeadID: "bv-1", CommitSHA: "abc1234"})
+	if err == nil || !strings.Contains(err.Error(), "not a git repository") {
+		t.Fatalf("expected a not-a-repo error, got %v", err)
+	}
+	if runner.calls != 1 || len(*slept) != 0 {
+		t.Errorf("permanent error retried: %d calls, slept %v", runner.calls, *slept)
+	}
+}
+
+func TestCoCommitRetry_GivesUpAfterMaxAttempts(t *testing.T) {
+	lockErr := errors.New("fatal: cannot lock ref 'HEAD'")
+	runner := &fakeRunner{failures: []error{lockErr, lockErr, lockErr, lockErr}}
+	c, slept := retryTestExtractor(runner)
+
+	if c.RetryPolicy() != DefaultGitRetryPolicy() {
+		t.Fatalf("new extractor policy = %+v, want the default", c.RetryPolicy())
+	}
+	_, err := c.ExtractCoCommittedFiles(BeadEvent{BeadID: "bv-1", CommitSHA: "abc1234"})
+	if err == nil || !strings.Contains(err.Error(), "giving up after 3 attempts") {
+		t.Fatalf("expected to give up after 3 attempts, got %v", err)
+	}
+	if runner.calls != 3 || len(*slept) != 2 {
+		t.Errorf("got %d calls and %d sleeps, want 3 and 2", runner.calls, len(*slept))
+	}
+
+	// A policy of one attempt disables retries
+	runner = &fakeRunner{failures: []error{lockErr}}
+	c, _ = retryTestExtractor(runner)
+	c.SetRetryPolicy(GitRetryPolicy{MaxAttempts: 1})
+	if _, err := c.ExtractCoCommittedFiles(BeadEvent{CommitSHA: "abc1234"}); err == nil || runner.calls != 1 {
+		t.Errorf("MaxAttempts 1: err=%v calls=%d, want an error after one call", err, runner.calls)
+	}
+}
@@ -72,7 +72,7 @@ func (rl *ReverseLookup) LookupByCommit(sha string) (*CommitBeadResult, error) {
 
 	result := &CommitBeadResult{
 		CommitSHA:    fullSHA,
-		ShortSHA:     shortSHA(fullSHA),
+		ShortSHA:     gitAdapter{}.ShortID(fullSHA),
 		RelatedBeads: []RelatedBead{},
 	}
 
@@ -102,7 +102,7 @@ func (rl *ReverseLookup) LookupByCommit(sha string) (*CommitBeadResult, error) {
 			if strings.HasPrefix(indexSHA, sha) {
 				beadIDs = rl.index[indexSHA]
 				result.CommitSHA = indexSHA
-				result.ShortSHA = shortSHA(indexSHA)
+				result.ShortSHA = gitAdapter{}.ShortID(indexSHA)
 				break
 			}
 		}
@@ -297,7 +297,7 @@ func (rl *ReverseLookup) getAllCodeCommits(opts ExtractOptions) ([]OrphanCommit,
 
 		commits = append(commits, OrphanCommit{
 			SHA:         info.SHA,
-			ShortSHA:    shortSHA(info.SHA),
+			ShortSHA:    gitAdapter{}.ShortID(info.SHA),
 			Message:     info.Message,
 			Author:      info.Author,
 			AuthorEmail: info.AuthorEmail,
@@ -35,6 +35,12 @@ var MethodRanges = map[CorrelationMethod]ConfidenceRange{
 		Max:    0.85,
 		Desc:   "By same author during bead's active window (temporal correlation)",
 	},
+	MethodTimeProximity: {
+		Method: MethodTimeProximity,
+		Min:    0.0,
+		Max:    0.90,
+		Desc:   "By same author within minutes of a bead event (time proximity)",
+	},
 }
 
 // Scorer provides methods for calculating and combining confidence scores.
@@ -259,6 +265,19 @@ func (s *Scorer) MergeCommits(sources ...[]CorrelatedCommit) []CorrelatedCommit
 		}
 		result.Files = allFiles
 
+		// Union reason codes, keeping first-seen order
+		seenCodes := make(map[ReasonCode]bool)
+		var codes []ReasonCode
+		for _, c := range commits {
+			for _, code := range c.ReasonCodes {
+				if !seenCodes[code] {
+					seenCodes[code] = true
+					codes = append(codes, code)
+				}
+			}
+		}
+		result.ReasonCodes = codes
+
 		merged = append(merged, result)
 	}
 
@@ -397,6 +416,17 @@ func (s *Scorer) ExtractSignals(commit CorrelatedCommit) []CorrelationSignal {
 			Weight: 15,
 			Detail: fmt.Sprintf("By assignee: %s", commit.Author),
 		})
+	case MethodTimeProximity:
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalTiming,
+			Weight: 20,
+			Detail: "Commit landed close to a bead status change",
+		})
+		signals = append(signals, CorrelationSignal{
+			Type:   SignalAuthorMatch,
+			Weight: 15,
+			Detail: fmt.Sprintf("By event author: %s", commit.Author),
+		})
 	}
 
 	// File-based signals
@@ -433,6 +463,8 @@ func (s *Scorer) buildSummary(commit CorrelatedCommit, signals []CorrelationSign
 		methodDesc = "Explicitly references bead ID"
 	case MethodTemporalAuthor:
 		methodDesc = "Temporal+author correlation"
+	case MethodTimeProximity:
+		methodDesc = "Time-proximity correlation"
 	}
 
 	return fmt.Sprintf("%s (%.0f%% confidence, %d signals)",
@@ -1,6 +1,7 @@
 package correlation
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -284,6 +285,26 @@ func TestMergeCommits_WithDuplicates(t *testing.T) {
 	}
 }
 
+func TestMergeCommits_UnionsReasonCodes(t *testing.T) {
+	s := NewScorer()
+
+	source1 := []CorrelatedCommit{
+		{SHA: "aaa", Confidence: 0.90, Method: MethodCoCommitted, ReasonCodes: []ReasonCode{ReasonCoCommitted, ReasonIDMention}},
+	}
+	source2 := []CorrelatedCommit{
+		{SHA: "aaa", Confidence: 0.40, Method: MethodTimeProximity, ReasonCodes: []ReasonCode{ReasonTimeProximity, ReasonIDMention}},
+	}
+
+	got := s.MergeCommits(source1, source2)
+	if len(got) != 1 {
+		t.Fatalf("MergeCommits() got %d commits, want 1", len(got))
+	}
+	want := []ReasonCode{ReasonCoCommitted, ReasonIDMention, ReasonTimeProximity}
+	if !reflect.DeepEqual(got[0].ReasonCodes, want) {
+		t.Errorf("MergeCommits() reason codes = %v, want %v", got[0].ReasonCodes, want)
+	}
+}
+
 func TestMergeCommits_SortedByConfidence(t *testing.T) {
 	s := NewScorer()
 
@@ -496,7 +496,7 @@ func (b *BatchFileStatsExtractor) extractIndividually(shas []string) (map[string
 func filterCodeFiles(files []FileChange) []FileChange {
 	var result []FileChange
 	for _, f := range files {
-		if isCodeFile(f.Path) && !isExcludedPath(f.Path) {
+		if defaultCodeFiles.isCodeFile(f.Path) && !defaultCodeFiles.isExcludedPath(f.Path) {
 			result = append(result, f)
 		}
 	}
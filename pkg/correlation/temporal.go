@@ -14,9 +14,10 @@ import (
 // TemporalCorrelator finds commits by the same author within a bead's active time window
 type TemporalCorrelator struct {
 	repoPath     string
-	coCommitter  *CoCommitExtractor // For getting file changes
-	seenCommits  map[string]bool    // Track commits already correlated by higher-confidence methods
-	activeByAuth map[string]int     // Count of active beads per author (for confidence scoring)
+	coCommitter  *CoCommitExtractor         // For getting file changes
+	seenCommits  map[string]bool            // Track commits already correlated by higher-confidence methods
+	activeByAuth map[string]int             // Count of active beads per author (for confidence scoring)
+	beadFiles    map[string]map[string]bool // Bead ID -> paths from already-correlated commits
 }
 
 // NewTemporalCorrelator creates a new temporal correlator
@@ -26,13 +27,24 @@ func NewTemporalCorrelator(repoPath string) *TemporalCorrelator {
 		coCommitter:  NewCoCommitExtractor(repoPath),
 		seenCommits:  make(map[string]bool),
 		activeByAuth: make(map[string]int),
+		beadFiles:    make(map[string]map[string]bool),
 	}
 }
 
-// SetSeenCommits marks commits that were already correlated via higher-confidence methods
+// SetSeenCommits marks commits that were already correlated via higher-confidence
+// methods and remembers which files each bead touched in them
 func (t *TemporalCorrelator) SetSeenCommits(commits []CorrelatedCommit) {
 	for _, c := range commits {
 		t.seenCommits[c.SHA] = true
+		if c.BeadID == "" {
+			continue
+		}
+		if t.beadFiles[c.BeadID] == nil {
+			t.beadFiles[c.BeadID] = make(map[string]bool)
+		}
+		for _, f := range c.Files {
+			t.beadFiles[c.BeadID][f.Path] = true
+		}
 	}
 }
 
@@ -123,7 +135,7 @@ func (t *TemporalCorrelator) FindCommitsInWindow(window TemporalWindow) ([]Corre
 
 		commits = append(commits, CorrelatedCommit{
 			SHA:         sha,
-			ShortSHA:    shortSHA(sha),
+			ShortSHA:    gitAdapter{}.ShortID(sha),
 			Message:     info.Message,
 			Author:      info.Author,
 			AuthorEmail: info.AuthorEmail,
@@ -132,6 +144,7 @@ func (t *TemporalCorrelator) FindCommitsInWindow(window TemporalWindow) ([]Corre
 			Method:      MethodTemporalAuthor,
 			Confidence:  confidence,
 			Reason:      reason,
+			ReasonCodes: []ReasonCode{ReasonTimeProximity},
 		})
 	}
 
@@ -57,6 +57,27 @@ const (
 	MethodExplicitID CorrelationMethod = "explicit_id"
 	// MethodTemporalAuthor means the commit is temporally close and by the assignee
 	MethodTemporalAuthor CorrelationMethod = "temporal_author"
+	// MethodTimeProximity means an untagged commit by the same author landed close to a bead event
+	MethodTimeProximity CorrelationMethod = "time_proximity"
+)
+
+// ReasonCode is a machine-readable factor behind a correlation, letting
+// consumers filter on why a commit was linked without parsing Reason
+type ReasonCode string
+
+const (
+	// ReasonCoCommitted means the commit changed the bead's status in beads.jsonl
+	ReasonCoCommitted ReasonCode = "co_committed"
+	// ReasonIDMention means the commit message references the bead ID
+	ReasonIDMention ReasonCode = "id_mention"
+	// ReasonTimeProximity means the commit was linked by timing and author, not content
+	ReasonTimeProximity ReasonCode = "time_proximity"
+	// ReasonTestOnly means the commit touches only test files
+	ReasonTestOnly ReasonCode = "test_only"
+	// ReasonShotgun means the commit touches too many files to be focused work
+	ReasonShotgun ReasonCode = "shotgun"
+	// ReasonSpread means the commit is scattered across many top-level directories
+	ReasonSpread ReasonCode = "spread"
 )
 
 // String returns the string representation of CorrelationMethod
@@ -67,7 +88,7 @@ func (c CorrelationMethod) String() string {
 // IsValid returns true if the correlation method is a recognized value
 func (c CorrelationMethod) IsValid() bool {
 	switch c {
-	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor:
+	case MethodCoCommitted, MethodExplicitID, MethodTemporalAuthor, MethodTimeProximity:
 		return true
 	}
 	return false
@@ -92,8 +113,10 @@ type CorrelatedCommit struct {
 	Timestamp   time.Time         `json:"timestamp"`
 	Files       []FileChange      `json:"files"`
 	Method      CorrelationMethod `json:"method"`
-	Confidence  float64           `json:"confidence"` // 0.0 to 1.0
-	Reason      string            `json:"reason"`     // Human-readable explanation
+	Confidence  float64           `json:"confidence"`             // 0.0 to 1.0
+	Reason      string            `json:"reason"`                 // Human-readable explanation
+	ReasonCodes []ReasonCode      `json:"reason_codes,omitempty"` // Factors behind Reason, for filtering
+	EventType   EventType         `json:"event_type,omitempty"`   // Bead event the commit accompanied, if any
 }
 
 // BeadMilestones contains key lifecycle timestamps for quick access
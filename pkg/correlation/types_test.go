@@ -52,6 +52,7 @@ func TestCorrelationMethod_String(t *testing.T) {
 		{MethodCoCommitted, "co_committed"},
 		{MethodExplicitID, "explicit_id"},
 		{MethodTemporalAuthor, "temporal_author"},
+		{MethodTimeProximity, "time_proximity"},
 	}
 	for _, tt := range tests {
 		if got := tt.c.String(); got != tt.want {
@@ -68,6 +69,7 @@ func TestCorrelationMethod_IsValid(t *testing.T) {
 		{MethodCoCommitted, true},
 		{MethodExplicitID, true},
 		{MethodTemporalAuthor, true},
+		{MethodTimeProximity, true},
 		{CorrelationMethod("invalid"), false},
 		{CorrelationMethod(""), false},
 	}
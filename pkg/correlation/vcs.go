@@ -0,0 +1,216 @@
+package correlation
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VCSAdapter abstracts the version control system used to look up the files
+// changed by a commit, so co-commit correlation works beyond git.
+type VCSAdapter interface {
+	// ShowCommand returns the program and arguments that print the header
+	// and per-file changes for a single commit or change id.
+	ShowCommand(id string) (string, []string)
+
+	// ParseLog parses ShowCommand output. Each commit header yields a
+	// BeadEvent carrying only commit metadata (no bead ID or event type);
+	// file changes from all commits are returned in order.
+	ParseLog(raw []byte) ([]BeadEvent, []FileChange, error)
+
+	// ShortID abbreviates a commit id for display.
+	ShortID(id string) string
+}
+
+// renamePattern matches brace notation for renames: {old => new}
+var renamePattern = regexp.MustCompile(`\{[^}]* => ([^}]*)\}`)
+
+// gitAdapter reads commits with git show --numstat --summary
+type gitAdapter struct{}
+
+// gitSummaryActions maps git --summary verbs to FileChange actions
+var gitSummaryActions = map[string]string{
+	"create": "A",
+	"delete": "D",
+	"rename": "R",
+	"copy":   "C",
+}
+
+// gitSummaryPattern matches --summary lines, e.g. " create mode 100644 path"
+// or " rename pkg/{old => new}/file.go (100%)"
+var gitSummaryPattern = regexp.MustCompile(`^ (create|delete) mode \d+ (.+)$|^ (rename|copy) (.+) \(\d+%\)$`)
+
+// ShowCommand implements VCSAdapter
+func (g gitAdapter) ShowCommand(id string) (string, []string) {
+	return "git", []string{"-c", "color.ui=false", "show", "-M", "--numstat", "--summary", "--format=" + gitLogHeaderFormat, id}
+}
+
+// ParseLog implements VCSAdapter for git show/log output in gitLogHeaderFormat
+// with --numstat and --summary.
+func (g gitAdapter) ParseLog(raw []byte) ([]BeadEvent, []FileChange, error) {
+	var commits []BeadEvent
+	var files []FileChange
+	index := make(map[string]int) // path -> position in files, per commit
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if commitPattern.MatchString(line) {
+			info, err := parseCommitInfo(line)
+			if err != nil {
+				return nil, nil, err
+			}
+			commits = append(commits, commitEvent(info))
+			index = make(map[string]int)
+			continue
+		}
+
+		if m := gitSummaryPattern.FindStringSubmatch(line); m != nil {
+			verb, path := m[1], m[2]
+			if verb == "" {
+				verb, path = m[3], g.extractNewPath(m[4])
+			}
+			if i, ok := index[path]; ok {
+				files[i].Action = gitSummaryActions[verb]
+			}
+			continue
+		}
+
+		// Format: "42\t10\tpath/to/file" or "-\t-\tbinary/file"
+		parts := strings.Split(line, "\t")
+		if len(parts) < 3 {
+			continue
+		}
+
+		f := FileChange{Path: g.extractNewPath(parts[2]), Action: "M"}
+		// Binary files show "-" instead of numbers
+		if parts[0] != "-" {
+			f.Insertions, _ = strconv.Atoi(parts[0])
+		}
+		if parts[1] != "-" {
+			f.Deletions, _ = strconv.Atoi(parts[1])
+		}
+		index[f.Path] = len(files)
+		files = append(files, f)
+	}
+
+	return commits, files, scanner.Err()
+}
+
+// ShortID returns the first 7 characters of a SHA
+func (g gitAdapter) ShortID(id string) string {
+	if len(id) > 7 {
+		return id[:7]
+	}
+	return id
+}
+
+// extractNewPath handles git's rename notation in numstat output
+func (g gitAdapter) extractNewPath(path string) string {
+	// Handle "{prefix/}{old => new}{/suffix}" format
+	if strings.Contains(path, "{") {
+		// Complex case: "pkg/{old => new}/file.go"
+		path = renamePattern.ReplaceAllString(path, "$1")
+		// Fix potential double slashes if a segment was removed (e.g. "{old => }")
+		return strings.ReplaceAll(path, "//", "/")
+	}
+
+	// Simple case: "old => new"
+	if idx := strings.Index(path, " => "); idx != -1 {
+		return path[idx+4:]
+	}
+
+	return path
+}
+
+// jjLogTemplate prints one NUL-separated header line per change, matching
+// the fields of gitLogHeaderFormat
+const jjLogTemplate = `commit_id ++ "\0" ++ author.timestamp().format("%Y-%m-%dT%H:%M:%S%:z") ++ "\0" ++ author.name() ++ "\0" ++ author.email() ++ "\0" ++ description.first_line() ++ "\n"`
+
+// jjCommitPattern matches a header line produced by jjLogTemplate
+var jjCommitPattern = regexp.MustCompile(`^[0-9a-f]{12,64}\x00`)
+
+// jjAdapter reads changes with jj log --summary (Jujutsu)
+type jjAdapter struct{}
+
+// ShowCommand implements VCSAdapter
+func (j jjAdapter) ShowCommand(id string) (string, []string) {
+	return "jj", []string{"log", "--no-graph", "--ignore-working-copy", "--color=never", "--summary", "-r", id, "-T", jjLogTemplate}
+}
+
+// ParseLog implements VCSAdapter for jj log output using jjLogTemplate.
+// jj's summary has no line counts, so Insertions/Deletions stay zero.
+func (j jjAdapter) ParseLog(raw []byte) ([]BeadEvent, []FileChange, error) {
+	var commits []BeadEvent
+	var files []FileChange
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), gitLogMaxScanTokenSize)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if jjCommitPattern.MatchString(line) {
+			info, err := parseCommitInfo(line)
+			if err != nil {
+				return nil, nil, err
+			}
+			commits = append(commits, commitEvent(info))
+			continue
+		}
+
+		// Format: "M path/to/file" or "R pkg/{old => new}/file.go"
+		if len(line) < 3 || line[1] != ' ' {
+			continue
+		}
+		action := line[:1]
+		if !strings.Contains("MADRC", action) {
+			continue
+		}
+		files = append(files, FileChange{
+			Path:   j.extractNewPath(line[2:]),
+			Action: action,
+		})
+	}
+
+	return commits, files, scanner.Err()
+}
+
+// ShortID returns the first 12 characters of a commit id, jj's default
+// abbreviation length
+func (j jjAdapter) ShortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
+
+// extractNewPath resolves jj's rename notation, which always uses braces
+// ("{old => new}", "dir/{a => b}/file") and never git's bare "old => new"
+func (j jjAdapter) extractNewPath(path string) string {
+	if !strings.Contains(path, " => ") {
+		return path
+	}
+	path = renamePattern.ReplaceAllString(path, "$1")
+	return strings.ReplaceAll(path, "//", "/")
+}
+
+// commitEvent converts parsed commit metadata into a BeadEvent shell
+func commitEvent(info commitInfo) BeadEvent {
+	return BeadEvent{
+		Timestamp:   info.Timestamp,
+		CommitSHA:   info.SHA,
+		CommitMsg:   info.Message,
+		Author:      info.Author,
+		AuthorEmail: info.AuthorEmail,
+	}
+}
@@ -0,0 +1,228 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const gitShowFixture = "146a25299bdb6399210c80c3b6483dddd4e1f6ce\x002025-01-15T10:30:00Z\x00Test User\x00test@example.com\x00feat: close bv-123\n" +
+	"\n" +
+	"1\t0\tb.go\n" +
+	"0\t0\tpkg/{old => new}/f.go\n" +
+	"12\t0\tc.go\n" +
+	"-\t-\tlogo.png\n" +
+	"0\t3\tgone.go\n" +
+	" rename pkg/{old => new}/f.go (100%)\n" +
+	" create mode 100644 c.go\n" +
+	" delete mode 100644 gone.go\n"
+
+func TestGitAdapterParseLog(t *testing.T) {
+	commits, files, err := gitAdapter{}.ParseLog([]byte(gitShowFixture))
+	if err != nil {
+		t.Fatalf("ParseLog: %v", err)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 commit, got %d", len(commits))
+	}
+	if commits[0].CommitSHA != "146a25299bdb6399210c80c3b6483dddd4e1f6ce" || commits[0].Author != "Test User" {
+		t.Errorf("unexpected commit metadata: %+v", commits[0])
+	}
+	if commits[0].BeadID != "" || commits[0].EventType != "" {
+		t.Errorf("commit headers should not carry bead data: %+v", commits[0])
+	}
+
+	want := []FileChange{
+		{Path: "b.go", Action: "M", Insertions: 1},
+		{Path: "pkg/new/f.go", Action: "R"},
+		{Path: "c.go", Action: "A", Insertions: 12},
+		{Path: "logo.png", Action: "M"},
+		{Path: "gone.go", Action: "D", Deletions: 3},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %d: %+v", len(want), len(files), files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files[%d] = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestGitAdapterParseLog_InvalidHeader(t *testing.T) {
+	raw := "146a25299bdb6399210c80c3b6483dddd4e1f6ce\x00not-a-time\x00A\x00a@b\x00msg\n"
+	if _, _, err := (gitAdapter{}).ParseLog([]byte(raw)); err == nil {
+		t.Error("expected an error for a malformed header timestamp")
+	}
+}
+
+const jjLogFixture = "8f3c2a1b9d4e7f60a1b2c3d4e5f60718293a4b5c\x002025-01-15T10:30:00+00:00\x00Test User\x00test@example.com\x00close bv-123\n" +
+	"M src/main.rs\n" +
+	"A src/new.rs\n" +
+	"D src/old.rs\n" +
+	"R src/{util => helpers}/mod.rs\n" +
+	"R {a.rs => b.rs}\n"
+
+func TestJJAdapterParseLog(t *testing.T) {
+	commits, files, err := jjAdapter{}.ParseLog([]byte(jjLogFixture))
+	if err != nil {
+		t.Fatalf("ParseLog: %v", err)
+	}
+
+	if len(commits) != 1 || commits[0].CommitMsg != "close bv-123" {
+		t.Fatalf("unexpected commits: %+v", commits)
+	}
+
+	want := []FileChange{
+		{Path: "src/main.rs", Action: "M"},
+		{Path: "src/new.rs", Action: "A"},
+		{Path: "src/old.rs", Action: "D"},
+		{Path: "src/helpers/mod.rs", Action: "R"},
+		{Path: "b.rs", Action: "R"},
+	}
+	if len(files) != len(want) {
+		t.Fatalf("expected %d files, got %d: %+v", len(want), len(files), files)
+	}
+	for i := range want {
+		if files[i] != want[i] {
+			t.Errorf("files[%d] = %+v, want %+v", i, files[i], want[i])
+		}
+	}
+}
+
+func TestJJAdapterExtractNewPath(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"src/{util => helpers}/mod.rs", "src/helpers/mod.rs"},
+		{"{a.rs => b.rs}", "b.rs"},
+		{"src/{old => }/file.rs", "src/file.rs"},
+		{"src/main.rs", "src/main.rs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := (jjAdapter{}).extractNewPath(tt.input); got != tt.want {
+				t.Errorf("extractNewPath(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVCSAdapterShortID(t *testing.T) {
+	id := "8f3c2a1b9d4e7f60a1b2c3d4e5f60718293a4b5c"
+	if got := (gitAdapter{}).ShortID(id); got != "8f3c2a1" {
+		t.Errorf("git ShortID = %q", got)
+	}
+	if got := (jjAdapter{}).ShortID(id); got != "8f3c2a1b9d4e" {
+		t.Errorf("jj ShortID = %q", got)
+	}
+	if got := (jjAdapter{}).ShortID("abc"); got != "abc" {
+		t.Errorf("jj ShortID should keep short ids, got %q", got)
+	}
+}
+
+// catAdapter replays canned jj output from a file so the extractor's
+// command plumbing runs without a real repository
+type catAdapter struct {
+	jjAdapter
+	path string
+}
+
+func (c catAdapter) ShowCommand(id string) (string, []string) {
+	return "cat", []string{c.path}
+}
+
+func TestCoCommitExtractorUsesAdapter(t *testing.T) {
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat not available")
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "log.txt")
+	raw := jjLogFixture + "M .beads/beads.jsonl\nM docs/logo.png\n"
+	if err := os.WriteFile(path, []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c := NewCoCommitExtractorWithAdapter(dir, catAdapter{path: path})
+	event := BeadEvent{BeadID: "bv-123", EventType: EventClosed, CommitSHA: "8f3c2a1b9d4e7f60a1b2c3d4e5f60718293a4b5c"}
+
+	files, err := c.ExtractCoCommittedFiles(event)
+	if err != nil {
+		t.Fatalf("ExtractCoCommittedFiles: %v", err)
+	}
+	if len(files) != 5 {
+		t.Errorf("expected 5 code files (beads and binary files filtered), got %d: %+v", len(files), files)
+	}
+
+	commit := c.CreateCorrelatedCommit(event, files)
+	if commit.ShortSHA != "8f3c2a1b9d4e" {
+		t.Errorf("ShortSHA should come from the adapter, got %q", commit.ShortSHA)
+	}
+}
+
+func TestNewCoCommitExtractorDefaultsToGit(t *testing.T) {
+	if _, ok := NewCoCommitExtractor("/tmp").vcs.(gitAdapter); !ok {
+		t.Error("NewCoCommitExtractor should be git-backed")
+	}
+	if _, ok := NewCoCommitExtractorWithAdapter("/tmp", nil).vcs.(gitAdapter); !ok {
+		t.Error("a nil adapter should fall back to git")
+	}
+}
+
+func TestGitAdapterExtractsFromRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test User")
+	write("pkg/old/f.go", "package old\n")
+	write("main.go", "package main\n")
+	run("add", "-A")
+	run("commit", "-qm", "init")
+	run("mv", "pkg/old", "pkg/new")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	run("add", "-A")
+	run("commit", "-qm", "close bv-1")
+	sha := run("rev-parse", "HEAD")[:40]
+
+	files, err := NewCoCommitExtractor(dir).ExtractCoCommittedFiles(BeadEvent{CommitSHA: sha})
+	if err != nil {
+		t.Fatalf("ExtractCoCommittedFiles: %v", err)
+	}
+	got := make(map[string]FileChange)
+	for _, f := range files {
+		got[f.Path] = f
+	}
+	if f := got["main.go"]; f.Action != "M" || f.Insertions != 2 {
+		t.Errorf("main.go = %+v, want M with 2 insertions", f)
+	}
+	if f := got["pkg/new/f.go"]; f.Action != "R" {
+		t.Errorf("pkg/new/f.go = %+v, want rename", f)
+	}
+}
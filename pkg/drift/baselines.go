@@ -0,0 +1,145 @@
+package drift
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// BaselinesDir is the directory (under .bv) holding named baselines
+const BaselinesDir = "baselines"
+
+// validBaselineName restricts names to simple filenames so they can't escape
+// the baselines directory.
+var validBaselineName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// BaselineInfo describes a saved baseline for listing
+type BaselineInfo struct {
+	Name      string    `json:"name"`            // Baseline name ("" for the default baseline)
+	Path      string    `json:"path"`            // File path of the baseline
+	CreatedAt time.Time `json:"created_at"`      // When the baseline was saved
+	Label     string    `json:"label,omitempty"` // User-provided description
+	IsDefault bool      `json:"is_default"`      // True for the unnamed .bv/baseline.json
+}
+
+// ValidateBaselineName checks that a baseline name is usable as a filename.
+// The empty name is valid and refers to the default baseline.
+func ValidateBaselineName(name string) error {
+	if name == "" {
+		return nil
+	}
+	if !validBaselineName.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid baseline name %q: use letters, digits, '.', '_' or '-'", name)
+	}
+	return nil
+}
+
+// BaselinePath returns the file path for a named baseline.
+// An empty name maps to the default .bv/baseline.json for backward compatibility.
+func BaselinePath(projectDir, name string) (string, error) {
+	if err := ValidateBaselineName(name); err != nil {
+		return "", err
+	}
+	if name == "" {
+		return baseline.DefaultPath(projectDir), nil
+	}
+	return filepath.Join(projectDir, ".bv", BaselinesDir, name+".json"), nil
+}
+
+// SaveBaselineNamed saves bl under .bv/baselines/<name>.json (or the default
+// path when name is empty). A non-empty label replaces bl.Description.
+// Drift dismissals tied to the baseline it replaces expire.
+func SaveBaselineNamed(projectDir, name, label string, bl *baseline.Baseline) error {
+	if bl == nil {
+		return fmt.Errorf("saving baseline %q: nil baseline", name)
+	}
+	path, err := BaselinePath(projectDir, name)
+	if err != nil {
+		return err
+	}
+	if label != "" {
+		bl.Description = label
+	}
+	var replaced *baseline.Baseline
+	if baseline.Exists(path) {
+		replaced, _ = baseline.Load(path) // An unreadable old baseline has nothing to expire
+	}
+	if err := bl.Save(path); err != nil {
+		return fmt.Errorf("saving baseline %q: %w", name, err)
+	}
+	if replaced != nil {
+		if err := ExpireDismissals(projectDir, replaced.ID()); err != nil {
+			return fmt.Errorf("expiring drift dismissals: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadBaselineNamed loads a named baseline (or the default when name is empty)
+func LoadBaselineNamed(projectDir, name string) (*baseline.Baseline, error) {
+	path, err := BaselinePath(projectDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return baseline.Load(path)
+}
+
+// ListBaselines returns the default baseline (if present) followed by all
+// named baselines sorted by name. Files that fail to parse are skipped.
+func ListBaselines(projectDir string) ([]BaselineInfo, error) {
+	var infos []BaselineInfo
+
+	defaultPath := baseline.DefaultPath(projectDir)
+	if baseline.Exists(defaultPath) {
+		if bl, err := baseline.Load(defaultPath); err == nil {
+			infos = append(infos, BaselineInfo{
+				Path:      defaultPath,
+				CreatedAt: bl.CreatedAt,
+				Label:     bl.Description,
+				IsDefault: true,
+			})
+		}
+	}
+
+	dir := filepath.Join(projectDir, ".bv", BaselinesDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return infos, nil
+		}
+		return nil, fmt.Errorf("reading baselines directory: %w", err)
+	}
+
+	var named []BaselineInfo
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		if ValidateBaselineName(name) != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		bl, err := baseline.Load(path)
+		if err != nil {
+			continue
+		}
+		named = append(named, BaselineInfo{
+			Name:      name,
+			Path:      path,
+			CreatedAt: bl.CreatedAt,
+			Label:     bl.Description,
+		})
+	}
+	sort.Slice(named, func(i, j int) bool {
+		return named[i].Name < named[j].Name
+	})
+
+	return append(infos, named...), nil
+}
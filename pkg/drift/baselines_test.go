@@ -0,0 +1,123 @@
+package drift
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+func TestBaselinePath(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := BaselinePath(dir, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != baseline.DefaultPath(dir) {
+		t.Errorf("empty name should map to default path, got %s", path)
+	}
+
+	path, err = BaselinePath(dir, "sprint-start")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := filepath.Join(dir, ".bv", "baselines", "sprint-start.json")
+	if path != want {
+		t.Errorf("BaselinePath = %s, want %s", path, want)
+	}
+
+	for _, bad := range []string{"../escape", "a/b", ".hidden", "has space", "x..y"} {
+		if _, err := BaselinePath(dir, bad); err == nil {
+			t.Errorf("expected error for name %q", bad)
+		}
+	}
+}
+
+func TestNamedBaselinesDriftAgainstEach(t *testing.T) {
+	dir := t.TempDir()
+
+	release := &baseline.Baseline{
+		Version:   baseline.CurrentVersion,
+		CreatedAt: time.Now().Add(-30 * 24 * time.Hour),
+		Stats:     baseline.GraphStats{NodeCount: 50, EdgeCount: 50, Density: 0.02, BlockedCount: 2},
+	}
+	sprint := &baseline.Baseline{
+		Version:   baseline.CurrentVersion,
+		CreatedAt: time.Now().Add(-7 * 24 * time.Hour),
+		Stats:     baseline.GraphStats{NodeCount: 100, EdgeCount: 200, Density: 0.04, BlockedCount: 10},
+	}
+	if err := SaveBaselineNamed(dir, "last-release", "v1.2.0", release); err != nil {
+		t.Fatalf("save last-release: %v", err)
+	}
+	if err := SaveBaselineNamed(dir, "sprint-start", "Sprint 14", sprint); err != nil {
+		t.Fatalf("save sprint-start: %v", err)
+	}
+
+	current := &baseline.Baseline{
+		Stats: baseline.GraphStats{NodeCount: 100, EdgeCount: 200, Density: 0.04, BlockedCount: 10},
+	}
+
+	loadedRelease, err := LoadBaselineNamed(dir, "last-release")
+	if err != nil {
+		t.Fatalf("load last-release: %v", err)
+	}
+	releaseResult := NewCalculator(loadedRelease, current, nil).Calculate()
+	if !releaseResult.HasDrift {
+		t.Error("expected drift against last-release baseline")
+	}
+
+	loadedSprint, err := LoadBaselineNamed(dir, "sprint-start")
+	if err != nil {
+		t.Fatalf("load sprint-start: %v", err)
+	}
+	sprintResult := NewCalculator(loadedSprint, current, nil).Calculate()
+	if sprintResult.HasDrift {
+		t.Errorf("expected no drift against sprint-start baseline, got %d alerts", len(sprintResult.Alerts))
+	}
+
+	if _, err := LoadBaselineNamed(dir, "missing"); err == nil {
+		t.Error("expected error loading missing baseline")
+	}
+}
+
+func TestListBaselines(t *testing.T) {
+	dir := t.TempDir()
+
+	infos, err := ListBaselines(dir)
+	if err != nil {
+		t.Fatalf("ListBaselines on empty project: %v", err)
+	}
+	if len(infos) != 0 {
+		t.Fatalf("expected no baselines, got %d", len(infos))
+	}
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, name := range []string{"", "sprint-start", "last-release"} {
+		bl := &baseline.Baseline{Version: baseline.CurrentVersion, CreatedAt: created}
+		if err := SaveBaselineNamed(dir, name, "label-"+name, bl); err != nil {
+			t.Fatalf("save %q: %v", name, err)
+		}
+	}
+
+	infos, err = ListBaselines(dir)
+	if err != nil {
+		t.Fatalf("ListBaselines: %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 baselines, got %d", len(infos))
+	}
+	if !infos[0].IsDefault || infos[0].Name != "" {
+		t.Errorf("expected default baseline first, got %+v", infos[0])
+	}
+	if infos[1].Name != "last-release" || infos[2].Name != "sprint-start" {
+		t.Errorf("expected named baselines sorted by name, got %s, %s", infos[1].Name, infos[2].Name)
+	}
+	if infos[2].Label != "label-sprint-start" {
+		t.Errorf("expected label to round-trip, got %q", infos[2].Label)
+	}
+	if !infos[1].CreatedAt.Equal(created) {
+		t.Errorf("expected created_at %v, got %v", created, infos[1].CreatedAt)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"gopkg.in/yaml.v3"
 )
 
@@ -34,6 +38,15 @@ type Config struct {
 	// PageRankChangeWarningPct triggers warning when PageRank changes by this pct
 	PageRankChangeWarningPct float64 `yaml:"pagerank_change_warning_pct" json:"pagerank_change_warning_pct"`
 
+	// ClosureVelocityDropPct triggers warning when issues closed per week fall by this pct (0 disables)
+	ClosureVelocityDropPct float64 `yaml:"closure_velocity_drop_pct" json:"closure_velocity_drop_pct"`
+
+	// Label health drop thresholds: warn when a label's composite health falls
+	// by this many points, or by this percentage of its baseline health
+	// (0 disables either)
+	LabelHealthDropPoints int     `yaml:"label_health_drop_points" json:"label_health_drop_points"`
+	LabelHealthDropPct    float64 `yaml:"label_health_drop_pct" json:"label_health_drop_pct"`
+
 	// Staleness thresholds (days since last update)
 	StaleWarningDays  int `yaml:"stale_warning_days" json:"stale_warning_days"`
 	StaleCriticalDays int `yaml:"stale_critical_days" json:"stale_critical_days"`
@@ -45,13 +58,41 @@ type Config struct {
 	BlockingCascadeInfo    int `yaml:"blocking_cascade_info_threshold" json:"blocking_cascade_info_threshold"`
 	BlockingCascadeWarning int `yaml:"blocking_cascade_warning_threshold" json:"blocking_cascade_warning_threshold"`
 
+	// WIP limit: warn when more than MaxInProgress issues are in progress,
+	// critical past MaxInProgressCritical (0 disables either threshold)
+	MaxInProgress         int `yaml:"max_in_progress" json:"max_in_progress"`
+	MaxInProgressCritical int `yaml:"max_in_progress_critical" json:"max_in_progress_critical"`
+
+	// IgnoreClosedInGraphMetrics compares density, node and edge counts on the
+	// live graph (closed issues left out of both snapshots), so pruning closed
+	// issues isn't reported as drift. Velocity still counts closures.
+	IgnoreClosedInGraphMetrics bool `yaml:"ignore_closed_in_graph_metrics" json:"ignore_closed_in_graph_metrics"`
+
+	// HistoryMaxEntries caps .bv/drift-history.jsonl; older runs are rotated out
+	HistoryMaxEntries int `yaml:"history_max_entries" json:"history_max_entries"`
+
 	// Alert type enable/disable flags (bv-167)
 	// Disabled alert types will not generate alerts
 	DisabledAlerts []string `yaml:"disabled_alerts,omitempty" json:"disabled_alerts,omitempty"`
 
+	// SeverityOverrides forces the severity of an alert type (e.g. new_cycle:
+	// warning), replacing whatever the thresholds assigned. The overridden
+	// severities drive the summary counts and exit code.
+	SeverityOverrides map[string]string `yaml:"severity_overrides,omitempty" json:"severity_overrides,omitempty"`
+
+	// Ignore lists for long-lived tracking issues (epics, meta-issues).
+	// Ignored issues still count toward graph shape (nodes, edges, density)
+	// but are skipped for staleness, blocked-count and cascade alerts.
+	IgnoreIssueIDs []string `yaml:"ignore_issue_ids,omitempty" json:"ignore_issue_ids,omitempty"`
+	IgnoreLabels   []string `yaml:"ignore_labels,omitempty" json:"ignore_labels,omitempty"`
+
 	// Per-label staleness overrides (bv-167)
 	// Labels can have tighter or looser thresholds than the default
 	LabelOverrides map[string]*LabelConfig `yaml:"label_overrides,omitempty" json:"label_overrides,omitempty"`
+
+	// PerLabel holds partial threshold overrides evaluated against each label's
+	// subgraph. Zero values fall back to the global thresholds (see ForLabel).
+	PerLabel map[string]Config `yaml:"per_label,omitempty" json:"per_label,omitempty"`
 }
 
 // LabelConfig allows per-label threshold customization (bv-167)
@@ -75,11 +116,14 @@ func DefaultConfig() *Config {
 		ActionableDecreaseWarningPct: 30,  // 30% decrease in actionable triggers warning
 		ActionableIncreaseInfoPct:    20,  // 20% change in actionable triggers info
 		PageRankChangeWarningPct:     50,  // 50% PageRank change triggers warning
+		ClosureVelocityDropPct:       50,  // 50% drop in closures/week triggers warning
+		LabelHealthDropPoints:        20,  // Label health down 20+ points triggers warning
 		StaleWarningDays:             14,  // Warn after 14 days inactive
 		StaleCriticalDays:            30,  // Critical after 30 days inactive
 		InProgressStaleMultiplier:    0.5, // In-progress thresholds are half as long
 		BlockingCascadeInfo:          3,   // Info alert when unblocks >=3
 		BlockingCascadeWarning:       5,   // Warning when unblocks >=5
+		HistoryMaxEntries:            500, // Keep the last 500 --check-drift runs
 	}
 }
 
@@ -91,23 +135,60 @@ func ConfigPath(projectDir string) string {
 	return filepath.Join(projectDir, ".bv", ConfigFilename)
 }
 
+// UserConfigPath returns the per-user drift config path (~/.config/bv/drift.yaml)
+func UserConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "bv", ConfigFilename), nil
+}
+
 // LoadConfig loads drift configuration from .bv/drift.yaml
 // Returns default config if file doesn't exist
 func LoadConfig(projectDir string) (*Config, error) {
-	path := ConfigPath(projectDir)
+	return loadConfigLayers(ConfigPath(projectDir))
+}
 
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// Return defaults if no config file
-			return DefaultConfig(), nil
-		}
-		return nil, fmt.Errorf("reading drift config: %w", err)
+// LoadConfigLayered loads drift configuration in layers: defaults, then the
+// user config (~/.config/bv/drift.yaml), then the project's .bv/drift.yaml,
+// then BV_DRIFT_* environment variables. Missing files are skipped.
+//
+// Each file is decoded on top of the previous layers, so only keys present
+// in a file override earlier values. Unlike MergeConfig, a key explicitly set
+// to zero (e.g. closure_velocity_drop_pct: 0 to disable that alert) does
+// override. Only the final result is validated, so a user file may hold
+// values that are only valid together with the project's.
+func LoadConfigLayered(projectDir string) (*Config, error) {
+	var paths []string
+	if userPath, err := UserConfigPath(); err == nil {
+		paths = append(paths, userPath)
 	}
+	paths = append(paths, ConfigPath(projectDir))
+	return loadConfigLayers(paths...)
+}
 
+// loadConfigLayers decodes each existing file over the defaults in order,
+// applies environment overrides and validates the result.
+func loadConfigLayers(paths ...string) (*Config, error) {
 	config := DefaultConfig() // Start with defaults
-	if err := yaml.Unmarshal(data, config); err != nil {
-		return nil, fmt.Errorf("parsing drift config: %w", err)
+
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("reading drift config: %w", err)
+		}
+		if err := yaml.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("parsing drift config %s: %w", path, err)
+		}
+	}
+
+	// Environment variables win over file values (e.g. tighter CI thresholds)
+	if err := config.ApplyEnvOverrides(); err != nil {
+		return nil, fmt.Errorf("drift config env override: %w", err)
 	}
 
 	// Validate loaded config
@@ -118,6 +199,249 @@ func LoadConfig(projectDir string) (*Config, error) {
 	return config, nil
 }
 
+// MergeConfig returns a copy of base with every non-zero field of override
+// layered on top. Zero values (0, nil or empty slices and maps) mean "unset"
+// and keep the base value, so an override cannot set a threshold to zero;
+// decode YAML over the base instead (as LoadConfigLayered does) when an
+// explicit zero must win. Slices are replaced wholesale; LabelOverrides and
+// PerLabel are merged per label. Either argument may be nil.
+func MergeConfig(base, override *Config) *Config {
+	merged := DefaultConfig()
+	if base != nil {
+		*merged = *base
+		merged.LabelOverrides = copyMap(base.LabelOverrides)
+		merged.PerLabel = copyMap(base.PerLabel)
+	}
+	if override == nil {
+		return merged
+	}
+
+	// Numeric thresholds share the field table with env overrides and Validate
+	overlayFields(merged, override, false)
+	if len(override.IgnoreIssueIDs) > 0 {
+		merged.IgnoreIssueIDs = override.IgnoreIssueIDs
+	}
+	if len(override.IgnoreLabels) > 0 {
+		merged.IgnoreLabels = override.IgnoreLabels
+	}
+	for label, lc := range override.LabelOverrides {
+		if merged.LabelOverrides == nil {
+			merged.LabelOverrides = make(map[string]*LabelConfig)
+		}
+		merged.LabelOverrides[label] = lc
+	}
+	for label, pc := range override.PerLabel {
+		if merged.PerLabel == nil {
+			merged.PerLabel = make(map[string]Config)
+		}
+		merged.PerLabel[label] = pc
+	}
+	return merged
+}
+
+// overlayFields copies every non-zero field-table value of src onto dst.
+// When skipGlobalOnly is set, fields that only make sense globally are left alone.
+func overlayFields(dst, src *Config, skipGlobalOnly bool) {
+	dstSpecs := dst.fieldSpecs()
+	for i, f := range src.fieldSpecs() {
+		if skipGlobalOnly && f.globalOnly {
+			continue
+		}
+		switch target := f.target.(type) {
+		case *float64:
+			if *target != 0 {
+				*dstSpecs[i].target.(*float64) = *target
+			}
+		case *int:
+			if *target != 0 {
+				*dstSpecs[i].target.(*int) = *target
+			}
+		case *[]string:
+			if len(*target) > 0 {
+				*dstSpecs[i].target.(*[]string) = *target
+			}
+		}
+	}
+}
+
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	cp := make(map[K]V, len(m))
+	for k, v := range m {
+		cp[k] = v
+	}
+	return cp
+}
+
+// EnvVarPrefix is the prefix for drift threshold environment overrides
+const EnvVarPrefix = "BV_DRIFT_"
+
+// fieldSpec describes a threshold field. The same table drives environment
+// overrides, range checks in Validate and ConfigJSONSchema so they can't diverge.
+// The environment variable name is EnvVarPrefix + the upper-cased YAML key.
+type fieldSpec struct {
+	key          string
+	target       any // *float64, *int, or *[]string
+	description  string
+	min          float64 // inclusive lower bound (exclusive when exclusiveMin)
+	exclusiveMin bool
+	max          float64 // inclusive upper bound; 0 means unbounded
+	minKey       string  // value must be >= this field's value
+	maxKey       string  // value must be <= this field's value
+	globalOnly   bool    // not meaningful as a per-label override
+}
+
+func (c *Config) fieldSpecs() []fieldSpec {
+	return []fieldSpec{
+		{key: "density_warning_pct", target: &c.DensityWarningPct, max: 1000,
+			description: "Warn when graph density increases by this percentage"},
+		{key: "density_info_pct", target: &c.DensityInfoPct, maxKey: "density_warning_pct",
+			description: "Info when graph density increases by this percentage"},
+		{key: "node_growth_info_pct", target: &c.NodeGrowthInfoPct, max: 1000,
+			description: "Info when node count changes by this percentage"},
+		{key: "edge_growth_info_pct", target: &c.EdgeGrowthInfoPct, max: 1000,
+			description: "Info when edge count changes by this percentage"},
+		{key: "blocked_increase_threshold", target: &c.BlockedIncreaseThreshold,
+			description: "Warn when this many more issues are blocked"},
+		{key: "actionable_decrease_warning_pct", target: &c.ActionableDecreaseWarningPct, max: 100,
+			description: "Warn when actionable issues decrease by this percentage"},
+		{key: "actionable_increase_info_pct", target: &c.ActionableIncreaseInfoPct, max: 1000,
+			description: "Info when actionable issues change by this percentage"},
+		{key: "pagerank_change_warning_pct", target: &c.PageRankChangeWarningPct, max: 1000,
+			description: "Warn when an issue's PageRank changes by this percentage"},
+		{key: "closure_velocity_drop_pct", target: &c.ClosureVelocityDropPct, max: 100,
+			description: "Warn when issues closed per week drop by this percentage (0 disables)"},
+		{key: "label_health_drop_points", target: &c.LabelHealthDropPoints, max: 100, globalOnly: true,
+			description: "Warn when a label's health drops by this many points (0 disables)"},
+		{key: "label_health_drop_pct", target: &c.LabelHealthDropPct, max: 100, globalOnly: true,
+			description: "Warn when a label's health drops by this percentage (0 disables)"},
+		{key: "stale_warning_days", target: &c.StaleWarningDays, exclusiveMin: true,
+			description: "Warn when an issue is inactive for this many days"},
+		{key: "stale_critical_days", target: &c.StaleCriticalDays, exclusiveMin: true, minKey: "stale_warning_days",
+			description: "Critical when an issue is inactive for this many days"},
+		{key: "in_progress_stale_multiplier", target: &c.InProgressStaleMultiplier, exclusiveMin: true, max: 5,
+			description: "Multiplier applied to staleness thresholds for in_progress issues (<1 tightens)"},
+		{key: "blocking_cascade_info_threshold", target: &c.BlockingCascadeInfo,
+			description: "Info when completing an issue unblocks this many items"},
+		{key: "blocking_cascade_warning_threshold", target: &c.BlockingCascadeWarning, minKey: "blocking_cascade_info_threshold",
+			description: "Warn when completing an issue unblocks this many items"},
+		{key: "max_in_progress", target: &c.MaxInProgress, globalOnly: true,
+			description: "Warn when more than this many issues are in progress (0 disables)"},
+		{key: "max_in_progress_critical", target: &c.MaxInProgressCritical, globalOnly: true,
+			description: "Critical when more than this many issues are in progress (0 disables)"},
+		{key: "history_max_entries", target: &c.HistoryMaxEntries, exclusiveMin: true, globalOnly: true,
+			description: "Maximum runs kept in .bv/drift-history.jsonl before the oldest are rotated out"},
+		{key: "disabled_alerts", target: &c.DisabledAlerts,
+			description: "Alert types that should never be raised (e.g. stale_issue)"},
+	}
+}
+
+// number returns the field's current value for numeric fields
+func (f fieldSpec) number() (float64, bool) {
+	switch target := f.target.(type) {
+	case *float64:
+		return *target, true
+	case *int:
+		return float64(*target), true
+	}
+	return 0, false
+}
+
+// checkRange validates a numeric field against its bounds. values holds the
+// current numbers of all fields so minKey/maxKey can be resolved.
+func (f fieldSpec) checkRange(values map[string]float64) error {
+	v, ok := f.number()
+	if !ok {
+		return nil
+	}
+	tooLow := v < f.min || (f.exclusiveMin && v <= f.min)
+	switch {
+	case f.maxKey != "" && (tooLow || v > values[f.maxKey]):
+		return fmt.Errorf("%s must be between %g and %s", f.key, f.min, f.maxKey)
+	case f.max > 0 && (tooLow || v > f.max):
+		return fmt.Errorf("%s must be between %g and %g", f.key, f.min, f.max)
+	case tooLow && f.exclusiveMin && f.min == 0:
+		return fmt.Errorf("%s must be positive", f.key)
+	case tooLow && f.min == 0:
+		return fmt.Errorf("%s must be non-negative", f.key)
+	case tooLow:
+		return fmt.Errorf("%s must be >= %g", f.key, f.min)
+	case f.minKey != "" && v < values[f.minKey]:
+		return fmt.Errorf("%s must be >= %s", f.key, f.minKey)
+	}
+	return nil
+}
+
+func envVarName(key string) string {
+	return EnvVarPrefix + strings.ToUpper(key)
+}
+
+// EnvVarNames lists the environment variables honored by ApplyEnvOverrides.
+// disabled_alerts takes a comma-separated list of alert types.
+func EnvVarNames() []string {
+	specs := (&Config{}).fieldSpecs()
+	names := make([]string, 0, len(specs))
+	for _, f := range specs {
+		names = append(names, envVarName(f.key))
+	}
+	return names
+}
+
+// ApplyEnvOverrides overlays BV_DRIFT_* environment variables on top of the
+// current values. Empty variables are ignored; unknown names and unparseable
+// values return an error naming the offending variable.
+func (c *Config) ApplyEnvOverrides() error {
+	specs := c.fieldSpecs()
+	known := make(map[string]bool, len(specs))
+	for _, f := range specs {
+		known[envVarName(f.key)] = true
+	}
+	var unknown []string
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, EnvVarPrefix) && !known[name] && strings.TrimSpace(value) != "" {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%s: unknown drift setting", unknown[0])
+	}
+
+	for _, f := range specs {
+		name := envVarName(f.key)
+		raw := strings.TrimSpace(os.Getenv(name))
+		if raw == "" {
+			continue
+		}
+		switch target := f.target.(type) {
+		case *float64:
+			v, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return fmt.Errorf("%s: invalid number %q", name, raw)
+			}
+			*target = v
+		case *int:
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return fmt.Errorf("%s: invalid integer %q", name, raw)
+			}
+			*target = v
+		case *[]string:
+			var items []string
+			for _, item := range strings.Split(raw, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			*target = items
+		}
+	}
+	return nil
+}
+
 // SaveConfig saves drift configuration to .bv/drift.yaml
 func SaveConfig(projectDir string, config *Config) error {
 	// Validate before saving
@@ -161,45 +485,45 @@ func (c *Config) Validate() error {
 	if c.InProgressStaleMultiplier == 0 {
 		c.InProgressStaleMultiplier = DefaultConfig().InProgressStaleMultiplier
 	}
-
-	if c.DensityWarningPct < 0 || c.DensityWarningPct > 1000 {
-		return fmt.Errorf("density_warning_pct must be between 0 and 1000")
-	}
-	if c.DensityInfoPct < 0 || c.DensityInfoPct > c.DensityWarningPct {
-		return fmt.Errorf("density_info_pct must be between 0 and density_warning_pct")
-	}
-	if c.NodeGrowthInfoPct < 0 || c.NodeGrowthInfoPct > 1000 {
-		return fmt.Errorf("node_growth_info_pct must be between 0 and 1000")
-	}
-	if c.EdgeGrowthInfoPct < 0 || c.EdgeGrowthInfoPct > 1000 {
-		return fmt.Errorf("edge_growth_info_pct must be between 0 and 1000")
-	}
-	if c.BlockedIncreaseThreshold < 0 {
-		return fmt.Errorf("blocked_increase_threshold must be non-negative")
+	if c.HistoryMaxEntries == 0 {
+		c.HistoryMaxEntries = DefaultConfig().HistoryMaxEntries
 	}
-	if c.ActionableDecreaseWarningPct < 0 || c.ActionableDecreaseWarningPct > 100 {
-		return fmt.Errorf("actionable_decrease_warning_pct must be between 0 and 100")
-	}
-	if c.ActionableIncreaseInfoPct < 0 || c.ActionableIncreaseInfoPct > 1000 {
-		return fmt.Errorf("actionable_increase_info_pct must be between 0 and 1000")
-	}
-	if c.PageRankChangeWarningPct < 0 || c.PageRankChangeWarningPct > 1000 {
-		return fmt.Errorf("pagerank_change_warning_pct must be between 0 and 1000")
+
+	// Range checks come from the shared field table (see fieldSpecs)
+	specs := c.fieldSpecs()
+	values := make(map[string]float64, len(specs))
+	for _, f := range specs {
+		if v, ok := f.number(); ok {
+			values[f.key] = v
+		}
 	}
-	if c.StaleWarningDays <= 0 || c.StaleCriticalDays <= 0 {
-		return fmt.Errorf("stale_warning_days and stale_critical_days must be positive")
+	for _, f := range specs {
+		if err := f.checkRange(values); err != nil {
+			return err
+		}
 	}
-	if c.StaleCriticalDays < c.StaleWarningDays {
-		return fmt.Errorf("stale_critical_days must be >= stale_warning_days")
+	// WIP thresholds are optional, so only order them when both are set
+	if c.MaxInProgress > 0 && c.MaxInProgressCritical > 0 && c.MaxInProgressCritical < c.MaxInProgress {
+		return fmt.Errorf("max_in_progress_critical must be >= max_in_progress")
 	}
-	if c.InProgressStaleMultiplier <= 0 || c.InProgressStaleMultiplier > 5 {
-		return fmt.Errorf("in_progress_stale_multiplier must be between 0 and 5")
+	// Severity overrides must name a known severity
+	overridden := make([]string, 0, len(c.SeverityOverrides))
+	for alertType := range c.SeverityOverrides {
+		overridden = append(overridden, alertType)
 	}
-	if c.BlockingCascadeInfo < 0 || c.BlockingCascadeWarning < 0 {
-		return fmt.Errorf("blocking cascade thresholds must be non-negative")
+	sort.Strings(overridden)
+	for _, alertType := range overridden {
+		switch sev := Severity(c.SeverityOverrides[alertType]); sev {
+		case SeverityInfo, SeverityWarning, SeverityCritical:
+		default:
+			return fmt.Errorf("severity_overrides %q: severity must be info, warning or critical, got %q", alertType, sev)
+		}
 	}
-	if c.BlockingCascadeWarning < c.BlockingCascadeInfo {
-		return fmt.Errorf("blocking_cascade_warning_threshold must be >= blocking_cascade_info_threshold")
+	// Validate per-label threshold overrides against their merged form
+	for label := range c.PerLabel {
+		if err := c.ForLabel(label).Validate(); err != nil {
+			return fmt.Errorf("per_label %q: %w", label, err)
+		}
 	}
 	// Validate label overrides (bv-167)
 	for label, lc := range c.LabelOverrides {
@@ -219,6 +543,22 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ForLabel returns the thresholds for a label's subgraph: the global config
+// with any non-zero fields from PerLabel[label] layered on top. The returned
+// config is a copy without PerLabel, so it is safe to validate or mutate.
+func (c *Config) ForLabel(label string) *Config {
+	merged := *c
+	merged.PerLabel = nil
+
+	o, ok := c.PerLabel[label]
+	if !ok {
+		return &merged
+	}
+	// Per-label fields share the field table with MergeConfig and env overrides
+	overlayFields(&merged, &o, true)
+	return &merged
+}
+
 // IsAlertDisabled returns true if the given alert type is in the disabled list (bv-167)
 func (c *Config) IsAlertDisabled(alertType string) bool {
 	for _, disabled := range c.DisabledAlerts {
@@ -229,6 +569,27 @@ func (c *Config) IsAlertDisabled(alertType string) bool {
 	return false
 }
 
+// IsIssueIgnored reports whether an issue is excluded from status-based alerts,
+// either by exact ID or by carrying one of IgnoreLabels. Safe on a nil config.
+func (c *Config) IsIssueIgnored(issue model.Issue) bool {
+	if c == nil {
+		return false
+	}
+	for _, id := range c.IgnoreIssueIDs {
+		if id == issue.ID {
+			return true
+		}
+	}
+	for _, ignored := range c.IgnoreLabels {
+		for _, label := range issue.Labels {
+			if label == ignored {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // GetStalenessThresholds returns the staleness thresholds for an issue based on its labels (bv-167)
 // Returns warn days, critical days, and in-progress multiplier.
 // If multiple labels have overrides, the tightest (smallest) non-zero thresholds among them are used.
@@ -306,6 +667,13 @@ actionable_increase_info_pct: 20     # Info if actionable changes 20%+
 # Metric change thresholds
 pagerank_change_warning_pct: 50  # Warn if PageRank changes 50%+
 
+# Throughput thresholds (0 disables)
+closure_velocity_drop_pct: 50    # Warn if issues closed per week drop 50%+
+
+# Label health thresholds, per label against the baseline (0 disables)
+label_health_drop_points: 20     # Warn if a label's health falls 20+ points
+label_health_drop_pct: 0         # Warn if a label's health falls by this percentage
+
 # Staleness thresholds (days since last update)
 stale_warning_days: 14           # Warn if an issue is inactive for 14+ days
 stale_critical_days: 30          # Critical if inactive for 30+ days
@@ -315,12 +683,38 @@ in_progress_stale_multiplier: 0.5  # In-progress items age twice as fast
 blocking_cascade_info_threshold: 3   # Info alert if completing an issue unblocks 3+ items
 blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 
+# WIP limit on in-progress issues (0 disables)
+max_in_progress: 0               # Warn if more than this many issues are in progress
+max_in_progress_critical: 0      # Critical if more than this many are in progress
+
+# Compare density/node/edge counts on open issues only, so pruning closed
+# issues after a cleanup isn't reported as drift
+ignore_closed_in_graph_metrics: false
+
+# Drift history (.bv/drift-history.jsonl, used by --drift-trend)
+history_max_entries: 500         # Keep the last 500 --check-drift runs
+
 # Disable specific alert types (bv-167)
 # Uncomment to disable:
 # disabled_alerts:
 #   - stale_issue
 #   - new_cycle
 #   - blocking_cascade
+#   - no_actionable
+
+# Force the severity of an alert type (info, warning or critical)
+# Uncomment to treat new cycles as warnings and density growth as critical:
+# severity_overrides:
+#   new_cycle: warning
+#   density_growth: critical
+
+# Exclude long-lived tracking issues from staleness, blocked and cascade alerts
+# They still count toward node/edge/density totals
+# ignore_issue_ids:
+#   - bv-epic-1
+# ignore_labels:
+#   - epic
+#   - meta
 
 # Per-label staleness overrides (bv-167)
 # Use tighter thresholds for urgent/priority labels
@@ -331,5 +725,15 @@ blocking_cascade_warning_threshold: 5 # Warning if unblocks 5+ items
 #   low-priority:
 #     stale_warning_days: 30
 #     stale_critical_days: 60
+
+# Per-label graph thresholds, checked against each label's subgraph
+# Unset fields inherit the global values above
+# per_label:
+#   experimental:
+#     density_warning_pct: 200
+#     density_info_pct: 100
+#   core:
+#     density_warning_pct: 20
+#     density_info_pct: 10
 `
 }
@@ -0,0 +1,126 @@
+package drift
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DismissalsFilename is the dismissed drift alerts file under .bv
+const DismissalsFilename = "drift-dismissals.json"
+
+// DismissalsPath returns the drift dismissals path for a project
+func DismissalsPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", DismissalsFilename)
+}
+
+// Dismissal records one acknowledged drift alert. It only applies while the
+// baseline it was raised against is in use.
+type Dismissal struct {
+	Fingerprint string    `json:"fingerprint"`
+	BaselineID  string    `json:"baseline_id"`
+	Type        AlertType `json:"type"`
+	Message     string    `json:"message"` // Alert message when dismissed, for reference
+	DismissedAt time.Time `json:"dismissed_at"`
+}
+
+// Dismissals is the set of dismissed drift alerts stored in
+// .bv/drift-dismissals.json
+type Dismissals struct {
+	Dismissals []Dismissal `json:"dismissals"`
+}
+
+// AlertFingerprint identifies an alert across runs against the same
+// baseline: a short hash of its type, its subject (label, issue or, for new
+// cycles, the cycles themselves) and the baseline ID. Values that drift from
+// run to run, like the message, are left out.
+func AlertFingerprint(alert Alert, baselineID string) string {
+	subject := alert.Label + "\x00" + alert.IssueID
+	if alert.Type == AlertNewCycle {
+		subject = strings.Join(alert.Details, "\x00")
+	}
+	sum := sha256.Sum256([]byte(string(alert.Type) + "\x00" + subject + "\x00" + baselineID))
+	return fmt.Sprintf("%x", sum[:6])
+}
+
+// LoadDismissals reads .bv/drift-dismissals.json. A missing file yields an
+// empty set.
+func LoadDismissals(projectDir string) (*Dismissals, error) {
+	data, err := os.ReadFile(DismissalsPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Dismissals{}, nil
+		}
+		return nil, fmt.Errorf("reading drift dismissals: %w", err)
+	}
+	var d Dismissals
+	if err := json.Unmarshal(data, &d); err != nil {
+		return nil, fmt.Errorf("parsing drift dismissals: %w", err)
+	}
+	return &d, nil
+}
+
+// Save writes the dismissals to .bv/drift-dismissals.json
+func (d *Dismissals) Save(projectDir string) error {
+	path := DismissalsPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding drift dismissals: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing drift dismissals: %w", err)
+	}
+	return nil
+}
+
+// Has reports whether the alert with this fingerprint is dismissed
+func (d *Dismissals) Has(fingerprint string) bool {
+	if d == nil {
+		return false
+	}
+	return slices.ContainsFunc(d.Dismissals, func(x Dismissal) bool { return x.Fingerprint == fingerprint })
+}
+
+// Dismiss records alert, which must carry its Fingerprint, as dismissed
+// against baselineID. Returns false if it already was.
+func (d *Dismissals) Dismiss(alert Alert, baselineID string, now time.Time) bool {
+	if d.Has(alert.Fingerprint) {
+		return false
+	}
+	d.Dismissals = append(d.Dismissals, Dismissal{
+		Fingerprint: alert.Fingerprint,
+		BaselineID:  baselineID,
+		Type:        alert.Type,
+		Message:     alert.Message,
+		DismissedAt: now.UTC(),
+	})
+	return true
+}
+
+// Expire drops the dismissals tied to baselineID and returns how many
+func (d *Dismissals) Expire(baselineID string) int {
+	before := len(d.Dismissals)
+	d.Dismissals = slices.DeleteFunc(d.Dismissals, func(x Dismissal) bool { return x.BaselineID == baselineID })
+	return before - len(d.Dismissals)
+}
+
+// ExpireDismissals removes the stored dismissals tied to baselineID, as when
+// that baseline is replaced. The file is left alone if none match.
+func ExpireDismissals(projectDir, baselineID string) error {
+	d, err := LoadDismissals(projectDir)
+	if err != nil {
+		return err
+	}
+	if d.Expire(baselineID) == 0 {
+		return nil
+	}
+	return d.Save(projectDir)
+}
@@ -0,0 +1,107 @@
+package drift
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// densityDrift returns a baseline and current snapshot whose only alert is a
+// density_growth warning
+func densityDrift(createdAt time.Time) (*baseline.Baseline, *baseline.Baseline) {
+	stats := baseline.GraphStats{NodeCount: 100, EdgeCount: 200, Density: 0.02}
+	bl := &baseline.Baseline{CreatedAt: createdAt, Stats: stats}
+	stats.Density = 0.04
+	return bl, &baseline.Baseline{Stats: stats}
+}
+
+func TestDismissedAlertSkipsExitCode(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	bl, current := densityDrift(now.AddDate(0, 0, -7))
+
+	result := NewCalculator(bl, current, nil).Calculate()
+	if len(result.Alerts) != 1 || result.Alerts[0].Type != AlertDensityGrowth || result.ExitCode() != 2 {
+		t.Fatalf("expected one density warning exiting 2, got %+v (exit %d)", result.Alerts, result.ExitCode())
+	}
+	alert := result.Alerts[0]
+	if alert.Fingerprint != AlertFingerprint(alert, bl.ID()) || len(alert.Fingerprint) != 12 {
+		t.Errorf("fingerprint = %q, want the 12-digit AlertFingerprint", alert.Fingerprint)
+	}
+
+	d, err := LoadDismissals(dir)
+	if err != nil || len(d.Dismissals) != 0 {
+		t.Fatalf("LoadDismissals on a fresh project = %+v, %v", d, err)
+	}
+	if !d.Dismiss(alert, bl.ID(), now) || d.Dismiss(alert, bl.ID(), now) {
+		t.Error("Dismiss should add the alert once")
+	}
+	if err := d.Save(dir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	d, err = LoadDismissals(dir)
+	if err != nil {
+		t.Fatalf("LoadDismissals: %v", err)
+	}
+
+	calc := NewCalculator(bl, current, nil)
+	calc.SetDismissals(d)
+	result = calc.Calculate()
+	if result.ExitCode() != 0 || result.HasDrift || result.WarningCount != 0 {
+		t.Errorf("dismissed alert still counts: exit %d, %+v", result.ExitCode(), result)
+	}
+	if len(result.Dismissed) != 1 || result.Dismissed[0].Fingerprint != alert.Fingerprint {
+		t.Errorf("Dismissed = %+v, want the density alert", result.Dismissed)
+	}
+
+	// The same drift against a different baseline is a different alert
+	other, _ := densityDrift(now)
+	calc = NewCalculator(other, current, nil)
+	calc.SetDismissals(d)
+	if got := calc.Calculate().ExitCode(); got != 2 {
+		t.Errorf("dismissal applied to another baseline: exit %d", got)
+	}
+}
+
+func TestSaveBaselineExpiresDismissals(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	old, current := densityDrift(now.AddDate(0, 0, -7))
+	if err := SaveBaselineNamed(dir, "", "", old); err != nil {
+		t.Fatalf("SaveBaselineNamed: %v", err)
+	}
+
+	alert := NewCalculator(old, current, nil).Calculate().Alerts[0]
+	d := &Dismissals{}
+	d.Dismiss(alert, old.ID(), now)
+	d.Dismiss(Alert{Type: AlertNewCycle, Fingerprint: "0123456789ab"}, "other-baseline", now)
+	if err := d.Save(dir); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	replacement, _ := densityDrift(now)
+	if err := SaveBaselineNamed(dir, "", "", replacement); err != nil {
+		t.Fatalf("SaveBaselineNamed: %v", err)
+	}
+	d, err := LoadDismissals(dir)
+	if err != nil {
+		t.Fatalf("LoadDismissals: %v", err)
+	}
+	if len(d.Dismissals) != 1 || d.Dismissals[0].BaselineID != "other-baseline" {
+		t.Errorf("dismissals after replacing the baseline = %+v, want only the other baseline's", d.Dismissals)
+	}
+}
+
+func TestSummaryListsDismissed(t *testing.T) {
+	r := &Result{Dismissed: []Alert{{Type: AlertDensityGrowth, Severity: SeverityWarning, Message: "Density up", Fingerprint: "abc123abc123"}}}
+	if got := r.Summary(); got != "No drift detected beyond 1 dismissed alert(s).\n" {
+		t.Errorf("Summary() = %q", got)
+	}
+	if got, want := r.DismissedSummary(), "Dismissed (1):\n  🟡 [density_growth] Density up (abc123abc123)\n\n"; got != want {
+		t.Errorf("DismissedSummary() = %q, want %q", got, want)
+	}
+	if got := (&Result{}).DismissedSummary(); got != "" {
+		t.Errorf("DismissedSummary() with nothing dismissed = %q", got)
+	}
+}
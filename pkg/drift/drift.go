@@ -4,6 +4,8 @@ package drift
 
 import (
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -38,6 +40,9 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertWIPExceeded        AlertType = "wip_exceeded"
+	AlertNoActionable       AlertType = "no_actionable"
+	AlertLabelHealthDrop    AlertType = "label_health_drop"
 )
 
 // Alert represents a single drift detection alert
@@ -50,12 +55,20 @@ type Alert struct {
 	Delta       float64   `json:"delta,omitempty"`
 	Details     []string  `json:"details,omitempty"`
 	IssueID     string    `json:"issue_id,omitempty"`
+	IssueIDs    []string  `json:"issue_ids,omitempty"` // Issues involved when there are several (e.g. cycle members)
 	Label       string    `json:"label,omitempty"`
 	DetectedAt  time.Time `json:"detected_at,omitempty"`
 
 	// Blocking cascade specific fields (bv-165)
 	UnblocksCount         int `json:"unblocks_count,omitempty"`
 	DownstreamPrioritySum int `json:"downstream_priority_sum,omitempty"`
+
+	// DefaultSeverity is the engine-assigned severity when a configured
+	// severity override replaced it
+	DefaultSeverity Severity `json:"default_severity,omitempty"`
+
+	// Fingerprint identifies the alert for --dismiss-drift (see AlertFingerprint)
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
 // Result contains the complete drift analysis
@@ -66,6 +79,14 @@ type Result struct {
 	// Alerts lists all detected drift issues
 	Alerts []Alert `json:"alerts"`
 
+	// Dismissed lists detected alerts that were dismissed; they are left out
+	// of Alerts, the counts below and the exit code
+	Dismissed []Alert `json:"dismissed,omitempty"`
+
+	// Groups holds Alerts again, grouped by owning label for routing (see
+	// GroupAlertsByLabel)
+	Groups map[string][]Alert `json:"groups,omitempty"`
+
 	// Summary statistics
 	CriticalCount int `json:"critical_count"`
 	WarningCount  int `json:"warning_count"`
@@ -74,10 +95,12 @@ type Result struct {
 
 // Calculator performs drift detection
 type Calculator struct {
-	config   *Config
-	baseline *baseline.Baseline
-	current  *baseline.Baseline
-	issues   []model.Issue
+	config     *Config
+	baseline   *baseline.Baseline
+	current    *baseline.Baseline
+	issues     []model.Issue
+	now        time.Time
+	dismissals *Dismissals
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -98,6 +121,19 @@ func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
 }
 
+// SetNow pins the reference time used for issue-level alerts such as
+// staleness, so historical runs measure inactivity as of that moment.
+// Defaults to the current time.
+func (c *Calculator) SetNow(now time.Time) {
+	c.now = now.UTC()
+}
+
+// SetDismissals attaches dismissed alerts, which Calculate moves out of
+// Result.Alerts into Result.Dismissed. Optional.
+func (c *Calculator) SetDismissals(d *Dismissals) {
+	c.dismissals = d
+}
+
 // Calculate performs drift detection and returns results
 func (c *Calculator) Calculate() *Result {
 	result := &Result{
@@ -119,15 +155,42 @@ func (c *Calculator) Calculate() *Result {
 	// Check actionable count changes (info)
 	c.checkActionable(result)
 
+	// Check for gridlock: open work but nothing actionable (critical)
+	c.checkNoActionable(result)
+
 	// Check PageRank changes (warning)
 	c.checkPageRankChanges(result)
 
+	// Check closure velocity collapse (warning)
+	c.checkVelocity(result)
+
+	// Check the in-progress WIP limit (uses current issues if provided)
+	c.checkWIP(result)
+
 	// Check staleness (uses current issues if provided)
 	c.checkStaleness(result)
 
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check label-scoped stats against per-label thresholds
+	c.checkPerLabel(result)
+
+	// Check per-label composite health against the baseline (warning)
+	c.checkLabelHealth(result)
+
+	// Force configured severities before counting, so the exit code follows them
+	c.applySeverityOverrides(result)
+
+	// Fingerprint alerts and set the dismissed ones aside before counting
+	c.applyDismissals(result)
+
+	issues := c.issues
+	if len(issues) == 0 {
+		issues = c.current.IssueList()
+	}
+	result.Groups = GroupAlertsByLabel(result.Alerts, issues)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -144,6 +207,39 @@ func (c *Calculator) Calculate() *Result {
 	return result
 }
 
+// applySeverityOverrides replaces engine-assigned severities with those
+// forced by SeverityOverrides, keeping the original in DefaultSeverity
+func (c *Calculator) applySeverityOverrides(result *Result) {
+	if len(c.config.SeverityOverrides) == 0 {
+		return
+	}
+	for i := range result.Alerts {
+		alert := &result.Alerts[i]
+		forced, ok := c.config.SeverityOverrides[string(alert.Type)]
+		if !ok || Severity(forced) == alert.Severity {
+			continue
+		}
+		alert.DefaultSeverity = alert.Severity
+		alert.Severity = Severity(forced)
+	}
+}
+
+// applyDismissals fingerprints every alert against the baseline and moves
+// the dismissed ones to result.Dismissed
+func (c *Calculator) applyDismissals(result *Result) {
+	baselineID := c.baseline.ID()
+	active := result.Alerts[:0]
+	for _, alert := range result.Alerts {
+		alert.Fingerprint = AlertFingerprint(alert, baselineID)
+		if c.dismissals.Has(alert.Fingerprint) {
+			result.Dismissed = append(result.Dismissed, alert)
+		} else {
+			active = append(active, alert)
+		}
+	}
+	result.Alerts = active
+}
+
 // checkCycles detects new cycles that weren't in the baseline
 func (c *Calculator) checkCycles(result *Result) {
 	// Check if alert type is disabled (bv-167)
@@ -167,9 +263,13 @@ func (c *Calculator) checkCycles(result *Result) {
 
 	if len(newCycles) > 0 {
 		details := make([]string, 0, len(newCycles))
+		var members []string
 		for _, cycle := range newCycles {
 			details = append(details, strings.Join(cycle, " → "))
+			members = append(members, cycle...)
 		}
+		slices.Sort(members)
+		members = slices.Compact(members)
 
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertNewCycle,
@@ -179,6 +279,7 @@ func (c *Calculator) checkCycles(result *Result) {
 			CurrentVal:  float64(len(c.current.Cycles)),
 			Delta:       float64(len(newCycles)),
 			Details:     details,
+			IssueIDs:    members,
 			DetectedAt:  time.Now().UTC(),
 		})
 	}
@@ -186,13 +287,21 @@ func (c *Calculator) checkCycles(result *Result) {
 
 // checkDensity checks for significant density changes
 func (c *Calculator) checkDensity(result *Result) {
+	checkDensityStats(result, c.baseline.Stats, c.current.Stats, c.config, "")
+}
+
+// checkDensityStats compares density between two stat snapshots.
+// label tags the emitted alerts when checking a label-scoped subgraph.
+func checkDensityStats(result *Result, bl, cur baseline.GraphStats, cfg *Config, label string) {
 	// Check if alert type is disabled (bv-167)
-	if c.config.IsAlertDisabled(string(AlertDensityGrowth)) {
+	if cfg.IsAlertDisabled(string(AlertDensityGrowth)) {
 		return
 	}
 
-	blDensity := c.baseline.Stats.Density
-	curDensity := c.current.Stats.Density
+	blDensity, curDensity := bl.Density, cur.Density
+	if useLiveGraph(bl, cur, cfg) {
+		blDensity, curDensity = bl.LiveDensity, cur.LiveDensity
+	}
 
 	if blDensity == 0 {
 		return // No baseline to compare
@@ -201,24 +310,26 @@ func (c *Calculator) checkDensity(result *Result) {
 	delta := curDensity - blDensity
 	pctChange := (delta / blDensity) * 100
 
-	if pctChange >= c.config.DensityWarningPct {
+	if pctChange >= cfg.DensityWarningPct {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertDensityGrowth,
 			Severity:    SeverityWarning,
-			Message:     fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
+			Message:     labelPrefix(label) + fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
 			BaselineVal: blDensity,
 			CurrentVal:  curDensity,
 			Delta:       delta,
+			Label:       label,
 			DetectedAt:  time.Now().UTC(),
 		})
-	} else if pctChange >= c.config.DensityInfoPct {
+	} else if pctChange >= cfg.DensityInfoPct {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertDensityGrowth,
 			Severity:    SeverityInfo,
-			Message:     fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
+			Message:     labelPrefix(label) + fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
 			BaselineVal: blDensity,
 			CurrentVal:  curDensity,
 			Delta:       delta,
+			Label:       label,
 			DetectedAt:  time.Now().UTC(),
 		})
 	}
@@ -226,113 +337,272 @@ func (c *Calculator) checkDensity(result *Result) {
 
 // checkGraphSize checks for significant node/edge count changes
 func (c *Calculator) checkGraphSize(result *Result) {
+	checkGraphSizeStats(result, c.baseline.Stats, c.current.Stats, c.config, "")
+}
+
+// checkGraphSizeStats compares node/edge counts between two stat snapshots
+func checkGraphSizeStats(result *Result, bl, cur baseline.GraphStats, cfg *Config, label string) {
 	// Check if alert types are disabled (bv-167)
-	nodeDisabled := c.config.IsAlertDisabled(string(AlertNodeCountChange))
-	edgeDisabled := c.config.IsAlertDisabled(string(AlertEdgeCountChange))
+	nodeDisabled := cfg.IsAlertDisabled(string(AlertNodeCountChange))
+	edgeDisabled := cfg.IsAlertDisabled(string(AlertEdgeCountChange))
 	if nodeDisabled && edgeDisabled {
 		return
 	}
 
-	blNodes := c.baseline.Stats.NodeCount
-	curNodes := c.current.Stats.NodeCount
+	blNodes, curNodes := bl.NodeCount, cur.NodeCount
+	blEdges, curEdges := bl.EdgeCount, cur.EdgeCount
+	if useLiveGraph(bl, cur, cfg) {
+		blNodes, curNodes = bl.LiveNodeCount, cur.LiveNodeCount
+		blEdges, curEdges = bl.LiveEdgeCount, cur.LiveEdgeCount
+	}
 	nodeDelta := curNodes - blNodes
 
 	if !nodeDisabled && blNodes > 0 {
 		nodePct := float64(nodeDelta) / float64(blNodes) * 100
-		if nodePct >= c.config.NodeGrowthInfoPct || nodePct <= -c.config.NodeGrowthInfoPct {
+		if nodePct >= cfg.NodeGrowthInfoPct || nodePct <= -cfg.NodeGrowthInfoPct {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertNodeCountChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Node count changed by %+d (%.1f%%)", nodeDelta, nodePct),
+				Message:     labelPrefix(label) + fmt.Sprintf("Node count changed by %+d (%.1f%%)", nodeDelta, nodePct),
 				BaselineVal: float64(blNodes),
 				CurrentVal:  float64(curNodes),
 				Delta:       float64(nodeDelta),
+				Label:       label,
 				DetectedAt:  time.Now().UTC(),
 			})
 		}
 	}
 
-	blEdges := c.baseline.Stats.EdgeCount
-	curEdges := c.current.Stats.EdgeCount
 	edgeDelta := curEdges - blEdges
 
 	if !edgeDisabled && blEdges > 0 {
 		edgePct := float64(edgeDelta) / float64(blEdges) * 100
-		if edgePct >= c.config.EdgeGrowthInfoPct || edgePct <= -c.config.EdgeGrowthInfoPct {
+		if edgePct >= cfg.EdgeGrowthInfoPct || edgePct <= -cfg.EdgeGrowthInfoPct {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertEdgeCountChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Edge count changed by %+d (%.1f%%)", edgeDelta, edgePct),
+				Message:     labelPrefix(label) + fmt.Sprintf("Edge count changed by %+d (%.1f%%)", edgeDelta, edgePct),
 				BaselineVal: float64(blEdges),
 				CurrentVal:  float64(curEdges),
 				Delta:       float64(edgeDelta),
+				Label:       label,
 				DetectedAt:  time.Now().UTC(),
 			})
 		}
 	}
 }
 
-// checkBlocked checks for increases in blocked issues
+// useLiveGraph reports whether graph shape should be compared on the live
+// graph. Snapshots from before live tracking have no live counts, so they
+// fall back to the totals rather than report every open issue as new.
+func useLiveGraph(bl, cur baseline.GraphStats, cfg *Config) bool {
+	if !cfg.IgnoreClosedInGraphMetrics {
+		return false
+	}
+	hasLive := func(s baseline.GraphStats) bool {
+		return s.LiveNodeCount > 0 || s.NodeCount == s.ClosedCount
+	}
+	return hasLive(bl) && hasLive(cur)
+}
+
+// checkBlocked checks for increases in blocked issues. Baselines whose
+// blocked count used an older definition are skipped (see
+// baseline.CurrentStatsVersion).
 func (c *Calculator) checkBlocked(result *Result) {
+	if !c.baseline.HasCurrentStats() {
+		return
+	}
+	checkBlockedStats(result, c.baseline.Stats, c.current.Stats, c.config, "")
+}
+
+// checkBlockedStats compares blocked counts between two stat snapshots
+func checkBlockedStats(result *Result, bl, cur baseline.GraphStats, cfg *Config, label string) {
 	// Check if alert type is disabled (bv-167)
-	if c.config.IsAlertDisabled(string(AlertBlockedIncrease)) {
+	if cfg.IsAlertDisabled(string(AlertBlockedIncrease)) {
 		return
 	}
 
-	blBlocked := c.baseline.Stats.BlockedCount
-	curBlocked := c.current.Stats.BlockedCount
+	blBlocked := bl.BlockedCount
+	curBlocked := cur.BlockedCount
 	delta := curBlocked - blBlocked
 
-	if delta > 0 && delta >= c.config.BlockedIncreaseThreshold {
+	if delta > 0 && delta >= cfg.BlockedIncreaseThreshold {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertBlockedIncrease,
 			Severity:    SeverityWarning,
-			Message:     fmt.Sprintf("Blocked issues increased by %d", delta),
+			Message:     labelPrefix(label) + fmt.Sprintf("Blocked issues increased by %d", delta),
 			BaselineVal: float64(blBlocked),
 			CurrentVal:  float64(curBlocked),
 			Delta:       float64(delta),
+			Label:       label,
 			DetectedAt:  time.Now().UTC(),
 		})
 	}
 }
 
-// checkActionable checks for significant changes in actionable issues
+// checkActionable checks for significant changes in actionable issues,
+// skipping baselines saved with an older actionable definition
 func (c *Calculator) checkActionable(result *Result) {
+	if !c.baseline.HasCurrentStats() {
+		return
+	}
+	checkActionableStats(result, c.baseline.Stats, c.current.Stats, c.config, "")
+}
+
+// checkActionableStats compares actionable counts between two stat snapshots
+func checkActionableStats(result *Result, bl, cur baseline.GraphStats, cfg *Config, label string) {
 	// Check if alert type is disabled (bv-167)
-	if c.config.IsAlertDisabled(string(AlertActionableChange)) {
+	if cfg.IsAlertDisabled(string(AlertActionableChange)) {
 		return
 	}
 
-	blAction := c.baseline.Stats.ActionableCount
-	curAction := c.current.Stats.ActionableCount
+	blAction := bl.ActionableCount
+	curAction := cur.ActionableCount
 	delta := curAction - blAction
 
 	if blAction > 0 {
 		pct := float64(delta) / float64(blAction) * 100
-		if pct <= -c.config.ActionableDecreaseWarningPct {
+		if pct <= -cfg.ActionableDecreaseWarningPct {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertActionableChange,
 				Severity:    SeverityWarning,
-				Message:     fmt.Sprintf("Actionable issues decreased by %d (%.1f%%)", -delta, -pct),
+				Message:     labelPrefix(label) + fmt.Sprintf("Actionable issues decreased by %d (%.1f%%)", -delta, -pct),
 				BaselineVal: float64(blAction),
 				CurrentVal:  float64(curAction),
 				Delta:       float64(delta),
+				Label:       label,
 				DetectedAt:  time.Now().UTC(),
 			})
-		} else if pct >= c.config.ActionableIncreaseInfoPct || pct <= -c.config.ActionableIncreaseInfoPct {
+		} else if pct >= cfg.ActionableIncreaseInfoPct || pct <= -cfg.ActionableIncreaseInfoPct {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertActionableChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Actionable issues changed by %+d (%.1f%%)", delta, pct),
+				Message:     labelPrefix(label) + fmt.Sprintf("Actionable issues changed by %+d (%.1f%%)", delta, pct),
 				BaselineVal: float64(blAction),
 				CurrentVal:  float64(curAction),
 				Delta:       float64(delta),
+				Label:       label,
 				DetectedAt:  time.Now().UTC(),
 			})
 		}
 	}
 }
 
+// checkNoActionable raises a critical alert when open issues exist but none
+// is actionable (per the current snapshot's ActionableCount), however low the
+// baseline already was. Relies on attached issues for the open count; no-op
+// if issues were not provided.
+func (c *Calculator) checkNoActionable(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertNoActionable)) || len(c.issues) == 0 {
+		return
+	}
+	if c.current.Stats.ActionableCount > 0 {
+		return
+	}
+
+	open := 0
+	for _, issue := range c.issues {
+		if issue.Status != model.StatusClosed && issue.Status != model.StatusTombstone {
+			open++
+		}
+	}
+	if open == 0 {
+		return
+	}
+
+	result.Alerts = append(result.Alerts, Alert{
+		Type:        AlertNoActionable,
+		Severity:    SeverityCritical,
+		Message:     fmt.Sprintf("No actionable issues: all %d open issues are blocked", open),
+		BaselineVal: float64(c.baseline.Stats.ActionableCount),
+		DetectedAt:  time.Now().UTC(),
+	})
+}
+
+// checkPerLabel evaluates label-scoped stats against the merged per-label
+// thresholds. Labels missing from either snapshot's LabelStats are skipped.
+func (c *Calculator) checkPerLabel(result *Result) {
+	if len(c.config.PerLabel) == 0 {
+		return
+	}
+
+	labels := make([]string, 0, len(c.config.PerLabel))
+	for label := range c.config.PerLabel {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		bl, okBl := c.baseline.LabelStats[label]
+		cur, okCur := c.current.LabelStats[label]
+		if !okBl || !okCur {
+			continue
+		}
+		cfg := c.config.ForLabel(label)
+		checkDensityStats(result, bl, cur, cfg, label)
+		checkGraphSizeStats(result, bl, cur, cfg, label)
+		if c.baseline.HasCurrentStats() {
+			checkBlockedStats(result, bl, cur, cfg, label)
+			checkActionableStats(result, bl, cur, cfg, label)
+		}
+	}
+}
+
+// checkLabelHealth warns for each label whose composite health fell by
+// LabelHealthDropPoints or LabelHealthDropPct since the baseline. Baselines
+// saved without label health are skipped, as are labels missing from either
+// snapshot.
+func (c *Calculator) checkLabelHealth(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertLabelHealthDrop)) || len(c.baseline.LabelHealth) == 0 {
+		return
+	}
+	points, pct := c.config.LabelHealthDropPoints, c.config.LabelHealthDropPct
+	if points <= 0 && pct <= 0 {
+		return
+	}
+
+	labels := make([]string, 0, len(c.baseline.LabelHealth))
+	for label := range c.baseline.LabelHealth {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	for _, label := range labels {
+		bl := c.baseline.LabelHealth[label]
+		cur, ok := c.current.LabelHealth[label]
+		if !ok || bl.Health <= 0 {
+			continue
+		}
+		drop := bl.Health - cur.Health
+		dropPct := float64(drop) / float64(bl.Health) * 100
+		if drop <= 0 || !((points > 0 && drop >= points) || (pct > 0 && dropPct >= pct)) {
+			continue
+		}
+		result.Alerts = append(result.Alerts, Alert{
+			Type:        AlertLabelHealthDrop,
+			Severity:    SeverityWarning,
+			Message:     fmt.Sprintf("Label %s health dropped from %d to %d (-%d points, %.1f%%)", label, bl.Health, cur.Health, drop, dropPct),
+			BaselineVal: float64(bl.Health),
+			CurrentVal:  float64(cur.Health),
+			Delta:       float64(-drop),
+			Label:       label,
+			Details: []string{
+				fmt.Sprintf("level=%s (was %s)", cur.HealthLevel, bl.HealthLevel),
+				fmt.Sprintf("issues=%d (was %d)", cur.IssueCount, bl.IssueCount),
+			},
+			DetectedAt: time.Now().UTC(),
+		})
+	}
+}
+
+// labelPrefix returns a message prefix identifying a label-scoped alert
+func labelPrefix(label string) string {
+	if label == "" {
+		return ""
+	}
+	return fmt.Sprintf("[%s] ", label)
+}
+
 // checkPageRankChanges detects significant changes in top PageRank items
 func (c *Calculator) checkPageRankChanges(result *Result) {
 	// Check if alert type is disabled (bv-167)
@@ -385,6 +655,98 @@ func (c *Calculator) checkPageRankChanges(result *Result) {
 	}
 }
 
+// checkVelocity warns when closures per week fall by more than the configured
+// percentage. Baselines without a recorded velocity are skipped.
+func (c *Calculator) checkVelocity(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertVelocityDrop)) || c.config.ClosureVelocityDropPct <= 0 {
+		return
+	}
+
+	blVel := c.baseline.Stats.ClosedPerWeek
+	curVel := c.current.Stats.ClosedPerWeek
+	if blVel <= 0 {
+		return // Older baseline (or no closures) - nothing to compare
+	}
+
+	delta := curVel - blVel
+	dropPct := -delta / blVel * 100
+	if dropPct < c.config.ClosureVelocityDropPct {
+		return
+	}
+
+	result.Alerts = append(result.Alerts, Alert{
+		Type:        AlertVelocityDrop,
+		Severity:    SeverityWarning,
+		Message:     fmt.Sprintf("Closure velocity dropped by %.1f%% (%.1f → %.1f issues/week)", dropPct, blVel, curVel),
+		BaselineVal: blVel,
+		CurrentVal:  curVel,
+		Delta:       delta,
+		DetectedAt:  time.Now().UTC(),
+	})
+}
+
+// ClosedPerWeek returns the project-wide closure velocity (issues closed per
+// week, averaged over the last 30 days) for recording in a baseline.
+func ClosedPerWeek(issues []model.Issue, now time.Time) float64 {
+	return ClosedPerWeekExcluding(issues, now, nil)
+}
+
+// ClosedPerWeekExcluding is ClosedPerWeek leaving out issues that carry any
+// of excludeLabels, such as the label health config's ExcludeFromVelocity.
+func ClosedPerWeekExcluding(issues []model.Issue, now time.Time, excludeLabels []string) float64 {
+	counted := issues
+	if len(excludeLabels) > 0 {
+		counted = make([]model.Issue, 0, len(issues))
+		for _, issue := range issues {
+			if !slices.ContainsFunc(issue.Labels, func(l string) bool { return slices.Contains(excludeLabels, l) }) {
+				counted = append(counted, issue)
+			}
+		}
+	}
+	v := analysis.ComputeVelocityMetrics(counted, now)
+	return float64(v.ClosedLast30Days) * 7 / 30
+}
+
+// checkWIP alerts when more issues are in progress than MaxInProgress allows,
+// escalating to critical past MaxInProgressCritical. Ignored issues don't
+// count; no-op if issues were not provided or no limit is set.
+func (c *Calculator) checkWIP(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertWIPExceeded)) || len(c.issues) == 0 {
+		return
+	}
+	if c.config.MaxInProgress <= 0 && c.config.MaxInProgressCritical <= 0 {
+		return
+	}
+
+	counted := make([]model.Issue, 0, len(c.issues))
+	for _, issue := range c.issues {
+		if !c.config.IsIssueIgnored(issue) {
+			counted = append(counted, issue)
+		}
+	}
+	inProgress := analysis.CountByStatus(counted)[string(model.StatusInProgress)]
+
+	var severity Severity
+	var limit int
+	switch {
+	case c.config.MaxInProgressCritical > 0 && inProgress > c.config.MaxInProgressCritical:
+		severity, limit = SeverityCritical, c.config.MaxInProgressCritical
+	case c.config.MaxInProgress > 0 && inProgress > c.config.MaxInProgress:
+		severity, limit = SeverityWarning, c.config.MaxInProgress
+	default:
+		return
+	}
+
+	result.Alerts = append(result.Alerts, Alert{
+		Type:       AlertWIPExceeded,
+		Severity:   severity,
+		Message:    fmt.Sprintf("%d issues in progress exceeds the WIP limit of %d", inProgress, limit),
+		CurrentVal: float64(inProgress),
+		Delta:      float64(inProgress - limit),
+		DetectedAt: time.Now().UTC(),
+	})
+}
+
 // checkStaleness emits alerts for issues that have been inactive beyond thresholds.
 // Relies on attached issues; no-op if issues were not provided.
 // Uses per-label threshold overrides when configured (bv-167).
@@ -397,11 +759,17 @@ func (c *Calculator) checkStaleness(result *Result) {
 	if len(c.issues) == 0 {
 		return
 	}
-	now := time.Now().UTC()
+	now := c.now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
 	for _, issue := range c.issues {
 		if issue.Status == model.StatusClosed || issue.Status == model.StatusTombstone {
 			continue
 		}
+		if c.config.IsIssueIgnored(issue) {
+			continue
+		}
 
 		lastActive := issue.UpdatedAt
 		if lastActive.IsZero() {
@@ -478,7 +846,17 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 	}
 
 	for _, iss := range actionable {
-		unblocks := analyzer.ComputeUnblocks(iss.ID)
+		if c.config.IsIssueIgnored(iss) {
+			continue
+		}
+		// Ignored dependents don't add to the cascade
+		var unblocks []string
+		for _, id := range analyzer.ComputeUnblocks(iss.ID) {
+			if dep, ok := issueMap[id]; ok && c.config.IsIssueIgnored(dep) {
+				continue
+			}
+			unblocks = append(unblocks, id)
+		}
 		count := len(unblocks)
 		if count == 0 {
 			continue
@@ -553,6 +931,9 @@ func cycleKey(cycle []string) string {
 // Summary returns a human-readable summary of drift results
 func (r *Result) Summary() string {
 	if !r.HasDrift {
+		if len(r.Dismissed) > 0 {
+			return fmt.Sprintf("No drift detected beyond %d dismissed alert(s).\n", len(r.Dismissed))
+		}
 		return "No drift detected. Project metrics are within baseline thresholds.\n"
 	}
 
@@ -570,8 +951,33 @@ func (r *Result) Summary() string {
 		sb.WriteString(fmt.Sprintf("🔵 INFO: %d issue(s)\n", r.InfoCount))
 	}
 
+	if len(r.Dismissed) > 0 {
+		sb.WriteString(fmt.Sprintf("(%d dismissed alert(s) not counted)\n", len(r.Dismissed)))
+	}
+
 	sb.WriteString("\nDetails:\n")
-	for _, alert := range r.Alerts {
+	writeAlertLines(&sb, r.Alerts)
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// DismissedSummary lists the dismissed alerts, or returns "" if there are none
+func (r *Result) DismissedSummary() string {
+	if len(r.Dismissed) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Dismissed (%d):\n", len(r.Dismissed)))
+	writeAlertLines(&sb, r.Dismissed)
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// writeAlertLines writes one line per alert, with its fingerprint when set,
+// followed by its details
+func writeAlertLines(sb *strings.Builder, alerts []Alert) {
+	for _, alert := range alerts {
 		icon := "ℹ️"
 		switch alert.Severity {
 		case SeverityCritical:
@@ -579,14 +985,15 @@ func (r *Result) Summary() string {
 		case SeverityWarning:
 			icon = "🟡"
 		}
-		sb.WriteString(fmt.Sprintf("  %s [%s] %s\n", icon, alert.Type, alert.Message))
+		sb.WriteString(fmt.Sprintf("  %s [%s] %s", icon, alert.Type, alert.Message))
+		if alert.Fingerprint != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", alert.Fingerprint))
+		}
+		sb.WriteString("\n")
 		for _, detail := range alert.Details {
 			sb.WriteString(fmt.Sprintf("      - %s\n", detail))
 		}
 	}
-	sb.WriteString("\n")
-
-	return sb.String()
 }
 
 // HasCritical returns true if there are any critical alerts
@@ -1,12 +1,16 @@
 package drift
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"gopkg.in/yaml.v3"
@@ -113,8 +117,66 @@ func TestCalculatorDensityGrowth(t *testing.T) {
 	}
 }
 
+func TestCalculatorSeverityOverrides(t *testing.T) {
+	t.Run("downgrade new_cycle", func(t *testing.T) {
+		bl := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 10, EdgeCount: 15}}
+		current := &baseline.Baseline{
+			Stats:  bl.Stats,
+			Cycles: [][]string{{"A", "B", "C", "A"}},
+		}
+		cfg := DefaultConfig()
+		cfg.SeverityOverrides = map[string]string{string(AlertNewCycle): string(SeverityWarning)}
+
+		result := NewCalculator(bl, current, cfg).Calculate()
+		if len(result.Alerts) != 1 || result.Alerts[0].Type != AlertNewCycle {
+			t.Fatalf("expected a single new_cycle alert, got %+v", result.Alerts)
+		}
+		alert := result.Alerts[0]
+		if alert.Severity != SeverityWarning || alert.DefaultSeverity != SeverityCritical {
+			t.Errorf("severity = %s (default %s), want warning overriding critical", alert.Severity, alert.DefaultSeverity)
+		}
+		if result.CriticalCount != 0 || result.WarningCount != 1 {
+			t.Errorf("counts critical=%d warning=%d, want 0/1", result.CriticalCount, result.WarningCount)
+		}
+		if code := result.ExitCode(); code != 2 {
+			t.Errorf("exit code = %d, want 2", code)
+		}
+	})
+
+	t.Run("upgrade density_growth", func(t *testing.T) {
+		bl := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 100, EdgeCount: 200, Density: 0.02}}
+		current := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 100, EdgeCount: 400, Density: 0.04}}
+		cfg := DefaultConfig()
+		cfg.SeverityOverrides = map[string]string{string(AlertDensityGrowth): string(SeverityCritical)}
+
+		result := NewCalculator(bl, current, cfg).Calculate()
+		found := false
+		for _, alert := range result.Alerts {
+			switch alert.Type {
+			case AlertDensityGrowth:
+				found = true
+				if alert.Severity != SeverityCritical || alert.DefaultSeverity != SeverityWarning {
+					t.Errorf("density severity = %s (default %s), want critical overriding warning", alert.Severity, alert.DefaultSeverity)
+				}
+			default:
+				// Alerts without an override keep their severity
+				if alert.DefaultSeverity != "" {
+					t.Errorf("%s alert should not be overridden: %+v", alert.Type, alert)
+				}
+			}
+		}
+		if !found {
+			t.Fatal("expected density_growth alert")
+		}
+		if result.CriticalCount != 1 || result.ExitCode() != 1 {
+			t.Errorf("critical=%d exit=%d, want 1 and 1", result.CriticalCount, result.ExitCode())
+		}
+	})
+}
+
 func TestCalculatorBlockedIncrease(t *testing.T) {
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:    100,
 			BlockedCount: 5,
@@ -216,6 +278,34 @@ func TestCalculatorStalenessWarningAndCritical(t *testing.T) {
 	}
 }
 
+func TestCalculatorStalenessUsesPinnedNow(t *testing.T) {
+	asOf := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		// Fresh as of asOf, long stale relative to the wall clock
+		{ID: "FRESH", Status: model.StatusOpen, UpdatedAt: asOf.Add(-2 * 24 * time.Hour)},
+		{ID: "STALE", Status: model.StatusOpen, UpdatedAt: asOf.Add(-40 * 24 * time.Hour)},
+	}
+
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	calc := NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	calc.SetNow(asOf)
+
+	var stale []string
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertStaleIssue {
+			stale = append(stale, a.IssueID)
+			if !a.DetectedAt.Equal(asOf) {
+				t.Errorf("%s detected at %s, want %s", a.IssueID, a.DetectedAt, asOf)
+			}
+		}
+	}
+	if !reflect.DeepEqual(stale, []string{"STALE"}) {
+		t.Fatalf("expected only STALE to be flagged as of %s, got %v", asOf.Format(time.DateOnly), stale)
+	}
+}
+
 func TestCalculatorBlockingCascade(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "A", Title: "Blocker A", Status: model.StatusOpen},
@@ -300,6 +390,73 @@ func TestCalculatorBlockingCascadeWithPriorities(t *testing.T) {
 	}
 }
 
+func TestCalculatorNoActionable(t *testing.T) {
+	blockedBy := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// A and B block each other, C waits on A: nothing can start
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: blockedBy("B")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blockedBy("A")},
+		{ID: "D", Status: model.StatusClosed},
+	}
+	// A baseline that was already at zero doesn't trip the relative thresholds
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{ActionableCount: 0}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{ActionableCount: 0}}
+
+	calc := NewCalculator(bl, current, nil)
+	calc.SetIssues(issues)
+	result := calc.Calculate()
+
+	var found *Alert
+	for i := range result.Alerts {
+		if result.Alerts[i].Type == AlertNoActionable {
+			found = &result.Alerts[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected no_actionable alert, got %+v", result.Alerts)
+	}
+	if found.Severity != SeverityCritical {
+		t.Errorf("expected critical severity, got %s", found.Severity)
+	}
+	if !strings.Contains(found.Message, "all 3 open issues") {
+		t.Errorf("unexpected message %q", found.Message)
+	}
+	if result.ExitCode() != 1 {
+		t.Errorf("expected critical exit code 1, got %d", result.ExitCode())
+	}
+
+	// One workable issue clears it
+	withE := &baseline.Baseline{Stats: baseline.GraphStats{ActionableCount: 1}}
+	calc = NewCalculator(bl, withE, nil)
+	calc.SetIssues(append(issues, model.Issue{ID: "E", Status: model.StatusOpen}))
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertNoActionable {
+			t.Errorf("unexpected no_actionable alert with an actionable issue: %s", a.Message)
+		}
+	}
+
+	// Nothing open at all is not gridlock
+	calc.SetIssues([]model.Issue{{ID: "D", Status: model.StatusClosed}})
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertNoActionable {
+			t.Errorf("unexpected no_actionable alert with no open issues: %s", a.Message)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{string(AlertNoActionable)}
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(issues)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertNoActionable {
+			t.Error("no_actionable should respect disabled_alerts")
+		}
+	}
+}
+
 func TestResultSummary(t *testing.T) {
 	result := &Result{
 		HasDrift: true,
@@ -679,6 +836,20 @@ func TestConfigValidate(t *testing.T) {
 	}
 }
 
+func TestConfigValidateSeverityOverrides(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.SeverityOverrides = map[string]string{"new_cycle": "warning", "stale_issue": "info", "density_growth": "critical"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("valid overrides rejected: %v", err)
+	}
+
+	cfg.SeverityOverrides["new_cycle"] = "fatal"
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "new_cycle") || !strings.Contains(err.Error(), "fatal") {
+		t.Errorf("expected an error naming new_cycle and the bad severity, got %v", err)
+	}
+}
+
 func TestCycleKey(t *testing.T) {
 	// Same cycle represented identically should match
 	key1 := cycleKey([]string{"A", "B", "C", "A"})
@@ -710,6 +881,7 @@ func TestCheckActionable_BaselineZero(t *testing.T) {
 	t.Log("Testing checkActionable when baseline actionable=0 (skip case)")
 
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:       100,
 			ActionableCount: 0, // Zero baseline should skip calculation
@@ -740,6 +912,7 @@ func TestCheckActionable_InfoIncrease(t *testing.T) {
 	t.Log("Testing checkActionable with 25% increase (info alert)")
 
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:       100,
 			ActionableCount: 100,
@@ -777,6 +950,7 @@ func TestCheckActionable_DecreaseWarning(t *testing.T) {
 	t.Log("Testing checkActionable with 35% decrease (warning alert)")
 
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:       100,
 			ActionableCount: 100,
@@ -814,6 +988,7 @@ func TestCheckActionable_InfoDecrease(t *testing.T) {
 	t.Log("Testing checkActionable with 25% decrease (info alert)")
 
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:       100,
 			ActionableCount: 80,
@@ -1094,6 +1269,7 @@ func TestCheckActionable_SmallChanges(t *testing.T) {
 	t.Log("Testing checkActionable with small changes (no alert)")
 
 	bl := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
 		Stats: baseline.GraphStats{
 			NodeCount:       100,
 			ActionableCount: 100,
@@ -1315,8 +1491,42 @@ func TestCalculatorLargeValues(t *testing.T) {
 	}
 }
 
+func TestLegacyStatsVersionSkipsBlockedAndActionable(t *testing.T) {
+	// Baselines saved before stats_version counted blocked and actionable
+	// issues differently, so their counts must not raise alerts
+	stats := func(blocked, actionable int) baseline.GraphStats {
+		return baseline.GraphStats{NodeCount: 100, BlockedCount: blocked, ActionableCount: actionable}
+	}
+	cfg := DefaultConfig()
+	cfg.PerLabel = map[string]Config{"api": {}}
+	bl := &baseline.Baseline{
+		Stats:      stats(0, 50),
+		LabelStats: map[string]baseline.GraphStats{"api": stats(0, 50)},
+	}
+	cur := &baseline.Baseline{
+		StatsVersion: baseline.CurrentStatsVersion,
+		Stats:        stats(20, 10),
+		LabelStats:   map[string]baseline.GraphStats{"api": stats(20, 10)},
+	}
+
+	for _, alert := range NewCalculator(bl, cur, cfg).Calculate().Alerts {
+		if alert.Type == AlertBlockedIncrease || alert.Type == AlertActionableChange {
+			t.Errorf("unexpected %s alert against a legacy baseline: %s", alert.Type, alert.Message)
+		}
+	}
+
+	bl.StatsVersion = baseline.CurrentStatsVersion
+	got := map[AlertType]int{}
+	for _, alert := range NewCalculator(bl, cur, cfg).Calculate().Alerts {
+		got[alert.Type]++
+	}
+	if got[AlertBlockedIncrease] != 2 || got[AlertActionableChange] != 2 {
+		t.Errorf("expected global and per-label blocked/actionable alerts, got %v", got)
+	}
+}
+
 func TestCheckBlocked_StrictThresholds(t *testing.T) {
-	bl := &baseline.Baseline{Stats: baseline.GraphStats{BlockedCount: 5}}
+	bl := &baseline.Baseline{StatsVersion: baseline.CurrentStatsVersion, Stats: baseline.GraphStats{BlockedCount: 5}}
 
 	tests := []struct {
 		name      string
@@ -1483,3 +1693,855 @@ func TestLabelOverridesValidation(t *testing.T) {
 		t.Error("negative days should fail validation")
 	}
 }
+
+func TestConfigEnvOverridesWinOverFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	bvDir := filepath.Join(tmpDir, ".bv")
+	if err := os.MkdirAll(bvDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configContent := `
+density_warning_pct: 75
+density_info_pct: 30
+stale_warning_days: 21
+`
+	if err := os.WriteFile(filepath.Join(bvDir, "drift.yaml"), []byte(configContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("BV_DRIFT_DENSITY_WARNING_PCT", "40")
+	t.Setenv("BV_DRIFT_STALE_WARNING_DAYS", "7")
+	t.Setenv("BV_DRIFT_DISABLED_ALERTS", "stale_issue, new_cycle")
+
+	config, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.DensityWarningPct != 40 {
+		t.Errorf("expected env density_warning_pct=40, got %f", config.DensityWarningPct)
+	}
+	if config.StaleWarningDays != 7 {
+		t.Errorf("expected env stale_warning_days=7, got %d", config.StaleWarningDays)
+	}
+	if config.DensityInfoPct != 30 {
+		t.Errorf("expected file density_info_pct=30 to be kept, got %f", config.DensityInfoPct)
+	}
+	if !config.IsAlertDisabled("stale_issue") || !config.IsAlertDisabled("new_cycle") {
+		t.Errorf("expected disabled alerts from env, got %v", config.DisabledAlerts)
+	}
+}
+
+func TestConfigEnvOverridesWithoutFile(t *testing.T) {
+	t.Setenv("BV_DRIFT_BLOCKED_INCREASE_THRESHOLD", "2")
+
+	config, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if config.BlockedIncreaseThreshold != 2 {
+		t.Errorf("expected env blocked_increase_threshold=2, got %d", config.BlockedIncreaseThreshold)
+	}
+}
+
+func TestConfigEnvOverridesInvalid(t *testing.T) {
+	t.Setenv("BV_DRIFT_STALE_CRITICAL_DAYS", "soon")
+
+	_, err := LoadConfig(t.TempDir())
+	if err == nil {
+		t.Fatal("expected error for unparseable env value")
+	}
+	if !strings.Contains(err.Error(), "BV_DRIFT_STALE_CRITICAL_DAYS") {
+		t.Errorf("error should name the variable, got: %v", err)
+	}
+}
+
+func TestConfigEnvOverridesUnknown(t *testing.T) {
+	t.Setenv("BV_DRIFT_STALE_WARN_DAYS", "7")
+
+	_, err := LoadConfig(t.TempDir())
+	if err == nil {
+		t.Fatal("expected error for unknown BV_DRIFT_ variable")
+	}
+	if !strings.Contains(err.Error(), "BV_DRIFT_STALE_WARN_DAYS") {
+		t.Errorf("error should name the variable, got: %v", err)
+	}
+}
+
+func TestConfigEnvOverridesValidated(t *testing.T) {
+	// Env values still go through Validate()
+	t.Setenv("BV_DRIFT_DENSITY_INFO_PCT", "90")
+
+	if _, err := LoadConfig(t.TempDir()); err == nil {
+		t.Fatal("expected validation error when density_info_pct exceeds density_warning_pct")
+	}
+}
+
+func TestEnvVarNames(t *testing.T) {
+	names := EnvVarNames()
+	want := map[string]bool{
+		"BV_DRIFT_DENSITY_WARNING_PCT": false,
+		"BV_DRIFT_STALE_WARNING_DAYS":  false,
+		"BV_DRIFT_DISABLED_ALERTS":     false,
+	}
+	for _, n := range names {
+		if !strings.HasPrefix(n, EnvVarPrefix) {
+			t.Errorf("env var %q missing prefix", n)
+		}
+		if _, ok := want[n]; ok {
+			want[n] = true
+		}
+	}
+	for n, found := range want {
+		if !found {
+			t.Errorf("expected %s in EnvVarNames()", n)
+		}
+	}
+}
+
+func TestPerLabelThresholds(t *testing.T) {
+	// Same 60% density increase in both label subgraphs
+	blLabelStats := map[string]baseline.GraphStats{
+		"experimental": {NodeCount: 10, EdgeCount: 10, Density: 0.10},
+		"core":         {NodeCount: 10, EdgeCount: 10, Density: 0.10},
+	}
+	curLabelStats := map[string]baseline.GraphStats{
+		"experimental": {NodeCount: 10, EdgeCount: 10, Density: 0.16},
+		"core":         {NodeCount: 10, EdgeCount: 10, Density: 0.16},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 20, EdgeCount: 20, Density: 0.05}, LabelStats: blLabelStats}
+	cur := &baseline.Baseline{Stats: bl.Stats, LabelStats: curLabelStats}
+
+	cfg := DefaultConfig()
+	cfg.PerLabel = map[string]Config{
+		"experimental": {DensityWarningPct: 200, DensityInfoPct: 100},
+		"core":         {DensityWarningPct: 20, DensityInfoPct: 10},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("config should be valid: %v", err)
+	}
+
+	result := NewCalculator(bl, cur, cfg).Calculate()
+
+	var coreAlert *Alert
+	for i, a := range result.Alerts {
+		if a.Type != AlertDensityGrowth {
+			continue
+		}
+		switch a.Label {
+		case "core":
+			coreAlert = &result.Alerts[i]
+		case "experimental":
+			t.Errorf("lenient experimental override should not alert, got %s", a.Severity)
+		case "":
+			t.Errorf("global density unchanged, should not alert: %s", a.Message)
+		}
+	}
+	if coreAlert == nil {
+		t.Fatal("expected density alert tagged with core label")
+	}
+	if coreAlert.Severity != SeverityWarning {
+		t.Errorf("core density alert severity = %s, want warning", coreAlert.Severity)
+	}
+	if !strings.Contains(coreAlert.Message, "[core]") {
+		t.Errorf("expected label in message, got %q", coreAlert.Message)
+	}
+
+	// A looser experimental override downgrades the same change to info
+	cfg.PerLabel["experimental"] = Config{DensityWarningPct: 100, DensityInfoPct: 40}
+	result = NewCalculator(bl, cur, cfg).Calculate()
+	sev := map[string]Severity{}
+	for _, a := range result.Alerts {
+		if a.Type == AlertDensityGrowth {
+			sev[a.Label] = a.Severity
+		}
+	}
+	if sev["experimental"] != SeverityInfo || sev["core"] != SeverityWarning {
+		t.Errorf("expected experimental=info core=warning, got %v", sev)
+	}
+}
+
+func TestConfigForLabelMerge(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.PerLabel = map[string]Config{
+		"core": {DensityWarningPct: 20, BlockedIncreaseThreshold: 1},
+	}
+
+	merged := cfg.ForLabel("core")
+	if merged.DensityWarningPct != 20 || merged.BlockedIncreaseThreshold != 1 {
+		t.Errorf("override fields not applied: %+v", merged)
+	}
+	if merged.DensityInfoPct != cfg.DensityInfoPct || merged.StaleWarningDays != cfg.StaleWarningDays {
+		t.Errorf("zero fields should fall back to global values")
+	}
+	if merged.PerLabel != nil {
+		t.Error("merged config should not carry PerLabel")
+	}
+
+	unknown := cfg.ForLabel("other")
+	if unknown.DensityWarningPct != cfg.DensityWarningPct {
+		t.Errorf("unknown label should use global thresholds")
+	}
+}
+
+func TestConfigValidatePerLabel(t *testing.T) {
+	cfg := DefaultConfig()
+	// info above the (global) warning threshold is invalid once merged
+	cfg.PerLabel = map[string]Config{"core": {DensityInfoPct: 80}}
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected validation error for per-label override")
+	}
+	if !strings.Contains(err.Error(), `"core"`) {
+		t.Errorf("error should name the label, got %v", err)
+	}
+}
+
+func TestComputeLabelStats(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Labels: []string{"core"}, Status: model.StatusOpen},
+		{ID: "B", Labels: []string{"core"}, Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Labels: []string{"ui"}, Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+	}
+
+	stats := ComputeLabelStats(issues, nil)
+	core := stats["core"]
+	if core.NodeCount != 2 || core.EdgeCount != 1 {
+		t.Errorf("core nodes/edges = %d/%d, want 2/1", core.NodeCount, core.EdgeCount)
+	}
+	if core.Density != 0.5 {
+		t.Errorf("core density = %f, want 0.5", core.Density)
+	}
+	if core.ActionableCount != 1 {
+		t.Errorf("core actionable = %d, want 1", core.ActionableCount)
+	}
+	ui := stats["ui"]
+	if ui.NodeCount != 1 || ui.EdgeCount != 0 || ui.ActionableCount != 0 {
+		t.Errorf("unexpected ui stats: %+v", ui)
+	}
+	if core.BlockedCount != 1 || ui.BlockedCount != 1 {
+		t.Errorf("blocked counts core/ui = %d/%d, want 1/1 (dependency-based)", core.BlockedCount, ui.BlockedCount)
+	}
+}
+
+func TestCountBlockedSkipsIgnored(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Labels: []string{"epic"}, Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "C", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "D", DependsOnID: "A", Type: model.DepRelated},
+		}},
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+
+	if got := CountBlocked(analyzer, issues, nil); got != 2 {
+		t.Errorf("CountBlocked(nil config) = %d, want 2", got)
+	}
+	cfg := DefaultConfig()
+	cfg.IgnoreLabels = []string{"epic"}
+	if got := CountBlocked(analyzer, issues, cfg); got != 1 {
+		t.Errorf("CountBlocked(ignore epic) = %d, want 1", got)
+	}
+}
+
+func TestCalculatorVelocityDrop(t *testing.T) {
+	now := time.Now()
+	var issues []model.Issue
+	// Only 2 closures in the last 30 days
+	for i := 0; i < 2; i++ {
+		closedAt := now.Add(-time.Duration(i+1) * 24 * time.Hour)
+		issues = append(issues, model.Issue{
+			ID:        "closed-" + string(rune('a'+i)),
+			Status:    model.StatusClosed,
+			CreatedAt: now.Add(-60 * 24 * time.Hour),
+			ClosedAt:  &closedAt,
+		})
+	}
+	curVel := ClosedPerWeek(issues, now)
+	if curVel <= 0 {
+		t.Fatalf("expected positive current velocity, got %f", curVel)
+	}
+
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 10, ClosedPerWeek: 7}}
+	cur := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 10, ClosedPerWeek: curVel}}
+
+	result := NewCalculator(bl, cur, nil).Calculate()
+	var found *Alert
+	for i, a := range result.Alerts {
+		if a.Type == AlertVelocityDrop {
+			found = &result.Alerts[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected velocity_drop alert, got %+v", result.Alerts)
+	}
+	if found.Severity != SeverityWarning {
+		t.Errorf("velocity_drop severity = %s, want warning", found.Severity)
+	}
+	if found.BaselineVal != 7 || found.CurrentVal != curVel {
+		t.Errorf("unexpected values baseline=%f current=%f", found.BaselineVal, found.CurrentVal)
+	}
+
+	// A modest dip below the threshold is ignored
+	cur.Stats.ClosedPerWeek = 5
+	for _, a := range NewCalculator(bl, cur, nil).Calculate().Alerts {
+		if a.Type == AlertVelocityDrop {
+			t.Errorf("unexpected velocity_drop alert for ~29%% dip: %s", a.Message)
+		}
+	}
+}
+
+func TestClosedPerWeekExcluding(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var issues []model.Issue
+	for i, labels := range [][]string{{"api"}, {"api", "dependabot"}, {"dependabot"}, {"automated"}, nil} {
+		closedAt := now.Add(-time.Duration(i+1) * 24 * time.Hour)
+		issues = append(issues, model.Issue{
+			ID:        fmt.Sprintf("c-%d", i),
+			Labels:    labels,
+			Status:    model.StatusClosed,
+			CreatedAt: now.Add(-60 * 24 * time.Hour),
+			ClosedAt:  &closedAt,
+		})
+	}
+
+	if got, want := ClosedPerWeekExcluding(issues, now, nil), ClosedPerWeek(issues, now); got != want {
+		t.Errorf("no exclusions = %f, want %f", got, want)
+	}
+	// Any excluded label drops the issue; api-1 and the unlabeled one remain
+	got := ClosedPerWeekExcluding(issues, now, []string{"dependabot", "automated"})
+	if want := 2.0 * 7 / 30; got != want {
+		t.Errorf("excluding bots = %f, want %f", got, want)
+	}
+}
+
+func TestCalculatorVelocityDropOldBaseline(t *testing.T) {
+	// Baselines saved before velocity tracking have no closed_per_week field
+	dir := t.TempDir()
+	path := filepath.Join(dir, "baseline.json")
+	old := `{"version":1,"created_at":"2024-01-01T00:00:00Z","stats":{"node_count":10,"edge_count":5,"density":0.05}}`
+	if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bl, err := baseline.Load(path)
+	if err != nil {
+		t.Fatalf("loading old baseline: %v", err)
+	}
+	if bl.Stats.ClosedPerWeek != 0 {
+		t.Fatalf("expected zero velocity for old baseline, got %f", bl.Stats.ClosedPerWeek)
+	}
+
+	cur := &baseline.Baseline{Stats: bl.Stats}
+	for _, a := range NewCalculator(bl, cur, nil).Calculate().Alerts {
+		if a.Type == AlertVelocityDrop {
+			t.Errorf("old baseline should not produce velocity_drop: %s", a.Message)
+		}
+	}
+}
+
+func TestCalculatorStalenessIgnoreList(t *testing.T) {
+	now := time.Now().UTC()
+	old := now.Add(-40 * 24 * time.Hour)
+	issues := []model.Issue{
+		{ID: "EPIC-1", Status: model.StatusOpen, UpdatedAt: old},
+		{ID: "META-1", Status: model.StatusOpen, UpdatedAt: old, Labels: []string{"meta"}},
+		{ID: "TASK-1", Status: model.StatusOpen, UpdatedAt: old, Labels: []string{"metadata"}},
+	}
+
+	cfg := DefaultConfig()
+	cfg.IgnoreIssueIDs = []string{"EPIC-1"}
+	cfg.IgnoreLabels = []string{"meta"}
+
+	calc := NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	result := calc.Calculate()
+
+	stale := map[string]bool{}
+	for _, a := range result.Alerts {
+		if a.Type == AlertStaleIssue {
+			stale[a.IssueID] = true
+		}
+	}
+	if stale["EPIC-1"] {
+		t.Error("ignored epic should not trigger a stale alert")
+	}
+	if stale["META-1"] {
+		t.Error("issue with ignored label should not trigger a stale alert")
+	}
+	if !stale["TASK-1"] {
+		t.Error("non-ignored issue should still trigger a stale alert (label match must be exact)")
+	}
+}
+
+func TestCalculatorBlockingCascadeIgnoreList(t *testing.T) {
+	blocks := []*model.Dependency{{DependsOnID: "EPIC", Type: model.DepBlocks}}
+	issues := []model.Issue{
+		{ID: "EPIC", Status: model.StatusOpen, Labels: []string{"epic"}},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blocks},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blocks},
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "TRACKER", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+	}
+	cfg := DefaultConfig()
+	cfg.BlockingCascadeInfo = 2
+	cfg.BlockingCascadeWarning = 3
+	cfg.IgnoreLabels = []string{"epic"}
+	cfg.IgnoreIssueIDs = []string{"TRACKER"}
+
+	calc := NewCalculator(&baseline.Baseline{}, &baseline.Baseline{}, cfg)
+	calc.SetIssues(issues)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type != AlertBlockingCascade {
+			continue
+		}
+		if a.IssueID == "EPIC" {
+			t.Error("ignored issue should not be reported as a cascade source")
+		}
+		if a.IssueID == "A" {
+			t.Errorf("ignored dependents should not count toward A's cascade: %v", a.Details)
+		}
+	}
+}
+
+func TestIgnoredIssuesKeepGraphShape(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "EPIC", Status: model.StatusBlocked, Labels: []string{"core"}, Dependencies: []*model.Dependency{
+			{IssueID: "EPIC", DependsOnID: "T2", Type: model.DepBlocks},
+		}},
+		{ID: "T1", Status: model.StatusBlocked, Labels: []string{"core"}, Dependencies: []*model.Dependency{
+			{IssueID: "T1", DependsOnID: "T2", Type: model.DepBlocks},
+		}},
+		{ID: "T2", Status: model.StatusOpen, Labels: []string{"core"}},
+	}
+	cfg := DefaultConfig()
+	cfg.IgnoreIssueIDs = []string{"EPIC"}
+
+	stats := ComputeLabelStats(issues, cfg)["core"]
+	if stats.NodeCount != 3 {
+		t.Errorf("ignored issues should still count as nodes, got %d", stats.NodeCount)
+	}
+	if stats.BlockedCount != 1 {
+		t.Errorf("ignored issues should be excluded from blocked count, got %d", stats.BlockedCount)
+	}
+
+	if got := ComputeLabelStats(issues, nil)["core"].BlockedCount; got != 2 {
+		t.Errorf("nil config should ignore nothing, got blocked=%d", got)
+	}
+}
+
+func TestLoadConfigLayeredPrecedence(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	userPath, err := UserConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(userPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	userContent := `
+density_warning_pct: 80
+blocked_increase_threshold: 8
+stale_warning_days: 10
+`
+	if err := os.WriteFile(userPath, []byte(userContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectContent := `
+density_warning_pct: 60
+closure_velocity_drop_pct: 0
+`
+	if err := os.WriteFile(ConfigPath(projectDir), []byte(projectContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfigLayered(projectDir)
+	if err != nil {
+		t.Fatalf("LoadConfigLayered failed: %v", err)
+	}
+	defaults := DefaultConfig()
+	if config.DensityWarningPct != 60 {
+		t.Errorf("expected project density_warning_pct=60, got %f", config.DensityWarningPct)
+	}
+	if config.BlockedIncreaseThreshold != 8 || config.StaleWarningDays != 10 {
+		t.Errorf("expected user values to override defaults, got blocked=%d stale=%d",
+			config.BlockedIncreaseThreshold, config.StaleWarningDays)
+	}
+	if config.DensityInfoPct != defaults.DensityInfoPct {
+		t.Errorf("expected default density_info_pct=%f, got %f", defaults.DensityInfoPct, config.DensityInfoPct)
+	}
+	if config.ClosureVelocityDropPct != 0 {
+		t.Errorf("explicit zero in project config should win, got %f", config.ClosureVelocityDropPct)
+	}
+
+	// Without a project file the user layer alone applies
+	config, err = LoadConfigLayered(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfigLayered failed: %v", err)
+	}
+	if config.DensityWarningPct != 80 {
+		t.Errorf("expected user density_warning_pct=80, got %f", config.DensityWarningPct)
+	}
+}
+
+func TestLoadConfigLayeredValidatesMergedResult(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	userPath, _ := UserConfigPath()
+	if err := os.MkdirAll(filepath.Dir(userPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// density_info_pct above the default warning is only valid with the project's warning
+	if err := os.WriteFile(userPath, []byte("density_info_pct: 70\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(projectDir), []byte("density_warning_pct: 90\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfigLayered(projectDir); err != nil {
+		t.Errorf("merged config should validate, got %v", err)
+	}
+	if _, err := LoadConfigLayered(t.TempDir()); err == nil {
+		t.Error("expected user layer alone to fail validation")
+	}
+}
+
+func TestMergeConfig(t *testing.T) {
+	base := DefaultConfig()
+	base.IgnoreLabels = []string{"epic"}
+	base.PerLabel = map[string]Config{"core": {DensityWarningPct: 20}}
+
+	user := &Config{DensityWarningPct: 80, StaleWarningDays: 10}
+	project := &Config{
+		DensityWarningPct: 60,
+		DisabledAlerts:    []string{"stale_issue"},
+		PerLabel:          map[string]Config{"ui": {BlockedIncreaseThreshold: 2}},
+	}
+
+	merged := MergeConfig(MergeConfig(base, user), project)
+	if merged.DensityWarningPct != 60 {
+		t.Errorf("project should win over user, got %f", merged.DensityWarningPct)
+	}
+	if merged.StaleWarningDays != 10 {
+		t.Errorf("user should win over defaults, got %d", merged.StaleWarningDays)
+	}
+	if merged.DensityInfoPct != base.DensityInfoPct || merged.ClosureVelocityDropPct != base.ClosureVelocityDropPct {
+		t.Errorf("zero override fields should keep base values: %+v", merged)
+	}
+	if !merged.IsAlertDisabled("stale_issue") || len(merged.IgnoreLabels) != 1 {
+		t.Errorf("slices not merged as expected: %+v", merged)
+	}
+	if len(merged.PerLabel) != 2 {
+		t.Errorf("PerLabel should be merged per label, got %v", merged.PerLabel)
+	}
+	if len(base.PerLabel) != 1 || base.DensityWarningPct != 50 {
+		t.Error("MergeConfig must not mutate base")
+	}
+
+	if got := MergeConfig(nil, nil); !reflect.DeepEqual(got, DefaultConfig()) {
+		t.Errorf("MergeConfig(nil, nil) = %+v, want defaults", got)
+	}
+}
+
+func inProgressIssues(n int) []model.Issue {
+	issues := []model.Issue{{ID: "OPEN", Status: model.StatusOpen}}
+	for i := 0; i < n; i++ {
+		issues = append(issues, model.Issue{ID: fmt.Sprintf("WIP-%d", i), Status: model.StatusInProgress})
+	}
+	return issues
+}
+
+func wipAlerts(result *Result) []Alert {
+	var alerts []Alert
+	for _, a := range result.Alerts {
+		if a.Type == AlertWIPExceeded {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+func TestCalculatorWIPExceeded(t *testing.T) {
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+
+	tests := []struct {
+		name       string
+		inProgress int
+		warn, crit int
+		want       Severity // "" means no alert
+	}{
+		{"disabled by default", 6, 0, 0, ""},
+		{"at the limit", 5, 5, 8, ""},
+		{"over the warning limit", 6, 5, 8, SeverityWarning},
+		{"at the critical limit", 8, 5, 8, SeverityWarning},
+		{"over the critical limit", 9, 5, 8, SeverityCritical},
+		{"critical only", 9, 0, 8, SeverityCritical},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MaxInProgress = tt.warn
+			cfg.MaxInProgressCritical = tt.crit
+			calc := NewCalculator(bl, current, cfg)
+			calc.SetIssues(inProgressIssues(tt.inProgress))
+
+			alerts := wipAlerts(calc.Calculate())
+			if tt.want == "" {
+				if len(alerts) != 0 {
+					t.Fatalf("expected no wip_exceeded alert, got %+v", alerts)
+				}
+				return
+			}
+			if len(alerts) != 1 {
+				t.Fatalf("expected one wip_exceeded alert, got %+v", alerts)
+			}
+			if alerts[0].Severity != tt.want || alerts[0].CurrentVal != float64(tt.inProgress) {
+				t.Errorf("alert = %+v, want %s with current %d", alerts[0], tt.want, tt.inProgress)
+			}
+		})
+	}
+}
+
+func TestCalculatorWIPExceeded_IgnoredAndDisabled(t *testing.T) {
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{}}
+
+	cfg := DefaultConfig()
+	cfg.MaxInProgress = 5
+	cfg.IgnoreIssueIDs = []string{"WIP-0"}
+	calc := NewCalculator(bl, current, cfg)
+	calc.SetIssues(inProgressIssues(6))
+	if alerts := wipAlerts(calc.Calculate()); len(alerts) != 0 {
+		t.Errorf("ignored issues should not count toward WIP, got %+v", alerts)
+	}
+
+	cfg = DefaultConfig()
+	cfg.MaxInProgress = 5
+	cfg.DisabledAlerts = []string{string(AlertWIPExceeded)}
+	calc = NewCalculator(bl, current, cfg)
+	calc.SetIssues(inProgressIssues(6))
+	if alerts := wipAlerts(calc.Calculate()); len(alerts) != 0 {
+		t.Errorf("disabled alert type should not fire, got %+v", alerts)
+	}
+}
+
+func TestConfigValidate_WIPThresholds(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxInProgress = 8
+	cfg.MaxInProgressCritical = 5
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error when max_in_progress_critical < max_in_progress")
+	}
+
+	for _, tc := range [][2]int{{5, 8}, {5, 5}, {5, 0}, {0, 8}} {
+		cfg := DefaultConfig()
+		cfg.MaxInProgress, cfg.MaxInProgressCritical = tc[0], tc[1]
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("warn=%d crit=%d: unexpected error %v", tc[0], tc[1], err)
+		}
+	}
+
+	cfg = DefaultConfig()
+	cfg.MaxInProgress = -1
+	if err := cfg.Validate(); err == nil {
+		t.Error("expected error for negative max_in_progress")
+	}
+}
+
+// snapshotStats builds baseline stats for issues the way the CLI does
+func snapshotStats(issues []model.Issue) baseline.GraphStats {
+	stats := baseline.GraphStats{NodeCount: len(issues)}
+	for _, iss := range issues {
+		if iss.Status == model.StatusClosed {
+			stats.ClosedCount++
+		} else {
+			stats.OpenCount++
+		}
+		stats.EdgeCount += len(iss.Dependencies)
+	}
+	stats.Density = analysis.GraphDensity(stats.NodeCount, stats.EdgeCount)
+	SetLiveGraphStats(&stats, issues)
+	return stats
+}
+
+func TestCalculatorIgnoreClosedInGraphMetrics(t *testing.T) {
+	// Two chains of five: one still open, one closed
+	var issues []model.Issue
+	for _, group := range []struct {
+		prefix string
+		status model.Status
+	}{{"open", model.StatusOpen}, {"done", model.StatusClosed}} {
+		for i := 0; i < 5; i++ {
+			iss := model.Issue{ID: fmt.Sprintf("%s-%d", group.prefix, i), Status: group.status}
+			if i > 0 {
+				iss.Dependencies = []*model.Dependency{{DependsOnID: fmt.Sprintf("%s-%d", group.prefix, i-1), Type: model.DepBlocks}}
+			}
+			issues = append(issues, iss)
+		}
+	}
+	bl := &baseline.Baseline{Stats: snapshotStats(issues)}
+	if bl.Stats.LiveNodeCount != 5 || bl.Stats.LiveEdgeCount != 4 {
+		t.Fatalf("live graph = %d nodes / %d edges, want 5 / 4", bl.Stats.LiveNodeCount, bl.Stats.LiveEdgeCount)
+	}
+	// A cleanup prunes every closed issue
+	cur := &baseline.Baseline{Stats: snapshotStats(issues[:5])}
+
+	shapeAlerts := func(cfg *Config) []AlertType {
+		var types []AlertType
+		for _, a := range NewCalculator(bl, cur, cfg).Calculate().Alerts {
+			switch a.Type {
+			case AlertNodeCountChange, AlertEdgeCountChange, AlertDensityGrowth:
+				types = append(types, a.Type)
+			}
+		}
+		return types
+	}
+
+	off := shapeAlerts(DefaultConfig())
+	if !slices.Contains(off, AlertNodeCountChange) {
+		t.Errorf("pruning half the issues should raise a node count alert by default, got %v", off)
+	}
+
+	cfg := DefaultConfig()
+	cfg.IgnoreClosedInGraphMetrics = true
+	if on := shapeAlerts(cfg); len(on) != 0 {
+		t.Errorf("live graph is unchanged, expected no shape alerts, got %v", on)
+	}
+
+	// Baselines saved before live tracking fall back to the totals
+	old := &baseline.Baseline{Stats: bl.Stats}
+	old.Stats.LiveNodeCount, old.Stats.LiveEdgeCount, old.Stats.LiveDensity = 0, 0, 0
+	bl = old
+	if got := shapeAlerts(cfg); !slices.Contains(got, AlertNodeCountChange) {
+		t.Errorf("old baseline should compare totals, got %v", got)
+	}
+}
+
+// labelHealthIssues returns two healthy labels, auth and ui, each with
+// recent closures and fresh open work
+func labelHealthIssues(now time.Time) []model.Issue {
+	var issues []model.Issue
+	for _, label := range []string{"auth", "ui"} {
+		for i := range 4 {
+			iss := model.Issue{
+				ID:        fmt.Sprintf("%s-%d", label, i),
+				Labels:    []string{label},
+				Status:    model.StatusOpen,
+				CreatedAt: now.AddDate(0, 0, -20),
+				UpdatedAt: now.AddDate(0, 0, -1),
+			}
+			if i%2 == 0 {
+				closedAt := now.AddDate(0, 0, -i-1)
+				iss.Status, iss.ClosedAt = model.StatusClosed, &closedAt
+			}
+			issues = append(issues, iss)
+		}
+	}
+	return issues
+}
+
+func TestCalculatorLabelHealthDrop(t *testing.T) {
+	now := time.Now().UTC()
+	healthCfg := analysis.DefaultLabelHealthConfig()
+	issues := labelHealthIssues(now)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	saved := &baseline.Baseline{LabelHealth: ComputeLabelHealth(issues, healthCfg, now)}
+	if err := saved.Save(path); err != nil {
+		t.Fatalf("saving baseline: %v", err)
+	}
+	bl, err := baseline.Load(path)
+	if err != nil {
+		t.Fatalf("loading baseline: %v", err)
+	}
+	if len(bl.LabelHealth) != 2 {
+		t.Fatalf("baseline label health = %+v, want auth and ui", bl.LabelHealth)
+	}
+
+	// Pile stale, blocked work onto auth; ui is untouched
+	stale := now.AddDate(0, 0, -90)
+	for i := range 6 {
+		issues = append(issues, model.Issue{
+			ID:           fmt.Sprintf("auth-stale-%d", i),
+			Labels:       []string{"auth"},
+			Status:       model.StatusBlocked,
+			CreatedAt:    stale,
+			UpdatedAt:    stale,
+			Dependencies: []*model.Dependency{{DependsOnID: "ui-1", Type: model.DepBlocks}},
+		})
+	}
+	cur := &baseline.Baseline{LabelHealth: ComputeLabelHealth(issues, healthCfg, now)}
+
+	var drops []Alert
+	for _, a := range NewCalculator(bl, cur, nil).Calculate().Alerts {
+		if a.Type == AlertLabelHealthDrop {
+			drops = append(drops, a)
+		}
+	}
+	if len(drops) != 1 {
+		t.Fatalf("got %d label_health_drop alerts %+v, want 1 for auth", len(drops), drops)
+	}
+	drop := drops[0]
+	if drop.Label != "auth" || !strings.Contains(drop.Message, "auth") || drop.Severity != SeverityWarning {
+		t.Errorf("unexpected alert: %+v", drop)
+	}
+	if drop.BaselineVal != float64(bl.LabelHealth["auth"].Health) || drop.CurrentVal != float64(cur.LabelHealth["auth"].Health) {
+		t.Errorf("alert values %v -> %v, want %+v -> %+v", drop.BaselineVal, drop.CurrentVal, bl.LabelHealth["auth"], cur.LabelHealth["auth"])
+	}
+
+	// A points threshold above the drop silences it unless the percentage catches it
+	cfg := DefaultConfig()
+	cfg.LabelHealthDropPoints = 100
+	for _, a := range NewCalculator(bl, cur, cfg).Calculate().Alerts {
+		if a.Type == AlertLabelHealthDrop {
+			t.Errorf("unexpected alert above the points threshold: %s", a.Message)
+		}
+	}
+	cfg.LabelHealthDropPct = 10
+	if alerts := NewCalculator(bl, cur, cfg).Calculate().Alerts; !slices.ContainsFunc(alerts, func(a Alert) bool {
+		return a.Type == AlertLabelHealthDrop && a.Label == "auth"
+	}) {
+		t.Errorf("expected the percentage threshold to flag auth, got %+v", alerts)
+	}
+}
+
+func TestCalculatorLabelHealthDropOldBaseline(t *testing.T) {
+	// Baselines saved before label health tracking have no label_health field
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	old := `{"version":1,"created_at":"2024-01-01T00:00:00Z","stats":{"node_count":8}}`
+	if err := os.WriteFile(path, []byte(old), 0644); err != nil {
+		t.Fatal(err)
+	}
+	bl, err := baseline.Load(path)
+	if err != nil {
+		t.Fatalf("loading old baseline: %v", err)
+	}
+
+	now := time.Now().UTC()
+	cur := &baseline.Baseline{Stats: bl.Stats, LabelHealth: ComputeLabelHealth(labelHealthIssues(now), analysis.DefaultLabelHealthConfig(), now)}
+	for _, a := range NewCalculator(bl, cur, nil).Calculate().Alerts {
+		if a.Type == AlertLabelHealthDrop {
+			t.Errorf("old baseline should not produce label_health_drop: %s", a.Message)
+		}
+	}
+}
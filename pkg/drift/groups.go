@@ -0,0 +1,79 @@
+package drift
+
+import "github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+// GlobalAlertGroup is the GroupAlertsByLabel group for alerts not tied to a
+// labeled issue, such as graph-wide density changes
+const GlobalAlertGroup = "global"
+
+// GroupAlertsByLabel groups alerts by the label that owns them, so each team
+// can be sent its own drift. A label-scoped alert goes under its Label;
+// otherwise the alert goes under the dominant label of the issues involved
+// (IssueID and IssueIDs): the one carried by the most of them, ties going to
+// the alphabetically first. Anything else goes under GlobalAlertGroup.
+// issues supplies the labels. Alerts keep their order within a group.
+func GroupAlertsByLabel(alerts []Alert, issues []model.Issue) map[string][]Alert {
+	indexes := GroupAlertIndexesByLabel(alerts, issues)
+	if indexes == nil {
+		return nil
+	}
+	groups := make(map[string][]Alert, len(indexes))
+	for group, idx := range indexes {
+		for _, i := range idx {
+			groups[group] = append(groups[group], alerts[i])
+		}
+	}
+	return groups
+}
+
+// GroupAlertIndexesByLabel is GroupAlertsByLabel returning positions in
+// alerts rather than copies, for outputs that already list the alerts
+func GroupAlertIndexesByLabel(alerts []Alert, issues []model.Issue) map[string][]int {
+	if len(alerts) == 0 {
+		return nil
+	}
+	labelsByID := make(map[string][]string, len(issues))
+	for _, iss := range issues {
+		labelsByID[iss.ID] = iss.Labels
+	}
+
+	groups := make(map[string][]int)
+	for i, alert := range alerts {
+		group := alert.Label
+		if group == "" {
+			group = dominantLabel(alert, labelsByID)
+		}
+		groups[group] = append(groups[group], i)
+	}
+	return groups
+}
+
+// dominantLabel returns the label carried by the most issues involved in
+// alert, or GlobalAlertGroup when none of them is labeled
+func dominantLabel(alert Alert, labelsByID map[string][]string) string {
+	ids := alert.IssueIDs
+	if alert.IssueID != "" {
+		ids = append([]string{alert.IssueID}, ids...)
+	}
+	counts := make(map[string]int)
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		for _, label := range labelsByID[id] {
+			if label != "" {
+				counts[label]++
+			}
+		}
+	}
+
+	best, bestCount := GlobalAlertGroup, 0
+	for label, n := range counts {
+		if n > bestCount || (n == bestCount && label < best) {
+			best, bestCount = label, n
+		}
+	}
+	return best
+}
@@ -0,0 +1,80 @@
+package drift
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCalculatorGroupsAlertsByLabel(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Labels: []string{"auth", "backend"}},
+		{ID: "B", Labels: []string{"auth"}},
+		{ID: "C", Labels: []string{"auth", "ui"}},
+		{ID: "D", Labels: []string{"ui"}},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 4, EdgeCount: 3, Density: 0.02}}
+	current := &baseline.Baseline{
+		Stats:  baseline.GraphStats{NodeCount: 4, EdgeCount: 3, Density: 0.04},
+		Cycles: [][]string{{"A", "B", "C", "A"}},
+		Issues: baseline.SnapshotIssues(issues),
+	}
+
+	result := NewCalculator(bl, current, nil).Calculate()
+
+	var groupedCount int
+	for _, alerts := range result.Groups {
+		groupedCount += len(alerts)
+	}
+	if groupedCount != len(result.Alerts) {
+		t.Errorf("groups hold %d alerts, want all %d", groupedCount, len(result.Alerts))
+	}
+
+	auth := result.Groups["auth"]
+	if len(auth) != 1 || auth[0].Type != AlertNewCycle {
+		t.Fatalf("auth group = %+v, want the cycle alert", auth)
+	}
+	if want := []string{"A", "B", "C"}; !reflect.DeepEqual(auth[0].IssueIDs, want) {
+		t.Errorf("cycle IssueIDs = %v, want %v", auth[0].IssueIDs, want)
+	}
+	global := result.Groups[GlobalAlertGroup]
+	if len(global) != 1 || global[0].Type != AlertDensityGrowth {
+		t.Errorf("global group = %+v, want the density alert", global)
+	}
+}
+
+func TestGroupAlertsByLabel(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Labels: []string{"db", "api"}},
+		{ID: "B", Labels: []string{"db", "api"}},
+		{ID: "U"},
+	}
+	alerts := []Alert{
+		{Type: AlertBlockedIncrease, Label: "ui"},                         // Label-scoped wins
+		{Type: AlertNewCycle, IssueIDs: []string{"A", "B"}},               // Tie goes to api
+		{Type: AlertStaleIssue, IssueID: "U"},                             // Unlabeled issue
+		{Type: AlertBlockingCascade, IssueID: "missing"},                  // Unknown issue
+		{Type: AlertNewCycle, IssueID: "B", IssueIDs: []string{"B", "A"}}, // Counted once per issue
+	}
+
+	groups := GroupAlertsByLabel(alerts, issues)
+	want := map[string][]Alert{
+		"ui":             {alerts[0]},
+		"api":            {alerts[1], alerts[4]},
+		GlobalAlertGroup: {alerts[2], alerts[3]},
+	}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("GroupAlertsByLabel = %+v\nwant %+v", groups, want)
+	}
+	if got := GroupAlertsByLabel(nil, issues); got != nil {
+		t.Errorf("no alerts should give nil groups, got %v", got)
+	}
+
+	indexes := GroupAlertIndexesByLabel(alerts, issues)
+	wantIndexes := map[string][]int{"ui": {0}, "api": {1, 4}, GlobalAlertGroup: {2, 3}}
+	if !reflect.DeepEqual(indexes, wantIndexes) {
+		t.Errorf("GroupAlertIndexesByLabel = %v, want %v", indexes, wantIndexes)
+	}
+}
@@ -0,0 +1,269 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/internal/jsonl"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// HistoryFilename is the append-only drift history file under .bv
+const HistoryFilename = "drift-history.jsonl"
+
+// TrendWindow is the number of most recent runs SummarizeDriftTrend considers
+const TrendWindow = 10
+
+// trendStableFraction is the relative change over the window below which a
+// metric is considered stable (5% of its mean magnitude).
+const trendStableFraction = 0.05
+
+// HistoryPath returns the drift history path for a project
+func HistoryPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", HistoryFilename)
+}
+
+// HistoryEntry records the outcome of a single --check-drift run
+type HistoryEntry struct {
+	Timestamp    time.Time           `json:"timestamp"`
+	BaselineName string              `json:"baseline_name,omitempty"`
+	Severity     string              `json:"severity"` // none, info, warning, or critical
+	AlertCounts  map[AlertType]int   `json:"alert_counts,omitempty"`
+	Stats        baseline.GraphStats `json:"stats"`
+}
+
+// NewHistoryEntry summarizes a drift result and the current metrics
+func NewHistoryEntry(result *Result, current *baseline.Baseline, baselineName string, now time.Time) HistoryEntry {
+	entry := HistoryEntry{
+		Timestamp:    now.UTC(),
+		BaselineName: baselineName,
+		Severity:     "none",
+	}
+	if current != nil {
+		entry.Stats = current.Stats
+	}
+	if result == nil {
+		return entry
+	}
+	switch {
+	case result.CriticalCount > 0:
+		entry.Severity = string(SeverityCritical)
+	case result.WarningCount > 0:
+		entry.Severity = string(SeverityWarning)
+	case result.InfoCount > 0:
+		entry.Severity = string(SeverityInfo)
+	}
+	if len(result.Alerts) > 0 {
+		entry.AlertCounts = make(map[AlertType]int)
+		for _, a := range result.Alerts {
+			entry.AlertCounts[a.Type]++
+		}
+	}
+	return entry
+}
+
+// TotalAlerts returns the number of alerts raised in the run
+func (e HistoryEntry) TotalAlerts() int {
+	total := 0
+	for _, n := range e.AlertCounts {
+		total += n
+	}
+	return total
+}
+
+// AppendDriftHistory appends an entry to .bv/drift-history.jsonl. When the file
+// holds more than maxEntries runs, the oldest are dropped (maxEntries <= 0
+// disables rotation).
+func AppendDriftHistory(projectDir string, entry HistoryEntry, maxEntries int) error {
+	path := HistoryPath(projectDir)
+	if err := jsonl.Append(path, entry); err != nil {
+		return fmt.Errorf("appending drift history: %w", err)
+	}
+	if maxEntries > 0 {
+		if err := jsonl.KeepLast(path, maxEntries); err != nil {
+			return fmt.Errorf("rotating drift history: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadDriftHistory reads all recorded runs, oldest first. A missing file
+// yields an empty history; malformed lines are skipped.
+func LoadDriftHistory(projectDir string) ([]HistoryEntry, error) {
+	lines, err := jsonl.ReadLines(HistoryPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	history := make([]HistoryEntry, 0, len(lines))
+	for _, line := range lines {
+		var entry HistoryEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// TrendDirection describes how a metric moved across recent runs
+type TrendDirection string
+
+const (
+	TrendUp   TrendDirection = "up"
+	TrendDown TrendDirection = "down"
+	TrendFlat TrendDirection = "flat"
+)
+
+// MetricTrend summarizes one metric over the trend window
+type MetricTrend struct {
+	Metric     string         `json:"metric"`
+	First      float64        `json:"first"`
+	Last       float64        `json:"last"`
+	Slope      float64        `json:"slope"` // Least-squares change per run
+	Direction  TrendDirection `json:"direction"`
+	Assessment string         `json:"assessment"` // worsening, improving, or stable
+}
+
+// DriftTrend summarizes recent drift history
+type DriftTrend struct {
+	Runs    int           `json:"runs"`
+	Since   time.Time     `json:"since,omitempty"`
+	Until   time.Time     `json:"until,omitempty"`
+	Metrics []MetricTrend `json:"metrics"`
+}
+
+// trendMetric extracts a value from a history entry. higherIsWorse decides
+// whether an upward trend is worsening or improving.
+type trendMetric struct {
+	name          string
+	higherIsWorse bool
+	value         func(HistoryEntry) float64
+}
+
+var trendMetrics = []trendMetric{
+	{"density", true, func(e HistoryEntry) float64 { return e.Stats.Density }},
+	{"blocked_count", true, func(e HistoryEntry) float64 { return float64(e.Stats.BlockedCount) }},
+	{"cycle_count", true, func(e HistoryEntry) float64 { return float64(e.Stats.CycleCount) }},
+	{"actionable_count", false, func(e HistoryEntry) float64 { return float64(e.Stats.ActionableCount) }},
+	{"closed_per_week", false, func(e HistoryEntry) float64 { return e.Stats.ClosedPerWeek }},
+	{"alert_count", true, func(e HistoryEntry) float64 { return float64(e.TotalAlerts()) }},
+}
+
+// SummarizeDriftTrend reports whether each key metric is trending up or down
+// over the last TrendWindow runs, using a least-squares slope so a single
+// noisy run doesn't flip the result.
+func SummarizeDriftTrend(history []HistoryEntry) DriftTrend {
+	if len(history) > TrendWindow {
+		history = history[len(history)-TrendWindow:]
+	}
+	trend := DriftTrend{Runs: len(history)}
+	if len(history) == 0 {
+		return trend
+	}
+	trend.Since = history[0].Timestamp
+	trend.Until = history[len(history)-1].Timestamp
+
+	for _, m := range trendMetrics {
+		values := make([]float64, len(history))
+		for i, e := range history {
+			values[i] = m.value(e)
+		}
+		mt := MetricTrend{
+			Metric:     m.name,
+			First:      values[0],
+			Last:       values[len(values)-1],
+			Slope:      slope(values),
+			Direction:  TrendFlat,
+			Assessment: "stable",
+		}
+
+		mean := 0.0
+		for _, v := range values {
+			mean += math.Abs(v)
+		}
+		mean /= float64(len(values))
+		span := mt.Slope * float64(len(values)-1)
+		if mean > 0 && math.Abs(span) > trendStableFraction*mean {
+			if span > 0 {
+				mt.Direction = TrendUp
+			} else {
+				mt.Direction = TrendDown
+			}
+			if (mt.Direction == TrendUp) == m.higherIsWorse {
+				mt.Assessment = "worsening"
+			} else {
+				mt.Assessment = "improving"
+			}
+		}
+		trend.Metrics = append(trend.Metrics, mt)
+	}
+	return trend
+}
+
+// slope returns the least-squares slope of values against their index
+func slope(values []float64) float64 {
+	n := float64(len(values))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range values {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denom
+}
+
+// Summary returns a human-readable trend report
+func (t DriftTrend) Summary() string {
+	if t.Runs == 0 {
+		return "No drift history yet. Run bv --check-drift to start recording.\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Drift Trend\n")
+	sb.WriteString("===========\n\n")
+	sb.WriteString(fmt.Sprintf("Last %d run(s): %s → %s\n\n",
+		t.Runs, t.Since.Local().Format("2006-01-02 15:04"), t.Until.Local().Format("2006-01-02 15:04")))
+	if t.Runs < 2 {
+		sb.WriteString("Need at least 2 runs to detect a trend.\n")
+		return sb.String()
+	}
+
+	for _, m := range t.Metrics {
+		icon := "➖"
+		switch m.Assessment {
+		case "worsening":
+			icon = "🔴"
+		case "improving":
+			icon = "🟢"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %-17s %-9s %s → %s\n",
+			icon, m.Metric, m.Assessment, formatTrendValue(m.First), formatTrendValue(m.Last)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func formatTrendValue(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.4g", v)
+}
@@ -0,0 +1,150 @@
+package drift
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+func TestDriftHistoryAppendAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	history, err := LoadDriftHistory(dir)
+	if err != nil || len(history) != 0 {
+		t.Fatalf("expected empty history for new project, got %v (err=%v)", history, err)
+	}
+
+	result := &Result{
+		HasDrift:      true,
+		CriticalCount: 1,
+		InfoCount:     2,
+		Alerts: []Alert{
+			{Type: AlertNewCycle, Severity: SeverityCritical},
+			{Type: AlertDensityGrowth, Severity: SeverityInfo},
+			{Type: AlertDensityGrowth, Severity: SeverityInfo},
+		},
+	}
+	current := &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: 10, Density: 0.1}}
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	if err := AppendDriftHistory(dir, NewHistoryEntry(result, current, "sprint", now), 0); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	history, err = LoadDriftHistory(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(history) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(history))
+	}
+	got := history[0]
+	if got.Severity != "critical" || got.BaselineName != "sprint" || !got.Timestamp.Equal(now) {
+		t.Errorf("unexpected entry: %+v", got)
+	}
+	if got.AlertCounts[AlertDensityGrowth] != 2 || got.TotalAlerts() != 3 {
+		t.Errorf("unexpected alert counts: %v", got.AlertCounts)
+	}
+	if got.Stats.Density != 0.1 {
+		t.Errorf("expected stats to round-trip, got %+v", got.Stats)
+	}
+
+	if e := NewHistoryEntry(&Result{}, current, "", now); e.Severity != "none" {
+		t.Errorf("expected severity none for clean run, got %s", e.Severity)
+	}
+}
+
+func TestDriftHistoryRotation(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 8; i++ {
+		entry := NewHistoryEntry(&Result{}, &baseline.Baseline{Stats: baseline.GraphStats{NodeCount: i}}, "", start.Add(time.Duration(i)*time.Hour))
+		if err := AppendDriftHistory(dir, entry, 5); err != nil {
+			t.Fatalf("append %d: %v", i, err)
+		}
+	}
+
+	history, err := LoadDriftHistory(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(history) != 5 {
+		t.Fatalf("expected rotation to keep 5 entries, got %d", len(history))
+	}
+	if history[0].Stats.NodeCount != 3 || history[4].Stats.NodeCount != 7 {
+		t.Errorf("expected oldest entries dropped, got first=%d last=%d", history[0].Stats.NodeCount, history[4].Stats.NodeCount)
+	}
+	if _, err := os.Stat(HistoryPath(dir) + ".tmp"); !os.IsNotExist(err) {
+		t.Errorf("rotation should not leave a temp file behind")
+	}
+}
+
+func trendFor(trend DriftTrend, metric string) MetricTrend {
+	for _, m := range trend.Metrics {
+		if m.Metric == metric {
+			return m
+		}
+	}
+	return MetricTrend{}
+}
+
+func TestSummarizeDriftTrendRisingDensity(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 6; i++ {
+		current := &baseline.Baseline{Stats: baseline.GraphStats{
+			Density:         0.02 + 0.01*float64(i), // steadily rising
+			BlockedCount:    4,                      // unchanged
+			ActionableCount: 10 + 2*i,               // rising is good
+		}}
+		entry := NewHistoryEntry(&Result{}, current, "", start.Add(time.Duration(i)*24*time.Hour))
+		if err := AppendDriftHistory(dir, entry, 100); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	history, err := LoadDriftHistory(dir)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	trend := SummarizeDriftTrend(history)
+	if trend.Runs != 6 {
+		t.Fatalf("expected 6 runs, got %d", trend.Runs)
+	}
+
+	density := trendFor(trend, "density")
+	if density.Direction != TrendUp || density.Assessment != "worsening" {
+		t.Errorf("rising density should be worsening, got %+v", density)
+	}
+	if blocked := trendFor(trend, "blocked_count"); blocked.Assessment != "stable" {
+		t.Errorf("unchanged blocked count should be stable, got %+v", blocked)
+	}
+	if actionable := trendFor(trend, "actionable_count"); actionable.Assessment != "improving" {
+		t.Errorf("rising actionable count should be improving, got %+v", actionable)
+	}
+}
+
+func TestSummarizeDriftTrendWindow(t *testing.T) {
+	var history []HistoryEntry
+	// Old runs show falling density; the recent window rises again
+	for i := 0; i < 20; i++ {
+		d := 0.5 - 0.02*float64(i)
+		if i >= 20-TrendWindow {
+			d = 0.1 + 0.02*float64(i)
+		}
+		history = append(history, HistoryEntry{Stats: baseline.GraphStats{Density: d}})
+	}
+
+	trend := SummarizeDriftTrend(history)
+	if trend.Runs != TrendWindow {
+		t.Fatalf("expected window of %d runs, got %d", TrendWindow, trend.Runs)
+	}
+	if d := trendFor(trend, "density"); d.Assessment != "worsening" {
+		t.Errorf("expected only recent runs to count, got %+v", d)
+	}
+
+	if single := SummarizeDriftTrend(history[:1]); trendFor(single, "density").Assessment != "stable" {
+		t.Errorf("a single run cannot show a trend")
+	}
+}
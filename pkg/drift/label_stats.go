@@ -0,0 +1,123 @@
+package drift
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ComputeLabelStats builds per-label graph statistics for storing in a
+// baseline's LabelStats. Each label's subgraph contains only the issues
+// carrying that label and the blocking edges between them. Blocked and
+// actionable counts use the whole graph, since a label's issues can be held
+// up by other labels. Issues ignored by cfg (which may be nil) are left out
+// of the blocked count.
+func ComputeLabelStats(issues []model.Issue, cfg *Config) map[string]baseline.GraphStats {
+	labels := analysis.ExtractLabels(issues)
+	if labels.LabelCount == 0 {
+		return nil
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+
+	result := make(map[string]baseline.GraphStats, labels.LabelCount)
+	for _, label := range labels.Labels {
+		members := make(map[string]bool)
+		var labeled []model.Issue
+		for _, iss := range issues {
+			if analysis.HasLabel(iss, label) {
+				members[iss.ID] = true
+				labeled = append(labeled, iss)
+			}
+		}
+
+		var stats baseline.GraphStats
+		stats.NodeCount = len(labeled)
+		for _, iss := range labeled {
+			switch iss.Status {
+			case model.StatusOpen, model.StatusInProgress:
+				stats.OpenCount++
+			case model.StatusClosed:
+				stats.ClosedCount++
+			}
+			if analyzer.IsBlocked(iss.ID) && !cfg.IsIssueIgnored(iss) {
+				stats.BlockedCount++
+			}
+			if analyzer.IsReady(iss.ID) {
+				stats.ActionableCount++
+			}
+			for _, dep := range iss.Dependencies {
+				if dep != nil && dep.Type.IsBlocking() && members[dep.DependsOnID] {
+					stats.EdgeCount++
+				}
+			}
+		}
+		stats.Density = analysis.GraphDensity(stats.NodeCount, stats.EdgeCount)
+		SetLiveGraphStats(&stats, labeled)
+		result[label] = stats
+	}
+	return result
+}
+
+// ComputeLabelHealth builds the per-label health summaries stored in a
+// baseline's LabelHealth, scored with healthCfg as of now. Labels with too
+// few issues to score (see LabelHealthConfig.MinIssuesForHealth) are left out.
+func ComputeLabelHealth(issues []model.Issue, healthCfg analysis.LabelHealthConfig, now time.Time) map[string]baseline.LabelHealthSummary {
+	result := analysis.ComputeAllLabelHealth(issues, healthCfg, now, nil)
+	if len(result.Labels) == 0 {
+		return nil
+	}
+	summaries := make(map[string]baseline.LabelHealthSummary, len(result.Labels))
+	for _, h := range result.Labels {
+		if h.Insufficient {
+			continue
+		}
+		summaries[h.Label] = baseline.LabelHealthSummary{
+			Health:      h.Health,
+			HealthLevel: h.HealthLevel,
+			IssueCount:  h.IssueCount,
+		}
+	}
+	return summaries
+}
+
+// CountBlocked returns analyzer.BlockedCount() less the blocked issues that
+// cfg (which may be nil) ignores.
+func CountBlocked(analyzer *analysis.Analyzer, issues []model.Issue, cfg *Config) int {
+	count := analyzer.BlockedCount()
+	for _, iss := range issues {
+		if cfg.IsIssueIgnored(iss) && analyzer.IsBlocked(iss.ID) {
+			count--
+		}
+	}
+	return count
+}
+
+// SetLiveGraphStats fills the live graph fields of stats from issues: the
+// issues that aren't closed and the blocking edges between them.
+func SetLiveGraphStats(stats *baseline.GraphStats, issues []model.Issue) {
+	live := make(map[string]bool, len(issues))
+	for _, iss := range issues {
+		if !iss.Status.IsClosed() && !iss.Status.IsTombstone() {
+			live[iss.ID] = true
+		}
+	}
+	edges := 0
+	for _, iss := range issues {
+		if !live[iss.ID] {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, dep := range iss.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && live[dep.DependsOnID] && !seen[dep.DependsOnID] {
+				seen[dep.DependsOnID] = true
+				edges++
+			}
+		}
+	}
+	stats.LiveNodeCount = len(live)
+	stats.LiveEdgeCount = edges
+	stats.LiveDensity = analysis.GraphDensity(len(live), edges)
+}
@@ -0,0 +1,133 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// DriftReport bundles a drift result with the snapshots it compared
+type DriftReport struct {
+	Result       *Result
+	Baseline     *baseline.Baseline
+	Current      *baseline.Baseline
+	BaselineName string // Named baseline in use ("" for the default)
+}
+
+// severityBadge maps a severity to the emoji badge used in reports
+func severityBadge(s Severity) string {
+	switch s {
+	case SeverityCritical:
+		return "🔴 Critical"
+	case SeverityWarning:
+		return "🟡 Warning"
+	case SeverityInfo:
+		return "🔵 Info"
+	}
+	return string(s)
+}
+
+// overallBadge summarizes a result by its most severe alert
+func overallBadge(r *Result) string {
+	switch {
+	case r == nil || !r.HasDrift:
+		return "✅ No drift"
+	case r.CriticalCount > 0:
+		return severityBadge(SeverityCritical)
+	case r.WarningCount > 0:
+		return severityBadge(SeverityWarning)
+	}
+	return severityBadge(SeverityInfo)
+}
+
+// RenderMarkdown renders a drift report as Markdown suitable for pasting into
+// PR descriptions: a summary header, an alerts table and a baseline vs
+// current metrics comparison. Output is deterministic for a given report.
+func RenderMarkdown(report DriftReport) string {
+	var sb strings.Builder
+	r := report.Result
+	if r == nil {
+		r = &Result{}
+	}
+
+	sb.WriteString(fmt.Sprintf("## Drift Report: %s\n\n", overallBadge(r)))
+
+	if bl := report.Baseline; bl != nil {
+		name := "default"
+		if report.BaselineName != "" {
+			name = report.BaselineName
+		}
+		sb.WriteString(fmt.Sprintf("**Baseline:** `%s`", name))
+		if !bl.CreatedAt.IsZero() {
+			sb.WriteString(fmt.Sprintf(" saved %s", bl.CreatedAt.UTC().Format("2006-01-02 15:04 UTC")))
+		}
+		if bl.CommitSHA != "" {
+			sha := bl.CommitSHA
+			if len(sha) > 7 {
+				sha = sha[:7]
+			}
+			sb.WriteString(fmt.Sprintf(" at `%s`", sha))
+		}
+		if bl.Description != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", markdownCell(bl.Description)))
+		}
+		sb.WriteString("\n\n")
+	}
+
+	sb.WriteString("| Critical | Warning | Info |\n")
+	sb.WriteString("|---:|---:|---:|\n")
+	sb.WriteString(fmt.Sprintf("| %d | %d | %d |\n\n", r.CriticalCount, r.WarningCount, r.InfoCount))
+
+	sb.WriteString("### Alerts\n\n")
+	if len(r.Alerts) == 0 {
+		sb.WriteString("No drift detected. Project metrics are within baseline thresholds.\n\n")
+	} else {
+		sb.WriteString("| Severity | Type | Message |\n")
+		sb.WriteString("|---|---|---|\n")
+		for _, alert := range r.Alerts {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n",
+				severityBadge(alert.Severity), alert.Type, markdownCell(alert.Message)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if report.Baseline != nil && report.Current != nil {
+		writeMetricChanges(&sb, report.Baseline.Stats, report.Current.Stats)
+	}
+
+	return sb.String()
+}
+
+// writeMetricChanges renders the "what changed" table
+func writeMetricChanges(sb *strings.Builder, bl, cur baseline.GraphStats) {
+	sb.WriteString("### What Changed\n\n")
+	sb.WriteString("| Metric | Baseline | Current | Change |\n")
+	sb.WriteString("|---|---:|---:|---:|\n")
+
+	intRow := func(name string, b, c int) {
+		sb.WriteString(fmt.Sprintf("| %s | %d | %d | %+d |\n", name, b, c, c-b))
+	}
+	floatRow := func(name, format string, b, c float64) {
+		sb.WriteString(fmt.Sprintf("| %s | "+format+" | "+format+" | %+"+format[1:]+" |\n", name, b, c, c-b))
+	}
+
+	intRow("Nodes", bl.NodeCount, cur.NodeCount)
+	intRow("Edges", bl.EdgeCount, cur.EdgeCount)
+	floatRow("Density", "%.4f", bl.Density, cur.Density)
+	intRow("Open", bl.OpenCount, cur.OpenCount)
+	intRow("Closed", bl.ClosedCount, cur.ClosedCount)
+	intRow("Blocked", bl.BlockedCount, cur.BlockedCount)
+	intRow("Actionable", bl.ActionableCount, cur.ActionableCount)
+	intRow("Cycles", bl.CycleCount, cur.CycleCount)
+	if bl.ClosedPerWeek > 0 || cur.ClosedPerWeek > 0 {
+		floatRow("Closed / week", "%.1f", bl.ClosedPerWeek, cur.ClosedPerWeek)
+	}
+	sb.WriteString("\n")
+}
+
+// markdownCell escapes text for use inside a Markdown table cell
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,62 @@
+package drift
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+)
+
+func TestRenderMarkdownGolden(t *testing.T) {
+	bl := &baseline.Baseline{
+		Version:      baseline.CurrentVersion,
+		StatsVersion: baseline.CurrentStatsVersion,
+		CreatedAt:    time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC),
+		CommitSHA:    "0123456789abcdef",
+		Description:  "Sprint 14 | start",
+		Stats: baseline.GraphStats{
+			NodeCount: 20, EdgeCount: 10, Density: 0.0263, OpenCount: 12, ClosedCount: 6,
+			BlockedCount: 2, ActionableCount: 10, ClosedPerWeek: 4.5,
+		},
+	}
+	current := &baseline.Baseline{
+		Stats: baseline.GraphStats{
+			NodeCount: 22, EdgeCount: 16, Density: 0.0346, OpenCount: 13, ClosedCount: 6,
+			BlockedCount: 9, CycleCount: 1, ActionableCount: 8, ClosedPerWeek: 4.0,
+		},
+		Cycles: [][]string{{"bv-1", "bv-2", "bv-1"}},
+	}
+
+	result := NewCalculator(bl, current, nil).Calculate()
+	if result.CriticalCount == 0 {
+		t.Fatalf("fixture should include a critical new-cycle alert, got %+v", result.Alerts)
+	}
+
+	md := RenderMarkdown(DriftReport{Result: result, Baseline: bl, Current: current, BaselineName: "sprint-14"})
+
+	golden := testutil.NewGoldenFile(t, filepath.Join("..", "..", "testdata", "golden", "drift"), "report.md.golden")
+	golden.Assert(md)
+}
+
+func TestRenderMarkdownNoDrift(t *testing.T) {
+	stats := baseline.GraphStats{NodeCount: 5, EdgeCount: 2}
+	bl := &baseline.Baseline{Stats: stats}
+	cur := &baseline.Baseline{Stats: stats}
+
+	md := RenderMarkdown(DriftReport{Result: NewCalculator(bl, cur, nil).Calculate(), Baseline: bl, Current: cur})
+	if !strings.HasPrefix(md, "## Drift Report: ✅ No drift") {
+		t.Errorf("expected no-drift header, got:\n%s", md)
+	}
+	if !strings.Contains(md, "**Baseline:** `default`") {
+		t.Errorf("expected default baseline name, got:\n%s", md)
+	}
+	if strings.Contains(md, "| Severity | Type | Message |") {
+		t.Errorf("alerts table should be omitted without alerts")
+	}
+	if strings.Contains(md, "Closed / week") {
+		t.Errorf("velocity row should be omitted when untracked")
+	}
+}
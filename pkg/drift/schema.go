@@ -0,0 +1,153 @@
+package drift
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect emitted by ConfigJSONSchema
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// ConfigJSONSchema returns a JSON Schema describing .bv/drift.yaml for editor
+// autocompletion and validation. Field types, bounds and defaults come from
+// the same table Validate uses. Cross-field constraints (e.g. density_info_pct
+// <= density_warning_pct) can't be expressed in the schema and are documented
+// in the field description instead.
+func ConfigJSONSchema() []byte {
+	def := DefaultConfig()
+
+	properties := thresholdProperties(def, true)
+	properties["label_overrides"] = map[string]any{
+		"type":                 "object",
+		"description":          "Per-label staleness overrides; unset fields inherit the global values",
+		"additionalProperties": map[string]any{"$ref": "#/$defs/labelOverride"},
+	}
+	properties["ignore_issue_ids"] = map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string"},
+		"description": "Issue IDs excluded from staleness, blocked-count and cascade alerts",
+	}
+	properties["ignore_labels"] = map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string"},
+		"description": "Issues carrying any of these labels are excluded from staleness, blocked-count and cascade alerts",
+	}
+	properties["ignore_closed_in_graph_metrics"] = map[string]any{
+		"type":        "boolean",
+		"default":     false,
+		"description": "Compare density, node and edge counts on open issues only, so pruning closed issues isn't reported as drift",
+	}
+	properties["severity_overrides"] = map[string]any{
+		"type":        "object",
+		"description": "Force the severity of an alert type (e.g. new_cycle: warning); overrides drive the exit code",
+		"additionalProperties": map[string]any{
+			"type": "string",
+			"enum": []string{string(SeverityInfo), string(SeverityWarning), string(SeverityCritical)},
+		},
+	}
+	properties["per_label"] = map[string]any{
+		"type":                 "object",
+		"description":          "Per-label graph thresholds checked against each label's subgraph; unset fields inherit the global values",
+		"additionalProperties": map[string]any{"$ref": "#/$defs/labelThresholds"},
+	}
+
+	schema := map[string]any{
+		"$schema":              JSONSchemaDraft,
+		"title":                "bv drift configuration",
+		"description":          "Drift detection thresholds (.bv/" + ConfigFilename + ")",
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+		"$defs": map[string]any{
+			"labelThresholds": map[string]any{
+				"type":                 "object",
+				"properties":           thresholdProperties(def, false),
+				"additionalProperties": false,
+			},
+			"labelOverride": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"stale_warning_days": map[string]any{
+						"type": "integer", "minimum": 0,
+						"description": "Overrides stale_warning_days for issues with this label",
+					},
+					"stale_critical_days": map[string]any{
+						"type": "integer", "minimum": 0,
+						"description": "Overrides stale_critical_days for issues with this label (must be >= stale_warning_days)",
+					},
+					"in_progress_stale_multiplier": map[string]any{
+						"type": "number", "minimum": 0, "maximum": 5,
+						"description": "Overrides in_progress_stale_multiplier for issues with this label",
+					},
+				},
+				"additionalProperties": false,
+			},
+		},
+	}
+
+	// Keep "<=" and ">=" readable in descriptions
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schema); err != nil {
+		// Only plain maps, strings and numbers are encoded above
+		panic(fmt.Sprintf("drift: encoding config schema: %v", err))
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+// thresholdProperties builds schema properties from the field table. Global
+// properties carry defaults; per-label ones don't and allow 0 to mean "inherit".
+func thresholdProperties(def *Config, global bool) map[string]any {
+	properties := make(map[string]any)
+	for _, f := range def.fieldSpecs() {
+		if f.globalOnly && !global {
+			continue
+		}
+		prop := map[string]any{"description": fieldDescription(f)}
+		switch target := f.target.(type) {
+		case *float64:
+			prop["type"] = "number"
+		case *int:
+			prop["type"] = "integer"
+		case *[]string:
+			prop["type"] = "array"
+			prop["items"] = map[string]any{"type": "string"}
+			if global {
+				prop["default"] = []string{}
+			}
+			properties[f.key] = prop
+			continue
+		default:
+			panic(fmt.Sprintf("drift: unsupported field type %T for %s", target, f.key))
+		}
+
+		if f.exclusiveMin && global {
+			prop["exclusiveMinimum"] = f.min
+		} else {
+			prop["minimum"] = f.min
+		}
+		if f.max > 0 {
+			prop["maximum"] = f.max
+		}
+		if global {
+			prop["default"], _ = f.number()
+		}
+		properties[f.key] = prop
+	}
+	return properties
+}
+
+// fieldDescription appends cross-field constraints to a field's description
+func fieldDescription(f fieldSpec) string {
+	desc := f.description
+	if f.maxKey != "" {
+		desc += fmt.Sprintf(" (must be <= %s)", f.maxKey)
+	}
+	if f.minKey != "" {
+		desc += fmt.Sprintf(" (must be >= %s)", f.minKey)
+	}
+	return desc
+}
@@ -0,0 +1,118 @@
+package drift
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestConfigJSONSchema(t *testing.T) {
+	var schema struct {
+		Schema               string                    `json:"$schema"`
+		Type                 string                    `json:"type"`
+		AdditionalProperties bool                      `json:"additionalProperties"`
+		Properties           map[string]map[string]any `json:"properties"`
+		Defs                 map[string]struct {
+			Properties map[string]map[string]any `json:"properties"`
+		} `json:"$defs"`
+	}
+	if err := json.Unmarshal(ConfigJSONSchema(), &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+
+	if schema.Schema != JSONSchemaDraft {
+		t.Errorf("$schema = %q, want %q", schema.Schema, JSONSchemaDraft)
+	}
+	if schema.Type != "object" || schema.AdditionalProperties {
+		t.Errorf("expected closed object schema, got type=%q additionalProperties=%v", schema.Type, schema.AdditionalProperties)
+	}
+
+	// Every field in the shared table is described, along with the nested maps
+	for _, f := range DefaultConfig().fieldSpecs() {
+		if _, ok := schema.Properties[f.key]; !ok {
+			t.Errorf("schema missing property %q", f.key)
+		}
+	}
+	for _, key := range []string{"label_overrides", "per_label"} {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("schema missing property %q", key)
+		}
+	}
+
+	density := schema.Properties["density_warning_pct"]
+	if density["type"] != "number" || density["minimum"] != 0.0 || density["maximum"] != 1000.0 {
+		t.Errorf("unexpected density_warning_pct schema: %v", density)
+	}
+	if density["default"] != DefaultConfig().DensityWarningPct {
+		t.Errorf("density_warning_pct default = %v, want %v", density["default"], DefaultConfig().DensityWarningPct)
+	}
+
+	// density_info_pct is bounded by density_warning_pct, which JSON Schema
+	// can't express, so the constraint must be documented instead
+	info := schema.Properties["density_info_pct"]
+	if _, ok := info["maximum"]; ok {
+		t.Errorf("density_info_pct should not have a fixed maximum: %v", info)
+	}
+	if desc, _ := info["description"].(string); !strings.Contains(desc, "<= density_warning_pct") {
+		t.Errorf("density_info_pct description should document the density_warning_pct bound, got %q", desc)
+	}
+
+	stale := schema.Properties["stale_warning_days"]
+	if stale["type"] != "integer" || stale["exclusiveMinimum"] != 0.0 {
+		t.Errorf("unexpected stale_warning_days schema: %v", stale)
+	}
+	if desc, _ := schema.Properties["stale_critical_days"]["description"].(string); !strings.Contains(desc, ">= stale_warning_days") {
+		t.Errorf("stale_critical_days description should document its lower bound, got %q", desc)
+	}
+
+	if schema.Properties["disabled_alerts"]["type"] != "array" {
+		t.Errorf("disabled_alerts should be an array: %v", schema.Properties["disabled_alerts"])
+	}
+
+	// Per-label thresholds allow 0 (inherit) and carry no defaults
+	perLabel := schema.Defs["labelThresholds"].Properties["stale_warning_days"]
+	if perLabel["minimum"] != 0.0 {
+		t.Errorf("per-label stale_warning_days should allow 0, got %v", perLabel)
+	}
+	if _, ok := perLabel["default"]; ok {
+		t.Errorf("per-label thresholds should not have defaults: %v", perLabel)
+	}
+}
+
+// setField sets a numeric config field by YAML key
+func setField(cfg *Config, key string, v float64) {
+	for _, f := range cfg.fieldSpecs() {
+		if f.key != key {
+			continue
+		}
+		switch target := f.target.(type) {
+		case *float64:
+			*target = v
+		case *int:
+			*target = int(v)
+		}
+	}
+}
+
+func TestConfigJSONSchemaMatchesValidate(t *testing.T) {
+	// Values just outside each schema bound must also fail Validate
+	for _, f := range DefaultConfig().fieldSpecs() {
+		if _, ok := f.number(); !ok {
+			continue
+		}
+		cfg := DefaultConfig()
+		setField(cfg, f.key, f.min-1)
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), f.key) {
+			t.Errorf("%s below minimum: expected validation error naming the field, got %v", f.key, err)
+		}
+
+		if f.max == 0 {
+			continue
+		}
+		cfg = DefaultConfig()
+		setField(cfg, f.key, f.max+1)
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), f.key) {
+			t.Errorf("%s above maximum: expected validation error naming the field, got %v", f.key, err)
+		}
+	}
+}
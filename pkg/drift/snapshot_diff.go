@@ -0,0 +1,204 @@
+package drift
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// SnapshotDiff is a plain "what changed" report between two issue sets,
+// independent of the drift thresholds. All lists are sorted by issue ID.
+type SnapshotDiff struct {
+	Added   []IssueRef    `json:"added"`
+	Removed []IssueRef    `json:"removed"`
+	Changed []IssueChange `json:"changed"`
+}
+
+// IssueRef identifies an added or removed issue
+type IssueRef struct {
+	ID     string       `json:"id"`
+	Title  string       `json:"title,omitempty"`
+	Status model.Status `json:"status"`
+}
+
+// IssueChange holds every delta for one issue present in both snapshots.
+// Unchanged categories are nil.
+type IssueChange struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Status       *StatusChange     `json:"status,omitempty"`
+	Labels       *LabelChange      `json:"labels,omitempty"`
+	Dependencies *DependencyChange `json:"dependencies,omitempty"`
+}
+
+// StatusChange records a status transition
+type StatusChange struct {
+	Before model.Status `json:"before"`
+	After  model.Status `json:"after"`
+}
+
+// LabelChange records the label set before and after, plus the difference
+type LabelChange struct {
+	Before  []string `json:"before"`
+	After   []string `json:"after"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+}
+
+// DependencyRef is one outgoing dependency of an issue
+type DependencyRef struct {
+	DependsOnID string               `json:"depends_on_id"`
+	Type        model.DependencyType `json:"type"`
+}
+
+// DependencyChange records blocking dependencies added and removed. A
+// dependency removed and re-added between snapshots nets to nothing, and a
+// related link turned into a blocking one shows as an addition.
+type DependencyChange struct {
+	Added   []DependencyRef `json:"added,omitempty"`
+	Removed []DependencyRef `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether the snapshots were identical in every tracked way
+func (d SnapshotDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DiffSnapshots compares a baseline issue set (e.g. Baseline.IssueList) with
+// the current one, reporting added and removed issues and, for issues in
+// both, status, label and blocking dependency changes. An issue with several
+// kinds of change appears once with all of them. Titles come from whichever
+// side has them; baselines don't store titles.
+func DiffSnapshots(baseline, current []model.Issue) SnapshotDiff {
+	before := make(map[string]model.Issue, len(baseline))
+	for _, iss := range baseline {
+		before[iss.ID] = iss
+	}
+	after := make(map[string]model.Issue, len(current))
+	for _, iss := range current {
+		after[iss.ID] = iss
+	}
+
+	diff := SnapshotDiff{
+		Added:   []IssueRef{},
+		Removed: []IssueRef{},
+		Changed: []IssueChange{},
+	}
+	for id, cur := range after {
+		old, ok := before[id]
+		if !ok {
+			diff.Added = append(diff.Added, IssueRef{ID: id, Title: cur.Title, Status: cur.Status})
+			continue
+		}
+		if change, changed := diffIssue(old, cur); changed {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+	for id, old := range before {
+		if _, ok := after[id]; !ok {
+			diff.Removed = append(diff.Removed, IssueRef{ID: id, Title: old.Title, Status: old.Status})
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].ID < diff.Added[j].ID })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].ID < diff.Removed[j].ID })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].ID < diff.Changed[j].ID })
+	return diff
+}
+
+// diffIssue collects the tracked deltas between two versions of an issue
+func diffIssue(old, cur model.Issue) (IssueChange, bool) {
+	title := cur.Title
+	if title == "" {
+		title = old.Title
+	}
+	change := IssueChange{ID: cur.ID, Title: title}
+	changed := false
+
+	if old.Status != cur.Status {
+		change.Status = &StatusChange{Before: old.Status, After: cur.Status}
+		changed = true
+	}
+
+	oldLabels, curLabels := sortedUnique(old.Labels), sortedUnique(cur.Labels)
+	if added, removed := setDifference(oldLabels, curLabels); len(added) > 0 || len(removed) > 0 {
+		change.Labels = &LabelChange{Before: oldLabels, After: curLabels, Added: added, Removed: removed}
+		changed = true
+	}
+
+	oldDeps, curDeps := dependencySet(old.Dependencies), dependencySet(cur.Dependencies)
+	depsAdded, depsRemoved := dependencyDifference(curDeps, oldDeps), dependencyDifference(oldDeps, curDeps)
+	if len(depsAdded) > 0 || len(depsRemoved) > 0 {
+		change.Dependencies = &DependencyChange{Added: depsAdded, Removed: depsRemoved}
+		changed = true
+	}
+
+	return change, changed
+}
+
+// dependencySet collects the blocking dependencies, the only kind baselines
+// keep. Legacy untyped dependencies are reported as "blocks".
+func dependencySet(deps []*model.Dependency) map[DependencyRef]bool {
+	set := make(map[DependencyRef]bool, len(deps))
+	for _, dep := range deps {
+		if dep != nil && dep.DependsOnID != "" && dep.Type.IsBlocking() {
+			set[DependencyRef{DependsOnID: dep.DependsOnID, Type: model.DepBlocks}] = true
+		}
+	}
+	return set
+}
+
+// dependencyDifference returns the dependencies in a but not b, sorted by
+// target ID then type
+func dependencyDifference(a, b map[DependencyRef]bool) []DependencyRef {
+	var refs []DependencyRef
+	for ref := range a {
+		if !b[ref] {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].DependsOnID != refs[j].DependsOnID {
+			return refs[i].DependsOnID < refs[j].DependsOnID
+		}
+		return refs[i].Type < refs[j].Type
+	})
+	return refs
+}
+
+// sortedUnique returns a sorted copy of values without duplicates
+func sortedUnique(values []string) []string {
+	if len(values) == 0 {
+		return []string{}
+	}
+	out := append([]string(nil), values...)
+	sort.Strings(out)
+	n := 1
+	for i := 1; i < len(out); i++ {
+		if out[i] != out[n-1] {
+			out[n] = out[i]
+			n++
+		}
+	}
+	return out[:n]
+}
+
+// setDifference returns the values only in cur (added) and only in old
+// (removed). Both inputs must be sorted and unique; outputs stay sorted.
+func setDifference(old, cur []string) (added, removed []string) {
+	i, j := 0, 0
+	for i < len(old) || j < len(cur) {
+		switch {
+		case j == len(cur) || (i < len(old) && old[i] < cur[j]):
+			removed = append(removed, old[i])
+			i++
+		case i == len(old) || cur[j] < old[i]:
+			added = append(added, cur[j])
+			j++
+		default:
+			i++
+			j++
+		}
+	}
+	return added, removed
+}
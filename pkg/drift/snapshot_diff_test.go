@@ -0,0 +1,143 @@
+package drift
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func dep(on string, typ model.DependencyType) *model.Dependency {
+	return &model.Dependency{DependsOnID: on, Type: typ}
+}
+
+func TestDiffSnapshots_Categories(t *testing.T) {
+	before := []model.Issue{
+		{ID: "A", Title: "Kept", Status: model.StatusOpen, Labels: []string{"api"}},
+		{ID: "B", Title: "Both", Status: model.StatusOpen, Labels: []string{"api", "ui"}},
+		{ID: "C", Title: "Deps", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("A", model.DepBlocks), dep("B", "")}},
+		{ID: "D", Title: "Gone", Status: model.StatusClosed},
+	}
+	after := []model.Issue{
+		{ID: "E", Title: "New", Status: model.StatusOpen},
+		{ID: "C", Title: "Deps", Status: model.StatusOpen, Dependencies: []*model.Dependency{dep("A", model.DepBlocks), dep("E", model.DepBlocks), dep("D", model.DepRelated)}},
+		{ID: "B", Title: "Both", Status: model.StatusClosed, Labels: []string{"ui", "backend"}},
+		{ID: "A", Title: "Kept", Status: model.StatusOpen, Labels: []string{"api"}},
+	}
+
+	diff := DiffSnapshots(before, after)
+
+	if want := []IssueRef{{ID: "E", Title: "New", Status: model.StatusOpen}}; !reflect.DeepEqual(diff.Added, want) {
+		t.Errorf("Added = %+v, want %+v", diff.Added, want)
+	}
+	if want := []IssueRef{{ID: "D", Title: "Gone", Status: model.StatusClosed}}; !reflect.DeepEqual(diff.Removed, want) {
+		t.Errorf("Removed = %+v, want %+v", diff.Removed, want)
+	}
+	if len(diff.Changed) != 2 || diff.Changed[0].ID != "B" || diff.Changed[1].ID != "C" {
+		t.Fatalf("Changed = %+v, want B then C", diff.Changed)
+	}
+
+	// B changed status and labels: one entry with both deltas
+	b := diff.Changed[0]
+	if b.Status == nil || b.Status.Before != model.StatusOpen || b.Status.After != model.StatusClosed {
+		t.Errorf("B status = %+v", b.Status)
+	}
+	wantLabels := &LabelChange{
+		Before:  []string{"api", "ui"},
+		After:   []string{"backend", "ui"},
+		Added:   []string{"backend"},
+		Removed: []string{"api"},
+	}
+	if !reflect.DeepEqual(b.Labels, wantLabels) {
+		t.Errorf("B labels = %+v, want %+v", b.Labels, wantLabels)
+	}
+	if b.Dependencies != nil {
+		t.Errorf("B dependencies should be unchanged, got %+v", b.Dependencies)
+	}
+
+	c := diff.Changed[1]
+	wantDeps := &DependencyChange{
+		Added:   []DependencyRef{{DependsOnID: "E", Type: model.DepBlocks}},
+		Removed: []DependencyRef{{DependsOnID: "B", Type: model.DepBlocks}},
+	}
+	if !reflect.DeepEqual(c.Dependencies, wantDeps) {
+		t.Errorf("C dependencies = %+v, want %+v", c.Dependencies, wantDeps)
+	}
+	if c.Status != nil || c.Labels != nil {
+		t.Errorf("C should only have dependency changes, got %+v", c)
+	}
+}
+
+func TestDiffSnapshots_NetZeroChanges(t *testing.T) {
+	before := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"ui", "api"}, Dependencies: []*model.Dependency{dep("B", model.DepBlocks)}},
+		{ID: "B", Status: model.StatusOpen},
+	}
+	// Dependency removed and re-added, labels reordered and duplicated
+	after := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"api", "ui", "api"}, Dependencies: []*model.Dependency{nil, dep("B", model.DepBlocks)}},
+		{ID: "B", Status: model.StatusOpen},
+	}
+
+	diff := DiffSnapshots(before, after)
+	if !diff.IsEmpty() {
+		t.Errorf("expected no changes, got %+v", diff)
+	}
+	if diff.Added == nil || diff.Removed == nil || diff.Changed == nil {
+		t.Error("empty categories should be empty slices for JSON output")
+	}
+}
+
+func TestDiffSnapshots_DependencyTypeChange(t *testing.T) {
+	before := []model.Issue{{ID: "A", Dependencies: []*model.Dependency{dep("B", model.DepRelated)}}}
+	after := []model.Issue{{ID: "A", Dependencies: []*model.Dependency{dep("B", model.DepBlocks)}}}
+
+	diff := DiffSnapshots(before, after)
+	if len(diff.Changed) != 1 || diff.Changed[0].Dependencies == nil {
+		t.Fatalf("expected a dependency change, got %+v", diff)
+	}
+	deps := diff.Changed[0].Dependencies
+	if len(deps.Added) != 1 || deps.Added[0].Type != model.DepBlocks || len(deps.Removed) != 0 {
+		t.Errorf("related link turned blocking should be one addition, got %+v", deps)
+	}
+}
+
+func TestDiffSnapshots_FromSavedBaseline(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Task", Description: "Long text", Status: model.StatusOpen, Labels: []string{"api"},
+			Dependencies: []*model.Dependency{dep("B", model.DepBlocks), dep("B", model.DepRelated)}},
+		{ID: "B", Title: "Blocker", Status: model.StatusOpen},
+	}
+	bl := baseline.New(baseline.GraphStats{NodeCount: 2}, baseline.TopMetrics{}, nil, "test")
+	bl.Issues = baseline.SnapshotIssues(issues)
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := bl.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "Long text") || strings.Contains(string(data), "Blocker") {
+		t.Errorf("baseline should not store titles or descriptions:\n%s", data)
+	}
+	loaded, err := baseline.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Same blocking dependency and labels; only the status moved
+	current := append([]model.Issue(nil), issues...)
+	current[0].Status = model.StatusInProgress
+	diff := DiffSnapshots(loaded.IssueList(), current)
+	if len(diff.Changed) != 1 || diff.Changed[0].Status == nil || diff.Changed[0].Status.After != model.StatusInProgress {
+		t.Fatalf("expected only a status change from the saved baseline, got %+v", diff)
+	}
+	if c := diff.Changed[0]; c.Title != "Task" || c.Labels != nil || c.Dependencies != nil {
+		t.Errorf("unexpected extra deltas: %+v", c)
+	}
+}
@@ -174,6 +174,13 @@ func (g *GitLoader) resolveRevision(revision string) (string, error) {
 	return "", fmt.Errorf("git rev-parse failed: %w", err)
 }
 
+// ParseRevisionDate reports whether revision is a date or timestamp rather
+// than a git ref, returning the time it denotes. It accepts the same formats
+// LoadAt falls back to: RFC3339, or a date with optional time in local time.
+func ParseRevisionDate(revision string) (time.Time, bool) {
+	return parseDateString(revision)
+}
+
 // parseDateString attempts to parse common date/time formats used by users.
 // Returns the parsed time and true on success.
 func parseDateString(s string) (time.Time, bool) {
@@ -180,6 +180,27 @@ func (t IssueType) IsKnownType() bool {
 	return false
 }
 
+// BlockingDependencies returns the issue's non-nil dependencies whose type
+// is blocking (see DependencyType.IsBlocking)
+func (i *Issue) BlockingDependencies() []*Dependency {
+	return i.dependenciesWhere(func(d *Dependency) bool { return d.Type.IsBlocking() })
+}
+
+// RelatedDependencies returns the issue's non-nil "related" dependencies
+func (i *Issue) RelatedDependencies() []*Dependency {
+	return i.dependenciesWhere(func(d *Dependency) bool { return d.Type == DepRelated })
+}
+
+func (i *Issue) dependenciesWhere(keep func(*Dependency) bool) []*Dependency {
+	var out []*Dependency
+	for _, dep := range i.Dependencies {
+		if dep != nil && keep(dep) {
+			out = append(out, dep)
+		}
+	}
+	return out
+}
+
 // Dependency represents a relationship between issues
 type Dependency struct {
 	IssueID     string         `json:"issue_id"`
@@ -218,12 +239,19 @@ func (d DependencyType) IsValid() bool {
 	return false
 }
 
-// IsBlocking returns true if this dependency type represents a blocking relationship.
-// Note: An empty string ("") is treated as blocking for backward compatibility with
-// legacy beads data that predates the typed dependency system. This means dependencies
-// created without an explicit type will block by default.
+// BlockingDepTypes is the set of dependency types that block the dependent
+// issue. An empty string ("") is included for backward compatibility with
+// legacy beads data that predates the typed dependency system, so dependencies
+// created without an explicit type block by default.
+var BlockingDepTypes = map[DependencyType]bool{
+	"":        true,
+	DepBlocks: true,
+}
+
+// IsBlocking returns true if this dependency type represents a blocking
+// relationship, i.e. it is in BlockingDepTypes.
 func (d DependencyType) IsBlocking() bool {
-	return d == "" || d == DepBlocks
+	return BlockingDepTypes[d]
 }
 
 // Comment represents a comment on an issue
@@ -169,6 +169,37 @@ func TestDependencyType_IsBlocking(t *testing.T) {
 	}
 }
 
+func TestIssue_BlockingAndRelatedDependencies(t *testing.T) {
+	dep := func(on string, typ DependencyType) *Dependency {
+		return &Dependency{IssueID: "X", DependsOnID: on, Type: typ}
+	}
+	issue := Issue{ID: "X", Dependencies: []*Dependency{
+		dep("A", DepBlocks),
+		dep("B", DepRelated),
+		nil,
+		dep("C", ""), // Legacy untyped dependency blocks
+		dep("D", DepParentChild),
+		dep("E", DepRelated),
+	}}
+
+	ids := func(deps []*Dependency) string {
+		var out []string
+		for _, d := range deps {
+			out = append(out, d.DependsOnID)
+		}
+		return strings.Join(out, ",")
+	}
+	if got := ids(issue.BlockingDependencies()); got != "A,C" {
+		t.Errorf("BlockingDependencies() = %s, want A,C", got)
+	}
+	if got := ids(issue.RelatedDependencies()); got != "B,E" {
+		t.Errorf("RelatedDependencies() = %s, want B,E", got)
+	}
+	if got := (&Issue{}).BlockingDependencies(); got != nil {
+		t.Errorf("BlockingDependencies() with no dependencies = %v, want nil", got)
+	}
+}
+
 func TestIssue_Struct(t *testing.T) {
 	// This test verifies that we can construct an Issue with valid data
 	now := time.Now()
@@ -2618,6 +2618,8 @@ func methodLabel(method correlation.CorrelationMethod) string {
 		return "(explicit ID)"
 	case correlation.MethodTemporalAuthor:
 		return "(temporal)"
+	case correlation.MethodTimeProximity:
+		return "(proximity)"
 	default:
 		return ""
 	}
@@ -0,0 +1,120 @@
+package ui
+
+import "strings"
+
+// KeyReferenceBinding describes one entry of the keyboard reference. It is
+// separate from KeyBinding, which is a single row of a tutorial KeyTable.
+type KeyReferenceBinding struct {
+	Keys        []string // Alternative keys for the same action, e.g. {"j", "down"}
+	Description string
+	Context     string // Group heading, e.g. "Global" or "Tutorial"
+}
+
+// keyReferenceRegistry holds the bindings shown on the Keyboard Reference
+// tutorial page, in registration order.
+var keyReferenceRegistry []KeyReferenceBinding
+
+// RegisterKeyBindings adds bindings to the keyboard reference. Key handlers
+// register their bindings next to their definition so the reference page
+// can't drift from the code.
+func RegisterKeyBindings(bindings ...KeyReferenceBinding) {
+	keyReferenceRegistry = append(keyReferenceRegistry, bindings...)
+}
+
+// RegisteredKeyBindings returns a copy of all registered bindings.
+func RegisteredKeyBindings() []KeyReferenceBinding {
+	return append([]KeyReferenceBinding(nil), keyReferenceRegistry...)
+}
+
+// groupKeyBindings splits bindings by context, keeping contexts in the order
+// they first appear and bindings in their original order within each.
+func groupKeyBindings(bindings []KeyReferenceBinding) ([]string, map[string][]KeyReferenceBinding) {
+	var contexts []string
+	byContext := make(map[string][]KeyReferenceBinding)
+	for _, b := range bindings {
+		if _, seen := byContext[b.Context]; !seen {
+			contexts = append(contexts, b.Context)
+		}
+		byContext[b.Context] = append(byContext[b.Context], b)
+	}
+	return contexts, byContext
+}
+
+// formatKeys joins alternative keys for display, e.g. "j / down".
+func formatKeys(keys []string) string {
+	return strings.Join(keys, " / ")
+}
+
+// RenderKeyReference renders bindings as Markdown: one "###" heading and
+// Key/Action table per context.
+func RenderKeyReference(bindings []KeyReferenceBinding) string {
+	contexts, byContext := groupKeyBindings(bindings)
+
+	escape := strings.NewReplacer("|", `\|`)
+
+	var b strings.Builder
+	for i, ctx := range contexts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("### " + contextTitle(ctx) + "\n")
+		b.WriteString("| Key | Action |\n")
+		b.WriteString("|-----|--------|\n")
+		for _, kb := range byContext[ctx] {
+			b.WriteString("| **" + escape.Replace(formatKeys(kb.Keys)) + "** | " + escape.Replace(kb.Description) + " |\n")
+		}
+	}
+	return b.String()
+}
+
+// contextTitle names the group for bindings registered without a context.
+func contextTitle(ctx string) string {
+	if ctx == "" {
+		return "Other"
+	}
+	return ctx
+}
+
+// keyboardReferenceContent is the Markdown body of the Keyboard Reference
+// tutorial page, built from the registry at call time.
+func keyboardReferenceContent() string {
+	return "## Quick Keyboard Reference\n\n" +
+		RenderKeyReference(RegisteredKeyBindings()) +
+		"\n> Press **?** in any view for context help."
+}
+
+// keyReferenceElements renders bindings as structured tutorial components.
+func keyReferenceElements(bindings []KeyReferenceBinding) []TutorialElement {
+	contexts, byContext := groupKeyBindings(bindings)
+
+	var elements []TutorialElement
+	for _, ctx := range contexts {
+		rows := make([]KeyBinding, 0, len(byContext[ctx]))
+		for _, kb := range byContext[ctx] {
+			rows = append(rows, KeyBinding{Key: formatKeys(kb.Keys), Desc: kb.Description})
+		}
+		elements = append(elements, Section{Title: contextTitle(ctx)}, KeyTable{Bindings: rows}, Spacer{Lines: 1})
+	}
+	return elements
+}
+
+// Main view keys (handled in Model.Update)
+func init() {
+	RegisterKeyBindings(
+		KeyReferenceBinding{Keys: []string{"?"}, Description: "Help overlay", Context: "Global"},
+		KeyReferenceBinding{Keys: []string{"q"}, Description: "Quit", Context: "Global"},
+		KeyReferenceBinding{Keys: []string{"Esc"}, Description: "Close/go back", Context: "Global"},
+		KeyReferenceBinding{Keys: []string{"b", "g", "i", "h"}, Description: "Switch views", Context: "Global"},
+
+		KeyReferenceBinding{Keys: []string{"j", "k"}, Description: "Move down/up", Context: "Navigation"},
+		KeyReferenceBinding{Keys: []string{"h", "l"}, Description: "Move left/right", Context: "Navigation"},
+		KeyReferenceBinding{Keys: []string{"g", "G"}, Description: "Top/bottom", Context: "Navigation"},
+		KeyReferenceBinding{Keys: []string{"Enter"}, Description: "Select", Context: "Navigation"},
+
+		KeyReferenceBinding{Keys: []string{"/"}, Description: "Fuzzy search", Context: "Filtering"},
+		KeyReferenceBinding{Keys: []string{"Ctrl+S"}, Description: "Semantic search", Context: "Filtering"},
+		KeyReferenceBinding{Keys: []string{"H"}, Description: "Hybrid ranking", Context: "Filtering"},
+		KeyReferenceBinding{Keys: []string{"Alt+H"}, Description: "Hybrid preset", Context: "Filtering"},
+		KeyReferenceBinding{Keys: []string{"o", "c", "r", "a"}, Description: "Status filter", Context: "Filtering"},
+	)
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderKeyReference_GroupsByContext(t *testing.T) {
+	bindings := []KeyReferenceBinding{
+		{Keys: []string{"q"}, Description: "Quit", Context: "Global"},
+		{Keys: []string{"j", "down"}, Description: "Move down", Context: "Navigation"},
+		{Keys: []string{"?"}, Description: "Help", Context: "Global"},
+		{Keys: []string{"|"}, Description: "Pipe a|b", Context: ""},
+	}
+
+	got := RenderKeyReference(bindings)
+
+	global := strings.Index(got, "### Global\n")
+	nav := strings.Index(got, "### Navigation\n")
+	other := strings.Index(got, "### Other\n")
+	if global < 0 || nav < 0 || other < 0 {
+		t.Fatalf("missing context headings:\n%s", got)
+	}
+	if !(global < nav && nav < other) {
+		t.Errorf("contexts should appear in first-registration order:\n%s", got)
+	}
+
+	// Both Global bindings sit in the Global table, before Navigation
+	for _, row := range []string{"| **q** | Quit |", "| **?** | Help |"} {
+		idx := strings.Index(got, row)
+		if idx < global || idx > nav {
+			t.Errorf("row %q not under Global:\n%s", row, got)
+		}
+	}
+	if !strings.Contains(got, "| **j / down** | Move down |") {
+		t.Errorf("alternative keys should be joined:\n%s", got)
+	}
+	if !strings.Contains(got, `| **\|** | Pipe a\|b |`) {
+		t.Errorf("pipes should be escaped:\n%s", got)
+	}
+	if n := strings.Count(got, "| Key | Action |"); n != 3 {
+		t.Errorf("expected 3 tables, got %d", n)
+	}
+}
+
+func TestKeyboardReferencePage_IncludesRegisteredBindings(t *testing.T) {
+	registered := RegisteredKeyBindings()
+	if len(registered) == 0 {
+		t.Fatal("expected registered key bindings")
+	}
+
+	var content string
+	for _, page := range defaultTutorialPages() {
+		if page.ID == "ref-keyboard" {
+			content = page.Content
+		}
+	}
+	if content == "" {
+		t.Fatal("ref-keyboard page not found")
+	}
+
+	contexts, byContext := groupKeyBindings(registered)
+	for _, ctx := range contexts {
+		heading := "### " + contextTitle(ctx) + "\n"
+		start := strings.Index(content, heading)
+		if start < 0 {
+			t.Errorf("missing heading for context %q", ctx)
+			continue
+		}
+		section := content[start+len(heading):]
+		if end := strings.Index(section, "### "); end >= 0 {
+			section = section[:end]
+		}
+		for _, kb := range byContext[ctx] {
+			if !strings.Contains(section, "| **"+formatKeys(kb.Keys)+"** | "+kb.Description+" |") {
+				t.Errorf("context %q is missing binding %v", ctx, kb.Keys)
+			}
+		}
+	}
+
+	// The tutorial's own navigation keys register themselves
+	found := false
+	for _, kb := range registered {
+		if kb.Context == "Tutorial" && kb.Description == "Toggle table of contents" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("tutorial navigation keys should be registered")
+	}
+}
+
+func TestKeyboardReferenceStructuredPage(t *testing.T) {
+	page := getStructuredPage("ref-keyboard")
+	if page == nil {
+		t.Fatal("structured ref-keyboard page not found")
+	}
+	contexts, byContext := groupKeyBindings(RegisteredKeyBindings())
+	var tables int
+	for _, el := range page.Elements {
+		if kt, ok := el.(KeyTable); ok {
+			if tables < len(contexts) && len(kt.Bindings) != len(byContext[contexts[tables]]) {
+				t.Errorf("table %d has %d rows, want %d", tables, len(kt.Bindings), len(byContext[contexts[tables]]))
+			}
+			tables++
+		}
+	}
+	if tables != len(contexts) {
+		t.Errorf("got %d key tables, want one per context (%d)", tables, len(contexts))
+	}
+}
@@ -14,6 +14,24 @@ type MarkdownRenderer struct {
 	isDark   bool
 	theme    *Theme // nil if using built-in styles, non-nil if using custom theme
 	useTheme bool   // true if created with NewMarkdownRendererWithTheme
+
+	// variants caches renderers for theme variants, built on first use and
+	// dropped whenever the width or theme changes
+	variants map[string]*glamour.TermRenderer
+}
+
+// markdownVariants adjusts the theme style for a named variant. Variants only
+// apply to themed renderers.
+var markdownVariants = map[string]func(style *ansi.StyleConfig, theme Theme, isDark bool){
+	// danger tints headings and emphasis with the blocked color, for warnings
+	"danger": func(style *ansi.StyleConfig, theme Theme, isDark bool) {
+		danger := stringPtr(extractHex(theme.Blocked, isDark))
+		for _, block := range []*ansi.StyleBlock{&style.Heading, &style.H1, &style.H2, &style.H3, &style.H4, &style.H5, &style.H6} {
+			block.Color = danger
+		}
+		style.Strong.Color = danger
+		style.BlockQuote.Color = danger
+	},
 }
 
 // NewMarkdownRenderer creates a new markdown renderer using built-in styles.
@@ -84,6 +102,45 @@ func (mr *MarkdownRenderer) Render(markdown string) (string, error) {
 	return mr.renderer.Render(markdown)
 }
 
+// RenderVariant renders markdown with a named theme variant such as
+// "danger". An empty or unknown variant, or a renderer without a custom
+// theme, renders with the default style.
+func (mr *MarkdownRenderer) RenderVariant(markdown, variant string) (string, error) {
+	r := mr.variantRenderer(variant)
+	if r == nil {
+		return mr.Render(markdown)
+	}
+	return r.Render(markdown)
+}
+
+// variantRenderer returns the cached renderer for variant, building it on
+// first use. Returns nil when the default renderer should be used.
+func (mr *MarkdownRenderer) variantRenderer(variant string) *glamour.TermRenderer {
+	apply, ok := markdownVariants[variant]
+	if !ok || !mr.useTheme || mr.theme == nil {
+		return nil
+	}
+	if r, ok := mr.variants[variant]; ok {
+		return r
+	}
+
+	styleConfig := buildStyleFromTheme(*mr.theme, mr.isDark)
+	apply(&styleConfig, *mr.theme, mr.isDark)
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStyles(styleConfig),
+		glamour.WithWordWrap(mr.width),
+	)
+	if err != nil {
+		r = nil
+	}
+	if mr.variants == nil {
+		mr.variants = make(map[string]*glamour.TermRenderer)
+	}
+	// Cache failures too, so a broken variant isn't rebuilt every frame
+	mr.variants[variant] = r
+	return r
+}
+
 // SetWidth updates the word wrap width and recreates the renderer.
 // If the renderer was created with a theme, the theme is preserved.
 // Width is only updated if the new renderer is created successfully.
@@ -101,6 +158,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 		); err == nil {
 			mr.renderer = r
 			mr.width = width
+			mr.variants = nil
 		}
 		return
 	}
@@ -119,6 +177,7 @@ func (mr *MarkdownRenderer) SetWidth(width int) {
 	); err == nil {
 		mr.renderer = r
 		mr.width = width
+		mr.variants = nil
 	}
 }
 
@@ -156,6 +215,7 @@ func (mr *MarkdownRenderer) SetWidthWithTheme(width int, theme Theme) {
 		mr.width = width
 		mr.theme = &theme
 		mr.useTheme = true
+		mr.variants = nil
 	}
 }
 
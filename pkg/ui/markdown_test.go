@@ -175,6 +175,63 @@ func TestMarkdownRenderer_SetWidthWithThemeInvalidWidth(t *testing.T) {
 	}
 }
 
+func TestMarkdownRenderer_RenderVariant(t *testing.T) {
+	theme := DefaultTheme(lipgloss.DefaultRenderer())
+	mr := NewMarkdownRendererWithTheme(80, theme)
+	input := "# Careful\n\nThis deletes **everything**."
+
+	plain, err := mr.Render(input)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	danger, err := mr.RenderVariant(input, "danger")
+	if err != nil {
+		t.Fatalf("RenderVariant: %v", err)
+	}
+	if danger == plain {
+		t.Error("danger variant should render differently from the default theme")
+	}
+
+	unknown, err := mr.RenderVariant(input, "sparkly")
+	if err != nil {
+		t.Fatalf("RenderVariant(unknown): %v", err)
+	}
+	if unknown != plain {
+		t.Error("unknown variant should fall back to the default theme")
+	}
+	if _, cached := mr.variants["sparkly"]; cached {
+		t.Error("unknown variants should not be cached")
+	}
+}
+
+func TestMarkdownRenderer_VariantCache(t *testing.T) {
+	theme := DefaultTheme(lipgloss.DefaultRenderer())
+	mr := NewMarkdownRendererWithTheme(80, theme)
+
+	first := mr.variantRenderer("danger")
+	if first == nil {
+		t.Fatal("expected a danger renderer")
+	}
+	if again := mr.variantRenderer("danger"); again != first {
+		t.Error("variant renderer should be reused across calls")
+	}
+	if _, err := mr.RenderVariant("# Hi", "danger"); err != nil || mr.variants["danger"] != first {
+		t.Error("RenderVariant should use the cached renderer")
+	}
+
+	mr.SetWidth(60)
+	if len(mr.variants) != 0 {
+		t.Error("changing the width should drop cached variants")
+	}
+	if rebuilt := mr.variantRenderer("danger"); rebuilt == first {
+		t.Error("variant should be rebuilt for the new width")
+	}
+
+	if NewMarkdownRenderer(80).variantRenderer("danger") != nil {
+		t.Error("renderers without a custom theme have no variants")
+	}
+}
+
 func TestMarkdownRenderer_IsDarkMode(t *testing.T) {
 	mr := NewMarkdownRenderer(80)
 	// Just verify it returns a boolean without panicking
@@ -7867,22 +7867,23 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 	}
 
 	projectDir, _ := os.Getwd()
-	driftConfig, err := drift.LoadConfig(projectDir)
+	driftConfig, err := drift.LoadConfigLayered(projectDir)
 	if err != nil {
 		driftConfig = drift.DefaultConfig()
 	}
 
-	openCount, closedCount, blockedCount := 0, 0, 0
+	openCount, closedCount := 0, 0
 	for _, issue := range issues {
 		switch {
 		case isClosedLikeStatus(issue.Status):
 			closedCount++
 		case issue.Status == model.StatusBlocked:
-			blockedCount++
+			// Blocked work is counted from the dependency graph below
 		default:
 			openCount++
 		}
 	}
+	blockedCount := drift.CountBlocked(analyzer, issues, driftConfig)
 
 	curStats := baseline.GraphStats{
 		NodeCount:       stats.NodeCount,
@@ -7892,11 +7893,12 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 		ClosedCount:     closedCount,
 		BlockedCount:    blockedCount,
 		CycleCount:      len(stats.Cycles()),
-		ActionableCount: len(analyzer.GetActionableIssues()),
+		ActionableCount: analyzer.ActionableCount(),
 	}
+	drift.SetLiveGraphStats(&curStats, issues)
 
-	bl := &baseline.Baseline{Stats: curStats}
-	cur := &baseline.Baseline{Stats: curStats, Cycles: stats.Cycles()}
+	bl := &baseline.Baseline{Stats: curStats, StatsVersion: baseline.CurrentStatsVersion}
+	cur := &baseline.Baseline{Stats: curStats, StatsVersion: baseline.CurrentStatsVersion, Cycles: stats.Cycles()}
 
 	calc := drift.NewCalculator(bl, cur, driftConfig)
 	calc.SetIssues(issues)
@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,7 +17,9 @@ type TutorialPage struct {
 	Title    string   // Page title displayed in header
 	Content  string   // Markdown content
 	Section  string   // Parent section for TOC grouping
-	Contexts []string // Which view contexts this page applies to (empty = all)
+	Contexts []string // Which view contexts this page applies to (empty or "*" = all, "!name" excludes)
+
+	ThemeVariant string // Optional markdown style variant (e.g., "danger"); empty = default
 }
 
 // tutorialFocus tracks which element has focus (bv-wdsd)
@@ -34,6 +39,7 @@ type TutorialModel struct {
 	progress     map[string]bool // Tracks which pages have been viewed
 	width        int
 	height       int
+	barWidth     int // Header progress bar cells, scaled to width by SetSize
 	theme        Theme
 	contextMode  bool   // If true, filter pages by current context
 	context      string // Current view context (e.g., "list", "board", "graph")
@@ -45,10 +51,41 @@ type TutorialModel struct {
 	focus       tutorialFocus // Current focus: content or TOC
 	shouldClose bool          // Signal to parent to close tutorial
 	tocCursor   int           // Cursor position in TOC when focused
+	// In-tutorial full-text search (/ to start, n/N to cycle)
+	search tutorialSearch
+
+	// Cross-page links: f then a digit follows the numbered [[page-id]] link
+	linkPending bool
+
+	// Set when u finds no unviewed page; the footer says so until the next key
+	caughtUp bool
+
+	// Page-number prompt: : then digits then Enter jumps to any page
+	pageJumpActive bool
+	pageJumpInput  string
+
+	// Render fenced code unwrapped with horizontal scrolling (< and >)
+	preferNoWrapCode bool
+	codeScrollX      int
 }
 
 // NewTutorialModel creates a new tutorial model with default pages.
 func NewTutorialModel(theme Theme) TutorialModel {
+	return NewTutorialModelWithPages(theme, defaultTutorialPages())
+}
+
+// NewTutorialModelWithPages creates a tutorial model with custom pages
+// (e.g. from LoadTutorialPagesFromDir). Empty pages, or pages with duplicate
+// or empty IDs (see ValidateTutorialPages), fall back to the defaults; the
+// latter is logged as a warning.
+func NewTutorialModelWithPages(theme Theme, pages []TutorialPage) TutorialModel {
+	if len(pages) == 0 {
+		pages = defaultTutorialPages()
+	} else if errs := ValidateTutorialPages(pages, tutorialViewContexts); hasFatalTutorialError(errs) {
+		log.Printf("warning: invalid tutorial pages, using defaults: %v", errors.Join(errs...))
+		pages = defaultTutorialPages()
+	}
+
 	// Calculate initial content width for markdown renderer
 	contentWidth := 80 - 6 // default width minus padding
 	if contentWidth < 40 {
@@ -56,13 +93,14 @@ func NewTutorialModel(theme Theme) TutorialModel {
 	}
 
 	return TutorialModel{
-		pages:            defaultTutorialPages(),
+		pages:            pages,
 		currentPage:      0,
 		scrollOffset:     0,
 		tocVisible:       false,
 		progress:         make(map[string]bool),
 		width:            80,
 		height:           24,
+		barWidth:         tutorialBarWidth(80),
 		theme:            theme,
 		contextMode:      false,
 		context:          "",
@@ -70,6 +108,7 @@ func NewTutorialModel(theme Theme) TutorialModel {
 		focus:            focusTutorialContent,
 		shouldClose:      false,
 		tocCursor:        0,
+		preferNoWrapCode: true,
 	}
 }
 
@@ -82,6 +121,17 @@ func (m TutorialModel) Init() tea.Cmd {
 func (m TutorialModel) Update(msg tea.Msg) (TutorialModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.caughtUp = false
+
+		// Search captures all keys while active (including q and esc)
+		if m.search.active() {
+			return m.handleSearchKeys(msg), nil
+		}
+		// So does the page-number prompt
+		if m.pageJumpActive {
+			return m.handlePageJumpKeys(msg), nil
+		}
+
 		// Global keys (work in any focus mode)
 		switch msg.String() {
 		case "esc", "q":
@@ -129,8 +179,42 @@ func (m TutorialModel) Update(msg tea.Msg) (TutorialModel, tea.Cmd) {
 	return m, nil
 }
 
+// Tutorial keys, kept next to Update and the focus handlers below
+func init() {
+	RegisterKeyBindings(
+		KeyReferenceBinding{Keys: []string{"right", "l", "n", "Space"}, Description: "Next page", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"left", "h", "p", "Shift+Tab"}, Description: "Previous page", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"j", "k"}, Description: "Scroll down/up", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"Ctrl+D", "Ctrl+U"}, Description: "Half-page down/up", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"g", "G"}, Description: "Top/bottom of page", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"1-9"}, Description: "Jump to page", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{":"}, Description: "Jump to page number (then Enter)", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"u"}, Description: "Jump to first unviewed page", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"/"}, Description: "Search pages (n/N for next/previous match)", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"f"}, Description: "Follow link (then its number)", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"<", ">"}, Description: "Scroll code left/right", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"t"}, Description: "Toggle table of contents", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"Tab"}, Description: "Switch focus to contents (next page when hidden)", Context: "Tutorial"},
+		KeyReferenceBinding{Keys: []string{"Esc", "q"}, Description: "Close tutorial", Context: "Tutorial"},
+
+		KeyReferenceBinding{Keys: []string{"j", "k"}, Description: "Move down/up", Context: "Tutorial Contents"},
+		KeyReferenceBinding{Keys: []string{"g", "G"}, Description: "First/last page", Context: "Tutorial Contents"},
+		KeyReferenceBinding{Keys: []string{"Enter", "Space"}, Description: "Open page", Context: "Tutorial Contents"},
+		KeyReferenceBinding{Keys: []string{"h", "left"}, Description: "Back to content", Context: "Tutorial Contents"},
+	)
+}
+
 // handleContentKeys handles keys when content area has focus (bv-wdsd).
 func (m TutorialModel) handleContentKeys(msg tea.KeyMsg) TutorialModel {
+	// Digit after f follows a link; any other key cancels
+	if m.linkPending {
+		m.linkPending = false
+		if key := msg.String(); len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+			m.followLink(int(key[0] - '0'))
+		}
+		return m
+	}
+
 	switch msg.String() {
 	// Page navigation
 	case "right", "l", "n", " ": // Space added for next page
@@ -169,6 +253,33 @@ func (m TutorialModel) handleContentKeys(msg tea.KeyMsg) TutorialModel {
 	case "G", "end":
 		m.scrollOffset = 9999 // Will be clamped in View()
 
+	// Full-text search
+	case "/":
+		m.startSearch()
+
+	// Horizontal scrolling for unwrapped code blocks
+	case ">":
+		if m.cursorInCode() {
+			m.scrollCode(codeScrollStep)
+		}
+	case "<":
+		if m.cursorInCode() {
+			m.scrollCode(-codeScrollStep)
+		}
+
+	// Skip ahead to the first page not yet viewed
+	case "u":
+		m.caughtUp = !m.JumpToFirstUnviewed()
+
+	// Follow a cross-page link
+	case "f":
+		m.linkPending = len(m.CurrentLinks()) > 0
+
+	// Prompt for a page number of any length
+	case ":":
+		m.pageJumpActive = true
+		m.pageJumpInput = ""
+
 	// Jump to specific page (1-9)
 	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 		pageNum := int(msg.String()[0] - '0')
@@ -180,6 +291,35 @@ func (m TutorialModel) handleContentKeys(msg tea.KeyMsg) TutorialModel {
 	return m
 }
 
+// maxPageJumpDigits caps the page-number prompt; no tutorial comes close
+const maxPageJumpDigits = 4
+
+// handlePageJumpKeys handles keys while the page-number prompt is open.
+// Digits build the number, Enter jumps to it if it names a visible page
+// (out-of-range numbers are ignored), and any other key cancels.
+func (m TutorialModel) handlePageJumpKeys(msg tea.KeyMsg) TutorialModel {
+	key := msg.String()
+	switch {
+	case len(key) == 1 && key[0] >= '0' && key[0] <= '9':
+		if len(m.pageJumpInput) < maxPageJumpDigits {
+			m.pageJumpInput += key
+		}
+		return m
+	case key == "backspace":
+		if n := len(m.pageJumpInput); n > 0 {
+			m.pageJumpInput = m.pageJumpInput[:n-1]
+		}
+		return m
+	case key == "enter":
+		if pageNum, err := strconv.Atoi(m.pageJumpInput); err == nil && pageNum >= 1 {
+			m.JumpToPage(pageNum - 1)
+		}
+	}
+	m.pageJumpActive = false
+	m.pageJumpInput = ""
+	return m
+}
+
 // handleTOCKeys handles keys when TOC has focus (bv-wdsd).
 func (m TutorialModel) handleTOCKeys(msg tea.KeyMsg) TutorialModel {
 	pages := m.visiblePages()
@@ -231,13 +371,7 @@ func (m TutorialModel) View() string {
 	r := m.theme.Renderer
 
 	// Calculate dimensions
-	contentWidth := m.width - 6 // padding and borders
-	if m.tocVisible {
-		contentWidth -= 24 // TOC sidebar width
-	}
-	if contentWidth < 40 {
-		contentWidth = 40
-	}
+	contentWidth := m.contentWidth()
 
 	// Build the view
 	var b strings.Builder
@@ -290,13 +424,28 @@ func (m TutorialModel) View() string {
 	return modalStyle.Render(b.String())
 }
 
+// Bounds for the header progress bar, which scales with the terminal width
+const (
+	tutorialMinBarWidth = 10
+	tutorialMaxBarWidth = 40
+)
+
+// tutorialBarWidth returns the header progress bar width for a terminal width
+func tutorialBarWidth(width int) int {
+	return max(tutorialMinBarWidth, min(tutorialMaxBarWidth, width/5))
+}
+
 // renderHeader renders the tutorial header with title and progress bar.
+// The progress block is right-aligned within the modal; when space runs out
+// the bar shrinks or is dropped, then the title is truncated, so the header
+// never wraps.
 func (m TutorialModel) renderHeader(page TutorialPage, totalPages int) string {
 	r := m.theme.Renderer
 
 	titleStyle := r.NewStyle().
 		Bold(true).
 		Foreground(m.theme.Primary)
+	titleText := "📚 beads_viewer Tutorial"
 
 	// Progress indicator: [2/15] ███░░░
 	pageNum := m.currentPage + 1
@@ -304,33 +453,50 @@ func (m TutorialModel) renderHeader(page TutorialPage, totalPages int) string {
 		Foreground(m.theme.Subtext).
 		Render(fmt.Sprintf("[%d/%d]", pageNum, totalPages))
 
-	// Visual progress bar
-	barWidth := 10
-	filledWidth := 0
-	if totalPages > 0 {
-		filledWidth = (pageNum * barWidth) / totalPages
-		// Ensure at least 1 filled bar when on any page
-		if filledWidth < 1 && pageNum > 0 {
-			filledWidth = 1
+	// Inside the modal's border and padding
+	available := m.width - 4
+	barWidth := m.barWidth
+	if available > 0 {
+		barWidth = min(barWidth, available-lipgloss.Width(titleText)-2-lipgloss.Width(progressText)-1)
+		if barWidth < 3 {
+			barWidth = 0 // Too short to read as a bar
 		}
 	}
-	if filledWidth > barWidth {
-		filledWidth = barWidth
-	}
-	progressBar := r.NewStyle().
-		Foreground(m.theme.Open). // Using Open (green) for progress
-		Render(strings.Repeat("█", filledWidth)) +
-		r.NewStyle().
-			Foreground(m.theme.Muted).
-			Render(strings.Repeat("░", barWidth-filledWidth))
 
-	// Title
-	title := titleStyle.Render("📚 beads_viewer Tutorial")
+	progress := progressText
+	if barWidth > 0 {
+		// Visual progress bar
+		filledWidth := 0
+		if totalPages > 0 {
+			filledWidth = (pageNum * barWidth) / totalPages
+			// Ensure at least 1 filled bar when on any page
+			if filledWidth < 1 && pageNum > 0 {
+				filledWidth = 1
+			}
+		}
+		if filledWidth > barWidth {
+			filledWidth = barWidth
+		}
+		progress += " " + r.NewStyle().
+			Foreground(m.theme.Open). // Using Open (green) for progress
+			Render(strings.Repeat("█", filledWidth)) +
+			r.NewStyle().
+				Foreground(m.theme.Muted).
+				Render(strings.Repeat("░", barWidth-filledWidth))
+	}
 
-	// Calculate spacing to align progress to the right
-	headerContent := title + "  " + progressText + " " + progressBar
+	if available <= 0 {
+		return titleStyle.Render(titleText) + "  " + progress
+	}
 
-	return headerContent
+	// Right-align the progress block, truncating the title if it still won't fit
+	titleRoom := available - lipgloss.Width(progress) - 1
+	if titleRoom <= 0 {
+		return progress
+	}
+	title := titleStyle.Render(truncate(titleText, titleRoom))
+	gap := available - lipgloss.Width(title) - lipgloss.Width(progress)
+	return title + strings.Repeat(" ", gap) + progress
 }
 
 // renderContent renders the page content with native lipgloss components or Glamour markdown.
@@ -344,39 +510,7 @@ func (m TutorialModel) renderContent(page TutorialPage, width int) string {
 	}
 
 	// Fallback to markdown rendering for unconverted pages
-	var renderedContent string
-	if m.markdownRenderer != nil {
-		rendered, err := m.markdownRenderer.Render(page.Content)
-		if err == nil {
-			renderedContent = strings.TrimSpace(rendered)
-		} else {
-			// Fallback to raw content on error
-			renderedContent = page.Content
-		}
-	} else {
-		renderedContent = page.Content
-	}
-
-	// Split rendered content into lines for scrolling
-	lines := strings.Split(renderedContent, "\n")
-
-	// Compress runs of 3+ blank lines into 2 blank lines max
-	// This helps with glamour sometimes adding excessive whitespace
-	var compressedLines []string
-	blankCount := 0
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			blankCount++
-			if blankCount <= 2 {
-				compressedLines = append(compressedLines, line)
-			}
-		} else {
-			blankCount = 0
-			compressedLines = append(compressedLines, line)
-		}
-	}
-	lines = compressedLines
+	lines, _ := m.contentLines(page, width)
 
 	// Calculate visible lines based on height
 	// Overhead: border (2) + padding (2) + header (1) + separator (1) + title (1) +
@@ -512,13 +646,25 @@ func (m TutorialModel) renderTOC(pages []TutorialPage) string {
 	}
 	b.WriteString("\n")
 
+	sectionProgress := m.SectionProgress()
 	currentSection := ""
 	for i, page := range pages {
-		// Show section header if changed
+		// Show section header if changed, with how much of it has been viewed
 		if page.Section != currentSection && page.Section != "" {
 			currentSection = page.Section
+			status := sectionProgress[currentSection]
+			pctStyle := itemStyle
+			if status.Completed {
+				pctStyle = viewedStyle
+			}
+			pct := fmt.Sprintf(" %d%%", status.Percent())
+			// Truncate long section names so the percentage stays on the header line
+			name := currentSection
+			if maxLen := 18 - len(pct); len(name) > maxLen {
+				name = name[:maxLen-1] + "…"
+			}
 			b.WriteString("\n")
-			b.WriteString(sectionStyle.Render("▸ " + currentSection))
+			b.WriteString(sectionStyle.Render("▸ "+name) + pctStyle.Render(pct))
 			b.WriteString("\n")
 		}
 
@@ -569,6 +715,23 @@ func (m TutorialModel) renderFooter(totalPages int) string {
 	sepStyle := r.NewStyle().
 		Foreground(m.theme.Muted)
 
+	if m.search.active() {
+		return m.searchStatus()
+	}
+	if m.caughtUp {
+		return r.NewStyle().Foreground(m.theme.Open).Render("✓ All caught up — every page has been viewed")
+	}
+	if m.pageJumpActive {
+		return keyStyle.Render("Go to page: ") + m.pageJumpInput + "_" +
+			descStyle.Render(fmt.Sprintf(" (1-%d)", totalPages)) + sepStyle.Render(" │ ") +
+			keyStyle.Render("Enter") + descStyle.Render(" jump") + sepStyle.Render(" │ ") +
+			keyStyle.Render("Esc") + descStyle.Render(" cancel")
+	}
+	if m.linkPending {
+		return keyStyle.Render("1-9") + descStyle.Render(" follow link") + sepStyle.Render(" │ ") +
+			keyStyle.Render("any key") + descStyle.Render(" cancel")
+	}
+
 	var hints []string
 
 	if m.focus == focusTutorialTOC && m.tocVisible {
@@ -586,9 +749,16 @@ func (m TutorialModel) renderFooter(totalPages int) string {
 			keyStyle.Render("←/→/Space") + descStyle.Render(" pages"),
 			keyStyle.Render("j/k") + descStyle.Render(" scroll"),
 			keyStyle.Render("Ctrl+d/u") + descStyle.Render(" half-page"),
+			keyStyle.Render("/") + descStyle.Render(" search"),
 			keyStyle.Render("t") + descStyle.Render(" TOC"),
 			keyStyle.Render("q") + descStyle.Render(" close"),
 		}
+		if len(m.CurrentLinks()) > 0 {
+			hints = append(hints[:len(hints)-1], keyStyle.Render("f+#")+descStyle.Render(" link"), hints[len(hints)-1])
+		}
+		if m.cursorInCode() {
+			hints = append(hints[:len(hints)-1], keyStyle.Render("</>")+descStyle.Render(" code"), hints[len(hints)-1])
+		}
 	}
 
 	sep := sepStyle.Render(" │ ")
@@ -614,6 +784,7 @@ func (m *TutorialModel) NextPage() {
 	if m.currentPage < len(pages)-1 {
 		m.currentPage++
 		m.scrollOffset = 0
+		m.codeScrollX = 0
 	}
 }
 
@@ -622,6 +793,7 @@ func (m *TutorialModel) PrevPage() {
 	if m.currentPage > 0 {
 		m.currentPage--
 		m.scrollOffset = 0
+		m.codeScrollX = 0
 	}
 }
 
@@ -631,9 +803,22 @@ func (m *TutorialModel) JumpToPage(index int) {
 	if index >= 0 && index < len(pages) {
 		m.currentPage = index
 		m.scrollOffset = 0
+		m.codeScrollX = 0
 	}
 }
 
+// JumpToFirstUnviewed jumps to the first visible page not yet viewed. It
+// returns false, leaving the position unchanged, when every page is viewed.
+func (m *TutorialModel) JumpToFirstUnviewed() bool {
+	for i, page := range m.visiblePages() {
+		if !m.progress[page.ID] {
+			m.JumpToPage(i)
+			return true
+		}
+	}
+	return false
+}
+
 // JumpToSection jumps to the first page in a section.
 func (m *TutorialModel) JumpToSection(sectionID string) {
 	pages := m.visiblePages()
@@ -641,6 +826,7 @@ func (m *TutorialModel) JumpToSection(sectionID string) {
 		if page.ID == sectionID || page.Section == sectionID {
 			m.currentPage = i
 			m.scrollOffset = 0
+			m.codeScrollX = 0
 			return
 		}
 	}
@@ -663,10 +849,38 @@ func (m *TutorialModel) SetContextMode(enabled bool) {
 	}
 }
 
+// SetPreferNoWrapCode toggles unwrapped, horizontally scrollable code blocks.
+func (m *TutorialModel) SetPreferNoWrapCode(enabled bool) {
+	m.preferNoWrapCode = enabled
+	m.codeScrollX = 0
+}
+
+// contentWidth returns the width available to page content.
+func (m TutorialModel) contentWidth() int {
+	width := m.width - 6 // padding and borders
+	if m.tocVisible {
+		width -= 24 // TOC sidebar width
+	}
+	if width < 40 {
+		width = 40
+	}
+	return width
+}
+
+// visibleContentHeight returns how many content lines fit (same overhead as renderContent).
+func (m TutorialModel) visibleContentHeight() int {
+	visibleHeight := m.height - 11
+	if visibleHeight < 5 {
+		visibleHeight = 5
+	}
+	return visibleHeight
+}
+
 // SetSize sets the tutorial dimensions and updates the markdown renderer.
 func (m *TutorialModel) SetSize(width, height int) {
 	m.width = width
 	m.height = height
+	m.barWidth = tutorialBarWidth(width)
 
 	// Update markdown renderer width to match content area
 	contentWidth := width - 6 // padding and borders
@@ -687,6 +901,47 @@ func (m *TutorialModel) MarkViewed(pageID string) {
 	m.progress[pageID] = true
 }
 
+// tutorialContextKeyPrefix marks synthetic progress keys recording that the
+// tutorial was auto-opened for a view context (e.g. "context:board").
+const tutorialContextKeyPrefix = "context:"
+
+// tutorialContextKey returns the synthetic progress key for a view context.
+func tutorialContextKey(ctx string) string {
+	return tutorialContextKeyPrefix + ctx
+}
+
+// OpenForContextIfUnseen prepares the tutorial for a view the user has not
+// learned about yet. If no page tagged with ctx has been viewed (and the
+// tutorial wasn't already auto-opened for it), it enables context mode,
+// records the context as seen and returns true so the parent can show the
+// overlay. Returns false otherwise, including when no pages target ctx.
+func (m *TutorialModel) OpenForContextIfUnseen(ctx string) bool {
+	if ctx == "" || m.progress[tutorialContextKey(ctx)] {
+		return false
+	}
+
+	tagged := false
+	for _, page := range m.pages {
+		for _, pageCtx := range page.Contexts {
+			if pageCtx != ctx {
+				continue
+			}
+			if m.progress[page.ID] {
+				return false
+			}
+			tagged = true
+		}
+	}
+	if !tagged {
+		return false
+	}
+
+	m.progress[tutorialContextKey(ctx)] = true
+	m.SetContextMode(true)
+	m.SetContext(ctx)
+	return true
+}
+
 // Progress returns the progress map for persistence.
 func (m TutorialModel) Progress() map[string]bool {
 	return m.progress
@@ -719,6 +974,44 @@ func (m TutorialModel) IsComplete() bool {
 	return len(pages) > 0
 }
 
+// SectionStatus summarizes how much of one tutorial section has been viewed.
+type SectionStatus struct {
+	Viewed    int  // Pages in the section that have been viewed
+	Total     int  // Pages in the section
+	Completed bool // True once every page in the section has been viewed
+}
+
+// Percent returns the viewed fraction of the section as a whole percentage.
+func (s SectionStatus) Percent() int {
+	if s.Total == 0 {
+		return 0
+	}
+	return s.Viewed * 100 / s.Total
+}
+
+// SectionProgress returns viewed/total page counts for each section, keyed by
+// section name. Only visible pages count, so context filtering applies; pages
+// without a section are left out.
+func (m TutorialModel) SectionProgress() map[string]SectionStatus {
+	sections := make(map[string]SectionStatus)
+	for _, page := range m.visiblePages() {
+		if page.Section == "" {
+			continue
+		}
+		status := sections[page.Section]
+		status.Total++
+		if m.progress[page.ID] {
+			status.Viewed++
+		}
+		sections[page.Section] = status
+	}
+	for name, status := range sections {
+		status.Completed = status.Viewed == status.Total
+		sections[name] = status
+	}
+	return sections
+}
+
 // ShouldClose returns true if user requested to close the tutorial (bv-wdsd).
 func (m TutorialModel) ShouldClose() bool {
 	return m.shouldClose
@@ -737,19 +1030,35 @@ func (m TutorialModel) visiblePages() []TutorialPage {
 
 	var filtered []TutorialPage
 	for _, page := range m.pages {
-		// Include if no context restriction or matches current context
-		if len(page.Contexts) == 0 {
+		if pageMatchesContext(page.Contexts, m.context) {
 			filtered = append(filtered, page)
-			continue
 		}
-		for _, ctx := range page.Contexts {
-			if ctx == m.context {
-				filtered = append(filtered, page)
-				break
+	}
+	return filtered
+}
+
+// pageMatchesContext reports whether a page with the given Contexts applies
+// to ctx. Plain entries match exactly, "*" matches every context and "!name"
+// excludes one. An explicit negation wins over any positive entry; a list
+// of only negations applies everywhere else, as does an empty list.
+func pageMatchesContext(contexts []string, ctx string) bool {
+	if len(contexts) == 0 {
+		return true
+	}
+	matched, hasPositive := false, false
+	for _, entry := range contexts {
+		if negated, ok := strings.CutPrefix(entry, "!"); ok {
+			if negated == ctx {
+				return false
 			}
+			continue
+		}
+		hasPositive = true
+		if entry == "*" || entry == ctx {
+			matched = true
 		}
 	}
-	return filtered
+	return matched || !hasPositive
 }
 
 // CenterTutorial returns the tutorial view centered in the terminal.
@@ -781,6 +1090,11 @@ func (m TutorialModel) CenterTutorial(termWidth, termHeight int) string {
 	return centered
 }
 
+// DefaultTutorialPages returns the built-in tutorial pages
+func DefaultTutorialPages() []TutorialPage {
+	return defaultTutorialPages()
+}
+
 // defaultTutorialPages returns the built-in tutorial content.
 // Content organized by section - see bv-kdv2, bv-sbib, bv-36wz, etc.
 func defaultTutorialPages() []TutorialPage {
@@ -993,34 +1307,7 @@ func defaultTutorialPages() []TutorialPage {
 			ID:      "ref-keyboard",
 			Title:   "Keyboard Reference",
 			Section: "Reference",
-			Content: `## Quick Keyboard Reference
-
-### Global
-| Key | Action |
-|-----|--------|
-| **?** | Help overlay |
-| **q** | Quit |
-| **Esc** | Close/go back |
-| **b/g/i/h** | Switch views |
-
-### Navigation
-| Key | Action |
-|-----|--------|
-| **j/k** | Move down/up |
-| **h/l** | Move left/right |
-| **g/G** | Top/bottom |
-| **Enter** | Select |
-
-### Filtering
-| Key | Action |
-|-----|--------|
-| **/** | Fuzzy search |
-| **Ctrl+S** | Semantic search |
-| **H** | Hybrid ranking |
-| **Alt+H** | Hybrid preset |
-| **o/c/r/a** | Status filter |
-
-> Press **?** in any view for context help.`,
+			Content: keyboardReferenceContent(),
 		},
 	}
 }
@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"strings"
+)
+
+// codeScrollStep is how far < and > move code blocks horizontally.
+const codeScrollStep = 8
+
+// tutorialSegment is a run of prose or a fenced code block in page content.
+type tutorialSegment struct {
+	code bool
+	text string // Prose markdown, or code without the fence lines
+}
+
+// splitFencedCode splits markdown into prose and ``` / ~~~ fenced code segments.
+// An unterminated fence runs to the end of the content.
+func splitFencedCode(content string) []tutorialSegment {
+	var segments []tutorialSegment
+	var buf []string
+	inCode := false
+	fence := ""
+
+	flush := func(code bool) {
+		if len(buf) > 0 {
+			segments = append(segments, tutorialSegment{code: code, text: strings.Join(buf, "\n")})
+		}
+		buf = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !inCode && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")):
+			flush(false)
+			inCode = true
+			fence = trimmed[:3]
+		case inCode && strings.HasPrefix(trimmed, fence):
+			flush(true)
+			inCode = false
+		default:
+			buf = append(buf, line)
+		}
+	}
+	flush(inCode)
+	return segments
+}
+
+// codeMoreIndicator marks code lines that continue past the right edge.
+const codeMoreIndicator = " → more"
+
+// renderCodeLines renders code without wrapping: each line is shifted left by
+// offset runes and clipped to width, with an indicator when more follows.
+func (m TutorialModel) renderCodeLines(code string, width, offset int) []string {
+	r := m.theme.Renderer
+	barStyle := r.NewStyle().Foreground(m.theme.Primary)
+	codeStyle := r.NewStyle().Foreground(m.theme.Open)
+	moreStyle := r.NewStyle().Foreground(m.theme.Muted)
+
+	avail := width - 2 // accent bar + space
+	if avail < 10 {
+		avail = 10
+	}
+
+	var out []string
+	for _, line := range strings.Split(code, "\n") {
+		runes := []rune(strings.ReplaceAll(line, "\t", "    "))
+		start := offset
+		if start > len(runes) {
+			start = len(runes)
+		}
+		visible := runes[start:]
+		more := ""
+		if len(visible) > avail {
+			cut := avail - len([]rune(codeMoreIndicator))
+			if cut < 1 {
+				cut = 1
+			}
+			visible = visible[:cut]
+			more = moreStyle.Render(codeMoreIndicator)
+		}
+		out = append(out, barStyle.Render("│ ")+codeStyle.Render(string(visible))+more)
+	}
+	return out
+}
+
+// maxCodeScroll returns the largest useful horizontal offset for the code
+// blocks in content at the given width (0 when everything fits).
+func maxCodeScroll(content string, width int) int {
+	avail := width - 2
+	if avail < 10 {
+		avail = 10
+	}
+	widest := 0
+	for _, seg := range splitFencedCode(content) {
+		if !seg.code {
+			continue
+		}
+		for _, line := range strings.Split(seg.text, "\n") {
+			if n := len([]rune(strings.ReplaceAll(line, "\t", "    "))); n > widest {
+				widest = n
+			}
+		}
+	}
+	if widest <= avail {
+		return 0
+	}
+	return widest - avail
+}
+
+// contentLines renders a markdown page into display lines, flagging which
+// lines belong to code blocks. With preferNoWrapCode, fenced code is rendered
+// unwrapped (see renderCodeLines) while prose keeps Glamour's wrapping.
+func (m TutorialModel) contentLines(page TutorialPage, width int) ([]string, []bool) {
+	source := renderTutorialLinks(page.Content, m.visiblePages())
+
+	var lines []string
+	var code []bool
+	if !m.preferNoWrapCode {
+		lines = strings.Split(m.renderMarkdown(source, page.ThemeVariant), "\n")
+		code = make([]bool, len(lines))
+	} else {
+		for _, seg := range splitFencedCode(source) {
+			var segLines []string
+			if seg.code {
+				segLines = m.renderCodeLines(seg.text, width, m.codeScrollX)
+			} else {
+				segLines = trimBlankLines(strings.Split(m.renderMarkdown(seg.text, page.ThemeVariant), "\n"))
+			}
+			if len(segLines) == 0 {
+				continue
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+				code = append(code, false)
+			}
+			lines = append(lines, segLines...)
+			for range segLines {
+				code = append(code, seg.code)
+			}
+		}
+	}
+
+	// Compress runs of 3+ blank lines into 2 blank lines max
+	// This helps with glamour sometimes adding excessive whitespace
+	var compressed []string
+	var compressedCode []bool
+	blankCount := 0
+	for i, line := range lines {
+		if !code[i] && strings.TrimSpace(line) == "" {
+			blankCount++
+			if blankCount > 2 {
+				continue
+			}
+		} else {
+			blankCount = 0
+		}
+		compressed = append(compressed, line)
+		compressedCode = append(compressedCode, code[i])
+	}
+	return compressed, compressedCode
+}
+
+// renderMarkdown renders markdown with Glamour in the page's theme variant,
+// falling back to the raw text.
+func (m TutorialModel) renderMarkdown(source, variant string) string {
+	if m.markdownRenderer == nil {
+		return source
+	}
+	rendered, err := m.markdownRenderer.RenderVariant(source, variant)
+	if err != nil {
+		return source
+	}
+	return strings.TrimSpace(rendered)
+}
+
+// trimBlankLines drops whitespace-only lines from both ends.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// cursorInCode reports whether the reading position is inside a code block:
+// the tutorial has no separate cursor, so any code line within the visible
+// window (scrollOffset onward, clamped like renderContent) counts.
+func (m TutorialModel) cursorInCode() bool {
+	pages := m.visiblePages()
+	if !m.preferNoWrapCode || m.currentPage < 0 || m.currentPage >= len(pages) {
+		return false
+	}
+	page := pages[m.currentPage]
+	if getStructuredPage(page.ID) != nil {
+		return false
+	}
+	_, code := m.contentLines(page, m.contentWidth())
+	visible := m.visibleContentHeight()
+	start := m.scrollOffset
+	if maxScroll := len(code) - visible; start > maxScroll {
+		start = maxScroll
+	}
+	if start < 0 {
+		start = 0
+	}
+	for i := start; i < len(code) && i < start+visible; i++ {
+		if code[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// scrollCode moves code blocks horizontally, clamped to the widest line.
+func (m *TutorialModel) scrollCode(delta int) {
+	pages := m.visiblePages()
+	if m.currentPage < 0 || m.currentPage >= len(pages) {
+		return
+	}
+	limit := maxCodeScroll(renderTutorialLinks(pages[m.currentPage].Content, pages), m.contentWidth())
+	m.codeScrollX += delta
+	if m.codeScrollX > limit {
+		m.codeScrollX = limit
+	}
+	if m.codeScrollX < 0 {
+		m.codeScrollX = 0
+	}
+}
@@ -8,7 +8,7 @@ type StructuredTutorialPage struct {
 	Title    string
 	Section  string
 	Elements []TutorialElement
-	Contexts []string // Which view contexts this page applies to (empty = all)
+	Contexts []string // Which view contexts this page applies to (empty or "*" = all, "!name" excludes)
 }
 
 // RenderStructuredPage renders a structured tutorial page
@@ -898,34 +898,9 @@ func structuredTutorialPages() []StructuredTutorialPage {
 			ID:      "ref-keyboard",
 			Title:   "Keyboard Reference",
 			Section: "Reference",
-			Elements: []TutorialElement{
-				Section{Title: "Global"},
-				KeyTable{Bindings: []KeyBinding{
-					{Key: "?", Desc: "Help overlay"},
-					{Key: "q", Desc: "Quit"},
-					{Key: "Esc", Desc: "Close/back"},
-					{Key: "b/g/i/h", Desc: "Switch views"},
-				}},
-				Spacer{Lines: 1},
-				Section{Title: "Navigation"},
-				KeyTable{Bindings: []KeyBinding{
-					{Key: "j / k", Desc: "Move down/up"},
-					{Key: "h / l", Desc: "Move left/right"},
-					{Key: "g / G", Desc: "Top/bottom"},
-					{Key: "Enter", Desc: "Select"},
-				}},
-				Spacer{Lines: 1},
-				Section{Title: "Filtering"},
-				KeyTable{Bindings: []KeyBinding{
-					{Key: "/", Desc: "Fuzzy search"},
-					{Key: "Ctrl+S", Desc: "Semantic search"},
-					{Key: "H", Desc: "Hybrid ranking"},
-					{Key: "Alt+H", Desc: "Hybrid preset"},
-					{Key: "o/c/r/a", Desc: "Status filter"},
-				}},
-				Spacer{Lines: 1},
+			Elements: append(keyReferenceElements(RegisteredKeyBindings()),
 				Tip{Text: "Press ? in any view for context-specific help"},
-			},
+			),
 		},
 	}
 }
@@ -0,0 +1,49 @@
+package ui
+
+import "strings"
+
+// ExportTutorialMarkdown renders pages as a single Markdown document for
+// publishing, in order: a level-1 heading each time the section changes,
+// then a level-2 heading with the page title and the page content as is.
+// Pages limited to some views get a note saying which.
+func ExportTutorialMarkdown(pages []TutorialPage) string {
+	var b strings.Builder
+	section := ""
+	for _, page := range pages {
+		if page.Section != section && page.Section != "" {
+			section = page.Section
+			b.WriteString("# " + section + "\n\n")
+		}
+		b.WriteString("## " + page.Title + "\n\n")
+		if note := tutorialContextNote(page.Contexts); note != "" {
+			b.WriteString("> " + note + "\n\n")
+		}
+		if content := strings.TrimSpace(page.Content); content != "" {
+			b.WriteString(content + "\n\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+// tutorialContextNote describes which views a page's Contexts limit it to,
+// or returns "" for a page shown everywhere. See pageMatchesContext.
+func tutorialContextNote(contexts []string) string {
+	var only, except []string
+	all := false
+	for _, ctx := range contexts {
+		if name, ok := strings.CutPrefix(ctx, "!"); ok {
+			except = append(except, name)
+		} else if ctx == "*" {
+			all = true
+		} else {
+			only = append(only, ctx)
+		}
+	}
+	if all || len(only) == 0 {
+		if len(except) == 0 {
+			return ""
+		}
+		return "**Views:** all except " + strings.Join(except, ", ")
+	}
+	return "**Views:** " + strings.Join(only, ", ")
+}
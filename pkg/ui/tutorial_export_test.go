@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportTutorialMarkdown(t *testing.T) {
+	pages := []TutorialPage{
+		{ID: "a", Title: "First", Section: "Basics", Content: "Hello [[b]].\n\n### Detail\n"},
+		{ID: "b", Title: "Second", Section: "Basics", Content: "More.", Contexts: []string{"list", "detail"}},
+		{ID: "c", Title: "Third", Section: "Views", Content: "Views.", Contexts: []string{"*", "!board"}},
+		{ID: "d", Title: "Fourth", Section: "Views", Contexts: []string{"*"}},
+	}
+
+	want := `# Basics
+
+## First
+
+Hello [[b]].
+
+### Detail
+
+## Second
+
+> **Views:** list, detail
+
+More.
+
+# Views
+
+## Third
+
+> **Views:** all except board
+
+Views.
+
+## Fourth
+`
+	if got := ExportTutorialMarkdown(pages); got != want {
+		t.Errorf("ExportTutorialMarkdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportTutorialMarkdown_DefaultPages(t *testing.T) {
+	pages := defaultTutorialPages()
+	doc := ExportTutorialMarkdown(pages)
+	lines := strings.Split(doc, "\n")
+
+	count := func(line string) int {
+		n := 0
+		for _, l := range lines {
+			if l == line {
+				n++
+			}
+		}
+		return n
+	}
+
+	sections := map[string]bool{}
+	for _, page := range pages {
+		sections[page.Section] = true
+		// Titles may recur inside page content, so look for the heading
+		// followed by this page's note or first content line
+		heading := "## " + page.Title + "\n\n"
+		next := strings.SplitN(strings.TrimSpace(page.Content), "\n", 2)[0]
+		if note := tutorialContextNote(page.Contexts); note != "" {
+			next = "> " + note
+		}
+		if n := strings.Count(doc, heading+next+"\n"); n != 1 {
+			t.Errorf("page %q appears %d times, want once", page.ID, n)
+		}
+	}
+	for section := range sections {
+		if n := count("# " + section); n != 1 {
+			t.Errorf("section %q has %d headings, want 1", section, n)
+		}
+	}
+}
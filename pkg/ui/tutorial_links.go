@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// tutorialLinkPattern matches cross-page links: [[page-id]] or [[page-id|Label]].
+var tutorialLinkPattern = regexp.MustCompile(`\[\[([^\[\]|]+)(?:\|([^\[\]]+))?\]\]`)
+
+// tutorialLink is a cross-page reference found in page content.
+type tutorialLink struct {
+	Target string // Page ID the link points at
+	Label  string // Display text (defaults to the target page title)
+	Valid  bool   // Target exists among the visible pages
+}
+
+// extractTutorialLinks returns the links in content in order of appearance.
+// pages resolves targets; links to unknown IDs are returned with Valid=false.
+func extractTutorialLinks(content string, pages []TutorialPage) []tutorialLink {
+	titles := make(map[string]string, len(pages))
+	for _, p := range pages {
+		titles[p.ID] = p.Title
+	}
+
+	var links []tutorialLink
+	for _, match := range tutorialLinkPattern.FindAllStringSubmatch(content, -1) {
+		link := tutorialLink{
+			Target: strings.TrimSpace(match[1]),
+			Label:  strings.TrimSpace(match[2]),
+		}
+		title, ok := titles[link.Target]
+		link.Valid = ok
+		if link.Label == "" {
+			link.Label = link.Target
+			if ok && title != "" {
+				link.Label = title
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
+
+// validTutorialLinks returns only the links that can be followed, numbered
+// from 1 in the order they are displayed.
+func validTutorialLinks(content string, pages []TutorialPage) []tutorialLink {
+	var valid []tutorialLink
+	for _, link := range extractTutorialLinks(content, pages) {
+		if link.Valid {
+			valid = append(valid, link)
+		}
+	}
+	return valid
+}
+
+// renderTutorialLinks rewrites link syntax for display: valid links become a
+// bold label with a numbered footnote marker, listed again at the end of the
+// content; unknown links become plain text.
+func renderTutorialLinks(content string, pages []TutorialPage) string {
+	links := extractTutorialLinks(content, pages)
+	if len(links) == 0 {
+		return content
+	}
+
+	var footnotes []string
+	i := 0
+	content = tutorialLinkPattern.ReplaceAllStringFunc(content, func(string) string {
+		link := links[i]
+		i++
+		if !link.Valid {
+			return link.Label
+		}
+		footnotes = append(footnotes, link.Label)
+		return fmt.Sprintf("**%s** [%d]", link.Label, len(footnotes))
+	})
+
+	if len(footnotes) > 0 {
+		var b strings.Builder
+		b.WriteString(content)
+		b.WriteString("\n\n---\n\n**Links** (press `f` then the number to jump)\n\n")
+		for n, label := range footnotes {
+			b.WriteString(fmt.Sprintf("%d. %s\n", n+1, label))
+		}
+		content = b.String()
+	}
+	return content
+}
+
+// CurrentLinks returns the followable links on the current page.
+func (m TutorialModel) CurrentLinks() []string {
+	pages := m.visiblePages()
+	if m.currentPage < 0 || m.currentPage >= len(pages) {
+		return nil
+	}
+	var targets []string
+	for _, link := range validTutorialLinks(pages[m.currentPage].Content, pages) {
+		targets = append(targets, link.Target)
+	}
+	return targets
+}
+
+// followLink jumps to the page referenced by the n-th (1-based) valid link on
+// the current page. Returns false if there is no such link.
+func (m *TutorialModel) followLink(n int) bool {
+	targets := m.CurrentLinks()
+	if n < 1 || n > len(targets) {
+		return false
+	}
+	m.JumpToSection(targets[n-1])
+	return true
+}
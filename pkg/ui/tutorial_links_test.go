@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newLinkTestTutorial() TutorialModel {
+	pages := []TutorialPage{
+		{ID: "intro", Title: "Welcome", Content: "See [[graph-view]] and [[board|the Board]]. Also [[missing-page|Nowhere]]."},
+		{ID: "board", Title: "Board View", Content: "Columns."},
+		{ID: "graph-view", Title: "Graph View", Content: "Nodes and edges."},
+	}
+	return NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+}
+
+func TestExtractTutorialLinks(t *testing.T) {
+	m := newLinkTestTutorial()
+	links := extractTutorialLinks(m.pages[0].Content, m.pages)
+
+	if len(links) != 3 {
+		t.Fatalf("expected 3 links, got %d: %+v", len(links), links)
+	}
+	want := []tutorialLink{
+		{Target: "graph-view", Label: "Graph View", Valid: true}, // label defaults to page title
+		{Target: "board", Label: "the Board", Valid: true},
+		{Target: "missing-page", Label: "Nowhere", Valid: false},
+	}
+	for i, w := range want {
+		if links[i] != w {
+			t.Errorf("link %d = %+v, want %+v", i, links[i], w)
+		}
+	}
+
+	if got := m.CurrentLinks(); len(got) != 2 || got[0] != "graph-view" || got[1] != "board" {
+		t.Errorf("CurrentLinks should list only valid targets, got %v", got)
+	}
+}
+
+func TestRenderTutorialLinks(t *testing.T) {
+	m := newLinkTestTutorial()
+	out := renderTutorialLinks(m.pages[0].Content, m.pages)
+
+	if strings.Contains(out, "[[") {
+		t.Errorf("link syntax should be rewritten, got %q", out)
+	}
+	if !strings.Contains(out, "**Graph View** [1]") || !strings.Contains(out, "**the Board** [2]") {
+		t.Errorf("valid links should be numbered footnotes, got %q", out)
+	}
+	if !strings.Contains(out, "Also Nowhere.") {
+		t.Errorf("unknown link should render as plain text, got %q", out)
+	}
+	if !strings.Contains(out, "1. Graph View") || !strings.Contains(out, "2. the Board") {
+		t.Errorf("expected footnote list, got %q", out)
+	}
+
+	if plain := "No links here."; renderTutorialLinks(plain, m.pages) != plain {
+		t.Error("content without links should be unchanged")
+	}
+}
+
+func TestTutorialFollowLink(t *testing.T) {
+	m := newLinkTestTutorial()
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if m.CurrentPageID() != "board" {
+		t.Fatalf("f 2 should follow the second link to 'board', got %q", m.CurrentPageID())
+	}
+
+	m.JumpToPage(0)
+	if !m.followLink(1) || m.currentPage != 2 {
+		t.Errorf("followLink(1) should jump to graph-view (index 2), got %d", m.currentPage)
+	}
+
+	// Out-of-range (including the unknown link's slot) is ignored
+	m.JumpToPage(0)
+	if m.followLink(3) || m.currentPage != 0 {
+		t.Errorf("followLink(3) should be ignored, page=%d", m.currentPage)
+	}
+
+	// Without f, digits keep their page-jump meaning
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("2")})
+	if m.currentPage != 1 {
+		t.Errorf("plain digit should jump to page 2, got index %d", m.currentPage)
+	}
+}
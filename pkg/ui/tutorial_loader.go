@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// tutorialFrontMatter is the YAML header of an external tutorial page.
+type tutorialFrontMatter struct {
+	ID       string   `yaml:"id"`
+	Title    string   `yaml:"title"`
+	Section  string   `yaml:"section"`
+	Contexts []string `yaml:"contexts"`
+}
+
+// LoadTutorialPagesFromDir loads tutorial pages from *.md files in dir, in
+// filename order. Each file starts with YAML front-matter between "---" lines
+// supplying id, title, section and contexts; the rest is the page content.
+// A missing or empty directory yields the built-in pages.
+func LoadTutorialPagesFromDir(dir string) ([]TutorialPage, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("listing tutorial pages: %w", err)
+	}
+	if len(files) == 0 {
+		return defaultTutorialPages(), nil
+	}
+	sort.Strings(files)
+
+	pages := make([]TutorialPage, 0, len(files))
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading tutorial page %s: %w", path, err)
+		}
+		page, err := parseTutorialPage(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing tutorial page %s: %w", path, err)
+		}
+		if page.ID == "" {
+			page.ID = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if page.Title == "" {
+			page.Title = page.ID
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// parseTutorialPage splits a Markdown file into front-matter and content.
+func parseTutorialPage(data []byte) (TutorialPage, error) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(data, []byte("---\n")) {
+		return TutorialPage{}, fmt.Errorf("missing front-matter (file must start with ---)")
+	}
+	rest := data[len("---\n"):]
+
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return TutorialPage{}, fmt.Errorf("unterminated front-matter")
+	}
+	header := rest[:end]
+	body := rest[end+len("\n---"):]
+	// Drop the remainder of the closing delimiter line
+	if nl := bytes.IndexByte(body, '\n'); nl >= 0 {
+		body = body[nl+1:]
+	} else {
+		body = nil
+	}
+
+	var fm tutorialFrontMatter
+	if err := yaml.Unmarshal(header, &fm); err != nil {
+		return TutorialPage{}, fmt.Errorf("invalid front-matter: %w", err)
+	}
+
+	return TutorialPage{
+		ID:       fm.ID,
+		Title:    fm.Title,
+		Section:  fm.Section,
+		Contexts: fm.Contexts,
+		Content:  strings.TrimSpace(string(body)),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func writeTutorialFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestLoadTutorialPagesFromDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTutorialFile(t, dir, "20-board.md", `---
+id: team-board
+title: Our Board Workflow
+section: Team
+contexts: [board, list]
+---
+# Board
+
+Move cards when work starts.
+`)
+	writeTutorialFile(t, dir, "10-welcome.md", "---\nid: team-welcome\ntitle: Welcome to the Team\nsection: Team\n---\nHello!\n")
+	writeTutorialFile(t, dir, "notes.txt", "ignored")
+
+	pages, err := LoadTutorialPagesFromDir(dir)
+	if err != nil {
+		t.Fatalf("LoadTutorialPagesFromDir: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	if pages[0].ID != "team-welcome" || pages[1].ID != "team-board" {
+		t.Errorf("expected filename order, got %s, %s", pages[0].ID, pages[1].ID)
+	}
+	if pages[0].Content != "Hello!" {
+		t.Errorf("unexpected content %q", pages[0].Content)
+	}
+	if len(pages[0].Contexts) != 0 {
+		t.Errorf("expected no contexts for welcome page, got %v", pages[0].Contexts)
+	}
+	board := pages[1]
+	if board.Title != "Our Board Workflow" || board.Section != "Team" {
+		t.Errorf("unexpected front-matter: %+v", board)
+	}
+	if len(board.Contexts) != 2 || board.Contexts[0] != "board" || board.Contexts[1] != "list" {
+		t.Errorf("unexpected contexts %v", board.Contexts)
+	}
+	if !strings.HasPrefix(board.Content, "# Board") {
+		t.Errorf("content should start after front-matter, got %q", board.Content)
+	}
+
+	// Loaded pages drive the model and context filtering
+	m := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+	m.SetContextMode(true)
+	m.SetContext("graph")
+	if got := len(m.visiblePages()); got != 1 {
+		t.Errorf("expected 1 page visible in graph context, got %d", got)
+	}
+}
+
+func TestLoadTutorialPagesFromDirBrokenFrontMatter(t *testing.T) {
+	dir := t.TempDir()
+	writeTutorialFile(t, dir, "01-ok.md", "---\nid: ok\ntitle: OK\n---\nfine\n")
+	writeTutorialFile(t, dir, "02-broken.md", "---\nid: broken\ncontexts: [board\n---\nbody\n")
+	writeTutorialFile(t, dir, "03-ok.md", "---\nid: ok2\ntitle: OK 2\n---\nfine\n")
+
+	_, err := LoadTutorialPagesFromDir(dir)
+	if err == nil {
+		t.Fatal("expected error for malformed front-matter")
+	}
+	if !strings.Contains(err.Error(), "02-broken.md") {
+		t.Errorf("error should name the offending file, got %v", err)
+	}
+
+	noHeader := t.TempDir()
+	writeTutorialFile(t, noHeader, "plain.md", "# Just markdown\n")
+	if _, err := LoadTutorialPagesFromDir(noHeader); err == nil || !strings.Contains(err.Error(), "plain.md") {
+		t.Errorf("expected missing front-matter error naming plain.md, got %v", err)
+	}
+}
+
+func TestLoadTutorialPagesFromDirFallback(t *testing.T) {
+	defaults := defaultTutorialPages()
+
+	for name, dir := range map[string]string{
+		"missing": filepath.Join(t.TempDir(), "does-not-exist"),
+		"empty":   t.TempDir(),
+	} {
+		pages, err := LoadTutorialPagesFromDir(dir)
+		if err != nil {
+			t.Fatalf("%s dir: unexpected error %v", name, err)
+		}
+		if len(pages) != len(defaults) || pages[0].ID != defaults[0].ID {
+			t.Errorf("%s dir: expected default pages, got %d pages", name, len(pages))
+		}
+	}
+
+	m := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, nil)
+	if len(m.pages) != len(defaults) {
+		t.Errorf("nil pages should fall back to defaults, got %d", len(m.pages))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
@@ -148,6 +149,18 @@ func (m *tutorialProgressManager) MarkPageViewed(pageID string) {
 	}
 }
 
+// MarkContextSeen records a synthetic context key (see OpenForContextIfUnseen)
+// without touching the resume point.
+func (m *tutorialProgressManager) MarkContextSeen(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.progress.ViewedPages[key] {
+		m.progress.ViewedPages[key] = true
+		m.dirty = true
+	}
+}
+
 // IsPageViewed returns whether a page has been viewed.
 func (m *tutorialProgressManager) IsPageViewed(pageID string) bool {
 	m.mu.Lock()
@@ -161,8 +174,8 @@ func (m *tutorialProgressManager) GetViewedCount() int {
 	defer m.mu.Unlock()
 
 	count := 0
-	for _, viewed := range m.progress.ViewedPages {
-		if viewed {
+	for id, viewed := range m.progress.ViewedPages {
+		if viewed && !strings.HasPrefix(id, tutorialContextKeyPrefix) {
 			count++
 		}
 	}
@@ -242,6 +255,13 @@ func (m *TutorialModel) SaveProgress() error {
 		pm.MarkPageViewed(m.pages[m.currentPage].ID)
 	}
 
+	// Persist contexts the tutorial was auto-opened for
+	for key, seen := range m.progress {
+		if seen && strings.HasPrefix(key, tutorialContextKeyPrefix) {
+			pm.MarkContextSeen(key)
+		}
+	}
+
 	// Check if all pages have been viewed
 	allViewed := true
 	for _, page := range m.pages {
@@ -267,6 +287,13 @@ func (m *TutorialModel) LoadProgress() {
 			m.progress[page.ID] = true
 		}
 	}
+
+	// Restore contexts the tutorial was already auto-opened for
+	for key, seen := range pm.GetProgress().ViewedPages {
+		if seen && strings.HasPrefix(key, tutorialContextKeyPrefix) {
+			m.progress[key] = true
+		}
+	}
 }
 
 // HasViewedPage returns whether a page has been viewed (from persisted data).
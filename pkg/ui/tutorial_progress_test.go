@@ -463,3 +463,24 @@ func TestTutorialModel_SaveProgress_AllViewed(t *testing.T) {
 		t.Error("Expected tutorial to be marked as completed when all pages viewed")
 	}
 }
+
+func TestTutorialProgressManager_ContextKeys(t *testing.T) {
+	pm := &tutorialProgressManager{
+		progress: &TutorialProgress{
+			ViewedPages: make(map[string]bool),
+		},
+	}
+
+	pm.MarkPageViewed("intro")
+	pm.MarkContextSeen(tutorialContextKey("board"))
+
+	if !pm.IsPageViewed(tutorialContextKey("board")) {
+		t.Error("expected context key to be recorded")
+	}
+	if pm.GetLastPageID() != "intro" {
+		t.Errorf("context keys should not change the resume point, got %q", pm.GetLastPageID())
+	}
+	if pm.GetViewedCount() != 1 {
+		t.Errorf("context keys should not count as viewed pages, got %d", pm.GetViewedCount())
+	}
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tutorialSearch holds in-tutorial full-text search state.
+type tutorialSearch struct {
+	typing     bool   // Query prompt is open
+	query      string // Current query text
+	matches    []int  // Indexes into visiblePages() that match the query
+	index      int    // Position within matches
+	prevPage   int    // Page to restore when search is cancelled
+	prevScroll int    // Scroll offset to restore when search is cancelled
+}
+
+// active reports whether a search is in progress (typing or cycling matches).
+func (s tutorialSearch) active() bool {
+	return s.typing || s.query != ""
+}
+
+// IsSearching returns true while the tutorial search prompt or results are active.
+func (m TutorialModel) IsSearching() bool {
+	return m.search.active()
+}
+
+// startSearch opens the search prompt, remembering where to return on cancel.
+func (m *TutorialModel) startSearch() {
+	m.search = tutorialSearch{
+		typing:     true,
+		prevPage:   m.currentPage,
+		prevScroll: m.scrollOffset,
+	}
+}
+
+// cancelSearch clears the search and restores the page shown before it began.
+func (m *TutorialModel) cancelSearch() {
+	m.currentPage = m.search.prevPage
+	m.scrollOffset = m.search.prevScroll
+	m.search = tutorialSearch{}
+}
+
+// handleSearchKeys handles input while a search is active.
+func (m TutorialModel) handleSearchKeys(msg tea.KeyMsg) TutorialModel {
+	key := msg.String()
+	if key == "esc" {
+		m.cancelSearch()
+		return m
+	}
+
+	if m.search.typing {
+		switch msg.Type {
+		case tea.KeyEnter:
+			m.search.typing = false
+			m.runSearch()
+			if len(m.search.matches) == 0 {
+				// Nothing to cycle through; leave the prompt closed and restore
+				m.cancelSearch()
+			}
+		case tea.KeyBackspace:
+			if r := []rune(m.search.query); len(r) > 0 {
+				m.search.query = string(r[:len(r)-1])
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.search.query += string(msg.Runes)
+		}
+		return m
+	}
+
+	switch key {
+	case "n":
+		m.cycleSearch(1)
+	case "N":
+		m.cycleSearch(-1)
+	case "/":
+		m.search.typing = true
+	default:
+		// Any other key ends the search but keeps the current page
+		m.search = tutorialSearch{}
+		return m.handleContentKeys(msg)
+	}
+	return m
+}
+
+// runSearch finds pages whose title or content contain the query
+// (case-insensitive) and jumps to the first match.
+func (m *TutorialModel) runSearch() {
+	m.search.matches = nil
+	m.search.index = 0
+	query := strings.ToLower(strings.TrimSpace(m.search.query))
+	if query == "" {
+		return
+	}
+	for i, page := range m.visiblePages() {
+		if strings.Contains(strings.ToLower(page.Title), query) ||
+			strings.Contains(strings.ToLower(page.Content), query) {
+			m.search.matches = append(m.search.matches, i)
+		}
+	}
+	m.jumpToSearchMatch()
+}
+
+// cycleSearch moves to the next (delta=1) or previous (delta=-1) match, wrapping.
+func (m *TutorialModel) cycleSearch(delta int) {
+	n := len(m.search.matches)
+	if n == 0 {
+		return
+	}
+	m.search.index = ((m.search.index+delta)%n + n) % n
+	m.jumpToSearchMatch()
+}
+
+// jumpToSearchMatch shows the current match's page, scrolled to the first
+// content line containing the query.
+func (m *TutorialModel) jumpToSearchMatch() {
+	if len(m.search.matches) == 0 {
+		return
+	}
+	pages := m.visiblePages()
+	idx := m.search.matches[m.search.index]
+	if idx < 0 || idx >= len(pages) {
+		return
+	}
+	m.currentPage = idx
+	m.scrollOffset = 0
+
+	query := strings.ToLower(strings.TrimSpace(m.search.query))
+	for i, line := range strings.Split(pages[idx].Content, "\n") {
+		if strings.Contains(strings.ToLower(line), query) {
+			m.scrollOffset = i
+			break
+		}
+	}
+}
+
+// searchStatus renders the search prompt or match counter for the footer.
+func (m TutorialModel) searchStatus() string {
+	r := m.theme.Renderer
+	keyStyle := r.NewStyle().Bold(true).Foreground(m.theme.Primary)
+	descStyle := r.NewStyle().Foreground(m.theme.Subtext)
+
+	if m.search.typing {
+		return keyStyle.Render("/") + m.search.query + descStyle.Render("▏ Enter search • Esc cancel")
+	}
+	counter := fmt.Sprintf("[%d/%d]", m.search.index+1, len(m.search.matches))
+	return keyStyle.Render(counter) + descStyle.Render(fmt.Sprintf(" %q", m.search.query)) +
+		"  " + keyStyle.Render("n/N") + descStyle.Render(" next/prev") +
+		"  " + keyStyle.Render("Esc") + descStyle.Render(" cancel")
+}
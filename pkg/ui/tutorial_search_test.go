@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newSearchTestTutorial() TutorialModel {
+	pages := []TutorialPage{
+		{ID: "intro", Title: "Welcome", Content: "Start here."},
+		{ID: "agents", Title: "Agents", Content: "Line one\nLine two\nUse --robot-plan for a parallel plan."},
+		{ID: "board", Title: "Board View", Content: "Kanban columns."},
+		{ID: "robot", Title: "Robot-Plan Deep Dive", Content: "Details about plans."},
+	}
+	return NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+}
+
+func typeTutorialKeys(m TutorialModel, keys ...tea.KeyMsg) TutorialModel {
+	for _, k := range keys {
+		m, _ = m.Update(k)
+	}
+	return m
+}
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTutorialSearchCyclesMatches(t *testing.T) {
+	m := newSearchTestTutorial()
+	m.SetSize(100, 30)
+
+	m = typeTutorialKeys(m, runeKey("/"))
+	if !m.IsSearching() || !m.search.typing {
+		t.Fatal("expected / to open the search prompt")
+	}
+	// Letters like "n" go into the query rather than navigating
+	m = typeTutorialKeys(m, runeKey("R"), runeKey("O"), runeKey("b"), runeKey("o"), runeKey("t"), runeKey("-"), runeKey("p"), runeKey("l"), runeKey("a"), runeKey("n"))
+	if m.search.query != "RObot-plan" {
+		t.Fatalf("unexpected query %q", m.search.query)
+	}
+	m = typeTutorialKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+
+	if len(m.search.matches) != 2 {
+		t.Fatalf("expected 2 matching pages, got %v", m.search.matches)
+	}
+	if m.CurrentPageID() != "agents" {
+		t.Errorf("expected first match 'agents', got %q", m.CurrentPageID())
+	}
+	if m.scrollOffset != 2 {
+		t.Errorf("expected scroll to the matching line (2), got %d", m.scrollOffset)
+	}
+	if footer := m.renderFooter(len(m.pages)); !strings.Contains(footer, "[1/2]") {
+		t.Errorf("footer should show match count, got %q", footer)
+	}
+
+	m = typeTutorialKeys(m, runeKey("n"))
+	if m.CurrentPageID() != "robot" {
+		t.Errorf("n should advance to 'robot', got %q", m.CurrentPageID())
+	}
+	if footer := m.renderFooter(len(m.pages)); !strings.Contains(footer, "[2/2]") {
+		t.Errorf("footer should show [2/2], got %q", footer)
+	}
+
+	m = typeTutorialKeys(m, runeKey("n"))
+	if m.CurrentPageID() != "agents" {
+		t.Errorf("n should wrap back to 'agents', got %q", m.CurrentPageID())
+	}
+	m = typeTutorialKeys(m, runeKey("N"))
+	if m.CurrentPageID() != "robot" {
+		t.Errorf("N should go back to 'robot', got %q", m.CurrentPageID())
+	}
+}
+
+func TestTutorialSearchEscapeRestoresPage(t *testing.T) {
+	m := newSearchTestTutorial()
+	m.JumpToPage(2)
+	m.scrollOffset = 1
+
+	m = typeTutorialKeys(m, runeKey("/"), runeKey("w"), runeKey("e"), runeKey("l"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.CurrentPageID() != "intro" {
+		t.Fatalf("expected search to jump to 'intro', got %q", m.CurrentPageID())
+	}
+
+	m = typeTutorialKeys(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.IsSearching() {
+		t.Error("esc should cancel search")
+	}
+	if m.ShouldClose() {
+		t.Error("esc during search should not close the tutorial")
+	}
+	if m.CurrentPageID() != "board" || m.scrollOffset != 1 {
+		t.Errorf("expected prior page restored, got %q scroll=%d", m.CurrentPageID(), m.scrollOffset)
+	}
+
+	// A query with no matches restores the prior page too
+	m = typeTutorialKeys(m, runeKey("/"), runeKey("z"), runeKey("z"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.IsSearching() || m.CurrentPageID() != "board" {
+		t.Errorf("no-match search should end on the prior page, got %q searching=%v", m.CurrentPageID(), m.IsSearching())
+	}
+}
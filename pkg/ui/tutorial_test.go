@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -159,6 +160,83 @@ func TestTutorialJumpToPage(t *testing.T) {
 	// Should not change if page doesn't exist
 }
 
+// newPagedTutorialModel builds a tutorial with n numbered pages
+func newPagedTutorialModel(n int) TutorialModel {
+	pages := make([]TutorialPage, n)
+	for i := range pages {
+		pages[i] = TutorialPage{ID: fmt.Sprintf("page-%d", i+1), Title: fmt.Sprintf("Page %d", i+1), Content: "Body"}
+	}
+	m := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+	m.SetSize(100, 40)
+	return m
+}
+
+func TestTutorialPageJumpMultiDigit(t *testing.T) {
+	m := newPagedTutorialModel(15)
+
+	m = typeTutorialKeys(m, runeKey(":"), runeKey("1"), runeKey("2"))
+	if !m.pageJumpActive || m.pageJumpInput != "12" {
+		t.Fatalf("expected prompt holding 12, got active=%v input=%q", m.pageJumpActive, m.pageJumpInput)
+	}
+	if m.currentPage != 0 {
+		t.Errorf("page changed before Enter: %d", m.currentPage)
+	}
+	if footer := m.renderFooter(15); !strings.Contains(footer, "12") {
+		t.Errorf("expected footer to show typed number, got %q", footer)
+	}
+
+	m = typeTutorialKeys(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentPage != 11 {
+		t.Errorf("expected page index 11 after :12 Enter, got %d", m.currentPage)
+	}
+	if m.pageJumpActive || m.pageJumpInput != "" {
+		t.Error("expected prompt to close after Enter")
+	}
+
+	// Single-digit quick jump still works once the prompt is closed
+	m = typeTutorialKeys(m, runeKey("3"))
+	if m.currentPage != 2 {
+		t.Errorf("expected page index 2 after '3', got %d", m.currentPage)
+	}
+}
+
+func TestTutorialPageJumpOutOfRangeIsNoOp(t *testing.T) {
+	m := newPagedTutorialModel(15)
+	m.JumpToPage(4)
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	for _, keys := range [][]tea.KeyMsg{
+		{runeKey(":"), runeKey("9"), runeKey("9"), enter},
+		{runeKey(":"), runeKey("0"), enter},
+		{runeKey(":"), enter},
+	} {
+		m = typeTutorialKeys(m, keys...)
+		if m.currentPage != 4 {
+			t.Errorf("%v: expected to stay on index 4, got %d", keys, m.currentPage)
+		}
+		if m.pageJumpActive {
+			t.Errorf("%v: expected prompt to close", keys)
+		}
+	}
+}
+
+func TestTutorialPageJumpEditAndCancel(t *testing.T) {
+	m := newPagedTutorialModel(15)
+
+	m = typeTutorialKeys(m, runeKey(":"), runeKey("1"), runeKey("4"), tea.KeyMsg{Type: tea.KeyBackspace}, runeKey("3"), tea.KeyMsg{Type: tea.KeyEnter})
+	if m.currentPage != 12 {
+		t.Errorf("expected index 12 after :14<bs>3, got %d", m.currentPage)
+	}
+
+	// Esc and q cancel the prompt without closing the tutorial
+	for _, cancel := range []tea.KeyMsg{tea.KeyMsg{Type: tea.KeyEsc}, runeKey("q")} {
+		m = typeTutorialKeys(m, runeKey(":"), runeKey("2"), cancel)
+		if m.pageJumpActive || m.shouldClose || m.currentPage != 12 {
+			t.Errorf("%s: expected cancel only, got active=%v close=%v page=%d", cancel, m.pageJumpActive, m.shouldClose, m.currentPage)
+		}
+	}
+}
+
 func TestTutorialJumpMethods(t *testing.T) {
 	m := newTestTutorialModel()
 
@@ -202,6 +280,66 @@ func TestTutorialJumpMethods(t *testing.T) {
 	}
 }
 
+func TestTutorialJumpToFirstUnviewed(t *testing.T) {
+	m := sectionProgressTestModel()
+	m.MarkViewed("start-1")
+	m.MarkViewed("start-2")
+	m.MarkViewed("adv-2")
+	m.currentPage = 5
+	m.scrollOffset = 7
+
+	if !m.JumpToFirstUnviewed() {
+		t.Fatal("expected an unviewed page to be found")
+	}
+	if m.currentPage != 2 || m.scrollOffset != 0 {
+		t.Errorf("expected page 2 (adv-1) with scroll reset, got page %d scroll %d", m.currentPage, m.scrollOffset)
+	}
+
+	// Context filtering: adv-3 is hidden, so adv-4 is next once adv-1 is seen
+	m.MarkViewed("adv-1")
+	m.SetContextMode(true)
+	m.SetContext("board")
+	if !m.JumpToFirstUnviewed() || m.CurrentPageID() != "adv-4" {
+		t.Errorf("expected to land on adv-4 in board context, got %q", m.CurrentPageID())
+	}
+}
+
+func TestTutorialJumpToFirstUnviewed_AllViewed(t *testing.T) {
+	m := sectionProgressTestModel()
+	for _, page := range m.pages {
+		m.MarkViewed(page.ID)
+	}
+	m.currentPage = 3
+	m.scrollOffset = 4
+
+	if m.JumpToFirstUnviewed() {
+		t.Error("expected false when every page has been viewed")
+	}
+	if m.currentPage != 3 || m.scrollOffset != 4 {
+		t.Errorf("position should be unchanged, got page %d scroll %d", m.currentPage, m.scrollOffset)
+	}
+
+	// The u key reports it in the footer until the next key
+	m.SetSize(80, 30)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if !strings.Contains(m.View(), "All caught up") {
+		t.Error("footer should say all caught up after u with nothing unviewed")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if strings.Contains(m.View(), "All caught up") {
+		t.Error("caught-up notice should clear on the next key")
+	}
+}
+
+func TestTutorialUKeyJumpsToUnviewed(t *testing.T) {
+	m := sectionProgressTestModel()
+	m.MarkViewed("start-1")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'u'}})
+	if m.CurrentPageID() != "start-2" || m.caughtUp {
+		t.Errorf("u should jump to start-2, got %q (caughtUp=%v)", m.CurrentPageID(), m.caughtUp)
+	}
+}
+
 func TestTutorialContextFiltering(t *testing.T) {
 	m := newTestTutorialModel()
 
@@ -240,6 +378,42 @@ func TestTutorialContextFiltering(t *testing.T) {
 	}
 }
 
+func TestTutorialContextWildcardAndNegation(t *testing.T) {
+	m := newTestTutorialModel()
+	m.pages = []TutorialPage{
+		{ID: "everywhere", Title: "Everywhere"},
+		{ID: "star", Title: "Star", Contexts: []string{"*"}},
+		{ID: "not-graph", Title: "Not graph", Contexts: []string{"!graph"}},
+		{ID: "list-only", Title: "List only", Contexts: []string{"list"}},
+		{ID: "star-not-board", Title: "Star but not board", Contexts: []string{"*", "!board"}},
+		{ID: "list-negated", Title: "Negation wins", Contexts: []string{"list", "!list"}},
+	}
+	m.SetContextMode(true)
+
+	visibleIDs := func(ctx string) []string {
+		m.SetContext(ctx)
+		var ids []string
+		for _, page := range m.visiblePages() {
+			ids = append(ids, page.ID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		ctx  string
+		want []string
+	}{
+		{"list", []string{"everywhere", "star", "not-graph", "list-only", "star-not-board"}},
+		{"graph", []string{"everywhere", "star", "star-not-board"}},
+		{"board", []string{"everywhere", "star", "not-graph"}},
+	}
+	for _, tt := range tests {
+		if got := visibleIDs(tt.ctx); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("context %q: visible pages %v, want %v", tt.ctx, got, tt.want)
+		}
+	}
+}
+
 func TestTutorialProgress(t *testing.T) {
 	m := newTestTutorialModel()
 
@@ -549,6 +723,53 @@ func TestTutorialViewHeader(t *testing.T) {
 	}
 }
 
+func TestTutorialHeaderProgressBarScales(t *testing.T) {
+	barCells := func(header string) int {
+		return strings.Count(header, "█") + strings.Count(header, "░")
+	}
+
+	m := newTestTutorialModel()
+	m.NextPage()
+	total := len(m.pages)
+
+	m.SetSize(40, 24)
+	narrow := m.renderHeader(m.pages[m.currentPage], total)
+	m.SetSize(200, 24)
+	wide := m.renderHeader(m.pages[m.currentPage], total)
+
+	if barCells(wide) != tutorialMaxBarWidth {
+		t.Errorf("wide bar has %d cells, want %d", barCells(wide), tutorialMaxBarWidth)
+	}
+	if barCells(narrow) >= barCells(wide) {
+		t.Errorf("narrow bar (%d cells) should be shorter than wide bar (%d cells)", barCells(narrow), barCells(wide))
+	}
+
+	for width, header := range map[int]string{40: narrow, 200: wide} {
+		if strings.Contains(header, "\n") {
+			t.Errorf("width %d: header wrapped: %q", width, header)
+		}
+		if got := lipgloss.Width(header); got > width-4 {
+			t.Errorf("width %d: header is %d cells, want at most %d", width, got, width-4)
+		}
+		if !strings.Contains(header, "[2/") {
+			t.Errorf("width %d: header lost the page counter: %q", width, header)
+		}
+	}
+	// Right-aligned: the wide header fills the line exactly
+	if got := lipgloss.Width(wide); got != 196 {
+		t.Errorf("wide header is %d cells, want the full 196", got)
+	}
+}
+
+func TestTutorialBarWidthClamped(t *testing.T) {
+	tests := map[int]int{0: 10, 40: 10, 80: 16, 150: 30, 200: 40, 500: 40}
+	for width, want := range tests {
+		if got := tutorialBarWidth(width); got != want {
+			t.Errorf("tutorialBarWidth(%d) = %d, want %d", width, got, want)
+		}
+	}
+}
+
 func TestTutorialViewFooter(t *testing.T) {
 	m := newTestTutorialModel()
 	// Use large dimensions to ensure footer isn't clipped
@@ -606,6 +827,72 @@ func TestTutorialTOCProgressCheckmarks(t *testing.T) {
 	}
 }
 
+func sectionProgressTestModel() TutorialModel {
+	m := newTestTutorialModel()
+	m.pages = []TutorialPage{
+		{ID: "start-1", Title: "Welcome", Section: "Getting Started"},
+		{ID: "start-2", Title: "Keys", Section: "Getting Started"},
+		{ID: "adv-1", Title: "Graphs", Section: "Advanced"},
+		{ID: "adv-2", Title: "Board", Section: "Advanced", Contexts: []string{"board"}},
+		{ID: "adv-3", Title: "Insights", Section: "Advanced", Contexts: []string{"insights"}},
+		{ID: "adv-4", Title: "Robots", Section: "Advanced"},
+	}
+	return m
+}
+
+func TestTutorialSectionProgress(t *testing.T) {
+	m := sectionProgressTestModel()
+	m.MarkViewed("start-1")
+	m.MarkViewed("start-2")
+	m.MarkViewed("adv-1")
+
+	progress := m.SectionProgress()
+	if len(progress) != 2 {
+		t.Fatalf("expected 2 sections, got %v", progress)
+	}
+	if got, want := progress["Getting Started"], (SectionStatus{Viewed: 2, Total: 2, Completed: true}); got != want {
+		t.Errorf("Getting Started = %+v, want %+v", got, want)
+	}
+	adv := progress["Advanced"]
+	if adv != (SectionStatus{Viewed: 1, Total: 4}) || adv.Percent() != 25 {
+		t.Errorf("Advanced = %+v (%d%%), want 1/4 viewed (25%%)", adv, adv.Percent())
+	}
+	if m.IsComplete() {
+		t.Error("tutorial should not be complete with Advanced partly viewed")
+	}
+
+	// Context filtering hides the insights page from the counts
+	m.SetContextMode(true)
+	m.SetContext("board")
+	adv = m.SectionProgress()["Advanced"]
+	if adv != (SectionStatus{Viewed: 1, Total: 3}) || adv.Percent() != 33 {
+		t.Errorf("Advanced in board context = %+v (%d%%), want 1/3 viewed", adv, adv.Percent())
+	}
+
+	m.MarkViewed("adv-2")
+	m.MarkViewed("adv-4")
+	if adv := m.SectionProgress()["Advanced"]; !adv.Completed || adv.Percent() != 100 {
+		t.Errorf("Advanced should be complete once its visible pages are viewed, got %+v", adv)
+	}
+}
+
+func TestTutorialTOCSectionPercentages(t *testing.T) {
+	m := sectionProgressTestModel()
+	m.SetSize(80, 30)
+	m.tocVisible = true
+	m.MarkViewed("start-2")
+
+	// Viewing renders the current page (start-1), completing Getting Started,
+	// whose name is truncated to keep the percentage on the header line
+	view := m.View()
+	if !strings.Contains(view, "Getting Star… 100%") {
+		t.Error("TOC should show Getting Started as 100% viewed")
+	}
+	if !strings.Contains(view, "Advanced 0%") {
+		t.Error("TOC should show Advanced as 0% viewed")
+	}
+}
+
 func TestTutorialPageTitleDisplay(t *testing.T) {
 	m := newTestTutorialModel()
 	// Use large height to ensure content isn't clipped
@@ -671,6 +958,26 @@ func TestTutorialSetSizeUpdatesMarkdownRenderer(t *testing.T) {
 	}
 }
 
+func TestTutorialPageThemeVariant(t *testing.T) {
+	m := NewTutorialModel(DefaultTheme(lipgloss.DefaultRenderer()))
+	m.SetSize(80, 30)
+
+	page := TutorialPage{ID: "variant-test", Title: "Warning", Content: "## Destructive\n\nThis **cannot** be undone."}
+	plain, _ := m.contentLines(page, m.contentWidth())
+
+	page.ThemeVariant = "danger"
+	danger, _ := m.contentLines(page, m.contentWidth())
+	if strings.Join(danger, "\n") == strings.Join(plain, "\n") {
+		t.Error("a page with a theme variant should render differently")
+	}
+
+	page.ThemeVariant = "no-such-variant"
+	unknown, _ := m.contentLines(page, m.contentWidth())
+	if strings.Join(unknown, "\n") != strings.Join(plain, "\n") {
+		t.Error("an unknown variant should render with the default theme")
+	}
+}
+
 func TestTutorialMarkdownWithCodeBlocks(t *testing.T) {
 	m := newTestTutorialModel()
 	m.SetSize(100, 60) // Larger to show more content
@@ -1220,3 +1527,116 @@ func TestGlamourRenderingAllPages(t *testing.T) {
 		})
 	}
 }
+
+func TestTutorialOpenForContextIfUnseen(t *testing.T) {
+	pages := []TutorialPage{
+		{ID: "intro", Title: "Welcome", Content: "Hi"},
+		{ID: "board-basics", Title: "Board Basics", Content: "Columns", Contexts: []string{"board"}},
+		{ID: "graph-basics", Title: "Graph Basics", Content: "Nodes", Contexts: []string{"graph"}},
+	}
+	m := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+
+	if !m.OpenForContextIfUnseen("board") {
+		t.Fatal("expected first board visit to open the tutorial")
+	}
+	if !m.contextMode || m.context != "board" {
+		t.Errorf("expected context mode for board, got mode=%v ctx=%q", m.contextMode, m.context)
+	}
+	if len(m.visiblePages()) != 2 {
+		t.Errorf("expected intro + board page visible, got %d", len(m.visiblePages()))
+	}
+	if !m.Progress()[tutorialContextKey("board")] {
+		t.Error("expected synthetic context key in progress map")
+	}
+
+	if m.OpenForContextIfUnseen("board") {
+		t.Error("second board visit should not reopen the tutorial")
+	}
+
+	// Viewing a tagged page also counts as having seen the context
+	m.MarkViewed("graph-basics")
+	if m.OpenForContextIfUnseen("graph") {
+		t.Error("graph context should be considered seen once its page was viewed")
+	}
+
+	// Contexts without tagged pages never auto-open
+	if m.OpenForContextIfUnseen("insights") || m.OpenForContextIfUnseen("") {
+		t.Error("contexts without tagged pages should return false")
+	}
+
+	// Seen contexts survive a round trip through the progress map
+	restored := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+	restored.SetProgress(m.Progress())
+	if restored.OpenForContextIfUnseen("board") {
+		t.Error("restored progress should remember the board context")
+	}
+}
+
+func TestTutorialCodeBlocksDoNotWrap(t *testing.T) {
+	longCmd := "bd create --title=\"Implement the frobnicator\" --type=feature --priority=1 --labels=backend,api --description=END_OF_LINE"
+	var intro strings.Builder
+	for i := 1; i <= 40; i++ {
+		fmt.Fprintf(&intro, "- step %d\n", i)
+	}
+	pages := []TutorialPage{{
+		ID:      "custom-code",
+		Title:   "Creating Issues",
+		Content: intro.String() + "\nRun this:\n\n```bash\n" + longCmd + "\n```\n\nThen check the list.",
+	}}
+	m := NewTutorialModelWithPages(Theme{Renderer: lipgloss.DefaultRenderer()}, pages)
+	m.SetSize(60, 40)
+
+	lines, code := m.contentLines(m.pages[0], m.contentWidth())
+	codeLines := 0
+	for i, line := range lines {
+		if !code[i] {
+			continue
+		}
+		codeLines++
+		if !strings.Contains(line, "bd create --title=") {
+			t.Errorf("code line should start with the command, got %q", line)
+		}
+		if !strings.Contains(line, "→ more") {
+			t.Errorf("clipped code line should show a more indicator, got %q", line)
+		}
+	}
+	if codeLines != 1 {
+		t.Fatalf("expected the long command on exactly 1 line, got %d", codeLines)
+	}
+	// Prose outside code blocks still renders
+	if !strings.Contains(strings.Join(lines, "\n"), "check") {
+		t.Error("expected prose after the code block")
+	}
+
+	// > only scrolls when a code block is in view
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	if m.codeScrollX != 0 {
+		t.Errorf("expected no horizontal scroll while only prose is visible, got %d", m.codeScrollX)
+	}
+	for i := range code {
+		if code[i] {
+			m.scrollOffset = i
+			break
+		}
+	}
+	if out := m.View(); strings.Contains(out, "END_OF_LINE") {
+		t.Error("the end of the long line should be scrolled out of view, not wrapped")
+	}
+	for i := 0; i < 50; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	}
+	if limit := maxCodeScroll(pages[0].Content, m.contentWidth()); m.codeScrollX != limit || limit == 0 {
+		t.Errorf("expected scroll clamped to %d, got %d", limit, m.codeScrollX)
+	}
+	lines, code = m.contentLines(m.pages[0], m.contentWidth())
+	for i, line := range lines {
+		if code[i] && !strings.Contains(line, "END_OF_LINE") {
+			t.Errorf("fully scrolled code line should show the line end, got %q", line)
+		}
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("<")})
+	if m.codeScrollX != maxCodeScroll(pages[0].Content, m.contentWidth())-codeScrollStep {
+		t.Errorf("< should scroll back by one step, got %d", m.codeScrollX)
+	}
+}
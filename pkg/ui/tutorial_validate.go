@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// tutorialViewContexts are the view contexts the tutorial can be shown in,
+// as produced by ContextFromFocus plus the split view
+var tutorialViewContexts = []string{
+	"list", "detail", "split", "board", "graph", "insights", "history", "actionable", "label",
+}
+
+// TutorialPageError is one problem found by ValidateTutorialPages.
+type TutorialPageError struct {
+	Index   int    // Position of the page in the list
+	PageID  string // ID of the page, possibly empty
+	Problem string // What is wrong
+	Fatal   bool   // Navigation breaks (duplicate or empty IDs), not just content
+}
+
+func (e TutorialPageError) Error() string {
+	if e.PageID == "" {
+		return fmt.Sprintf("tutorial page %d: %s", e.Index+1, e.Problem)
+	}
+	return fmt.Sprintf("tutorial page %d (%s): %s", e.Index+1, e.PageID, e.Problem)
+}
+
+// ValidateTutorialPages checks pages for empty or duplicate IDs, empty
+// titles, contexts outside knownContexts and [[page-id]] links to pages that
+// don't exist. Each problem is a TutorialPageError; nil means the pages are
+// fine. Context entries may be "*" or negated with "!"; a nil knownContexts
+// skips the context check.
+func ValidateTutorialPages(pages []TutorialPage, knownContexts []string) []error {
+	var errs []error
+	report := func(i int, fatal bool, format string, args ...any) {
+		errs = append(errs, TutorialPageError{
+			Index:   i,
+			PageID:  pages[i].ID,
+			Problem: fmt.Sprintf(format, args...),
+			Fatal:   fatal,
+		})
+	}
+
+	firstIndex := make(map[string]int, len(pages))
+	for i, page := range pages {
+		if strings.TrimSpace(page.ID) == "" {
+			report(i, true, "empty ID")
+		} else if first, dup := firstIndex[page.ID]; dup {
+			report(i, true, "duplicate ID, first used by page %d", first+1)
+		} else {
+			firstIndex[page.ID] = i
+		}
+		if strings.TrimSpace(page.Title) == "" {
+			report(i, false, "empty title")
+		}
+		if knownContexts != nil {
+			for _, ctx := range page.Contexts {
+				name := strings.TrimPrefix(ctx, "!")
+				if name != "*" && !slices.Contains(knownContexts, name) {
+					report(i, false, "unknown context %q", ctx)
+				}
+			}
+		}
+		for _, link := range extractTutorialLinks(page.Content, pages) {
+			if !link.Valid {
+				report(i, false, "link to unknown page %q", link.Target)
+			}
+		}
+	}
+	return errs
+}
+
+// hasFatalTutorialError reports whether any of errs is a fatal
+// TutorialPageError
+func hasFatalTutorialError(errs []error) bool {
+	for _, err := range errs {
+		if pageErr, ok := err.(TutorialPageError); ok && pageErr.Fatal {
+			return true
+		}
+	}
+	return false
+}
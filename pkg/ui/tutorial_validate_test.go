@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func validationPages() []TutorialPage {
+	return []TutorialPage{
+		{ID: "intro", Title: "Intro", Content: "See [[keys]].", Contexts: []string{"*"}},
+		{ID: "keys", Title: "Keys", Content: "Back to [[intro|the start]].", Contexts: []string{"list", "!board"}},
+	}
+}
+
+// pageErrors returns the TutorialPageErrors in errs, failing on anything else
+func pageErrors(t *testing.T, errs []error) []TutorialPageError {
+	t.Helper()
+	var out []TutorialPageError
+	for _, err := range errs {
+		var pageErr TutorialPageError
+		if !errors.As(err, &pageErr) {
+			t.Fatalf("unexpected error type %T: %v", err, err)
+		}
+		out = append(out, pageErr)
+	}
+	return out
+}
+
+func TestValidateTutorialPages_Clean(t *testing.T) {
+	if errs := ValidateTutorialPages(validationPages(), tutorialViewContexts); len(errs) != 0 {
+		t.Errorf("expected no problems, got %v", errs)
+	}
+	if errs := ValidateTutorialPages(defaultTutorialPages(), tutorialViewContexts); len(errs) != 0 {
+		t.Errorf("built-in pages should validate, got %v", errs)
+	}
+}
+
+func TestValidateTutorialPages_Problems(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(pages []TutorialPage) []TutorialPage
+		index   int
+		problem string
+		fatal   bool
+	}{
+		{
+			name:    "duplicate ID",
+			mutate:  func(p []TutorialPage) []TutorialPage { return append(p, TutorialPage{ID: "intro", Title: "Again"}) },
+			index:   2,
+			problem: "duplicate ID, first used by page 1",
+			fatal:   true,
+		},
+		{
+			name:    "empty ID",
+			mutate:  func(p []TutorialPage) []TutorialPage { p[1].ID = " "; p[0].Content = ""; return p },
+			index:   1,
+			problem: "empty ID",
+			fatal:   true,
+		},
+		{
+			name:    "empty title",
+			mutate:  func(p []TutorialPage) []TutorialPage { p[0].Title = ""; return p },
+			index:   0,
+			problem: "empty title",
+		},
+		{
+			name:    "unknown context",
+			mutate:  func(p []TutorialPage) []TutorialPage { p[1].Contexts = []string{"list", "!kanban"}; return p },
+			index:   1,
+			problem: `unknown context "!kanban"`,
+		},
+		{
+			name:    "dangling link",
+			mutate:  func(p []TutorialPage) []TutorialPage { p[0].Content = "See [[keyz]]."; return p },
+			index:   0,
+			problem: `link to unknown page "keyz"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := pageErrors(t, ValidateTutorialPages(tt.mutate(validationPages()), tutorialViewContexts))
+			if len(errs) != 1 {
+				t.Fatalf("got %d problems %v, want 1", len(errs), errs)
+			}
+			got := errs[0]
+			if got.Index != tt.index || got.Problem != tt.problem || got.Fatal != tt.fatal {
+				t.Errorf("got {index %d, %q, fatal %v}, want {index %d, %q, fatal %v}",
+					got.Index, got.Problem, got.Fatal, tt.index, tt.problem, tt.fatal)
+			}
+		})
+	}
+}
+
+func TestValidateTutorialPages_NilContextsSkipsCheck(t *testing.T) {
+	pages := validationPages()
+	pages[0].Contexts = []string{"anything"}
+	if errs := ValidateTutorialPages(pages, nil); len(errs) != 0 {
+		t.Errorf("nil known contexts should skip the check, got %v", errs)
+	}
+}
+
+func TestNewTutorialModelWithPages_FallsBackOnFatalProblems(t *testing.T) {
+	var logged bytes.Buffer
+	prev := log.Writer()
+	log.SetOutput(&logged)
+	defer log.SetOutput(prev)
+
+	dup := append(validationPages(), TutorialPage{ID: "keys", Title: "Keys again"})
+	m := NewTutorialModelWithPages(testTheme(), dup)
+	if got, want := len(m.pages), len(defaultTutorialPages()); got != want {
+		t.Errorf("got %d pages, want the %d defaults", got, want)
+	}
+	if !strings.Contains(logged.String(), "duplicate ID") {
+		t.Errorf("expected a logged warning, got %q", logged.String())
+	}
+
+	// Non-fatal problems keep the custom pages
+	logged.Reset()
+	pages := validationPages()
+	pages[0].Content = "See [[nowhere]]."
+	m = NewTutorialModelWithPages(testTheme(), pages)
+	if len(m.pages) != 2 || m.pages[0].ID != "intro" {
+		t.Errorf("custom pages replaced for a non-fatal problem: %d pages", len(m.pages))
+	}
+	if logged.Len() != 0 {
+		t.Errorf("unexpected warning: %q", logged.String())
+	}
+}
@@ -0,0 +1,127 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is wrapped by Compare and AtLeast for malformed versions.
+var ErrInvalidVersion = errors.New("invalid version")
+
+// semver is a parsed vX.Y.Z[-pre] version.
+type semver struct {
+	core [3]int
+	pre  []string // Dot-separated pre-release identifiers; nil for releases
+}
+
+// parse parses "vX.Y.Z", "X.Y.Z" and "vX.Y.Z-pre", ignoring "+build" metadata.
+func parse(s string) (semver, error) {
+	var v semver
+	raw := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if idx := strings.Index(raw, "+"); idx != -1 {
+		raw = raw[:idx]
+	}
+	if idx := strings.Index(raw, "-"); idx != -1 {
+		pre := raw[idx+1:]
+		raw = raw[:idx]
+		if pre == "" {
+			return v, fmt.Errorf("%q: empty pre-release: %w", s, ErrInvalidVersion)
+		}
+		v.pre = strings.Split(pre, ".")
+		for _, id := range v.pre {
+			if id == "" {
+				return v, fmt.Errorf("%q: empty pre-release identifier: %w", s, ErrInvalidVersion)
+			}
+		}
+	}
+
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return v, fmt.Errorf("%q: want MAJOR.MINOR.PATCH: %w", s, ErrInvalidVersion)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || p[0] == '+' {
+			return v, fmt.Errorf("%q: non-numeric component %q: %w", s, p, ErrInvalidVersion)
+		}
+		v.core[i] = n
+	}
+	return v, nil
+}
+
+// Compare compares two versions by semantic version precedence, returning
+// -1 if a < b, 0 if equal and 1 if a > b. A leading "v" is optional and a
+// pre-release sorts before its release (v1.0.0-rc1 < v1.0.0).
+func Compare(a, b string) (int, error) {
+	va, err := parse(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return va.compare(vb), nil
+}
+
+// AtLeast reports whether the running Version is min or newer.
+func AtLeast(min string) (bool, error) {
+	c, err := Compare(Version, min)
+	if err != nil {
+		return false, err
+	}
+	return c >= 0, nil
+}
+
+func (v semver) compare(o semver) int {
+	for i := range v.core {
+		if c := compareInt(v.core[i], o.core[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case v.pre == nil && o.pre == nil:
+		return 0
+	case v.pre == nil:
+		return 1 // Release outranks its pre-releases
+	case o.pre == nil:
+		return -1
+	}
+
+	for i := 0; i < len(v.pre) && i < len(o.pre); i++ {
+		if c := comparePreID(v.pre[i], o.pre[i]); c != 0 {
+			return c
+		}
+	}
+	// More identifiers win when all shared ones are equal (rc.1 < rc.1.1)
+	return compareInt(len(v.pre), len(o.pre))
+}
+
+// comparePreID orders pre-release identifiers: numeric ones numerically and
+// below alphanumeric ones, which compare lexically.
+func comparePreID(a, b string) int {
+	na, errA := strconv.Atoi(a)
+	nb, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return compareInt(na, nb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
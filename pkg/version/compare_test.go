@@ -0,0 +1,89 @@
+package version
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		// Equal
+		{"v1.2.3", "v1.2.3", 0},
+		{"1.2.3", "v1.2.3", 0},
+		{"v1.2.3+build.7", "v1.2.3", 0},
+		{"v1.0.0-rc1", "1.0.0-rc1", 0},
+
+		// Greater
+		{"v1.2.4", "v1.2.3", 1},
+		{"v1.3.0", "v1.2.9", 1},
+		{"v2.0.0", "v1.99.99", 1},
+		{"v0.10.0", "v0.9.0", 1},
+
+		// Lesser
+		{"v1.2.3", "v1.2.4", -1},
+		{"v0.14.4", "v0.15.0", -1},
+
+		// Pre-release ordering
+		{"v1.0.0-rc1", "v1.0.0", -1},
+		{"v1.0.0", "v1.0.0-rc1", 1},
+		{"v1.0.0-alpha", "v1.0.0-beta", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-rc.1", "v1.0.0-rc.1.1", -1},
+		{"v1.0.0-1", "v1.0.0-alpha", -1},
+		{"v1.0.1-rc1", "v1.0.0", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+"_vs_"+tt.b, func(t *testing.T) {
+			got, err := Compare(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("Compare(%q, %q) error: %v", tt.a, tt.b, err)
+			}
+			if got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareInvalid(t *testing.T) {
+	for _, bad := range []string{"", "v1", "v1.2", "v1.2.3.4", "vx.2.3", "v1.2.3-", "v1.2.3-rc..1", "dev", "v1.-2.3"} {
+		t.Run(bad, func(t *testing.T) {
+			_, err := Compare(bad, "v1.0.0")
+			if !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("Compare(%q) error = %v, want ErrInvalidVersion", bad, err)
+			}
+			if _, err := Compare("v1.0.0", bad); !errors.Is(err, ErrInvalidVersion) {
+				t.Errorf("Compare with %q as second argument should fail, got %v", bad, err)
+			}
+		})
+	}
+}
+
+func TestAtLeast(t *testing.T) {
+	orig := Version
+	defer func() { Version = orig }()
+
+	Version = "v0.14.4"
+	for min, want := range map[string]bool{
+		"v0.14.4":     true,
+		"v0.14.3":     true,
+		"0.14.4-rc.1": true,
+		"v0.15.0":     false,
+	} {
+		got, err := AtLeast(min)
+		if err != nil {
+			t.Fatalf("AtLeast(%q) error: %v", min, err)
+		}
+		if got != want {
+			t.Errorf("AtLeast(%q) with Version %s = %v, want %v", min, Version, got, want)
+		}
+	}
+
+	if _, err := AtLeast("not-a-version"); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("expected ErrInvalidVersion, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package version
 
 import (
+	"fmt"
+	"runtime"
 	"runtime/debug"
 	"strings"
 )
@@ -19,18 +21,91 @@ const fallback = "v0.14.4"
 // Version is the resolved application version, populated by init().
 var Version string
 
+// Source records where Version came from: SourceLdflags, SourceBuildInfo or
+// SourceFallback. Populated by init().
+var Source string
+
+// Version sources, reported by Source and Info.
+const (
+	SourceLdflags   = "ldflags"
+	SourceBuildInfo = "buildinfo"
+	SourceFallback  = "fallback"
+)
+
 func init() {
+	Version, Source = resolve()
+}
+
+// resolve picks the version and its source in priority order.
+func resolve() (string, string) {
 	switch {
 	case version != "":
 		// 1. Build-time ldflags injection (GoReleaser, Nix, manual).
-		Version = version
+		return version, SourceLdflags
 	case versionFromBuildInfo() != "":
 		// 2. Module version from "go install ...@vX.Y.Z".
-		Version = versionFromBuildInfo()
+		return versionFromBuildInfo(), SourceBuildInfo
 	default:
 		// 3. Hardcoded fallback (always available, manually bumped per release).
-		Version = fallback
+		return fallback, SourceFallback
+	}
+}
+
+// BuildInfo describes the running binary for diagnostics and bug reports.
+type BuildInfo struct {
+	Version      string `json:"version"`
+	Source       string `json:"source"` // ldflags, buildinfo, or fallback
+	GoVersion    string `json:"go_version"`
+	Revision     string `json:"vcs_revision,omitempty"`
+	RevisionTime string `json:"vcs_time,omitempty"`
+	Modified     bool   `json:"vcs_modified,omitempty"` // Built from a dirty tree
+}
+
+// Info returns the resolved version with its provenance and the VCS settings
+// the Go toolchain stamped into the binary, when available.
+func Info() BuildInfo {
+	bi := BuildInfo{
+		Version:   Version,
+		Source:    Source,
+		GoVersion: runtime.Version(),
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return bi
+	}
+	if info.GoVersion != "" {
+		bi.GoVersion = info.GoVersion
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			bi.Revision = setting.Value
+		case "vcs.time":
+			bi.RevisionTime = setting.Value
+		case "vcs.modified":
+			bi.Modified = setting.Value == "true"
+		}
+	}
+	return bi
+}
+
+// String renders the build info as the multi-line --version --verbose output.
+func (b BuildInfo) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "bv %s\n", b.Version)
+	fmt.Fprintf(&sb, "  source:     %s\n", b.Source)
+	fmt.Fprintf(&sb, "  go:         %s\n", b.GoVersion)
+	if b.Revision != "" {
+		rev := b.Revision
+		if b.Modified {
+			rev += " (modified)"
+		}
+		fmt.Fprintf(&sb, "  revision:   %s\n", rev)
+	}
+	if b.RevisionTime != "" {
+		fmt.Fprintf(&sb, "  built from: %s\n", b.RevisionTime)
 	}
+	return sb.String()
 }
 
 // versionFromBuildInfo extracts the module version stamped by the Go toolchain
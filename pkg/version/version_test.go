@@ -0,0 +1,39 @@
+package version
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveSource(t *testing.T) {
+	orig := version
+	defer func() { version = orig }()
+
+	version = "v9.9.9"
+	if v, src := resolve(); v != "v9.9.9" || src != SourceLdflags {
+		t.Errorf("injected version: got (%q, %q), want (v9.9.9, %q)", v, src, SourceLdflags)
+	}
+
+	// Test binaries carry no module version, so nothing beats the fallback
+	version = ""
+	if v, src := resolve(); v != fallback || src != SourceFallback {
+		t.Errorf("no injection: got (%q, %q), want (%q, %q)", v, src, fallback, SourceFallback)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	info := Info()
+	if info.Version != Version || info.Source != Source {
+		t.Errorf("Info() = %+v, want Version %q and Source %q", info, Version, Source)
+	}
+	if !strings.HasPrefix(info.GoVersion, "go") {
+		t.Errorf("expected a Go version, got %q", info.GoVersion)
+	}
+
+	out := BuildInfo{Version: "v1.2.3", Source: SourceLdflags, GoVersion: "go1.25.0", Revision: "abc123", Modified: true}.String()
+	for _, want := range []string{"bv v1.2.3", "source:     ldflags", "abc123 (modified)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("String() missing %q:\n%s", want, out)
+		}
+	}
+}
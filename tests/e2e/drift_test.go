@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -463,3 +464,263 @@ func TestDriftErrorHandling(t *testing.T) {
 		t.Errorf("Expected warning about invalid config, got:\n%s", output)
 	}
 }
+
+func TestDriftExitPolicy(t *testing.T) {
+	bv := buildBvBinary(t)
+	envDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(envDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(envDir, ".beads", "beads.jsonl")
+
+	// Same warning-only scenario as TestDriftAlerts: ten free tasks, then
+	// nine of them blocked on A (density growth + blocked increase)
+	ids := []string{"B", "C", "D", "E", "F", "G", "H", "I", "J"}
+	var baselineContent, driftContent strings.Builder
+	baselineContent.WriteString(`{"id": "A", "title": "Task A", "status": "open", "issue_type": "task"}` + "\n")
+	driftContent.WriteString(`{"id": "A", "title": "Task A", "status": "open", "issue_type": "task"}` + "\n")
+	for _, id := range ids {
+		fmt.Fprintf(&baselineContent, `{"id": "%s", "title": "Task %s", "status": "open", "issue_type": "task"}`+"\n", id, id)
+		fmt.Fprintf(&driftContent, `{"id": "%s", "title": "Task %s", "status": "blocked", "issue_type": "task", "dependencies": [{"depends_on_id": "A", "type": "blocks"}]}`+"\n", id, id)
+	}
+
+	if err := os.WriteFile(beadsPath, []byte(baselineContent.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmdSave := exec.Command(bv, "--save-baseline", "Baseline")
+	cmdSave.Dir = envDir
+	if out, err := cmdSave.CombinedOutput(); err != nil {
+		t.Fatalf("Save baseline failed: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(beadsPath, []byte(driftContent.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	exitCode := func(err error) int {
+		t.Helper()
+		if err == nil {
+			return 0
+		}
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			t.Fatalf("Expected ExitError, got %T: %v", err, err)
+		}
+		return exitErr.ExitCode()
+	}
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{nil, 2}, // Default is strict
+		{[]string{"--drift-exit-policy", "strict"}, 2},
+		{[]string{"--drift-exit-policy", "warn-ok"}, 0},
+		{[]string{"--drift-exit-policy", "report-only"}, 0},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(bv, append([]string{"--check-drift"}, tt.args...)...)
+		cmd.Dir = envDir
+		out, err := cmd.CombinedOutput()
+		if got := exitCode(err); got != tt.want {
+			t.Errorf("%v: exit code %d, want %d\n%s", tt.args, got, tt.want, out)
+		}
+		// The report itself is the same in every mode
+		if !strings.Contains(string(out), "Blocked issues increased") {
+			t.Errorf("%v: output missing blocked warning:\n%s", tt.args, out)
+		}
+	}
+
+	// JSON output reports the exit code the policy chose
+	cmd := exec.Command(bv, "--check-drift", "--robot-drift", "--drift-exit-policy", "report-only")
+	cmd.Dir = envDir
+	out, err := cmd.Output()
+	if got := exitCode(err); got != 0 {
+		t.Fatalf("report-only JSON: exit code %d", got)
+	}
+	var result struct {
+		ExitCode int `json:"exit_code"`
+		Summary  struct {
+			Warning int `json:"warning"`
+		} `json:"summary"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if result.ExitCode != 0 || result.Summary.Warning == 0 {
+		t.Errorf("report-only JSON: exit_code=%d warnings=%d, want 0 and >0", result.ExitCode, result.Summary.Warning)
+	}
+
+	cmd = exec.Command(bv, "--check-drift", "--drift-exit-policy", "lenient")
+	cmd.Dir = envDir
+	out, err = cmd.CombinedOutput()
+	if got := exitCode(err); got != 1 || !strings.Contains(string(out), "invalid --drift-exit-policy") {
+		t.Errorf("invalid policy: exit code %d, output:\n%s", got, out)
+	}
+}
+
+func TestCheckDrift_WIPLimit(t *testing.T) {
+	bv := buildBvBinary(t)
+	envDir := t.TempDir()
+	writeBeads(t, envDir, `{"id":"A","title":"A","status":"in_progress","priority":1,"issue_type":"task"}
+{"id":"B","title":"B","status":"in_progress","priority":1,"issue_type":"task"}
+{"id":"C","title":"C","status":"in_progress","priority":1,"issue_type":"task"}`)
+
+	cmdSave := exec.Command(bv, "--save-baseline", "Baseline")
+	cmdSave.Dir = envDir
+	if out, err := cmdSave.CombinedOutput(); err != nil {
+		t.Fatalf("Save baseline failed: %v\n%s", err, out)
+	}
+	config := "max_in_progress: 1\nmax_in_progress_critical: 2\n"
+	if err := os.WriteFile(filepath.Join(envDir, ".bv", "drift.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--check-drift")
+	cmd.Dir = envDir
+	out, err := cmd.CombinedOutput()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected critical exit code 1, got %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "3 issues in progress exceeds the WIP limit of 2") {
+		t.Errorf("text output missing WIP alert:\n%s", out)
+	}
+
+	cmd = exec.Command(bv, "--check-drift", "--robot-drift")
+	cmd.Dir = envDir
+	out, err = cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("--robot-drift: expected exit code 1, got %v\n%s", err, out)
+	}
+	var result struct {
+		ExitCode int `json:"exit_code"`
+		Alerts   []struct {
+			Type     string `json:"type"`
+			Severity string `json:"severity"`
+		} `json:"alerts"`
+		Groups map[string][]int `json:"groups"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	found := false
+	for _, a := range result.Alerts {
+		if a.Type == "wip_exceeded" && a.Severity == "critical" {
+			found = true
+		}
+	}
+	if !found || result.ExitCode != 1 {
+		t.Errorf("expected a critical wip_exceeded alert and exit_code 1, got %s", out)
+	}
+
+	// Groups point into alerts rather than repeating them
+	grouped := 0
+	for group, idx := range result.Groups {
+		for _, i := range idx {
+			if i < 0 || i >= len(result.Alerts) {
+				t.Errorf("group %q has out-of-range alert index %d", group, i)
+			}
+		}
+		grouped += len(idx)
+	}
+	if grouped != len(result.Alerts) {
+		t.Errorf("groups cover %d alerts, want %d: %s", grouped, len(result.Alerts), out)
+	}
+}
+
+func TestCheckDrift_AsOfIsReproducible(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir := t.TempDir()
+	writeBeads(t, repoDir, `{"id":"A","title":"Task A","status":"open","priority":1,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-02T00:00:00Z"}
+{"id":"B","title":"Task B","status":"open","priority":2,"issue_type":"task","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-03T00:00:00Z"}`)
+
+	// --as-of loads history from git, so the beads file must be committed
+	for _, args := range [][]string{{"init"}, {"add", ".beads/beads.jsonl"}, {"commit", "-m", "issues"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	cmdSave := exec.Command(bv, "--save-baseline", "Baseline")
+	cmdSave.Dir = repoDir
+	if out, err := cmdSave.CombinedOutput(); err != nil {
+		t.Fatalf("Save baseline failed: %v\n%s", err, out)
+	}
+
+	// run returns the alerts without their detection timestamps
+	run := func(asOf string) []map[string]any {
+		t.Helper()
+		cmd := exec.Command(bv, "--check-drift", "--robot-drift", "--as-of", asOf)
+		cmd.Dir = repoDir
+		out, err := cmd.Output()
+		if _, ok := err.(*exec.ExitError); err != nil && !ok {
+			t.Fatalf("--check-drift --as-of %s: %v", asOf, err)
+		}
+		var result struct {
+			Alerts []map[string]any `json:"alerts"`
+		}
+		if err := json.Unmarshal(out, &result); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		for _, a := range result.Alerts {
+			delete(a, "detected_at")
+		}
+		return result.Alerts
+	}
+	hasStale := func(alerts []map[string]any) bool {
+		for _, a := range alerts {
+			if a["type"] == "stale_issue" {
+				return true
+			}
+		}
+		return false
+	}
+
+	first := run("2024-01-10T12:00:00Z")
+	second := run("2024-01-10T12:00:00Z")
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same --as-of produced different alerts:\n%v\n---\n%v", first, second)
+	}
+	if hasStale(first) {
+		t.Errorf("issues touched a week before --as-of should not be stale: %v", first)
+	}
+
+	// Months later the same issues have gone stale
+	if later := run("2024-06-01T12:00:00Z"); !hasStale(later) {
+		t.Errorf("expected stale_issue alerts for a later --as-of, got %v", later)
+	}
+}
+
+func TestCheckDrift_NoActionableIsCritical(t *testing.T) {
+	bv := buildBvBinary(t)
+	envDir := t.TempDir()
+	// A and B block each other and C waits on A: nothing can start
+	writeBeads(t, envDir, `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"B","type":"blocks"}]}
+{"id":"B","title":"B","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}
+{"id":"C","title":"C","status":"open","priority":1,"issue_type":"task","dependencies":[{"depends_on_id":"A","type":"blocks"}]}`)
+
+	// The baseline is already gridlocked, so only the absolute check can fire
+	cmdSave := exec.Command(bv, "--save-baseline", "Baseline")
+	cmdSave.Dir = envDir
+	if out, err := cmdSave.CombinedOutput(); err != nil {
+		t.Fatalf("Save baseline failed: %v\n%s", err, out)
+	}
+
+	cmd := exec.Command(bv, "--check-drift")
+	cmd.Dir = envDir
+	out, err := cmd.CombinedOutput()
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected critical exit code 1, got %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "No actionable issues") {
+		t.Errorf("output missing the no_actionable alert:\n%s", out)
+	}
+}
@@ -0,0 +1,122 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRobotExplain_HubIssue(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+
+	// HUB waits on ROOT and blocks A, B and C; C also waits on OTHER, and D waits on A.
+	writeBeads(t, env, `{"id":"ROOT","title":"Root","status":"open","priority":1,"issue_type":"task"}
+{"id":"OTHER","title":"Other","status":"open","priority":2,"issue_type":"task"}
+{"id":"HUB","title":"Hub","status":"open","priority":1,"issue_type":"task","labels":["core"],"dependencies":[{"issue_id":"HUB","depends_on_id":"ROOT","type":"blocks"}]}
+{"id":"A","title":"A","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"A","depends_on_id":"HUB","type":"blocks"}]}
+{"id":"B","title":"B","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"HUB","type":"blocks"}]}
+{"id":"C","title":"C","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"C","depends_on_id":"HUB","type":"blocks"},{"issue_id":"C","depends_on_id":"OTHER","type":"blocks"}]}
+{"id":"D","title":"D","status":"open","priority":3,"issue_type":"task","dependencies":[{"issue_id":"D","depends_on_id":"A","type":"blocks"}]}`)
+
+	cmd := exec.Command(bv, "--robot-explain", "HUB")
+	cmd.Dir = env
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-explain failed: %v\n%s", err, out)
+	}
+
+	var payload struct {
+		DataHash string `json:"data_hash"`
+		Result   struct {
+			ID             string   `json:"id"`
+			Labels         []string `json:"labels"`
+			Ready          bool     `json:"ready"`
+			Blocked        bool     `json:"blocked"`
+			OpenBlockers   []string `json:"open_blockers"`
+			Dependents     []string `json:"dependents"`
+			DirectUnblocks []string `json:"direct_unblocks"`
+			UnblockImpact  int      `json:"unblock_impact"`
+			PageRank       float64  `json:"pagerank"`
+			OnCriticalPath bool     `json:"on_critical_path"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	r := payload.Result
+
+	if payload.DataHash == "" || r.ID != "HUB" {
+		t.Fatalf("unexpected envelope: %s", out)
+	}
+	if !reflect.DeepEqual(r.Labels, []string{"core"}) {
+		t.Errorf("labels = %v, want [core]", r.Labels)
+	}
+	if r.Ready || !r.Blocked || !reflect.DeepEqual(r.OpenBlockers, []string{"ROOT"}) {
+		t.Errorf("HUB should be blocked by ROOT: ready=%v blocked=%v open_blockers=%v", r.Ready, r.Blocked, r.OpenBlockers)
+	}
+	if !reflect.DeepEqual(r.Dependents, []string{"A", "B", "C"}) {
+		t.Errorf("dependents = %v, want [A B C]", r.Dependents)
+	}
+	// C still waits on OTHER; D follows once A is free
+	if !reflect.DeepEqual(r.DirectUnblocks, []string{"A", "B"}) || r.UnblockImpact != 3 {
+		t.Errorf("direct_unblocks = %v, unblock_impact = %d; want [A B] and 3", r.DirectUnblocks, r.UnblockImpact)
+	}
+	if r.PageRank <= 0 {
+		t.Errorf("pagerank = %v, want > 0", r.PageRank)
+	}
+	// ROOT -> HUB -> A -> D is the longest chain
+	if !r.OnCriticalPath {
+		t.Error("HUB should be on the critical path")
+	}
+}
+
+func TestRobotExplain_UnknownIssue(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task"}`)
+
+	cmd := exec.Command(bv, "--robot-explain", "NOPE")
+	cmd.Dir = env
+	out, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("expected failure for unknown issue, got:\n%s", out)
+	}
+	if !strings.Contains(string(out), `issue "NOPE" not found`) {
+		t.Errorf("expected a not-found error, got:\n%s", out)
+	}
+}
+
+func TestRobotExplain_RespectsRepoFilter(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"api-1","title":"API","status":"open","priority":1,"issue_type":"task"}
+{"id":"web-1","title":"Web","status":"open","priority":1,"issue_type":"task","dependencies":[{"issue_id":"web-1","depends_on_id":"api-1","type":"blocks"}]}`)
+
+	// web-1's blocker lives outside the --repo scope, so it isn't counted
+	cmd := exec.Command(bv, "--robot-explain", "web-1", "--repo", "web")
+	cmd.Dir = env
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-explain failed: %v\n%s", err, out)
+	}
+	var payload struct {
+		Result struct {
+			OpenBlockers []string `json:"open_blockers"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if len(payload.Result.OpenBlockers) != 0 {
+		t.Errorf("open_blockers = %v, want none outside the repo scope", payload.Result.OpenBlockers)
+	}
+
+	cmd = exec.Command(bv, "--robot-explain", "api-1", "--repo", "web")
+	cmd.Dir = env
+	if out, err := cmd.CombinedOutput(); err == nil {
+		t.Fatalf("api-1 is filtered out by --repo and should not be found, got:\n%s", out)
+	}
+}
@@ -0,0 +1,184 @@
+package main_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+type robotLabelsOutput struct {
+	TotalLabels   int `json:"total_labels"`
+	CriticalCount int `json:"critical_count"`
+	Labels        []struct {
+		Label       string `json:"label"`
+		Health      int    `json:"health"`
+		HealthLevel string `json:"health_level"`
+	} `json:"labels"`
+	Summaries []struct {
+		Label  string `json:"label"`
+		Health int    `json:"health"`
+	} `json:"summaries"`
+	CrossLabelFlow *struct {
+		TotalCrossLabelDeps int `json:"total_cross_label_deps"`
+	} `json:"cross_label_flow"`
+}
+
+// runRobotLabels runs bv --robot-labels in dir and returns the parsed output and exit code.
+func runRobotLabels(t *testing.T, binPath, dir string, args ...string) (robotLabelsOutput, int) {
+	t.Helper()
+	cmd := exec.Command(binPath, append([]string{"--robot-labels"}, args...)...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	code := 0
+	if err := cmd.Run(); err != nil {
+		exitErr, ok := err.(*exec.ExitError)
+		if !ok {
+			t.Fatalf("running --robot-labels: %v\n%s", err, stderr.String())
+		}
+		code = exitErr.ExitCode()
+	}
+
+	var out robotLabelsOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v\n%s", err, stdout.String())
+	}
+	return out, code
+}
+
+func TestRobotLabels_HealthAndExitCode(t *testing.T) {
+	binPath := buildBvBinary(t)
+	envDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(envDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// api blocks ui across labels; docs is closed work
+	jsonlContent := `{"id": "A", "title": "Task A", "status": "open", "priority": 1, "issue_type": "task", "labels": ["api"]}
+{"id": "B", "title": "Task B", "status": "open", "priority": 1, "issue_type": "task", "labels": ["ui"], "dependencies": [{"depends_on_id": "A", "type": "blocks"}]}
+{"id": "C", "title": "Task C", "status": "closed", "priority": 2, "issue_type": "task", "labels": ["docs"]}`
+	if err := os.WriteFile(filepath.Join(envDir, ".beads", "beads.jsonl"), []byte(jsonlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, code := runRobotLabels(t, binPath, envDir)
+	if out.TotalLabels != 3 || len(out.Labels) != 3 || len(out.Summaries) != 3 {
+		t.Fatalf("expected 3 labels, got %+v", out)
+	}
+	for i, want := range []string{"api", "docs", "ui"} {
+		if out.Labels[i].Label != want {
+			t.Errorf("labels[%d] = %q, want %q (sorted by name)", i, out.Labels[i].Label, want)
+		}
+	}
+	if out.CrossLabelFlow == nil || out.CrossLabelFlow.TotalCrossLabelDeps != 1 {
+		t.Errorf("expected one cross-label dependency, got %+v", out.CrossLabelFlow)
+	}
+	wantCode := 0
+	if out.CriticalCount > 0 {
+		wantCode = 1
+	}
+	if code != wantCode {
+		t.Errorf("default threshold: exit code %d, want %d (critical_count=%d)", code, wantCode, out.CriticalCount)
+	}
+
+	// A threshold above every score fails; zero always passes
+	if _, code := runRobotLabels(t, binPath, envDir, "--labels-min-health", "101"); code != 1 {
+		t.Errorf("--labels-min-health 101: exit code %d, want 1", code)
+	}
+	if _, code := runRobotLabels(t, binPath, envDir, "--labels-min-health", "0"); code != 0 {
+		t.Errorf("--labels-min-health 0: exit code %d, want 0", code)
+	}
+}
+
+func TestRobotLabels_EmptyRepo(t *testing.T) {
+	binPath := buildBvBinary(t)
+	envDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(envDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(envDir, ".beads", "beads.jsonl"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, code := runRobotLabels(t, binPath, envDir)
+	if code != 0 {
+		t.Errorf("empty repo: exit code %d, want 0", code)
+	}
+	if out.TotalLabels != 0 || out.Labels == nil || len(out.Labels) != 0 {
+		t.Errorf("expected an empty result, got %+v", out)
+	}
+}
+
+func TestRobotLabels_AsOfIsReproducible(t *testing.T) {
+	binPath := buildBvBinary(t)
+	repoDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repoDir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	jsonlContent := `{"id": "A", "title": "Task A", "status": "open", "priority": 1, "issue_type": "task", "labels": ["api"], "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-02T00:00:00Z"}
+{"id": "B", "title": "Task B", "status": "open", "priority": 1, "issue_type": "task", "labels": ["ui"], "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-03T00:00:00Z", "dependencies": [{"depends_on_id": "A", "type": "blocks"}]}
+{"id": "C", "title": "Task C", "status": "closed", "priority": 2, "issue_type": "task", "labels": ["api"], "created_at": "2024-01-01T00:00:00Z", "updated_at": "2024-01-04T00:00:00Z", "closed_at": "2024-01-04T00:00:00Z"}`
+	if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(jsonlContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// --as-of loads history from git, so the beads file must be committed
+	for _, args := range [][]string{{"init"}, {"add", ".beads/beads.jsonl"}, {"commit", "-m", "issues"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Test",
+			"GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=Test",
+			"GIT_COMMITTER_EMAIL=test@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	run := func(asOf string) ([]byte, error) {
+		cmd := exec.Command(binPath, "--robot-labels", "--as-of", asOf, "--labels-min-health", "0")
+		cmd.Dir = repoDir
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		err := cmd.Run()
+		if err != nil {
+			return stderr.Bytes(), err
+		}
+		return stdout.Bytes(), nil
+	}
+
+	first, err := run("2024-01-10T12:00:00Z")
+	if err != nil {
+		t.Fatalf("--robot-labels --as-of failed: %v\n%s", err, first)
+	}
+	second, err := run("2024-01-10T12:00:00Z")
+	if err != nil {
+		t.Fatalf("second run failed: %v\n%s", err, second)
+	}
+	if !bytes.Equal(first, second) {
+		t.Errorf("same --as-of produced different output:\n%s\n---\n%s", first, second)
+	}
+
+	// Months later the same issues have gone stale, so health must change
+	later, err := run("2024-06-01T12:00:00Z")
+	if err != nil {
+		t.Fatalf("later run failed: %v\n%s", err, later)
+	}
+	if bytes.Equal(first, later) {
+		t.Error("expected a later --as-of to change the analysis")
+	}
+
+	if out, err := run("2024-13-45T00:00:00Z"); err == nil {
+		t.Errorf("expected invalid timestamp to fail, got:\n%s", out)
+	} else if !bytes.Contains(out, []byte("invalid --as-of timestamp")) {
+		t.Errorf("expected a clear validation error, got:\n%s", out)
+	}
+}